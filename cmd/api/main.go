@@ -7,12 +7,25 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	// La imagen alpine no incluye la base de datos de zonas horarias que usan las planificaciones de los automatismos
+	_ "time/tzdata"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grupoapi/proces-log/internal/adapter/blobstore"
 	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/adapter/repository/postgres"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+	"github.com/grupoapi/proces-log/internal/domain/service"
 	"github.com/grupoapi/proces-log/internal/infrastructure/config"
 	"github.com/grupoapi/proces-log/internal/infrastructure/database"
+	"github.com/grupoapi/proces-log/internal/infrastructure/worker"
+	automationUsecase "github.com/grupoapi/proces-log/internal/usecase/automation"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
 )
 
 func main() {
@@ -22,6 +35,18 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	// Cargar y validar las máquinas de estados antes de aceptar peticiones
+	stateMachine, err := loadStateMachine(cfg.StateMachine.DefinitionFile)
+	if err != nil {
+		log.Fatalf("Failed to load state machine: %v", err)
+	}
+
+	workflows, err := service.LoadWorkflowRegistry(stateMachine, cfg.StateMachine.WorkflowsDir)
+	if err != nil {
+		log.Fatalf("Failed to load workflow profiles: %v", err)
+	}
+	log.Printf("Available workflows: %s", strings.Join(workflows.Names(), ", "))
+
 	// Crear contexto base
 	ctx := context.Background()
 
@@ -34,8 +59,19 @@ func main() {
 
 	log.Println("Successfully connected to database")
 
+	// Inicializar el almacén del contenido de los artefactos
+	blobStore, err := newBlobStore(cfg.Artifacts)
+	if err != nil {
+		log.Fatalf("Failed to initialize artifact store: %v", err)
+	}
+	log.Printf("Artifact store: %s", cfg.Artifacts.Store)
+
 	// Configurar router
-	router := httpHandler.SetupRouter(dbPool, cfg.Server.GinMode)
+	router := httpHandler.SetupRouter(dbPool, cfg.Server.GinMode, workflows,
+		httpHandler.WithMaxPayloadBytes(cfg.Payload.MaxBytes),
+		httpHandler.WithBlobStore(blobStore),
+		httpHandler.WithMaxArtifactBytes(cfg.Artifacts.MaxBytes),
+	)
 
 	// Configurar servidor HTTP
 	server := &http.Server{
@@ -54,12 +90,37 @@ func main() {
 		}
 	}()
 
+	// Iniciar el reaper de tareas sin latidos, el monitor de SLA, el detector de ejecuciones perdidas
+	// y el purgador de artefactos en segundo plano
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	if cfg.Reaper.Interval > 0 {
+		reaper := newStaleTaskReaper(dbPool, workflows, cfg.Reaper)
+		go reaper.Run(jobsCtx)
+		log.Printf("Stale task reaper running every %s", cfg.Reaper.Interval)
+	}
+	if cfg.SLAMonitor.Interval > 0 {
+		monitor := newSLABreachMonitor(dbPool, cfg.SLAMonitor)
+		go monitor.Run(jobsCtx)
+		log.Printf("SLA breach monitor running every %s", cfg.SLAMonitor.Interval)
+	}
+	if cfg.MissedRuns.Interval > 0 {
+		detector := newMissedRunDetector(dbPool, cfg.MissedRuns)
+		go detector.Run(jobsCtx)
+		log.Printf("Missed run detector running every %s", cfg.MissedRuns.Interval)
+	}
+	if cfg.Artifacts.Purger.Interval > 0 {
+		purger := newArtifactPurger(dbPool, blobStore, cfg.Artifacts.Purger)
+		go purger.Run(jobsCtx)
+		log.Printf("Artifact purger running every %s", cfg.Artifacts.Purger.Interval)
+	}
+
 	// Esperar señal de interrupción para graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopJobs()
 
 	// Graceful shutdown con timeout de 5 segundos
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -72,3 +133,79 @@ func main() {
 
 	log.Println("Server exited")
 }
+
+// newStaleTaskReaper construye el reaper que cierra como FAILED las tareas sin latidos
+func newStaleTaskReaper(dbPool *pgxpool.Pool, workflows *service.WorkflowRegistry, cfg config.JobConfig) *worker.StaleTaskReaper {
+	useCase := taskUsecase.NewReapStaleTasksUseCase(
+		postgres.NewTaskRepository(dbPool),
+		postgres.NewTaskEventRepository(dbPool),
+		postgres.NewTransactionManager(dbPool),
+		postgres.NewAdvisoryLockManager(dbPool),
+		workflows,
+	)
+	return worker.NewStaleTaskReaper(useCase, cfg.Interval, cfg.BatchSize)
+}
+
+// newSLABreachMonitor construye el monitor que registra en el historial los incumplimientos de SLA
+func newSLABreachMonitor(dbPool *pgxpool.Pool, cfg config.JobConfig) *worker.SLABreachMonitor {
+	useCase := taskUsecase.NewRecordSLABreachesUseCase(
+		postgres.NewTaskRepository(dbPool),
+		postgres.NewTaskEventRepository(dbPool),
+		postgres.NewTransactionManager(dbPool),
+		postgres.NewAdvisoryLockManager(dbPool),
+	)
+	return worker.NewSLABreachMonitor(useCase, cfg.Interval, cfg.BatchSize)
+}
+
+// newMissedRunDetector construye el detector que registra las ejecuciones planificadas de los automatismos
+// que no se crearon dentro de su margen
+func newMissedRunDetector(dbPool *pgxpool.Pool, cfg config.JobConfig) *worker.MissedRunDetector {
+	useCase := automationUsecase.NewDetectMissedRunsUseCase(
+		postgres.NewAutomationRepository(dbPool),
+		postgres.NewTransactionManager(dbPool),
+		postgres.NewAdvisoryLockManager(dbPool),
+	)
+	return worker.NewMissedRunDetector(useCase, cfg.Interval, cfg.BatchSize)
+}
+
+// newArtifactPurger construye el purgador que borra el contenido de los artefactos de las tareas purgadas
+func newArtifactPurger(dbPool *pgxpool.Pool, blobStore repository.BlobStore, cfg config.JobConfig) *worker.ArtifactPurger {
+	useCase := taskUsecase.NewPurgeArtifactBlobsUseCase(postgres.NewArtifactRepository(dbPool), blobStore)
+	return worker.NewArtifactPurger(useCase, cfg.Interval, cfg.BatchSize)
+}
+
+// newBlobStore construye el almacén del contenido de los artefactos configurado
+func newBlobStore(cfg config.ArtifactsConfig) (repository.BlobStore, error) {
+	if cfg.Store == "s3" {
+		return blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PathStyle:       cfg.S3PathStyle,
+		}, nil)
+	}
+	return blobstore.NewFilesystemStore(cfg.Dir)
+}
+
+// loadStateMachine construye la máquina de estados desde el fichero de definición configurado
+// Sin fichero se usan las transiciones por defecto
+func loadStateMachine(path string) (*service.StateMachine, error) {
+	if path == "" {
+		return service.NewStateMachine(), nil
+	}
+
+	def, err := service.LoadStateMachineDefinition(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stateMachine, err := service.NewStateMachineFromDefinition(def)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Loaded state machine definition from %s", path)
+	return stateMachine, nil
+}
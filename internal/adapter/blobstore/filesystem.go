@@ -0,0 +1,132 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// FilesystemStore guarda el contenido de los artefactos como ficheros bajo un directorio raíz
+// Pensado para una sola réplica o para un volumen compartido entre réplicas
+type FilesystemStore struct {
+	root string
+}
+
+// NewFilesystemStore crea un almacén bajo root, creando el directorio si no existe
+func NewFilesystemStore(root string) (repository.BlobStore, error) {
+	if root == "" {
+		return nil, errors.New("blob store directory is required")
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve blob store directory: %w", err)
+	}
+	if err := os.MkdirAll(absRoot, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory: %w", err)
+	}
+
+	return &FilesystemStore{root: absRoot}, nil
+}
+
+// Put escribe el contenido en un fichero temporal del mismo directorio y lo renombra al completarse,
+// de forma que una lectura concurrente nunca ve un fichero a medio escribir
+func (s *FilesystemStore) Put(ctx context.Context, key string, content io.Reader, size int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // tras el renombrado el temporal ya no existe
+
+	written, err := io.Copy(tmp, content)
+	if err != nil {
+		tmp.Close() //nolint:errcheck
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if written != size {
+		tmp.Close() //nolint:errcheck
+		return fmt.Errorf("failed to write blob: got %d bytes, expected %d", written, size)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+
+	return nil
+}
+
+// Get abre el fichero del contenido
+func (s *FilesystemStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrBlobNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+
+	return file, nil
+}
+
+// Delete borra el fichero del contenido y los directorios que quedan vacíos por encima de él
+func (s *FilesystemStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+
+	// os.Remove no borra directorios con contenido: se detiene en el primero que no está vacío
+	for dir := filepath.Dir(path); dir != s.root; dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+// path resuelve la ruta del fichero de una clave, rechazando las claves que podrían salir del directorio raíz
+func (s *FilesystemStore) path(key string) (string, error) {
+	if key == "" || strings.ContainsRune(key, '\\') {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
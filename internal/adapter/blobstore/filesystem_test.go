@@ -0,0 +1,77 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+func TestFilesystemStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := NewFilesystemStore(root)
+	require.NoError(t, err)
+
+	key := "tasks/1/artifacts/2"
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("report"), 6, "text/plain"))
+
+	// Reemplazar el contenido existente
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("report v2"), 9, "text/plain"))
+
+	reader, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, "report v2", string(content))
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, entity.ErrBlobNotFound)
+
+	// Los directorios vacíos se borran con el último fichero y borrar dos veces no es un error
+	_, err = os.Stat(filepath.Join(root, "tasks"))
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, store.Delete(ctx, key))
+}
+
+func TestFilesystemStore_PutSizeMismatch(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := NewFilesystemStore(root)
+	require.NoError(t, err)
+
+	err = store.Put(ctx, "tasks/1/artifacts/2", strings.NewReader("short"), 10, "")
+	require.Error(t, err)
+
+	// Ni el contenido incompleto ni el temporal quedan en el directorio
+	_, err = store.Get(ctx, "tasks/1/artifacts/2")
+	assert.ErrorIs(t, err, entity.ErrBlobNotFound)
+	entries, err := os.ReadDir(filepath.Join(root, "tasks", "1", "artifacts"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFilesystemStore_InvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFilesystemStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../outside", "tasks/../../outside", "/etc/passwd", "tasks//1", `tasks\1`} {
+		t.Run(key, func(t *testing.T) {
+			assert.Error(t, store.Put(ctx, key, strings.NewReader("x"), 1, ""))
+			_, err := store.Get(ctx, key)
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, entity.ErrBlobNotFound)
+			assert.Error(t, store.Delete(ctx, key))
+		})
+	}
+}
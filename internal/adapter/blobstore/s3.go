@@ -0,0 +1,185 @@
+package blobstore
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// maxS3ErrorBody es el máximo de bytes que se leen de una respuesta de error de S3
+const maxS3ErrorBody = 4 * 1024
+
+// S3Config configura el acceso a un almacén compatible con S3 (AWS S3, MinIO, Ceph...)
+type S3Config struct {
+	// Endpoint es la URL base del servicio, p. ej. https://s3.eu-west-1.amazonaws.com o http://minio:9000
+	Endpoint string
+
+	// Region es la región con la que se firman las peticiones (us-east-1 en la mayoría de servicios compatibles)
+	Region string
+
+	// Bucket es el bucket, ya existente, en el que se guardan los objetos
+	Bucket string
+
+	// AccessKeyID y SecretAccessKey son las credenciales con las que se firman las peticiones
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// PathStyle direcciona el bucket en la ruta (http://minio:9000/bucket/key) en lugar de en el host
+	// (https://bucket.s3.amazonaws.com/key); lo necesitan la mayoría de servicios compatibles
+	PathStyle bool
+}
+
+// S3Store guarda el contenido de los artefactos como objetos de un bucket S3
+// Habla directamente la API REST de S3 con peticiones firmadas con SigV4, sin SDK
+type S3Store struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3Store crea un almacén sobre el bucket configurado; client nil usa http.DefaultClient
+func NewS3Store(cfg S3Config, client *http.Client) (repository.BlobStore, error) {
+	if cfg.Endpoint == "" || cfg.Region == "" || cfg.Bucket == "" {
+		return nil, errors.New("s3 endpoint, region and bucket are required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("s3 access key id and secret access key are required")
+	}
+
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", cfg.Endpoint)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &S3Store{cfg: cfg, endpoint: endpoint, client: client}, nil
+}
+
+// Put sube el contenido con un único PutObject; S3 limita cada PutObject a 5 GiB
+// El cuerpo no se firma (UNSIGNED-PAYLOAD) para poder enviarlo en streaming sin leerlo dos veces
+func (s *S3Store) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	if size == 0 {
+		content = http.NoBody
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), content)
+	if err != nil {
+		return fmt.Errorf("failed to build s3 request: %w", err)
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(req, resp)
+	}
+	return nil
+}
+
+// Get descarga el objeto; el cuerpo de la respuesta se entrega sin leer
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build s3 request: %w", err)
+	}
+
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close() //nolint:errcheck
+		return nil, fmt.Errorf("%w: %s", entity.ErrBlobNotFound, key)
+	default:
+		defer resp.Body.Close()
+		return nil, s3Error(req, resp)
+	}
+}
+
+// Delete borra el objeto; S3 responde 204 aunque el objeto no exista
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build s3 request: %w", err)
+	}
+
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return s3Error(req, resp)
+	}
+}
+
+// do firma y envía la petición
+func (s *S3Store) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	signV4(req, sigV4Credentials{
+		AccessKeyID:     s.cfg.AccessKeyID,
+		SecretAccessKey: s.cfg.SecretAccessKey,
+		Region:          s.cfg.Region,
+		Service:         "s3",
+	}, payloadHash, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s request failed: %w", req.Method, err)
+	}
+	return resp, nil
+}
+
+// objectURL retorna la URL del objeto de key, con la ruta codificada como la firma SigV4
+func (s *S3Store) objectURL(key string) string {
+	host := s.endpoint.Host
+	path := strings.TrimSuffix(s.endpoint.EscapedPath(), "/")
+	if s.cfg.PathStyle {
+		path += "/" + uriEncode(s.cfg.Bucket, true)
+	} else {
+		host = s.cfg.Bucket + "." + host
+	}
+	return s.endpoint.Scheme + "://" + host + path + "/" + uriEncode(key, false)
+}
+
+// s3ErrorResponse es el cuerpo XML de las respuestas de error de S3
+type s3ErrorResponse struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// s3Error construye el error de una respuesta inesperada, con el código y el mensaje de S3 si los incluye
+func s3Error(req *http.Request, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxS3ErrorBody))
+
+	var parsed s3ErrorResponse
+	if xml.Unmarshal(body, &parsed) == nil && parsed.Code != "" {
+		return fmt.Errorf("s3 %s %s failed with status %d: %s: %s",
+			req.Method, req.URL.Path, resp.StatusCode, parsed.Code, parsed.Message)
+	}
+	return fmt.Errorf("s3 %s %s failed with status %d", req.Method, req.URL.Path, resp.StatusCode)
+}
@@ -0,0 +1,225 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// fakeS3Object es un objeto guardado por fakeS3
+type fakeS3Object struct {
+	content     []byte
+	contentType string
+}
+
+// fakeS3 es un servidor compatible con S3 en memoria, al estilo de MinIO, para un único bucket
+// Comprueba la firma SigV4 de cada petición como lo haría el servicio
+type fakeS3 struct {
+	bucket string
+	creds  sigV4Credentials
+
+	mu      sync.Mutex
+	objects map[string]fakeS3Object
+}
+
+var signedHeadersPattern = regexp.MustCompile(`SignedHeaders=([^,]+)`)
+
+func newFakeS3(t *testing.T, bucket string, creds sigV4Credentials) *httptest.Server {
+	fake := &fakeS3{bucket: bucket, creds: creds, objects: make(map[string]fakeS3Object)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.validSignature(r) {
+		writeS3Error(w, http.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match")
+		return
+	}
+
+	prefix := "/" + f.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		content, err := io.ReadAll(r.Body)
+		if err != nil || int64(len(content)) != r.ContentLength {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody", "The request body is incomplete")
+			return
+		}
+		f.objects[key] = fakeS3Object{content: content, contentType: r.Header.Get("Content-Type")}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist")
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.WriteHeader(http.StatusOK)
+		w.Write(object.content) //nolint:errcheck
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The method is not allowed")
+	}
+}
+
+// validSignature recalcula la firma con las cabeceras firmadas tal como llegaron al servidor
+func (f *fakeS3) validSignature(r *http.Request) bool {
+	authorization := r.Header.Get("Authorization")
+	match := signedHeadersPattern.FindStringSubmatch(authorization)
+	signedAt, err := time.Parse(amzDateFormat, r.Header.Get("X-Amz-Date"))
+	if match == nil || err != nil {
+		return false
+	}
+
+	expected, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	if err != nil {
+		return false
+	}
+	for _, name := range strings.Split(match[1], ";") {
+		if name != "host" {
+			expected.Header[http.CanonicalHeaderKey(name)] = r.Header.Values(name)
+		}
+	}
+	signV4(expected, f.creds, r.Header.Get("X-Amz-Content-Sha256"), signedAt)
+
+	return expected.Header.Get("Authorization") == authorization
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Error><Code>"+code+"</Code><Message>"+message+"</Message></Error>") //nolint:errcheck
+}
+
+var testS3Credentials = sigV4Credentials{
+	AccessKeyID:     "minioadmin",
+	SecretAccessKey: "minioadmin-secret",
+	Region:          "us-east-1",
+	Service:         "s3",
+}
+
+func newTestS3Store(t *testing.T, endpoint, secret string) *S3Store {
+	store, err := NewS3Store(S3Config{
+		Endpoint:        endpoint,
+		Region:          testS3Credentials.Region,
+		Bucket:          "artifacts",
+		AccessKeyID:     testS3Credentials.AccessKeyID,
+		SecretAccessKey: secret,
+		PathStyle:       true,
+	}, nil)
+	require.NoError(t, err)
+	return store.(*S3Store)
+}
+
+func TestS3Store_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeS3(t, "artifacts", testS3Credentials)
+	store := newTestS3Store(t, server.URL, testS3Credentials.SecretAccessKey)
+
+	// Claves con caracteres que deben codificarse igual en la URL y en la firma
+	for _, key := range []string{"tasks/1/artifacts/2", "tasks/1/report v2 (final)$.pdf", "tasks/1/empty"} {
+		t.Run(key, func(t *testing.T) {
+			content := "content of " + key
+			if strings.HasSuffix(key, "empty") {
+				content = ""
+			}
+
+			require.NoError(t, store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain"))
+
+			reader, err := store.Get(ctx, key)
+			require.NoError(t, err)
+			got, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.NoError(t, reader.Close())
+			assert.Equal(t, content, string(got))
+
+			require.NoError(t, store.Delete(ctx, key))
+			_, err = store.Get(ctx, key)
+			assert.ErrorIs(t, err, entity.ErrBlobNotFound)
+
+			// Borrar un objeto que ya no existe no es un error
+			assert.NoError(t, store.Delete(ctx, key))
+		})
+	}
+}
+
+func TestS3Store_InvalidCredentials(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeS3(t, "artifacts", testS3Credentials)
+	store := newTestS3Store(t, server.URL, "wrong-secret")
+
+	err := store.Put(ctx, "tasks/1/artifacts/2", strings.NewReader("x"), 1, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SignatureDoesNotMatch")
+
+	_, err = store.Get(ctx, "tasks/1/artifacts/2")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, entity.ErrBlobNotFound)
+	assert.Contains(t, err.Error(), "status 403")
+}
+
+func TestS3Store_PathStyleURL(t *testing.T) {
+	store := newTestS3Store(t, "http://gateway:8080/storage/", testS3Credentials.SecretAccessKey)
+
+	assert.Equal(t, "http://gateway:8080/storage/artifacts/tasks/1/a%24b", store.objectURL("tasks/1/a$b"))
+}
+
+func TestS3Store_VirtualHostedURL(t *testing.T) {
+	store, err := NewS3Store(S3Config{
+		Endpoint:        "https://s3.eu-west-1.amazonaws.com",
+		Region:          "eu-west-1",
+		Bucket:          "reports",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	}, nil)
+	require.NoError(t, err)
+
+	objectURL := store.(*S3Store).objectURL("tasks/1/report v2.pdf")
+	assert.Equal(t, "https://reports.s3.eu-west-1.amazonaws.com/tasks/1/report%20v2.pdf", objectURL)
+
+	parsed, err := url.Parse(objectURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/tasks/1/report%20v2.pdf", parsed.EscapedPath())
+}
+
+func TestNewS3Store_InvalidConfig(t *testing.T) {
+	valid := S3Config{Endpoint: "http://minio:9000", Region: "us-east-1", Bucket: "b", AccessKeyID: "k", SecretAccessKey: "s"}
+
+	tests := map[string]func(cfg *S3Config){
+		"missing bucket":          func(cfg *S3Config) { cfg.Bucket = "" },
+		"missing credentials":     func(cfg *S3Config) { cfg.SecretAccessKey = "" },
+		"endpoint without scheme": func(cfg *S3Config) { cfg.Endpoint = "minio:9000" },
+	}
+
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := valid
+			mutate(&cfg)
+			_, err := NewS3Store(cfg, nil)
+			assert.Error(t, err)
+		})
+	}
+}
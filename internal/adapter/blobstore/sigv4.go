@@ -0,0 +1,161 @@
+package blobstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// sigV4Algorithm identifica la firma AWS Signature Version 4 en la cabecera Authorization
+	sigV4Algorithm = "AWS4-HMAC-SHA256"
+
+	// unsignedPayload indica a S3 que el cuerpo no forma parte de la firma (ver x-amz-content-sha256)
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// emptyPayloadHash es el SHA-256 de un cuerpo vacío
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// amzDateFormat es el formato de x-amz-date (ISO 8601 básico en UTC)
+	amzDateFormat = "20060102T150405Z"
+)
+
+// sigV4Credentials son las credenciales y el ámbito con los que se firman las peticiones
+type sigV4Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Service         string
+}
+
+// signV4 firma req con AWS Signature Version 4 en el instante now
+// payloadHash es el SHA-256 hexadecimal del cuerpo, o unsignedPayload si el cuerpo no se firma.
+// Se firman el host y todas las cabeceras presentes en req, que no deben modificarse después.
+func signV4(req *http.Request, creds sigV4Credentials, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Del("Authorization")
+
+	canonicalHeaders, signedHeaders := canonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req),
+		canonicalQuery(req),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{amzDate[:8], creds.Region, creds.Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), amzDate[:8])
+	for _, part := range []string{creds.Region, creds.Service, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", sigV4Algorithm+
+		" Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+signature)
+}
+
+// canonicalURI retorna la ruta ya codificada de la petición; S3 no la vuelve a codificar ni la normaliza
+func canonicalURI(req *http.Request) string {
+	if uri := req.URL.EscapedPath(); uri != "" {
+		return uri
+	}
+	return "/"
+}
+
+// canonicalQuery retorna los parámetros de la query codificados y ordenados por nombre y valor
+func canonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// canonicalHeaders retorna las cabeceras firmadas en forma canónica y la lista de sus nombres
+func canonicalHeaders(req *http.Request) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "authorization" {
+			continue
+		}
+		trimmed := make([]string, 0, len(values))
+		for _, value := range values {
+			trimmed = append(trimmed, strings.Join(strings.Fields(value), " "))
+		}
+		headers[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// uriEncode codifica s como exige SigV4: todo salvo los caracteres no reservados de RFC 3986,
+// con hexadecimales en mayúsculas; la '/' solo se codifica si encodeSlash es true
+func uriEncode(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var encoded strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			encoded.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			encoded.WriteByte(c)
+		default:
+			encoded.WriteByte('%')
+			encoded.WriteByte(hexDigits[c>>4])
+			encoded.WriteByte(hexDigits[c&0x0F])
+		}
+	}
+	return encoded.String()
+}
+
+// hexSHA256 retorna el SHA-256 de data en hexadecimal
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 retorna el HMAC-SHA256 de data con key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
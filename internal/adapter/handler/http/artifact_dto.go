@@ -0,0 +1,39 @@
+package http
+
+import (
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// ArtifactResponse representa los metadatos de un artefacto de una tarea
+type ArtifactResponse struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	UploadedBy  string    `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ArtifactListResponse representa los artefactos de una tarea
+type ArtifactListResponse struct {
+	TaskID    string             `json:"task_id"`
+	Artifacts []ArtifactResponse `json:"artifacts"`
+}
+
+// ToArtifactResponse convierte una entidad Artifact a ArtifactResponse
+func ToArtifactResponse(artifact *entity.Artifact) ArtifactResponse {
+	return ArtifactResponse{
+		ID:          artifact.ID.String(),
+		TaskID:      artifact.TaskID.String(),
+		Name:        artifact.Name,
+		ContentType: artifact.ContentType,
+		Size:        artifact.Size,
+		SHA256:      artifact.SHA256,
+		UploadedBy:  artifact.UploadedBy,
+		CreatedAt:   artifact.CreatedAt,
+	}
+}
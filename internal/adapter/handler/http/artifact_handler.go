@@ -0,0 +1,163 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// multipartOverheadBytes es el margen que se admite en el cuerpo multipart sobre el tamaño máximo del fichero,
+// para las cabeceras de las partes y el campo uploaded_by
+const multipartOverheadBytes = 64 * 1024
+
+// UploadArtifactUseCaseInterface define la interfaz para subir artefactos
+type UploadArtifactUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.UploadArtifactInput) (*taskUsecase.UploadArtifactOutput, error)
+}
+
+// GetArtifactUseCaseInterface define la interfaz para descargar artefactos
+type GetArtifactUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.GetArtifactInput) (*taskUsecase.GetArtifactOutput, error)
+}
+
+// ListArtifactsUseCaseInterface define la interfaz para consultar los artefactos de una tarea
+type ListArtifactsUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.ListArtifactsInput) (*taskUsecase.ListArtifactsOutput, error)
+}
+
+// ArtifactHandler maneja las peticiones HTTP de los artefactos de las tareas
+type ArtifactHandler struct {
+	uploadUseCase UploadArtifactUseCaseInterface
+	getUseCase    GetArtifactUseCaseInterface
+	listUseCase   ListArtifactsUseCaseInterface
+	maxBytes      int64
+}
+
+// NewArtifactHandler crea una nueva instancia de ArtifactHandler
+// maxBytes es el tamaño máximo del fichero de cada artefacto
+func NewArtifactHandler(
+	uploadUseCase UploadArtifactUseCaseInterface,
+	getUseCase GetArtifactUseCaseInterface,
+	listUseCase ListArtifactsUseCaseInterface,
+	maxBytes int64,
+) *ArtifactHandler {
+	return &ArtifactHandler{
+		uploadUseCase: uploadUseCase,
+		getUseCase:    getUseCase,
+		listUseCase:   listUseCase,
+		maxBytes:      maxBytes,
+	}
+}
+
+// Upload maneja POST /Automatizacion/{uuid}/artifacts
+// El cuerpo es multipart/form-data con el fichero en el campo file y el autor en el campo uploaded_by
+func (h *ArtifactHandler) Upload(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverheadBytes)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			MapErrorToProblemDetails(c, fmt.Errorf("%w: the maximum is %d bytes", entity.ErrArtifactTooLarge, h.maxBytes))
+			return
+		}
+		MapErrorToProblemDetails(c, fmt.Errorf("%w: a multipart/form-data body with a file field is required", entity.ErrInvalidArtifact))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		MapErrorToProblemDetails(c, fmt.Errorf("failed to open uploaded file: %w", err))
+		return
+	}
+	defer file.Close()
+
+	output, err := h.uploadUseCase.Execute(c.Request.Context(), taskUsecase.UploadArtifactInput{
+		TaskID:      taskID,
+		Name:        fileHeader.Filename,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Size:        fileHeader.Size,
+		Content:     file,
+		UploadedBy:  c.PostForm("uploaded_by"),
+	})
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToArtifactResponse(output.Artifact))
+}
+
+// Download maneja GET /Automatizacion/{uuid}/artifacts/{id}
+// El contenido se envía en streaming con el nombre original en Content-Disposition
+func (h *ArtifactHandler) Download(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+	artifactID, ok := parseUUIDOrError(c, c.Param("id"), entity.ErrArtifactNotFound)
+	if !ok {
+		return
+	}
+
+	output, err := h.getUseCase.Execute(c.Request.Context(), taskUsecase.GetArtifactInput{
+		TaskID:     taskID,
+		ArtifactID: artifactID,
+	})
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+	defer output.Content.Close()
+
+	artifact := output.Artifact
+	c.Header("Content-Type", artifact.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(artifact.Size, 10))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": artifact.Name}))
+	c.Header("X-Checksum-Sha256", artifact.SHA256)
+	c.Status(http.StatusOK)
+
+	// Las cabeceras ya se han enviado: un fallo a mitad de la copia solo puede registrarse
+	if _, err := io.Copy(c.Writer, output.Content); err != nil {
+		log.Printf("Failed to stream artifact %s of task %s: %v", artifact.ID, taskID, err)
+	}
+}
+
+// List maneja GET /Automatizacion/{uuid}/artifacts
+func (h *ArtifactHandler) List(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	output, err := h.listUseCase.Execute(c.Request.Context(), taskUsecase.ListArtifactsInput{TaskID: taskID})
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	// Convertir a response
+	artifacts := make([]ArtifactResponse, 0, len(output.Artifacts))
+	for _, artifact := range output.Artifacts {
+		artifacts = append(artifacts, ToArtifactResponse(artifact))
+	}
+
+	c.JSON(http.StatusOK, ArtifactListResponse{
+		TaskID:    taskID.String(),
+		Artifacts: artifacts,
+	})
+}
@@ -0,0 +1,348 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// MockUploadArtifactUseCase es un mock del UploadArtifactUseCase
+// Lee el contenido recibido para poder comprobarlo en los tests
+type MockUploadArtifactUseCase struct {
+	mock.Mock
+	content []byte
+}
+
+func (m *MockUploadArtifactUseCase) Execute(ctx context.Context, input taskUsecase.UploadArtifactInput) (*taskUsecase.UploadArtifactOutput, error) {
+	content, err := io.ReadAll(input.Content)
+	if err != nil {
+		return nil, err
+	}
+	m.content = content
+	input.Content = nil
+
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.UploadArtifactOutput), args.Error(1)
+}
+
+// MockGetArtifactUseCase es un mock del GetArtifactUseCase
+type MockGetArtifactUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetArtifactUseCase) Execute(ctx context.Context, input taskUsecase.GetArtifactInput) (*taskUsecase.GetArtifactOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.GetArtifactOutput), args.Error(1)
+}
+
+// MockListArtifactsUseCase es un mock del ListArtifactsUseCase
+type MockListArtifactsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListArtifactsUseCase) Execute(ctx context.Context, input taskUsecase.ListArtifactsInput) (*taskUsecase.ListArtifactsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.ListArtifactsOutput), args.Error(1)
+}
+
+func setupArtifactTestRouter(handler *ArtifactHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/Automatizacion/:uuid/artifacts", handler.Upload)
+	router.GET("/Automatizacion/:uuid/artifacts", handler.List)
+	router.GET("/Automatizacion/:uuid/artifacts/:id", handler.Download)
+	return router
+}
+
+// newArtifactUploadRequest construye una subida multipart con el fichero y, si no está vacío, el campo uploaded_by
+func newArtifactUploadRequest(t *testing.T, taskID uuid.UUID, filename, contentType, content, uploadedBy string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if uploadedBy != "" {
+		require.NoError(t, writer.WriteField("uploaded_by", uploadedBy))
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	require.NoError(t, err)
+	_, err = io.WriteString(part, content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+taskID.String()+"/artifacts", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func newTestArtifact(taskID uuid.UUID) *entity.Artifact {
+	id := uuid.New()
+	return &entity.Artifact{
+		ID:          id,
+		TaskID:      taskID,
+		Name:        "report.pdf",
+		ContentType: "application/pdf",
+		Size:        11,
+		SHA256:      "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		StorageKey:  entity.ArtifactStorageKey(taskID, id),
+		UploadedBy:  "robot-01",
+		CreatedAt:   time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestArtifactHandler_Upload_Success(t *testing.T) {
+	// Setup
+	mockUpload := new(MockUploadArtifactUseCase)
+	handler := NewArtifactHandler(mockUpload, new(MockGetArtifactUseCase), new(MockListArtifactsUseCase), 1024)
+	router := setupArtifactTestRouter(handler)
+
+	taskID := uuid.New()
+	artifact := newTestArtifact(taskID)
+
+	// Configurar mock
+	mockUpload.On("Execute", mock.Anything, taskUsecase.UploadArtifactInput{
+		TaskID:      taskID,
+		Name:        "report.pdf",
+		ContentType: "application/pdf",
+		Size:        11,
+		UploadedBy:  "robot-01",
+	}).Return(&taskUsecase.UploadArtifactOutput{Artifact: artifact}, nil)
+
+	// Request
+	req := newArtifactUploadRequest(t, taskID, "report.pdf", "application/pdf", "hello world", "robot-01")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response ArtifactResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, artifact.ID.String(), response.ID)
+	assert.Equal(t, taskID.String(), response.TaskID)
+	assert.Equal(t, "report.pdf", response.Name)
+	assert.Equal(t, int64(11), response.Size)
+	assert.Equal(t, artifact.SHA256, response.SHA256)
+	assert.Equal(t, "hello world", string(mockUpload.content))
+	mockUpload.AssertExpectations(t)
+}
+
+func TestArtifactHandler_Upload_MissingFile(t *testing.T) {
+	// Setup
+	mockUpload := new(MockUploadArtifactUseCase)
+	handler := NewArtifactHandler(mockUpload, new(MockGetArtifactUseCase), new(MockListArtifactsUseCase), 1024)
+	router := setupArtifactTestRouter(handler)
+
+	// Request
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+uuid.New().String()+"/artifacts",
+		strings.NewReader(`{"name": "report.pdf"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-artifact", response.Type)
+	mockUpload.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestArtifactHandler_Upload_TooLarge(t *testing.T) {
+	// Setup
+	mockUpload := new(MockUploadArtifactUseCase)
+	handler := NewArtifactHandler(mockUpload, new(MockGetArtifactUseCase), new(MockListArtifactsUseCase), 1024)
+	router := setupArtifactTestRouter(handler)
+
+	// Request: el cuerpo supera el máximo más el margen del multipart
+	content := strings.Repeat("x", 1024+multipartOverheadBytes)
+	req := newArtifactUploadRequest(t, uuid.New(), "dump.bin", "application/octet-stream", content, "robot-01")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/artifact-too-large", response.Type)
+	mockUpload.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestArtifactHandler_Upload_TaskNotFound(t *testing.T) {
+	// Setup
+	mockUpload := new(MockUploadArtifactUseCase)
+	handler := NewArtifactHandler(mockUpload, new(MockGetArtifactUseCase), new(MockListArtifactsUseCase), 1024)
+	router := setupArtifactTestRouter(handler)
+
+	taskID := uuid.New()
+
+	// Configurar mock
+	mockUpload.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrTaskNotFound)
+
+	// Request
+	req := newArtifactUploadRequest(t, taskID, "report.pdf", "application/pdf", "hello world", "robot-01")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockUpload.AssertExpectations(t)
+}
+
+func TestArtifactHandler_Download_Success(t *testing.T) {
+	// Setup
+	mockGet := new(MockGetArtifactUseCase)
+	handler := NewArtifactHandler(new(MockUploadArtifactUseCase), mockGet, new(MockListArtifactsUseCase), 1024)
+	router := setupArtifactTestRouter(handler)
+
+	taskID := uuid.New()
+	artifact := newTestArtifact(taskID)
+	artifact.Name = "informe final.pdf"
+
+	// Configurar mock
+	mockGet.On("Execute", mock.Anything, taskUsecase.GetArtifactInput{
+		TaskID:     taskID,
+		ArtifactID: artifact.ID,
+	}).Return(&taskUsecase.GetArtifactOutput{
+		Artifact: artifact,
+		Content:  io.NopCloser(strings.NewReader("hello world")),
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String()+"/artifacts/"+artifact.ID.String(), nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello world", w.Body.String())
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, "11", w.Header().Get("Content-Length"))
+	assert.Equal(t, `attachment; filename="informe final.pdf"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, artifact.SHA256, w.Header().Get("X-Checksum-Sha256"))
+	mockGet.AssertExpectations(t)
+}
+
+func TestArtifactHandler_Download_NotFound(t *testing.T) {
+	// Setup
+	mockGet := new(MockGetArtifactUseCase)
+	handler := NewArtifactHandler(new(MockUploadArtifactUseCase), mockGet, new(MockListArtifactsUseCase), 1024)
+	router := setupArtifactTestRouter(handler)
+
+	taskID := uuid.New()
+	artifactID := uuid.New()
+
+	// Configurar mock
+	mockGet.On("Execute", mock.Anything, taskUsecase.GetArtifactInput{
+		TaskID:     taskID,
+		ArtifactID: artifactID,
+	}).Return(nil, entity.ErrArtifactNotFound)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String()+"/artifacts/"+artifactID.String(), nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/artifact-not-found", response.Type)
+	mockGet.AssertExpectations(t)
+}
+
+func TestArtifactHandler_Download_InvalidArtifactID(t *testing.T) {
+	// Setup
+	mockGet := new(MockGetArtifactUseCase)
+	handler := NewArtifactHandler(new(MockUploadArtifactUseCase), mockGet, new(MockListArtifactsUseCase), 1024)
+	router := setupArtifactTestRouter(handler)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+uuid.New().String()+"/artifacts/not-a-uuid", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockGet.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestArtifactHandler_List_Success(t *testing.T) {
+	// Setup
+	mockList := new(MockListArtifactsUseCase)
+	handler := NewArtifactHandler(new(MockUploadArtifactUseCase), new(MockGetArtifactUseCase), mockList, 1024)
+	router := setupArtifactTestRouter(handler)
+
+	taskID := uuid.New()
+	first := newTestArtifact(taskID)
+	second := newTestArtifact(taskID)
+	second.Name = "screenshot.png"
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, taskUsecase.ListArtifactsInput{TaskID: taskID}).
+		Return(&taskUsecase.ListArtifactsOutput{Artifacts: []*entity.Artifact{first, second}}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String()+"/artifacts", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ArtifactListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, taskID.String(), response.TaskID)
+	require.Len(t, response.Artifacts, 2)
+	assert.Equal(t, "report.pdf", response.Artifacts[0].Name)
+	assert.Equal(t, "screenshot.png", response.Artifacts[1].Name)
+	mockList.AssertExpectations(t)
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// RetryTaskRequest representa el request para reintentar una tarea fallida
+type RetryTaskRequest struct {
+	RetriedBy string `json:"retried_by" binding:"required"`
+}
+
+// TaskAttemptResponse representa un intento de una tarea
+// RetriedBy y RetriedAt solo se informan en los intentos cerrados por un reintento
+type TaskAttemptResponse struct {
+	Attempt         int                      `json:"attempt"`
+	State           string                   `json:"state"`
+	Current         bool                     `json:"current"`
+	StartDate       *time.Time               `json:"start_date,omitempty"`
+	EndDate         *time.Time               `json:"end_date,omitempty"`
+	DurationSeconds *int64                   `json:"duration_seconds,omitempty"`
+	PausedSeconds   int64                    `json:"paused_seconds,omitempty"`
+	Failure         *FailureResponse         `json:"failure,omitempty"`
+	Output          json.RawMessage          `json:"output,omitempty"`
+	RetriedBy       *string                  `json:"retried_by,omitempty"`
+	RetriedAt       *time.Time               `json:"retried_at,omitempty"`
+	Subtasks        []SubtaskAttemptResponse `json:"subtasks,omitempty"`
+}
+
+// SubtaskAttemptResponse representa el estado de una subtarea en un intento
+type SubtaskAttemptResponse struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	State     string           `json:"state"`
+	StartDate *time.Time       `json:"start_date,omitempty"`
+	EndDate   *time.Time       `json:"end_date,omitempty"`
+	Failure   *FailureResponse `json:"failure,omitempty"`
+	Output    json.RawMessage  `json:"output,omitempty"`
+}
+
+// TaskAttemptsResponse representa todos los intentos de una tarea, del primero al actual
+type TaskAttemptsResponse struct {
+	TaskID   string                `json:"task_id"`
+	Attempts []TaskAttemptResponse `json:"attempts"`
+}
+
+// ToTaskAttemptResponse convierte un intento cerrado a TaskAttemptResponse, incluyendo sus subtareas
+func ToTaskAttemptResponse(attempt *entity.TaskAttempt) TaskAttemptResponse {
+	subtasks := make([]SubtaskAttemptResponse, 0, len(attempt.Subtasks))
+	for _, subtask := range attempt.Subtasks {
+		subtasks = append(subtasks, SubtaskAttemptResponse{
+			ID:        subtask.SubtaskID.String(),
+			Name:      subtask.Name,
+			State:     subtask.State.String(),
+			StartDate: subtask.StartDate,
+			EndDate:   subtask.EndDate,
+			Failure:   toFailureResponse(subtask.Failure),
+			Output:    subtask.Output,
+		})
+	}
+
+	retriedBy := attempt.RetriedBy
+	retriedAt := attempt.RetriedAt
+
+	return TaskAttemptResponse{
+		Attempt:         attempt.Number,
+		State:           attempt.State.String(),
+		StartDate:       attempt.StartDate,
+		EndDate:         attempt.EndDate,
+		DurationSeconds: durationSeconds(attempt.ActiveDuration()),
+		PausedSeconds:   int64(attempt.PausedDuration / time.Second),
+		Failure:         toFailureResponse(attempt.Failure),
+		Output:          attempt.Output,
+		RetriedBy:       &retriedBy,
+		RetriedAt:       &retriedAt,
+		Subtasks:        subtasks,
+	}
+}
+
+// ToCurrentAttemptResponse representa el intento en curso de una tarea, incluyendo sus subtareas activas
+func ToCurrentAttemptResponse(task *entity.Task) TaskAttemptResponse {
+	subtasks := make([]SubtaskAttemptResponse, 0, len(task.Subtasks))
+	for _, subtask := range task.Subtasks {
+		if subtask.IsDeleted() {
+			continue
+		}
+		subtasks = append(subtasks, SubtaskAttemptResponse{
+			ID:        subtask.ID.String(),
+			Name:      subtask.Name,
+			State:     subtask.State.String(),
+			StartDate: subtask.StartDate,
+			EndDate:   subtask.EndDate,
+			Failure:   toFailureResponse(subtask.Failure),
+			Output:    subtask.Output,
+		})
+	}
+
+	now := task.Now()
+
+	return TaskAttemptResponse{
+		Attempt:         task.Attempt,
+		State:           task.State.String(),
+		Current:         true,
+		StartDate:       task.StartDate,
+		EndDate:         task.EndDate,
+		DurationSeconds: durationSeconds(task.ActiveDuration(now)),
+		PausedSeconds:   pausedSeconds(task.PauseTracking, task.EndDate, now),
+		Failure:         toFailureResponse(task.Failure),
+		Output:          task.Output,
+		Subtasks:        subtasks,
+	}
+}
+
+// ToTaskAttemptsResponse convierte los intentos cerrados y el intento en curso de una tarea
+func ToTaskAttemptsResponse(task *entity.Task, attempts []*entity.TaskAttempt) TaskAttemptsResponse {
+	responses := make([]TaskAttemptResponse, 0, len(attempts)+1)
+	for _, attempt := range attempts {
+		responses = append(responses, ToTaskAttemptResponse(attempt))
+	}
+	responses = append(responses, ToCurrentAttemptResponse(task))
+
+	return TaskAttemptsResponse{
+		TaskID:   task.ID.String(),
+		Attempts: responses,
+	}
+}
+
+// ToTaskAttemptsSummary resume los intentos de una tarea para GET /Automatizacion/{uuid}
+// Omite las subtareas y los resultados de cada intento; el detalle está en GET /Automatizacion/{uuid}/attempts
+func ToTaskAttemptsSummary(task *entity.Task, attempts []*entity.TaskAttempt) []TaskAttemptResponse {
+	summary := ToTaskAttemptsResponse(task, attempts).Attempts
+	for i := range summary {
+		summary[i].Subtasks = nil
+		summary[i].Output = nil
+	}
+	return summary
+}
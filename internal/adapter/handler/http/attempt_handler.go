@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// RetryTaskUseCaseInterface define la interfaz para reintentar tareas fallidas
+type RetryTaskUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.RetryTaskInput) (*taskUsecase.RetryTaskOutput, error)
+}
+
+// ListTaskAttemptsUseCaseInterface define la interfaz para consultar los intentos de una tarea
+type ListTaskAttemptsUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.ListTaskAttemptsInput) (*taskUsecase.ListTaskAttemptsOutput, error)
+}
+
+// AttemptHandler maneja las peticiones HTTP relacionadas con los intentos de una tarea
+type AttemptHandler struct {
+	retryUseCase RetryTaskUseCaseInterface
+	listUseCase  ListTaskAttemptsUseCaseInterface
+}
+
+// NewAttemptHandler crea una nueva instancia de AttemptHandler
+func NewAttemptHandler(retryUseCase RetryTaskUseCaseInterface, listUseCase ListTaskAttemptsUseCaseInterface) *AttemptHandler {
+	return &AttemptHandler{
+		retryUseCase: retryUseCase,
+		listUseCase:  listUseCase,
+	}
+}
+
+// Retry maneja POST /Automatizacion/{uuid}/retry
+func (h *AttemptHandler) Retry(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	var req RetryTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+		return
+	}
+
+	expectedVersion, ok := parseIfMatchOrError(c)
+	if !ok {
+		return
+	}
+
+	input := taskUsecase.RetryTaskInput{
+		ID:              taskID,
+		RetriedBy:       req.RetriedBy,
+		ExpectedVersion: expectedVersion,
+	}
+
+	output, err := h.retryUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	setETag(c, output.Task.Version)
+	c.JSON(http.StatusOK, ToTaskResponse(output.Task))
+}
+
+// List maneja GET /Automatizacion/{uuid}/attempts
+func (h *AttemptHandler) List(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	output, err := h.listUseCase.Execute(c.Request.Context(), taskUsecase.ListTaskAttemptsInput{TaskID: taskID})
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToTaskAttemptsResponse(output.Task, output.Attempts))
+}
@@ -0,0 +1,258 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+	"github.com/grupoapi/proces-log/test/helpers"
+)
+
+// MockRetryTaskUseCase es un mock del RetryTaskUseCase
+type MockRetryTaskUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRetryTaskUseCase) Execute(ctx context.Context, input taskUsecase.RetryTaskInput) (*taskUsecase.RetryTaskOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.RetryTaskOutput), args.Error(1)
+}
+
+// MockListTaskAttemptsUseCase es un mock del ListTaskAttemptsUseCase
+type MockListTaskAttemptsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListTaskAttemptsUseCase) Execute(ctx context.Context, input taskUsecase.ListTaskAttemptsInput) (*taskUsecase.ListTaskAttemptsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.ListTaskAttemptsOutput), args.Error(1)
+}
+
+func setupAttemptTestRouter(handler *AttemptHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/Automatizacion/:uuid/retry", handler.Retry)
+	router.GET("/Automatizacion/:uuid/attempts", handler.List)
+	return router
+}
+
+// failedAndRetriedTask retorna una tarea en su segundo intento y el intento cerrado, que falló con motivo y resultados
+func failedAndRetriedTask(t *testing.T) (*entity.Task, *entity.TaskAttempt) {
+	t.Helper()
+
+	task, err := entity.NewTask("Retried Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	subtask, err := entity.NewSubtask("Step 1", helpers.NewTestClock())
+	require.NoError(t, err)
+	task.AddSubtask(subtask)
+	require.NoError(t, task.UpdateState(entity.StateInProgress, "test-user"))
+	failure := &entity.Failure{Code: "HTTP_TIMEOUT", Message: "upstream timed out", Details: map[string]interface{}{"url": "https://example.com"}}
+	require.NoError(t, task.UpdateStateWithFailure(entity.StateFailed, "test-user", failure))
+	require.NoError(t, task.SetOutput(entity.Payload(`{"rows":1200}`), entity.DefaultMaxPayloadBytes))
+
+	attempt, err := task.Retry("retry-user")
+	require.NoError(t, err)
+	return task, attempt
+}
+
+func TestAttemptHandler_Retry_Success(t *testing.T) {
+	// Setup
+	mockRetry := new(MockRetryTaskUseCase)
+	handler := NewAttemptHandler(mockRetry, new(MockListTaskAttemptsUseCase))
+	router := setupAttemptTestRouter(handler)
+
+	task, attempt := failedAndRetriedTask(t)
+	task.Version = 3
+	expectedVersion := 2
+
+	// Configurar mock
+	mockRetry.On("Execute", mock.Anything, taskUsecase.RetryTaskInput{
+		ID:              task.ID,
+		RetriedBy:       "retry-user",
+		ExpectedVersion: &expectedVersion,
+	}).Return(&taskUsecase.RetryTaskOutput{Task: task, Attempt: attempt}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+task.ID.String()+"/retry",
+		bytes.NewBufferString(`{"retried_by":"retry-user"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	var response TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "PENDING", response.State)
+	assert.Equal(t, 2, response.Attempt)
+	assert.Nil(t, response.StartDate)
+	require.Len(t, response.Subtasks, 1)
+	assert.Equal(t, "PENDING", response.Subtasks[0].State)
+	mockRetry.AssertExpectations(t)
+}
+
+func TestAttemptHandler_Retry_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		useCaseErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "task not failed",
+			body:           `{"retried_by":"retry-user"}`,
+			useCaseErr:     fmt.Errorf("%w: task is COMPLETED", entity.ErrTaskNotRetryable),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "task not found",
+			body:           `{"retried_by":"retry-user"}`,
+			useCaseErr:     entity.ErrTaskNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "missing retried_by",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockRetry := new(MockRetryTaskUseCase)
+			handler := NewAttemptHandler(mockRetry, new(MockListTaskAttemptsUseCase))
+			router := setupAttemptTestRouter(handler)
+
+			taskID := uuid.New()
+			if tt.useCaseErr != nil {
+				mockRetry.On("Execute", mock.Anything, mock.Anything).Return(nil, tt.useCaseErr)
+			}
+
+			// Request
+			req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+taskID.String()+"/retry", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Execute
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockRetry.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttemptHandler_List_Success(t *testing.T) {
+	// Setup
+	mockList := new(MockListTaskAttemptsUseCase)
+	handler := NewAttemptHandler(new(MockRetryTaskUseCase), mockList)
+	router := setupAttemptTestRouter(handler)
+
+	task, attempt := failedAndRetriedTask(t)
+	require.NoError(t, task.UpdateState(entity.StateInProgress, "test-user"))
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, taskUsecase.ListTaskAttemptsInput{TaskID: task.ID}).
+		Return(&taskUsecase.ListTaskAttemptsOutput{Task: task, Attempts: []*entity.TaskAttempt{attempt}}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+task.ID.String()+"/attempts", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskAttemptsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, task.ID.String(), response.TaskID)
+	require.Len(t, response.Attempts, 2)
+
+	previous := response.Attempts[0]
+	assert.Equal(t, 1, previous.Attempt)
+	assert.Equal(t, "FAILED", previous.State)
+	assert.False(t, previous.Current)
+	assert.NotNil(t, previous.EndDate)
+	assert.NotNil(t, previous.DurationSeconds)
+	assert.Equal(t, "retry-user", *previous.RetriedBy)
+	require.NotNil(t, previous.Failure)
+	assert.Equal(t, "HTTP_TIMEOUT", previous.Failure.ErrorCode)
+	assert.Equal(t, "upstream timed out", previous.Failure.ErrorMessage)
+	assert.Equal(t, map[string]interface{}{"url": "https://example.com"}, previous.Failure.Details)
+	assert.JSONEq(t, `{"rows":1200}`, string(previous.Output))
+	require.Len(t, previous.Subtasks, 1)
+	assert.Equal(t, "FAILED", previous.Subtasks[0].State)
+
+	current := response.Attempts[1]
+	assert.Equal(t, 2, current.Attempt)
+	assert.Equal(t, "IN_PROGRESS", current.State)
+	assert.True(t, current.Current)
+	assert.Nil(t, current.RetriedBy)
+	assert.Nil(t, current.Failure)
+	assert.Nil(t, current.Output)
+	require.Len(t, current.Subtasks, 1)
+	assert.Equal(t, "PENDING", current.Subtasks[0].State)
+	mockList.AssertExpectations(t)
+}
+
+func TestAttemptHandler_List_TaskNotFound(t *testing.T) {
+	// Setup
+	mockList := new(MockListTaskAttemptsUseCase)
+	handler := NewAttemptHandler(new(MockRetryTaskUseCase), mockList)
+	router := setupAttemptTestRouter(handler)
+
+	taskID := uuid.New()
+	mockList.On("Execute", mock.Anything, taskUsecase.ListTaskAttemptsInput{TaskID: taskID}).
+		Return(nil, entity.ErrTaskNotFound)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String()+"/attempts", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockList.AssertExpectations(t)
+}
+
+func TestToTaskAttemptsSummary(t *testing.T) {
+	task, attempt := failedAndRetriedTask(t)
+
+	summary := ToTaskAttemptsSummary(task, []*entity.TaskAttempt{attempt})
+
+	require.Len(t, summary, 2)
+	assert.Equal(t, 1, summary[0].Attempt)
+	assert.Equal(t, 2, summary[1].Attempt)
+	assert.True(t, summary[1].Current)
+	assert.Nil(t, summary[0].Subtasks)
+	assert.Nil(t, summary[1].Subtasks)
+	assert.Nil(t, summary[0].Output)
+	assert.NotNil(t, summary[0].Failure)
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// SubtaskTemplateItem representa una subtarea de la plantilla de un automatismo, en requests y respuestas
+type SubtaskTemplateItem struct {
+	Name      string                `json:"name"`
+	DependsOn []string              `json:"depends_on,omitempty"` // Nombres de otras subtareas de la plantilla
+	Subtasks  []SubtaskTemplateItem `json:"subtasks,omitempty"`   // Subtareas anidadas bajo esta
+}
+
+// CreateAutomationRequest representa el request para registrar un automatismo
+type CreateAutomationRequest struct {
+	Key             string                `json:"key" binding:"required"`
+	OwnerTeam       string                `json:"owner_team" binding:"required"`
+	Description     string                `json:"description,omitempty"`
+	SubtaskTemplate []SubtaskTemplateItem `json:"subtask_template,omitempty"`
+	Schedule        *string               `json:"schedule,omitempty"`     // Expresión cron de cinco campos
+	Timezone        string                `json:"timezone,omitempty"`     // Zona horaria IANA, UTC por defecto
+	GracePeriod     *int                  `json:"grace_period,omitempty"` // Segundos para que se cree cada ejecución planificada
+	CreatedBy       string                `json:"created_by" binding:"required"`
+}
+
+// UpdateAutomationRequest representa el request para modificar un automatismo
+// Los campos omitidos no se modifican; schedule vacío elimina la planificación
+type UpdateAutomationRequest struct {
+	OwnerTeam       *string                `json:"owner_team,omitempty"`
+	Description     *string                `json:"description,omitempty"`
+	SubtaskTemplate *[]SubtaskTemplateItem `json:"subtask_template,omitempty"`
+	Schedule        *string                `json:"schedule,omitempty"`
+	Timezone        *string                `json:"timezone,omitempty"`
+	GracePeriod     *int                   `json:"grace_period,omitempty"`
+	UpdatedBy       string                 `json:"updated_by" binding:"required"`
+}
+
+// DeleteAutomationRequest representa el request para eliminar un automatismo
+type DeleteAutomationRequest struct {
+	DeletedBy string `json:"deleted_by" binding:"required"`
+}
+
+// AutomationResponse representa la respuesta de un automatismo
+type AutomationResponse struct {
+	ID              string                `json:"id"`
+	Key             string                `json:"key"`
+	OwnerTeam       string                `json:"owner_team"`
+	Description     string                `json:"description,omitempty"`
+	SubtaskTemplate []SubtaskTemplateItem `json:"subtask_template"`
+	// Planificación esperada (se omite en los automatismos que se lanzan bajo demanda)
+	Schedule *string `json:"schedule,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+	// Segundos tras cada activación en los que debe crearse su ejecución para no darla por perdida
+	GracePeriod int        `json:"grace_period"`
+	CreatedBy   string     `json:"created_by"`
+	UpdatedBy   string     `json:"updated_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Version     int        `json:"version"`
+	// Resumen de las ejecuciones (se omite en las respuestas de creación y modificación)
+	Runs *AutomationRunsResponse `json:"runs,omitempty"`
+}
+
+// AutomationRunsResponse resume las ejecuciones no eliminadas de un automatismo y las perdidas
+type AutomationRunsResponse struct {
+	Total   int                    `json:"total"`
+	ByState map[string]int         `json:"by_state"`
+	LastRun *AutomationRunResponse `json:"last_run,omitempty"`
+	// Activaciones planificadas sin ejecución y la más reciente de ellas
+	Missed       int        `json:"missed"`
+	LastMissedAt *time.Time `json:"last_missed_at,omitempty"`
+}
+
+// AutomationRunResponse identifica la última ejecución de un automatismo
+type AutomationRunResponse struct {
+	TaskID    string    `json:"task_id"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MissedRunResponse representa una activación planificada para la que no se creó ninguna ejecución
+type MissedRunResponse struct {
+	ID         string    `json:"id"`
+	ExpectedAt time.Time `json:"expected_at"`
+	Deadline   time.Time `json:"deadline"` // Fin del margen para crear la ejecución
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// MissedRunListResponse representa la respuesta del listado de ejecuciones perdidas de un automatismo
+type MissedRunListResponse struct {
+	Automation string              `json:"automation"`
+	MissedRuns []MissedRunResponse `json:"missed_runs"`
+	Pagination PaginationResponse  `json:"pagination"`
+}
+
+// AutomationListResponse representa la respuesta del listado de automatismos
+type AutomationListResponse struct {
+	Automations []AutomationResponse `json:"automations"`
+	Pagination  PaginationResponse   `json:"pagination"`
+}
+
+// ToAutomationResponse convierte una entidad Automation a AutomationResponse; runs es opcional
+func ToAutomationResponse(automation *entity.Automation, runs *entity.AutomationRunSummary) AutomationResponse {
+	response := AutomationResponse{
+		ID:              automation.ID.String(),
+		Key:             automation.Key,
+		OwnerTeam:       automation.OwnerTeam,
+		Description:     automation.Description,
+		SubtaskTemplate: toSubtaskTemplateItems(automation.SubtaskTemplate),
+		CreatedBy:       automation.CreatedBy,
+		UpdatedBy:       automation.UpdatedBy,
+		CreatedAt:       automation.CreatedAt,
+		UpdatedAt:       automation.UpdatedAt,
+		GracePeriod:     int(automation.GracePeriod.Seconds()),
+		DeletedAt:       automation.DeletedAt,
+		Version:         automation.Version,
+		Runs:            toAutomationRunsResponse(runs),
+	}
+	if automation.Schedule != nil {
+		response.Schedule = &automation.Schedule.Expression
+		response.Timezone = &automation.Schedule.Timezone
+	}
+	return response
+}
+
+// toAutomationRunsResponse convierte el resumen de ejecuciones; nil si no se calculó
+func toAutomationRunsResponse(runs *entity.AutomationRunSummary) *AutomationRunsResponse {
+	if runs == nil {
+		return nil
+	}
+
+	byState := make(map[string]int, len(runs.ByState))
+	for state, count := range runs.ByState {
+		byState[state.String()] = count
+	}
+
+	response := &AutomationRunsResponse{
+		Total:        runs.Total,
+		ByState:      byState,
+		Missed:       runs.Missed,
+		LastMissedAt: runs.LastMissedAt,
+	}
+	if runs.LastRun != nil {
+		response.LastRun = &AutomationRunResponse{
+			TaskID:    runs.LastRun.TaskID.String(),
+			State:     runs.LastRun.State.String(),
+			CreatedAt: runs.LastRun.CreatedAt,
+		}
+	}
+	return response
+}
+
+// ToMissedRunResponse convierte una entidad MissedRun a MissedRunResponse
+func ToMissedRunResponse(run *entity.MissedRun) MissedRunResponse {
+	return MissedRunResponse{
+		ID:         run.ID.String(),
+		ExpectedAt: run.ExpectedAt,
+		Deadline:   run.Deadline,
+		DetectedAt: run.DetectedAt,
+	}
+}
+
+// toSubtaskTemplateItems convierte la plantilla de la entidad a su representación JSON
+func toSubtaskTemplateItems(template []entity.SubtaskTemplate) []SubtaskTemplateItem {
+	items := make([]SubtaskTemplateItem, 0, len(template))
+	for _, item := range template {
+		items = append(items, SubtaskTemplateItem{
+			Name:      item.Name,
+			DependsOn: item.DependsOn,
+			Subtasks:  toSubtaskTemplateItems(item.Subtasks),
+		})
+	}
+	return items
+}
+
+// toSubtaskTemplate convierte la plantilla del request a la entidad
+func toSubtaskTemplate(items []SubtaskTemplateItem) []entity.SubtaskTemplate {
+	template := make([]entity.SubtaskTemplate, 0, len(items))
+	for _, item := range items {
+		template = append(template, entity.SubtaskTemplate{
+			Name:      item.Name,
+			DependsOn: item.DependsOn,
+			Subtasks:  toSubtaskTemplate(item.Subtasks),
+		})
+	}
+	return template
+}
@@ -0,0 +1,250 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	automationUsecase "github.com/grupoapi/proces-log/internal/usecase/automation"
+)
+
+// CreateAutomationUseCaseInterface define la interfaz para registrar automatismos
+type CreateAutomationUseCaseInterface interface {
+	Execute(ctx context.Context, input automationUsecase.CreateAutomationInput) (*automationUsecase.CreateAutomationOutput, error)
+}
+
+// GetAutomationUseCaseInterface define la interfaz para obtener un automatismo
+type GetAutomationUseCaseInterface interface {
+	Execute(ctx context.Context, input automationUsecase.GetAutomationInput) (*automationUsecase.GetAutomationOutput, error)
+}
+
+// ListAutomationsUseCaseInterface define la interfaz para listar automatismos
+type ListAutomationsUseCaseInterface interface {
+	Execute(ctx context.Context, input automationUsecase.ListAutomationsInput) (*automationUsecase.ListAutomationsOutput, error)
+}
+
+// UpdateAutomationUseCaseInterface define la interfaz para modificar automatismos
+type UpdateAutomationUseCaseInterface interface {
+	Execute(ctx context.Context, input automationUsecase.UpdateAutomationInput) (*automationUsecase.UpdateAutomationOutput, error)
+}
+
+// DeleteAutomationUseCaseInterface define la interfaz para eliminar automatismos
+type DeleteAutomationUseCaseInterface interface {
+	Execute(ctx context.Context, input automationUsecase.DeleteAutomationInput) (*automationUsecase.DeleteAutomationOutput, error)
+}
+
+// ListMissedRunsUseCaseInterface define la interfaz para listar las ejecuciones perdidas de un automatismo
+type ListMissedRunsUseCaseInterface interface {
+	Execute(ctx context.Context, input automationUsecase.ListMissedRunsInput) (*automationUsecase.ListMissedRunsOutput, error)
+}
+
+// AutomationHandler maneja las peticiones HTTP de las definiciones de automatismos
+type AutomationHandler struct {
+	createUseCase CreateAutomationUseCaseInterface
+	getUseCase    GetAutomationUseCaseInterface
+	listUseCase   ListAutomationsUseCaseInterface
+	updateUseCase UpdateAutomationUseCaseInterface
+	deleteUseCase DeleteAutomationUseCaseInterface
+	missedUseCase ListMissedRunsUseCaseInterface
+}
+
+// NewAutomationHandler crea una nueva instancia de AutomationHandler
+func NewAutomationHandler(
+	createUseCase CreateAutomationUseCaseInterface,
+	getUseCase GetAutomationUseCaseInterface,
+	listUseCase ListAutomationsUseCaseInterface,
+	updateUseCase UpdateAutomationUseCaseInterface,
+	deleteUseCase DeleteAutomationUseCaseInterface,
+	missedUseCase ListMissedRunsUseCaseInterface,
+) *AutomationHandler {
+	return &AutomationHandler{
+		createUseCase: createUseCase,
+		getUseCase:    getUseCase,
+		listUseCase:   listUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+		missedUseCase: missedUseCase,
+	}
+}
+
+// Create maneja POST /Automatismo
+func (h *AutomationHandler) Create(c *gin.Context) {
+	var req CreateAutomationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+		return
+	}
+
+	input := automationUsecase.CreateAutomationInput{
+		Key:             req.Key,
+		OwnerTeam:       req.OwnerTeam,
+		Description:     req.Description,
+		SubtaskTemplate: toSubtaskTemplate(req.SubtaskTemplate),
+		Schedule:        req.Schedule,
+		Timezone:        req.Timezone,
+		GracePeriod:     req.GracePeriod,
+		CreatedBy:       req.CreatedBy,
+	}
+
+	output, err := h.createUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	setETag(c, output.Automation.Version)
+	c.JSON(http.StatusCreated, ToAutomationResponse(output.Automation, nil))
+}
+
+// Get maneja GET /Automatismo/{key}
+func (h *AutomationHandler) Get(c *gin.Context) {
+	output, err := h.getUseCase.Execute(c.Request.Context(), automationUsecase.GetAutomationInput{Key: c.Param("key")})
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	setETag(c, output.Automation.Version)
+	c.JSON(http.StatusOK, ToAutomationResponse(output.Automation, output.Runs))
+}
+
+// List maneja GET /AutomatismoListado
+// Cada automatismo incluye el resumen de sus ejecuciones agrupadas por estado y la última de ellas
+func (h *AutomationHandler) List(c *gin.Context) {
+	var ownerTeam *string
+	if ownerTeamStr := c.Query("owner_team"); ownerTeamStr != "" {
+		ownerTeam = &ownerTeamStr
+	}
+
+	page, limit, ok := parsePaginationOrError(c, 20)
+	if !ok {
+		return
+	}
+
+	input := automationUsecase.ListAutomationsInput{
+		OwnerTeam: ownerTeam,
+		Page:      page,
+		Limit:     limit,
+	}
+
+	output, err := h.listUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	automations := make([]AutomationResponse, 0, len(output.Automations))
+	for _, automation := range output.Automations {
+		automations = append(automations, ToAutomationResponse(automation, output.Runs[automation.Key]))
+	}
+
+	c.JSON(http.StatusOK, AutomationListResponse{
+		Automations: automations,
+		Pagination: PaginationResponse{
+			Page:       output.Page,
+			Limit:      output.Limit,
+			Total:      output.Total,
+			TotalPages: output.TotalPages,
+		},
+	})
+}
+
+// Update maneja PUT /Automatismo/{key}
+// Si se envía la cabecera If-Match, la modificación solo se aplica si coincide con la versión actual
+func (h *AutomationHandler) Update(c *gin.Context) {
+	expectedVersion, ok := parseIfMatchOrError(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateAutomationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+		return
+	}
+
+	input := automationUsecase.UpdateAutomationInput{
+		Key:         c.Param("key"),
+		OwnerTeam:   req.OwnerTeam,
+		Description: req.Description,
+		Schedule:    req.Schedule,
+		Timezone:    req.Timezone,
+		GracePeriod: req.GracePeriod,
+		UpdatedBy:   req.UpdatedBy,
+
+		ExpectedVersion: expectedVersion,
+	}
+	if req.SubtaskTemplate != nil {
+		template := toSubtaskTemplate(*req.SubtaskTemplate)
+		input.SubtaskTemplate = &template
+	}
+
+	output, err := h.updateUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	setETag(c, output.Automation.Version)
+	c.JSON(http.StatusOK, ToAutomationResponse(output.Automation, nil))
+}
+
+// Delete maneja DELETE /Automatismo/{key}
+func (h *AutomationHandler) Delete(c *gin.Context) {
+	var req DeleteAutomationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+		return
+	}
+
+	input := automationUsecase.DeleteAutomationInput{
+		Key:       c.Param("key"),
+		DeletedBy: req.DeletedBy,
+	}
+
+	if _, err := h.deleteUseCase.Execute(c.Request.Context(), input); err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListMissed maneja GET /Automatismo/{key}/missed
+// Lista las activaciones planificadas para las que no se creó ninguna ejecución, de la más reciente a la más antigua
+func (h *AutomationHandler) ListMissed(c *gin.Context) {
+	page, limit, ok := parsePaginationOrError(c, 20)
+	if !ok {
+		return
+	}
+
+	input := automationUsecase.ListMissedRunsInput{
+		Key:   c.Param("key"),
+		Page:  page,
+		Limit: limit,
+	}
+
+	output, err := h.missedUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	missedRuns := make([]MissedRunResponse, 0, len(output.MissedRuns))
+	for _, run := range output.MissedRuns {
+		missedRuns = append(missedRuns, ToMissedRunResponse(run))
+	}
+
+	c.JSON(http.StatusOK, MissedRunListResponse{
+		Automation: input.Key,
+		MissedRuns: missedRuns,
+		Pagination: PaginationResponse{
+			Page:       output.Page,
+			Limit:      output.Limit,
+			Total:      output.Total,
+			TotalPages: output.TotalPages,
+		},
+	})
+}
@@ -0,0 +1,478 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	automationUsecase "github.com/grupoapi/proces-log/internal/usecase/automation"
+	"github.com/grupoapi/proces-log/test/helpers"
+)
+
+// MockCreateAutomationUseCase es un mock del CreateAutomationUseCase
+type MockCreateAutomationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCreateAutomationUseCase) Execute(ctx context.Context, input automationUsecase.CreateAutomationInput) (*automationUsecase.CreateAutomationOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*automationUsecase.CreateAutomationOutput), args.Error(1)
+}
+
+// MockGetAutomationUseCase es un mock del GetAutomationUseCase
+type MockGetAutomationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetAutomationUseCase) Execute(ctx context.Context, input automationUsecase.GetAutomationInput) (*automationUsecase.GetAutomationOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*automationUsecase.GetAutomationOutput), args.Error(1)
+}
+
+// MockListAutomationsUseCase es un mock del ListAutomationsUseCase
+type MockListAutomationsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListAutomationsUseCase) Execute(ctx context.Context, input automationUsecase.ListAutomationsInput) (*automationUsecase.ListAutomationsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*automationUsecase.ListAutomationsOutput), args.Error(1)
+}
+
+// MockUpdateAutomationUseCase es un mock del UpdateAutomationUseCase
+type MockUpdateAutomationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUpdateAutomationUseCase) Execute(ctx context.Context, input automationUsecase.UpdateAutomationInput) (*automationUsecase.UpdateAutomationOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*automationUsecase.UpdateAutomationOutput), args.Error(1)
+}
+
+// MockDeleteAutomationUseCase es un mock del DeleteAutomationUseCase
+type MockDeleteAutomationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDeleteAutomationUseCase) Execute(ctx context.Context, input automationUsecase.DeleteAutomationInput) (*automationUsecase.DeleteAutomationOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*automationUsecase.DeleteAutomationOutput), args.Error(1)
+}
+
+// MockListMissedRunsUseCase es un mock del ListMissedRunsUseCase
+type MockListMissedRunsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListMissedRunsUseCase) Execute(ctx context.Context, input automationUsecase.ListMissedRunsInput) (*automationUsecase.ListMissedRunsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*automationUsecase.ListMissedRunsOutput), args.Error(1)
+}
+
+// automationHandlerMocks agrupa los mocks de los casos de uso del AutomationHandler
+type automationHandlerMocks struct {
+	create *MockCreateAutomationUseCase
+	get    *MockGetAutomationUseCase
+	list   *MockListAutomationsUseCase
+	update *MockUpdateAutomationUseCase
+	delete *MockDeleteAutomationUseCase
+	missed *MockListMissedRunsUseCase
+}
+
+func setupAutomationTestRouter() (*gin.Engine, automationHandlerMocks) {
+	mocks := automationHandlerMocks{
+		create: new(MockCreateAutomationUseCase),
+		get:    new(MockGetAutomationUseCase),
+		list:   new(MockListAutomationsUseCase),
+		update: new(MockUpdateAutomationUseCase),
+		delete: new(MockDeleteAutomationUseCase),
+		missed: new(MockListMissedRunsUseCase),
+	}
+	handler := NewAutomationHandler(mocks.create, mocks.get, mocks.list, mocks.update, mocks.delete, mocks.missed)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/Automatismo", handler.Create)
+	router.GET("/Automatismo/:key", handler.Get)
+	router.PUT("/Automatismo/:key", handler.Update)
+	router.DELETE("/Automatismo/:key", handler.Delete)
+	router.GET("/AutomatismoListado", handler.List)
+	router.GET("/Automatismo/:key/missed", handler.ListMissed)
+	return router, mocks
+}
+
+func newTestAutomation(t *testing.T) *entity.Automation {
+	automation, err := entity.NewAutomation("billing.nightly-export", "billing", "scheduler", helpers.NewTestClock())
+	require.NoError(t, err)
+	require.NoError(t, automation.SetSubtaskTemplate([]entity.SubtaskTemplate{
+		{Name: "extract"},
+		{Name: "load", DependsOn: []string{"extract"}},
+	}))
+	schedule, err := entity.ParseSchedule("0 2 * * *", "Europe/Madrid")
+	require.NoError(t, err)
+	automation.SetSchedule(schedule)
+	return automation
+}
+
+func TestAutomationHandler_Create_Success(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+	automation := newTestAutomation(t)
+	schedule := "0 2 * * *"
+
+	// Configurar mock
+	mocks.create.On("Execute", mock.Anything, automationUsecase.CreateAutomationInput{
+		Key:       "billing.nightly-export",
+		OwnerTeam: "billing",
+		SubtaskTemplate: []entity.SubtaskTemplate{
+			{Name: "extract", Subtasks: []entity.SubtaskTemplate{}},
+			{Name: "load", DependsOn: []string{"extract"}, Subtasks: []entity.SubtaskTemplate{}},
+		},
+		Schedule:  &schedule,
+		Timezone:  "Europe/Madrid",
+		CreatedBy: "scheduler",
+	}).Return(&automationUsecase.CreateAutomationOutput{Automation: automation}, nil)
+
+	// Request
+	body := `{
+		"key": "billing.nightly-export",
+		"owner_team": "billing",
+		"subtask_template": [{"name": "extract"}, {"name": "load", "depends_on": ["extract"]}],
+		"schedule": "0 2 * * *",
+		"timezone": "Europe/Madrid",
+		"created_by": "scheduler"
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/Automatismo", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	var response AutomationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "billing.nightly-export", response.Key)
+	assert.Equal(t, "billing", response.OwnerTeam)
+	require.Len(t, response.SubtaskTemplate, 2)
+	assert.Equal(t, []string{"extract"}, response.SubtaskTemplate[1].DependsOn)
+	require.NotNil(t, response.Schedule)
+	assert.Equal(t, "0 2 * * *", *response.Schedule)
+	assert.Equal(t, "Europe/Madrid", *response.Timezone)
+	assert.Equal(t, 900, response.GracePeriod)
+	assert.Nil(t, response.Runs)
+	mocks.create.AssertExpectations(t)
+}
+
+func TestAutomationHandler_Create_MissingOwnerTeam(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+
+	// Request
+	body := `{"key": "billing.nightly-export", "created_by": "scheduler"}`
+	req := httptest.NewRequest(http.MethodPost, "/Automatismo", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mocks.create.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestAutomationHandler_Create_KeyInUse(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+
+	// Configurar mock
+	mocks.create.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrAutomationExists)
+
+	// Request
+	body := `{"key": "billing.nightly-export", "owner_team": "billing", "created_by": "scheduler"}`
+	req := httptest.NewRequest(http.MethodPost, "/Automatismo", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/automation-exists", response.Type)
+}
+
+func TestAutomationHandler_Get_WithRuns(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+	automation := newTestAutomation(t)
+	lastRunID := uuid.New()
+	lastRunAt := time.Date(2026, 3, 2, 2, 0, 5, 0, time.UTC)
+
+	// Configurar mock
+	mocks.get.On("Execute", mock.Anything, automationUsecase.GetAutomationInput{Key: "billing.nightly-export"}).
+		Return(&automationUsecase.GetAutomationOutput{
+			Automation: automation,
+			Runs: &entity.AutomationRunSummary{
+				Total:   3,
+				ByState: map[entity.State]int{entity.StateCompleted: 2, entity.StateFailed: 1},
+				LastRun: &entity.AutomationRun{TaskID: lastRunID, State: entity.StateFailed, CreatedAt: lastRunAt},
+			},
+		}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatismo/billing.nightly-export", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response AutomationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.NotNil(t, response.Runs)
+	assert.Equal(t, 3, response.Runs.Total)
+	assert.Equal(t, map[string]int{"COMPLETED": 2, "FAILED": 1}, response.Runs.ByState)
+	require.NotNil(t, response.Runs.LastRun)
+	assert.Equal(t, lastRunID.String(), response.Runs.LastRun.TaskID)
+	assert.Equal(t, "FAILED", response.Runs.LastRun.State)
+	mocks.get.AssertExpectations(t)
+}
+
+func TestAutomationHandler_Get_NotFound(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+
+	// Configurar mock
+	mocks.get.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrAutomationNotFound)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatismo/unknown", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/automation-not-found", response.Type)
+}
+
+func TestAutomationHandler_List_Success(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+	automation := newTestAutomation(t)
+	ownerTeam := "billing"
+
+	// Configurar mock
+	mocks.list.On("Execute", mock.Anything, automationUsecase.ListAutomationsInput{
+		OwnerTeam: &ownerTeam,
+		Page:      1,
+		Limit:     20,
+	}).Return(&automationUsecase.ListAutomationsOutput{
+		Automations: []*entity.Automation{automation},
+		Runs:        map[string]*entity.AutomationRunSummary{automation.Key: entity.NewAutomationRunSummary()},
+		Total:       1,
+		Page:        1,
+		Limit:       20,
+		TotalPages:  1,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatismoListado?owner_team=billing", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response AutomationListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Automations, 1)
+	require.NotNil(t, response.Automations[0].Runs)
+	assert.Equal(t, 0, response.Automations[0].Runs.Total)
+	assert.Nil(t, response.Automations[0].Runs.LastRun)
+	assert.Equal(t, 1, response.Pagination.Total)
+	mocks.list.AssertExpectations(t)
+}
+
+func TestAutomationHandler_Update_WithIfMatch(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+	automation := newTestAutomation(t)
+	automation.Version = 3
+	expectedVersion := 2
+	emptySchedule := ""
+
+	// Configurar mock
+	mocks.update.On("Execute", mock.Anything, automationUsecase.UpdateAutomationInput{
+		Key:             "billing.nightly-export",
+		Schedule:        &emptySchedule,
+		SubtaskTemplate: &[]entity.SubtaskTemplate{},
+		UpdatedBy:       "alice",
+		ExpectedVersion: &expectedVersion,
+	}).Return(&automationUsecase.UpdateAutomationOutput{Automation: automation}, nil)
+
+	// Request
+	body := `{"schedule": "", "subtask_template": [], "updated_by": "alice"}`
+	req := httptest.NewRequest(http.MethodPut, "/Automatismo/billing.nightly-export", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	mocks.update.AssertExpectations(t)
+}
+
+func TestAutomationHandler_Update_VersionConflict(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+
+	// Configurar mock
+	mocks.update.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrVersionConflict)
+
+	// Request
+	body := `{"description": "Exports yesterday's invoices", "updated_by": "alice"}`
+	req := httptest.NewRequest(http.MethodPut, "/Automatismo/billing.nightly-export", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+}
+
+func TestAutomationHandler_Delete_Success(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+
+	// Configurar mock
+	mocks.delete.On("Execute", mock.Anything, automationUsecase.DeleteAutomationInput{
+		Key:       "billing.nightly-export",
+		DeletedBy: "alice",
+	}).Return(&automationUsecase.DeleteAutomationOutput{Success: true}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodDelete, "/Automatismo/billing.nightly-export", bytes.NewBufferString(`{"deleted_by": "alice"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mocks.delete.AssertExpectations(t)
+}
+
+func TestAutomationHandler_ListMissed_Success(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+	automation := newTestAutomation(t)
+	expectedAt := time.Date(2026, 3, 2, 1, 0, 0, 0, time.UTC)
+	missed := entity.NewMissedRun(automation, expectedAt, expectedAt.Add(20*time.Minute))
+
+	// Configurar mock
+	mocks.missed.On("Execute", mock.Anything, automationUsecase.ListMissedRunsInput{
+		Key:   "billing.nightly-export",
+		Page:  2,
+		Limit: 10,
+	}).Return(&automationUsecase.ListMissedRunsOutput{
+		MissedRuns: []*entity.MissedRun{missed},
+		Total:      11,
+		Page:       2,
+		Limit:      10,
+		TotalPages: 2,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatismo/billing.nightly-export/missed?page=2&limit=10", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response MissedRunListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "billing.nightly-export", response.Automation)
+	require.Len(t, response.MissedRuns, 1)
+	assert.Equal(t, missed.ID.String(), response.MissedRuns[0].ID)
+	assert.True(t, expectedAt.Equal(response.MissedRuns[0].ExpectedAt))
+	assert.True(t, expectedAt.Add(15*time.Minute).Equal(response.MissedRuns[0].Deadline))
+	assert.Equal(t, 11, response.Pagination.Total)
+	mocks.missed.AssertExpectations(t)
+}
+
+func TestAutomationHandler_ListMissed_NotFound(t *testing.T) {
+	// Setup
+	router, mocks := setupAutomationTestRouter()
+
+	// Configurar mock
+	mocks.missed.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrAutomationNotFound)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatismo/unknown/missed", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "automation-not-found")
+	mocks.missed.AssertExpectations(t)
+}
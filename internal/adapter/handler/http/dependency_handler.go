@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// ListReadySubtasksUseCaseInterface define la interfaz para consultar las subtareas listas para arrancar
+type ListReadySubtasksUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.ListReadySubtasksInput) (*taskUsecase.ListReadySubtasksOutput, error)
+}
+
+// DependencyHandler maneja las peticiones HTTP relacionadas con las dependencias entre subtareas
+type DependencyHandler struct {
+	readyUseCase ListReadySubtasksUseCaseInterface
+}
+
+// NewDependencyHandler crea una nueva instancia de DependencyHandler
+func NewDependencyHandler(readyUseCase ListReadySubtasksUseCaseInterface) *DependencyHandler {
+	return &DependencyHandler{readyUseCase: readyUseCase}
+}
+
+// Ready maneja GET /Automatizacion/{uuid}/ready
+func (h *DependencyHandler) Ready(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	output, err := h.readyUseCase.Execute(c.Request.Context(), taskUsecase.ListReadySubtasksInput{TaskID: taskID})
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToReadySubtasksResponse(output.Task, output.Subtasks))
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+	"github.com/grupoapi/proces-log/test/helpers"
+)
+
+// MockListReadySubtasksUseCase es un mock del ListReadySubtasksUseCase
+type MockListReadySubtasksUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListReadySubtasksUseCase) Execute(ctx context.Context, input taskUsecase.ListReadySubtasksInput) (*taskUsecase.ListReadySubtasksOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.ListReadySubtasksOutput), args.Error(1)
+}
+
+func setupDependencyTestRouter(handler *DependencyHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/Automatizacion/:uuid/ready", handler.Ready)
+	return router
+}
+
+func TestDependencyHandler_Ready_Success(t *testing.T) {
+	// Setup
+	mockReady := new(MockListReadySubtasksUseCase)
+	handler := NewDependencyHandler(mockReady)
+	router := setupDependencyTestRouter(handler)
+
+	task, err := entity.NewTask("Pipeline", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	extract, _ := entity.NewSubtask("Extract", helpers.NewTestClock())
+	load, _ := entity.NewSubtask("Load", helpers.NewTestClock())
+	task.AddSubtask(extract)
+	task.AddSubtask(load)
+	require.NoError(t, task.SetDependencies(load, []string{"Extract"}))
+	require.NoError(t, task.UpdateState(entity.StateInProgress, "test-user"))
+	extract.UpdateState(entity.StateInProgress)
+	extract.UpdateState(entity.StateCompleted)
+
+	// Configurar mock
+	mockReady.On("Execute", mock.Anything, taskUsecase.ListReadySubtasksInput{TaskID: task.ID}).
+		Return(&taskUsecase.ListReadySubtasksOutput{Task: task, Subtasks: task.ReadySubtasks()}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+task.ID.String()+"/ready", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ReadySubtasksResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, task.ID.String(), response.TaskID)
+	assert.Equal(t, "IN_PROGRESS", response.TaskState)
+	require.Len(t, response.Subtasks, 1)
+	assert.Equal(t, load.ID.String(), response.Subtasks[0].ID)
+	assert.Equal(t, []string{extract.ID.String()}, response.Subtasks[0].DependsOn)
+	mockReady.AssertExpectations(t)
+}
+
+func TestDependencyHandler_Ready_TaskNotFound(t *testing.T) {
+	// Setup
+	mockReady := new(MockListReadySubtasksUseCase)
+	handler := NewDependencyHandler(mockReady)
+	router := setupDependencyTestRouter(handler)
+
+	taskID := uuid.New()
+	mockReady.On("Execute", mock.Anything, taskUsecase.ListReadySubtasksInput{TaskID: taskID}).
+		Return(nil, entity.ErrTaskNotFound)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String()+"/ready", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockReady.AssertExpectations(t)
+}
+
+func TestDependencyHandler_Ready_InvalidUUID(t *testing.T) {
+	// Setup
+	mockReady := new(MockListReadySubtasksUseCase)
+	handler := NewDependencyHandler(mockReady)
+	router := setupDependencyTestRouter(handler)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/not-a-uuid/ready", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockReady.AssertNotCalled(t, "Execute")
+}
@@ -62,6 +62,180 @@ func MapErrorToProblemDetails(c *gin.Context, err error) {
 		pd.Status = http.StatusNotFound
 		pd.Detail = err.Error()
 
+	case errors.Is(err, entity.ErrArtifactNotFound):
+		pd.Type = "https://api.grupoapi.com/problems/artifact-not-found"
+		pd.Title = "Artifact Not Found"
+		pd.Status = http.StatusNotFound
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrAutomationNotFound):
+		pd.Type = "https://api.grupoapi.com/problems/automation-not-found"
+		pd.Title = "Automation Not Found"
+		pd.Status = http.StatusNotFound
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrVersionConflict):
+		pd.Type = "https://api.grupoapi.com/problems/version-conflict"
+		pd.Title = "Precondition Failed"
+		pd.Status = http.StatusPreconditionFailed
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidIdempotencyKey):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-idempotency-key"
+		pd.Title = "Invalid Idempotency Key"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrIdempotencyKeyReused):
+		pd.Type = "https://api.grupoapi.com/problems/idempotency-key-reused"
+		pd.Title = "Idempotency Key Reused"
+		pd.Status = http.StatusUnprocessableEntity
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidWorkflow):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-workflow"
+		pd.Title = "Invalid Workflow"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrUnknownWorkflow):
+		pd.Type = "https://api.grupoapi.com/problems/unknown-workflow"
+		pd.Title = "Unknown Workflow"
+		pd.Status = http.StatusUnprocessableEntity
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidCompletionPolicy):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-completion-policy"
+		pd.Title = "Invalid Completion Policy"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidDependency):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-dependency"
+		pd.Title = "Invalid Dependency"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidProgress):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-progress"
+		pd.Title = "Invalid Progress"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidHeartbeatTimeout):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-heartbeat-timeout"
+		pd.Title = "Invalid Heartbeat Timeout"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidSLA):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-sla"
+		pd.Title = "Invalid SLA"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidFailure):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-failure"
+		pd.Title = "Invalid Failure"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidPayload):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-payload"
+		pd.Title = "Invalid Payload"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidArtifact):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-artifact"
+		pd.Title = "Invalid Artifact"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrArtifactTooLarge):
+		pd.Type = "https://api.grupoapi.com/problems/artifact-too-large"
+		pd.Title = "Artifact Too Large"
+		pd.Status = http.StatusRequestEntityTooLarge
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidAutomation):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-automation"
+		pd.Title = "Invalid Automation"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidSchedule):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-schedule"
+		pd.Title = "Invalid Schedule"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidLogLine):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-log-line"
+		pd.Title = "Invalid Log Line"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidLogQuery):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-log-query"
+		pd.Title = "Invalid Log Query"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidLabels):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-labels"
+		pd.Title = "Invalid Labels"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidMetadata):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-metadata"
+		pd.Title = "Invalid Metadata"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrInvalidSubtaskParent):
+		pd.Type = "https://api.grupoapi.com/problems/invalid-subtask-parent"
+		pd.Title = "Invalid Subtask Parent"
+		pd.Status = http.StatusBadRequest
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrDependencyCycle):
+		pd.Type = "https://api.grupoapi.com/problems/dependency-cycle"
+		pd.Title = "Dependency Cycle"
+		pd.Status = http.StatusUnprocessableEntity
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrDependenciesNotMet):
+		pd.Type = "https://api.grupoapi.com/problems/dependencies-not-met"
+		pd.Title = "Dependencies Not Met"
+		pd.Status = http.StatusConflict
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrTaskNotRetryable):
+		pd.Type = "https://api.grupoapi.com/problems/task-not-retryable"
+		pd.Title = "Task Not Retryable"
+		pd.Status = http.StatusConflict
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrLogLimitExceeded):
+		pd.Type = "https://api.grupoapi.com/problems/log-limit-exceeded"
+		pd.Title = "Log Limit Exceeded"
+		pd.Status = http.StatusConflict
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrTaskNotActive):
+		pd.Type = "https://api.grupoapi.com/problems/task-not-active"
+		pd.Title = "Task Not Active"
+		pd.Status = http.StatusConflict
+		pd.Detail = err.Error()
+
+	case errors.Is(err, entity.ErrAutomationExists):
+		pd.Type = "https://api.grupoapi.com/problems/automation-exists"
+		pd.Title = "Automation Already Exists"
+		pd.Status = http.StatusConflict
+		pd.Detail = err.Error()
+
 	case errors.Is(err, entity.ErrDatabaseUnavailable):
 		pd.Type = "https://api.grupoapi.com/problems/database-unavailable"
 		pd.Title = "Database Unavailable"
@@ -34,6 +34,60 @@ func setupErrorTestRouter() *gin.Engine {
 			err = entity.ErrTaskNotFound
 		case "subtask-not-found":
 			err = entity.ErrSubtaskNotFound
+		case "invalid-idempotency-key":
+			err = entity.ErrInvalidIdempotencyKey
+		case "idempotency-key-reused":
+			err = entity.ErrIdempotencyKeyReused
+		case "version-conflict":
+			err = entity.ErrVersionConflict
+		case "invalid-completion-policy":
+			err = entity.ErrInvalidCompletionPolicy
+		case "invalid-dependency":
+			err = entity.ErrInvalidDependency
+		case "invalid-progress":
+			err = entity.ErrInvalidProgress
+		case "invalid-heartbeat-timeout":
+			err = entity.ErrInvalidHeartbeatTimeout
+		case "invalid-sla":
+			err = entity.ErrInvalidSLA
+		case "invalid-failure":
+			err = entity.ErrInvalidFailure
+		case "invalid-payload":
+			err = entity.ErrInvalidPayload
+		case "artifact-not-found":
+			err = entity.ErrArtifactNotFound
+		case "invalid-artifact":
+			err = entity.ErrInvalidArtifact
+		case "artifact-too-large":
+			err = entity.ErrArtifactTooLarge
+		case "automation-not-found":
+			err = entity.ErrAutomationNotFound
+		case "automation-exists":
+			err = entity.ErrAutomationExists
+		case "invalid-automation":
+			err = entity.ErrInvalidAutomation
+		case "invalid-schedule":
+			err = entity.ErrInvalidSchedule
+		case "invalid-log-line":
+			err = entity.ErrInvalidLogLine
+		case "invalid-log-query":
+			err = entity.ErrInvalidLogQuery
+		case "log-limit-exceeded":
+			err = entity.ErrLogLimitExceeded
+		case "invalid-labels":
+			err = entity.ErrInvalidLabels
+		case "invalid-metadata":
+			err = entity.ErrInvalidMetadata
+		case "invalid-subtask-parent":
+			err = entity.ErrInvalidSubtaskParent
+		case "dependency-cycle":
+			err = entity.ErrDependencyCycle
+		case "dependencies-not-met":
+			err = entity.ErrDependenciesNotMet
+		case "task-not-retryable":
+			err = entity.ErrTaskNotRetryable
+		case "task-not-active":
+			err = entity.ErrTaskNotActive
 		case "database-unavailable":
 			err = entity.ErrDatabaseUnavailable
 		case "database-error":
@@ -145,6 +199,470 @@ func TestErrorMapper_SubtaskNotFound(t *testing.T) {
 	assert.Equal(t, "Subtask Not Found", response.Title)
 }
 
+func TestErrorMapper_IdempotencyErrors(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	tests := []struct {
+		name           string
+		errorType      string
+		expectedStatus int
+		expectedType   string
+	}{
+		{
+			name:           "invalid key",
+			errorType:      "invalid-idempotency-key",
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "https://api.grupoapi.com/problems/invalid-idempotency-key",
+		},
+		{
+			name:           "key reused with different body",
+			errorType:      "idempotency-key-reused",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedType:   "https://api.grupoapi.com/problems/idempotency-key-reused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test?error="+tt.errorType, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response ProblemDetails
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedType, response.Type)
+		})
+	}
+}
+
+func TestErrorMapper_VersionConflict(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=version-conflict", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/version-conflict", response.Type)
+	assert.Equal(t, "Precondition Failed", response.Title)
+	assert.Equal(t, http.StatusPreconditionFailed, response.Status)
+}
+
+func TestErrorMapper_InvalidCompletionPolicy(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-completion-policy", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-completion-policy", response.Type)
+	assert.Equal(t, "Invalid Completion Policy", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidDependency(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-dependency", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-dependency", response.Type)
+	assert.Equal(t, "Invalid Dependency", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidProgress(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-progress", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-progress", response.Type)
+	assert.Equal(t, "Invalid Progress", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidHeartbeatTimeout(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-heartbeat-timeout", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-heartbeat-timeout", response.Type)
+	assert.Equal(t, "Invalid Heartbeat Timeout", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidSubtaskParent(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-subtask-parent", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-subtask-parent", response.Type)
+	assert.Equal(t, "Invalid Subtask Parent", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_DependencyCycle(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=dependency-cycle", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/dependency-cycle", response.Type)
+	assert.Equal(t, "Dependency Cycle", response.Title)
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Status)
+}
+
+func TestErrorMapper_DependenciesNotMet(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=dependencies-not-met", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/dependencies-not-met", response.Type)
+	assert.Equal(t, "Dependencies Not Met", response.Title)
+	assert.Equal(t, http.StatusConflict, response.Status)
+}
+
+func TestErrorMapper_TaskNotRetryable(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=task-not-retryable", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/task-not-retryable", response.Type)
+	assert.Equal(t, "Task Not Retryable", response.Title)
+	assert.Equal(t, http.StatusConflict, response.Status)
+}
+
+func TestErrorMapper_TaskNotActive(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=task-not-active", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/task-not-active", response.Type)
+	assert.Equal(t, "Task Not Active", response.Title)
+	assert.Equal(t, http.StatusConflict, response.Status)
+}
+
+func TestErrorMapper_InvalidSLA(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-sla", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-sla", response.Type)
+	assert.Equal(t, "Invalid SLA", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidFailure(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-failure", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-failure", response.Type)
+	assert.Equal(t, "Invalid Failure", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidLogLine(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-log-line", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-log-line", response.Type)
+	assert.Equal(t, "Invalid Log Line", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidPayload(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-payload", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-payload", response.Type)
+	assert.Equal(t, "Invalid Payload", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_ArtifactNotFound(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=artifact-not-found", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/artifact-not-found", response.Type)
+	assert.Equal(t, "Artifact Not Found", response.Title)
+	assert.Equal(t, http.StatusNotFound, response.Status)
+}
+
+func TestErrorMapper_InvalidArtifact(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-artifact", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-artifact", response.Type)
+	assert.Equal(t, "Invalid Artifact", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_ArtifactTooLarge(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=artifact-too-large", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/artifact-too-large", response.Type)
+	assert.Equal(t, "Artifact Too Large", response.Title)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.Status)
+}
+
+func TestErrorMapper_AutomationNotFound(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=automation-not-found", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/automation-not-found", response.Type)
+	assert.Equal(t, "Automation Not Found", response.Title)
+	assert.Equal(t, http.StatusNotFound, response.Status)
+}
+
+func TestErrorMapper_AutomationExists(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=automation-exists", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/automation-exists", response.Type)
+	assert.Equal(t, "Automation Already Exists", response.Title)
+	assert.Equal(t, http.StatusConflict, response.Status)
+}
+
+func TestErrorMapper_InvalidAutomation(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-automation", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-automation", response.Type)
+	assert.Equal(t, "Invalid Automation", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidSchedule(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-schedule", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-schedule", response.Type)
+	assert.Equal(t, "Invalid Schedule", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidLogQuery(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-log-query", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-log-query", response.Type)
+	assert.Equal(t, "Invalid Log Query", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_LogLimitExceeded(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=log-limit-exceeded", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/log-limit-exceeded", response.Type)
+	assert.Equal(t, "Log Limit Exceeded", response.Title)
+	assert.Equal(t, http.StatusConflict, response.Status)
+}
+
+func TestErrorMapper_InvalidLabels(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-labels", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-labels", response.Type)
+	assert.Equal(t, "Invalid Labels", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
+func TestErrorMapper_InvalidMetadata(t *testing.T) {
+	router := setupErrorTestRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test?error=invalid-metadata", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-metadata", response.Type)
+	assert.Equal(t, "Invalid Metadata", response.Title)
+	assert.Equal(t, http.StatusBadRequest, response.Status)
+}
+
 func TestErrorMapper_DatabaseUnavailable(t *testing.T) {
 	router := setupErrorTestRouter()
 
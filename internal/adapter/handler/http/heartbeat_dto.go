@@ -0,0 +1,29 @@
+package http
+
+import (
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// HeartbeatResponse representa el resultado de registrar un latido
+// El proceso puede usar el estado para detectar que la tarea se pausó o canceló
+type HeartbeatResponse struct {
+	TaskID           string    `json:"task_id"`
+	TaskState        string    `json:"task_state"`
+	LastHeartbeatAt  time.Time `json:"last_heartbeat_at"`
+	HeartbeatTimeout *int64    `json:"heartbeat_timeout,omitempty"`
+}
+
+// ToHeartbeatResponse convierte una tarea con su último latido a HeartbeatResponse
+func ToHeartbeatResponse(task *entity.Task) HeartbeatResponse {
+	response := HeartbeatResponse{
+		TaskID:           task.ID.String(),
+		TaskState:        task.State.String(),
+		HeartbeatTimeout: optionalSeconds(task.HeartbeatTimeout),
+	}
+	if task.LastHeartbeatAt != nil {
+		response.LastHeartbeatAt = *task.LastHeartbeatAt
+	}
+	return response
+}
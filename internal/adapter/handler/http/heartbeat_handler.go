@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// RecordHeartbeatUseCaseInterface define la interfaz para registrar latidos de tareas
+type RecordHeartbeatUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.RecordHeartbeatInput) (*taskUsecase.RecordHeartbeatOutput, error)
+}
+
+// HeartbeatHandler maneja las peticiones HTTP de los latidos de las tareas
+type HeartbeatHandler struct {
+	heartbeatUseCase RecordHeartbeatUseCaseInterface
+}
+
+// NewHeartbeatHandler crea una nueva instancia de HeartbeatHandler
+func NewHeartbeatHandler(heartbeatUseCase RecordHeartbeatUseCaseInterface) *HeartbeatHandler {
+	return &HeartbeatHandler{heartbeatUseCase: heartbeatUseCase}
+}
+
+// Record maneja POST /Automatizacion/{uuid}/heartbeat
+func (h *HeartbeatHandler) Record(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	output, err := h.heartbeatUseCase.Execute(c.Request.Context(), taskUsecase.RecordHeartbeatInput{ID: taskID})
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToHeartbeatResponse(output.Task))
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+	"github.com/grupoapi/proces-log/test/helpers"
+)
+
+// MockRecordHeartbeatUseCase es un mock del RecordHeartbeatUseCase
+type MockRecordHeartbeatUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRecordHeartbeatUseCase) Execute(ctx context.Context, input taskUsecase.RecordHeartbeatInput) (*taskUsecase.RecordHeartbeatOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.RecordHeartbeatOutput), args.Error(1)
+}
+
+func setupHeartbeatTestRouter(handler *HeartbeatHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/Automatizacion/:uuid/heartbeat", handler.Record)
+	return router
+}
+
+func TestHeartbeatHandler_Record_Success(t *testing.T) {
+	// Setup
+	mockHeartbeat := new(MockRecordHeartbeatUseCase)
+	handler := NewHeartbeatHandler(mockHeartbeat)
+	router := setupHeartbeatTestRouter(handler)
+
+	clock := helpers.NewTestClock()
+	task, err := entity.NewTask("Nightly Import", "test-user", clock)
+	require.NoError(t, err)
+	require.NoError(t, task.SetHeartbeatTimeout(90))
+	require.NoError(t, task.UpdateState(entity.StateInProgress, "test-user"))
+	beatAt := clock.Advance(30 * time.Second)
+	require.NoError(t, task.RecordHeartbeat(beatAt))
+
+	// Configurar mock
+	mockHeartbeat.On("Execute", mock.Anything, taskUsecase.RecordHeartbeatInput{ID: task.ID}).
+		Return(&taskUsecase.RecordHeartbeatOutput{Task: task}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+task.ID.String()+"/heartbeat", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response HeartbeatResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, task.ID.String(), response.TaskID)
+	assert.Equal(t, "IN_PROGRESS", response.TaskState)
+	assert.Equal(t, helpers.DefaultTestTime.Add(30*time.Second), response.LastHeartbeatAt)
+	require.NotNil(t, response.HeartbeatTimeout)
+	assert.Equal(t, int64(90), *response.HeartbeatTimeout)
+	mockHeartbeat.AssertExpectations(t)
+}
+
+func TestHeartbeatHandler_Record_TaskNotActive(t *testing.T) {
+	// Setup
+	mockHeartbeat := new(MockRecordHeartbeatUseCase)
+	handler := NewHeartbeatHandler(mockHeartbeat)
+	router := setupHeartbeatTestRouter(handler)
+
+	taskID := uuid.New()
+	mockHeartbeat.On("Execute", mock.Anything, taskUsecase.RecordHeartbeatInput{ID: taskID}).
+		Return(nil, entity.ErrTaskNotActive)
+
+	// Request
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+taskID.String()+"/heartbeat", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockHeartbeat.AssertExpectations(t)
+}
+
+func TestHeartbeatHandler_Record_InvalidUUID(t *testing.T) {
+	// Setup
+	mockHeartbeat := new(MockRecordHeartbeatUseCase)
+	handler := NewHeartbeatHandler(mockHeartbeat)
+	router := setupHeartbeatTestRouter(handler)
+
+	// Request
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/not-a-uuid/heartbeat", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockHeartbeat.AssertNotCalled(t, "Execute")
+}
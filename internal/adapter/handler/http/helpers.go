@@ -1,6 +1,13 @@
 package http
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
@@ -35,3 +42,73 @@ func parseStateOrError(c *gin.Context, stateStr *string) (*entity.State, bool) {
 	}
 	return &parsedState, true
 }
+
+// parsePaginationOrError parsea los query parameters page y limit.
+// Retorna defaultLimit si no se especifica limit; el máximo permitido es 100.
+// Si falla, ya se ha enviado la respuesta HTTP al cliente.
+func parsePaginationOrError(c *gin.Context, defaultLimit int) (int, int, bool) {
+	page := 1
+	if pageStr := c.Query("page"); pageStr != "" {
+		parsedPage, err := strconv.Atoi(pageStr)
+		if err != nil || parsedPage < 1 {
+			MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+			return 0, 0, false
+		}
+		page = parsedPage
+	}
+
+	limit := defaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 || parsedLimit > 100 {
+			MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+			return 0, 0, false
+		}
+		limit = parsedLimit
+	}
+
+	return page, limit, true
+}
+
+// setETag expone la versión de un recurso en la cabecera ETag (entity tag fuerte)
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// parseIfMatchOrError parsea la cabecera If-Match y retorna la versión esperada.
+// Retorna nil si la cabecera no está presente o es "*" (cualquier versión).
+// Si la cabecera no contiene una versión válida, responde 412 y retorna false,
+// ya que un entity tag desconocido nunca puede coincidir con el recurso actual.
+func parseIfMatchOrError(c *gin.Context) (*int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		unquoted = tag
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		MapErrorToProblemDetails(c, fmt.Errorf("%w: invalid If-Match header %q", entity.ErrVersionConflict, header))
+		return nil, false
+	}
+
+	return &version, true
+}
+
+// hashRequest calcula la huella SHA-256 de un request ya parseado.
+// Se calcula sobre el JSON normalizado, de modo que diferencias de formato
+// (espacios, orden de campos) no cambian la huella.
+func hashRequest(req any) (string, error) {
+	normalized, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:]), nil
+}
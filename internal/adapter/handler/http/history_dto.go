@@ -0,0 +1,45 @@
+package http
+
+import (
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// TaskEventResponse representa una entrada del historial de una tarea
+type TaskEventResponse struct {
+	ID         int64     `json:"id"`
+	TaskID     string    `json:"task_id"`
+	SubtaskID  *string   `json:"subtask_id,omitempty"`
+	Type       string    `json:"type"`
+	Actor      string    `json:"actor"`
+	OldValue   *string   `json:"old_value,omitempty"`
+	NewValue   *string   `json:"new_value,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// TaskHistoryResponse representa la respuesta paginada del historial de una tarea
+type TaskHistoryResponse struct {
+	Events     []TaskEventResponse `json:"events"`
+	Pagination PaginationResponse  `json:"pagination"`
+}
+
+// ToTaskEventResponse convierte una entidad TaskEvent a TaskEventResponse
+func ToTaskEventResponse(event *entity.TaskEvent) TaskEventResponse {
+	var subtaskID *string
+	if event.SubtaskID != nil {
+		id := event.SubtaskID.String()
+		subtaskID = &id
+	}
+
+	return TaskEventResponse{
+		ID:         event.ID,
+		TaskID:     event.TaskID.String(),
+		SubtaskID:  subtaskID,
+		Type:       event.Type.String(),
+		Actor:      event.Actor,
+		OldValue:   event.OldValue,
+		NewValue:   event.NewValue,
+		OccurredAt: event.OccurredAt,
+	}
+}
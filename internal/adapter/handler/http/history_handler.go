@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// GetTaskHistoryUseCaseInterface define la interfaz para consultar el historial de tareas
+type GetTaskHistoryUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.GetTaskHistoryInput) (*taskUsecase.GetTaskHistoryOutput, error)
+}
+
+// HistoryHandler maneja las peticiones HTTP relacionadas con el historial de tareas
+type HistoryHandler struct {
+	historyUseCase GetTaskHistoryUseCaseInterface
+}
+
+// NewHistoryHandler crea una nueva instancia de HistoryHandler
+func NewHistoryHandler(historyUseCase GetTaskHistoryUseCaseInterface) *HistoryHandler {
+	return &HistoryHandler{
+		historyUseCase: historyUseCase,
+	}
+}
+
+// List maneja GET /Automatizacion/{uuid}/history
+func (h *HistoryHandler) List(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	page, limit, ok := parsePaginationOrError(c, 50)
+	if !ok {
+		return
+	}
+
+	input := taskUsecase.GetTaskHistoryInput{
+		TaskID: taskID,
+		Page:   page,
+		Limit:  limit,
+	}
+
+	output, err := h.historyUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	// Convertir a response
+	events := make([]TaskEventResponse, 0, len(output.Events))
+	for _, event := range output.Events {
+		events = append(events, ToTaskEventResponse(event))
+	}
+
+	response := TaskHistoryResponse{
+		Events: events,
+		Pagination: PaginationResponse{
+			Page:       output.Page,
+			Limit:      output.Limit,
+			Total:      output.Total,
+			TotalPages: output.TotalPages,
+		},
+	}
+
+	c.JSON(http.StatusOK, response)
+}
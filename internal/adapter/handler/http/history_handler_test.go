@@ -0,0 +1,162 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+	"github.com/grupoapi/proces-log/test/helpers"
+)
+
+// MockGetTaskHistoryUseCase es un mock del GetTaskHistoryUseCase
+type MockGetTaskHistoryUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetTaskHistoryUseCase) Execute(ctx context.Context, input taskUsecase.GetTaskHistoryInput) (*taskUsecase.GetTaskHistoryOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.GetTaskHistoryOutput), args.Error(1)
+}
+
+func setupHistoryTestRouter(handler *HistoryHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/Automatizacion/:uuid/history", handler.List)
+	return router
+}
+
+func TestHistoryHandler_List_Success(t *testing.T) {
+	// Setup
+	mockHistory := new(MockGetTaskHistoryUseCase)
+	handler := NewHistoryHandler(mockHistory)
+	router := setupHistoryTestRouter(handler)
+
+	taskID := uuid.New()
+	subtaskID := uuid.New()
+	pending := entity.StatePending.String()
+	inProgress := entity.StateInProgress.String()
+	subtaskName := "Subtask 1"
+	clock := helpers.NewTestClock()
+
+	created := entity.NewTaskEvent(taskID, entity.EventTaskCreated, "test-user", nil, nil, clock.Now())
+	created.ID = 1
+	started := entity.NewTaskEvent(taskID, entity.EventTaskStateChanged, "test-user", &pending, &inProgress, clock.Advance(time.Minute))
+	started.ID = 2
+	added := entity.NewSubtaskEvent(taskID, subtaskID, entity.EventSubtaskAdded, "other-user", nil, &subtaskName, clock.Advance(time.Minute))
+	added.ID = 3
+
+	// Configurar mock
+	mockHistory.On("Execute", mock.Anything, taskUsecase.GetTaskHistoryInput{
+		TaskID: taskID,
+		Page:   2,
+		Limit:  3,
+	}).Return(&taskUsecase.GetTaskHistoryOutput{
+		Events:     []*entity.TaskEvent{created, started, added},
+		Total:      7,
+		Page:       2,
+		Limit:      3,
+		TotalPages: 3,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String()+"/history?page=2&limit=3", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskHistoryResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Events, 3)
+	assert.Equal(t, "TASK_STATE_CHANGED", response.Events[1].Type)
+	assert.Equal(t, "PENDING", *response.Events[1].OldValue)
+	assert.Equal(t, "IN_PROGRESS", *response.Events[1].NewValue)
+	assert.Nil(t, response.Events[1].SubtaskID)
+	assert.Equal(t, subtaskID.String(), *response.Events[2].SubtaskID)
+	assert.Equal(t, "other-user", response.Events[2].Actor)
+	assert.Equal(t, helpers.DefaultTestTime.Add(2*time.Minute), response.Events[2].OccurredAt)
+	assert.Equal(t, 7, response.Pagination.Total)
+	assert.Equal(t, 3, response.Pagination.TotalPages)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestHistoryHandler_List_DefaultPagination(t *testing.T) {
+	// Setup
+	mockHistory := new(MockGetTaskHistoryUseCase)
+	handler := NewHistoryHandler(mockHistory)
+	router := setupHistoryTestRouter(handler)
+
+	taskID := uuid.New()
+	mockHistory.On("Execute", mock.Anything, taskUsecase.GetTaskHistoryInput{
+		TaskID: taskID,
+		Page:   1,
+		Limit:  50,
+	}).Return(&taskUsecase.GetTaskHistoryOutput{Events: []*entity.TaskEvent{}, Page: 1, Limit: 50}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String()+"/history", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"events":[],"pagination":{"page":1,"limit":50,"total":0,"total_pages":0}}`, w.Body.String())
+	mockHistory.AssertExpectations(t)
+}
+
+func TestHistoryHandler_List_TaskNotFound(t *testing.T) {
+	// Setup
+	mockHistory := new(MockGetTaskHistoryUseCase)
+	handler := NewHistoryHandler(mockHistory)
+	router := setupHistoryTestRouter(handler)
+
+	mockHistory.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrTaskNotFound)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+uuid.New().String()+"/history", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestHistoryHandler_List_InvalidLimit(t *testing.T) {
+	// Setup
+	mockHistory := new(MockGetTaskHistoryUseCase)
+	handler := NewHistoryHandler(mockHistory)
+	router := setupHistoryTestRouter(handler)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+uuid.New().String()+"/history?limit=500", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockHistory.AssertNotCalled(t, "Execute")
+}
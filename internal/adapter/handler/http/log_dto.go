@@ -0,0 +1,63 @@
+package http
+
+import (
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// AppendTaskLogsRequest representa un envío de líneas de log de una tarea
+type AppendTaskLogsRequest struct {
+	Lines []TaskLogLineRequest `json:"lines" binding:"required"`
+}
+
+// TaskLogLineRequest representa una línea del envío de logs
+type TaskLogLineRequest struct {
+	Timestamp *time.Time `json:"timestamp,omitempty"` // Momento en que se emitió; si se omite, el de recepción
+	Level     string     `json:"level"`
+	Message   string     `json:"message"`
+	SubtaskID *string    `json:"subtask_id,omitempty"`
+}
+
+// AppendTaskLogsResponse representa el resultado de un envío de logs
+type AppendTaskLogsResponse struct {
+	TaskID     string `json:"task_id"`
+	Accepted   int    `json:"accepted"`
+	TotalLines int    `json:"total_lines"`
+	MaxLines   int    `json:"max_lines"`
+}
+
+// TaskLogLineResponse representa una línea de log de una tarea
+type TaskLogLineResponse struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Level      string    `json:"level"`
+	Message    string    `json:"message"`
+	SubtaskID  *string   `json:"subtask_id,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// TaskLogsResponse representa una página de logs de una tarea
+// NextCursor se omite en la última página
+type TaskLogsResponse struct {
+	TaskID     string                `json:"task_id"`
+	Lines      []TaskLogLineResponse `json:"lines"`
+	NextCursor *string               `json:"next_cursor,omitempty"`
+	Limit      int                   `json:"limit"`
+}
+
+// ToTaskLogLineResponse convierte una entidad TaskLogLine a TaskLogLineResponse
+func ToTaskLogLineResponse(line *entity.TaskLogLine) TaskLogLineResponse {
+	var subtaskID *string
+	if line.SubtaskID != nil {
+		id := line.SubtaskID.String()
+		subtaskID = &id
+	}
+
+	return TaskLogLineResponse{
+		Timestamp:  line.LoggedAt,
+		Level:      line.Level.String(),
+		Message:    line.Message,
+		SubtaskID:  subtaskID,
+		ReceivedAt: line.ReceivedAt,
+	}
+}
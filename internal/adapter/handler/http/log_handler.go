@@ -0,0 +1,188 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// AppendTaskLogsUseCaseInterface define la interfaz para recibir logs de tareas
+type AppendTaskLogsUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.AppendTaskLogsInput) (*taskUsecase.AppendTaskLogsOutput, error)
+}
+
+// ListTaskLogsUseCaseInterface define la interfaz para consultar logs de tareas
+type ListTaskLogsUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.ListTaskLogsInput) (*taskUsecase.ListTaskLogsOutput, error)
+}
+
+// LogHandler maneja las peticiones HTTP de los logs de las tareas
+type LogHandler struct {
+	appendUseCase AppendTaskLogsUseCaseInterface
+	listUseCase   ListTaskLogsUseCaseInterface
+}
+
+// NewLogHandler crea una nueva instancia de LogHandler
+func NewLogHandler(appendUseCase AppendTaskLogsUseCaseInterface, listUseCase ListTaskLogsUseCaseInterface) *LogHandler {
+	return &LogHandler{
+		appendUseCase: appendUseCase,
+		listUseCase:   listUseCase,
+	}
+}
+
+// Append maneja POST /Automatizacion/{uuid}/logs
+func (h *LogHandler) Append(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	var req AppendTaskLogsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+		return
+	}
+
+	lines := make([]taskUsecase.AppendTaskLogLineInput, 0, len(req.Lines))
+	for i, lineReq := range req.Lines {
+		line := taskUsecase.AppendTaskLogLineInput{
+			Level:   lineReq.Level,
+			Message: lineReq.Message,
+		}
+		if lineReq.Timestamp != nil {
+			line.LoggedAt = *lineReq.Timestamp
+		}
+		if lineReq.SubtaskID != nil {
+			subtaskID, err := uuid.Parse(*lineReq.SubtaskID)
+			if err != nil {
+				MapErrorToProblemDetails(c, fmt.Errorf("%w: line %d: subtask_id must be a UUID", entity.ErrInvalidLogLine, i))
+				return
+			}
+			line.SubtaskID = &subtaskID
+		}
+		lines = append(lines, line)
+	}
+
+	output, err := h.appendUseCase.Execute(c.Request.Context(), taskUsecase.AppendTaskLogsInput{
+		TaskID: taskID,
+		Lines:  lines,
+	})
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, AppendTaskLogsResponse{
+		TaskID:     taskID.String(),
+		Accepted:   output.Accepted,
+		TotalLines: output.TotalLines,
+		MaxLines:   entity.MaxLogLinesPerTask,
+	})
+}
+
+// List maneja GET /Automatizacion/{uuid}/logs
+func (h *LogHandler) List(c *gin.Context) {
+	taskID, ok := parseUUIDOrError(c, c.Param("uuid"), entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	input, ok := parseListTaskLogsInput(c)
+	if !ok {
+		return
+	}
+	input.TaskID = taskID
+
+	output, err := h.listUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	// Convertir a response
+	lines := make([]TaskLogLineResponse, 0, len(output.Lines))
+	for _, line := range output.Lines {
+		lines = append(lines, ToTaskLogLineResponse(line))
+	}
+
+	response := TaskLogsResponse{
+		TaskID: taskID.String(),
+		Lines:  lines,
+		Limit:  output.Limit,
+	}
+	if output.NextCursor != nil {
+		cursor := output.NextCursor.String()
+		response.NextCursor = &cursor
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseListTaskLogsInput parsea los query parameters level, subtask_id, since, until, cursor y limit.
+// Si falla, ya se ha enviado la respuesta HTTP al cliente.
+func parseListTaskLogsInput(c *gin.Context) (taskUsecase.ListTaskLogsInput, bool) {
+	var input taskUsecase.ListTaskLogsInput
+
+	if levelStr := c.Query("level"); levelStr != "" {
+		level, ok := entity.ParseLogLevel(levelStr)
+		if !ok {
+			MapErrorToProblemDetails(c, fmt.Errorf("%w: level must be one of DEBUG, INFO, WARN, ERROR, got %q",
+				entity.ErrInvalidLogQuery, levelStr))
+			return input, false
+		}
+		input.MinLevel = &level
+	}
+
+	if subtaskStr := c.Query("subtask_id"); subtaskStr != "" {
+		subtaskID, err := uuid.Parse(subtaskStr)
+		if err != nil {
+			MapErrorToProblemDetails(c, fmt.Errorf("%w: subtask_id must be a UUID", entity.ErrInvalidLogQuery))
+			return input, false
+		}
+		input.SubtaskID = &subtaskID
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"since", &input.Since}, {"until", &input.Until}} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			MapErrorToProblemDetails(c, fmt.Errorf("%w: %s must be an RFC 3339 date-time", entity.ErrInvalidLogQuery, param.name))
+			return input, false
+		}
+		*param.target = &parsed
+	}
+
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := entity.ParseTaskLogCursor(cursorStr)
+		if err != nil {
+			MapErrorToProblemDetails(c, err)
+			return input, false
+		}
+		input.After = &cursor
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > entity.MaxLogBatchSize {
+			MapErrorToProblemDetails(c, fmt.Errorf("%w: limit must be between 1 and %d", entity.ErrInvalidLogQuery, entity.MaxLogBatchSize))
+			return input, false
+		}
+		input.Limit = limit
+	}
+
+	return input, true
+}
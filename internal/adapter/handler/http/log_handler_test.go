@@ -0,0 +1,221 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// MockAppendTaskLogsUseCase es un mock del AppendTaskLogsUseCase
+type MockAppendTaskLogsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAppendTaskLogsUseCase) Execute(ctx context.Context, input taskUsecase.AppendTaskLogsInput) (*taskUsecase.AppendTaskLogsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.AppendTaskLogsOutput), args.Error(1)
+}
+
+// MockListTaskLogsUseCase es un mock del ListTaskLogsUseCase
+type MockListTaskLogsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListTaskLogsUseCase) Execute(ctx context.Context, input taskUsecase.ListTaskLogsInput) (*taskUsecase.ListTaskLogsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.ListTaskLogsOutput), args.Error(1)
+}
+
+func setupLogTestRouter(handler *LogHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/Automatizacion/:uuid/logs", handler.Append)
+	router.GET("/Automatizacion/:uuid/logs", handler.List)
+	return router
+}
+
+func TestLogHandler_Append_Success(t *testing.T) {
+	// Setup
+	mockAppend := new(MockAppendTaskLogsUseCase)
+	handler := NewLogHandler(mockAppend, new(MockListTaskLogsUseCase))
+	router := setupLogTestRouter(handler)
+
+	taskID := uuid.New()
+	subtaskID := uuid.New()
+	loggedAt := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	// Configurar mock
+	mockAppend.On("Execute", mock.Anything, taskUsecase.AppendTaskLogsInput{
+		TaskID: taskID,
+		Lines: []taskUsecase.AppendTaskLogLineInput{
+			{LoggedAt: loggedAt, Level: "info", Message: "export started"},
+			{Level: "ERROR", Message: "row 42 rejected", SubtaskID: &subtaskID},
+		},
+	}).Return(&taskUsecase.AppendTaskLogsOutput{Accepted: 2, TotalLines: 12}, nil)
+
+	// Request
+	body := `{"lines": [
+		{"timestamp": "2026-03-01T08:00:00Z", "level": "info", "message": "export started"},
+		{"level": "ERROR", "message": "row 42 rejected", "subtask_id": "` + subtaskID.String() + `"}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+taskID.String()+"/logs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response AppendTaskLogsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, taskID.String(), response.TaskID)
+	assert.Equal(t, 2, response.Accepted)
+	assert.Equal(t, 12, response.TotalLines)
+	assert.Equal(t, entity.MaxLogLinesPerTask, response.MaxLines)
+	mockAppend.AssertExpectations(t)
+}
+
+func TestLogHandler_Append_InvalidSubtaskID(t *testing.T) {
+	// Setup
+	mockAppend := new(MockAppendTaskLogsUseCase)
+	handler := NewLogHandler(mockAppend, new(MockListTaskLogsUseCase))
+	router := setupLogTestRouter(handler)
+
+	// Request
+	body := `{"lines": [{"level": "INFO", "message": "hello", "subtask_id": "not-a-uuid"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+uuid.New().String()+"/logs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-log-line", response.Type)
+	mockAppend.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestLogHandler_Append_LimitExceeded(t *testing.T) {
+	// Setup
+	mockAppend := new(MockAppendTaskLogsUseCase)
+	handler := NewLogHandler(mockAppend, new(MockListTaskLogsUseCase))
+	router := setupLogTestRouter(handler)
+
+	// Configurar mock
+	mockAppend.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrLogLimitExceeded)
+
+	// Request
+	body := `{"lines": [{"level": "INFO", "message": "hello"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+uuid.New().String()+"/logs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockAppend.AssertExpectations(t)
+}
+
+func TestLogHandler_List_Success(t *testing.T) {
+	// Setup
+	mockList := new(MockListTaskLogsUseCase)
+	handler := NewLogHandler(new(MockAppendTaskLogsUseCase), mockList)
+	router := setupLogTestRouter(handler)
+
+	taskID := uuid.New()
+	since := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	after := entity.TaskLogCursor{LoggedAt: since.Add(time.Minute), ID: 7}
+	warn := entity.LogLevelWarn
+
+	line, err := entity.NewTaskLogLine(taskID, nil, "ERROR", "row 42 rejected", since.Add(2*time.Minute), since.Add(3*time.Minute))
+	require.NoError(t, err)
+	line.ID = 8
+	next := line.Cursor()
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTaskLogsInput) bool {
+		return input.TaskID == taskID && input.MinLevel != nil && *input.MinLevel == warn &&
+			input.Since != nil && input.Since.Equal(since) && input.Until == nil &&
+			input.After != nil && input.After.ID == after.ID && input.After.LoggedAt.Equal(after.LoggedAt) &&
+			input.Limit == 1
+	})).Return(&taskUsecase.ListTaskLogsOutput{
+		Lines:      []*entity.TaskLogLine{line},
+		NextCursor: &next,
+		Limit:      1,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String()+
+		"/logs?level=warn&since=2026-03-01T08:00:00Z&limit=1&cursor="+after.String(), nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskLogsResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Lines, 1)
+	assert.Equal(t, "ERROR", response.Lines[0].Level)
+	assert.Equal(t, "row 42 rejected", response.Lines[0].Message)
+	require.NotNil(t, response.NextCursor)
+	assert.Equal(t, next.String(), *response.NextCursor)
+	mockList.AssertExpectations(t)
+}
+
+func TestLogHandler_List_InvalidQuery(t *testing.T) {
+	queries := []string{"level=TRACE", "since=yesterday", "cursor=not:a:cursor", "limit=0", "subtask_id=abc"}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			// Setup
+			mockList := new(MockListTaskLogsUseCase)
+			handler := NewLogHandler(new(MockAppendTaskLogsUseCase), mockList)
+			router := setupLogTestRouter(handler)
+
+			// Request
+			req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+uuid.New().String()+"/logs?"+query, nil)
+			w := httptest.NewRecorder()
+
+			// Execute
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var response ProblemDetails
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "https://api.grupoapi.com/problems/invalid-log-query", response.Type)
+			mockList.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+		})
+	}
+}
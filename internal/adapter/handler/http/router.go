@@ -5,13 +5,68 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/grupoapi/proces-log/internal/adapter/repository/postgres"
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
 	"github.com/grupoapi/proces-log/internal/domain/service"
+	automationUsecase "github.com/grupoapi/proces-log/internal/usecase/automation"
 	subtaskUsecase "github.com/grupoapi/proces-log/internal/usecase/subtask"
 	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
 )
 
+// RouterOption ajusta la configuración opcional de SetupRouter
+type RouterOption func(*routerOptions)
+
+// routerOptions es la configuración opcional de SetupRouter, con sus valores por defecto
+type routerOptions struct {
+	maxPayloadBytes  int
+	blobStore        repository.BlobStore
+	maxArtifactBytes int64
+	clock            entity.Clock
+}
+
+// WithMaxPayloadBytes fija el tamaño máximo de los documentos input y output de tareas y subtareas
+// (entity.DefaultMaxPayloadBytes por defecto)
+func WithMaxPayloadBytes(maxBytes int) RouterOption {
+	return func(o *routerOptions) {
+		o.maxPayloadBytes = maxBytes
+	}
+}
+
+// WithBlobStore habilita los endpoints de artefactos, que guardan el contenido de los ficheros en store
+// Sin almacén los endpoints de artefactos no se registran
+func WithBlobStore(store repository.BlobStore) RouterOption {
+	return func(o *routerOptions) {
+		o.blobStore = store
+	}
+}
+
+// WithMaxArtifactBytes fija el tamaño máximo del fichero de cada artefacto
+// (entity.DefaultMaxArtifactBytes por defecto)
+func WithMaxArtifactBytes(maxBytes int64) RouterOption {
+	return func(o *routerOptions) {
+		o.maxArtifactBytes = maxBytes
+	}
+}
+
+// WithClock fija el reloj del que los casos de uso toman las fechas (entity.SystemClock por defecto)
+func WithClock(clock entity.Clock) RouterOption {
+	return func(o *routerOptions) {
+		o.clock = clock
+	}
+}
+
 // SetupRouter configura y retorna el router con todas las rutas
-func SetupRouter(db *pgxpool.Pool, ginMode string) *gin.Engine {
+// workflows define, por perfil de workflow, las transiciones de estado permitidas para tareas y subtareas
+func SetupRouter(db *pgxpool.Pool, ginMode string, workflows *service.WorkflowRegistry, opts ...RouterOption) *gin.Engine {
+	options := routerOptions{
+		maxPayloadBytes:  entity.DefaultMaxPayloadBytes,
+		maxArtifactBytes: entity.DefaultMaxArtifactBytes,
+		clock:            entity.SystemClock,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	gin.SetMode(ginMode)
 
 	router := gin.New()
@@ -23,24 +78,75 @@ func SetupRouter(db *pgxpool.Pool, ginMode string) *gin.Engine {
 	// Inicializar repositorios
 	taskRepo := postgres.NewTaskRepository(db)
 	subtaskRepo := postgres.NewSubtaskRepository(db)
-
-	// Inicializar servicios de dominio
-	stateMachine := service.NewStateMachine()
+	eventRepo := postgres.NewTaskEventRepository(db)
+	attemptRepo := postgres.NewTaskAttemptRepository(db)
+	logRepo := postgres.NewTaskLogRepository(db)
+	idempotencyRepo := postgres.NewIdempotencyRepository(db)
+	automationRepo := postgres.NewAutomationRepository(db)
+	txManager := postgres.NewTransactionManager(db)
 
 	// Inicializar casos de uso de tareas
-	createTaskUseCase := taskUsecase.NewCreateTaskUseCase(taskRepo)
-	getTaskUseCase := taskUsecase.NewGetTaskUseCase(taskRepo)
-	listTasksUseCase := taskUsecase.NewListTasksUseCase(taskRepo)
-	updateTaskUseCase := taskUsecase.NewUpdateTaskUseCase(taskRepo, subtaskRepo, stateMachine)
+	createTaskUseCase := taskUsecase.NewCreateTaskUseCase(taskRepo, eventRepo, automationRepo, txManager, workflows, options.maxPayloadBytes, options.clock)
+	getTaskUseCase := taskUsecase.NewGetTaskUseCase(taskRepo, attemptRepo, options.clock)
+	listTasksUseCase := taskUsecase.NewListTasksUseCase(taskRepo, options.clock)
+	updateTaskUseCase := taskUsecase.NewUpdateTaskUseCase(
+		taskRepo, subtaskRepo, eventRepo, txManager, workflows, options.maxPayloadBytes, options.clock,
+	)
+	deleteTaskUseCase := taskUsecase.NewDeleteTaskUseCase(taskRepo, eventRepo, txManager, options.clock)
+	restoreTaskUseCase := taskUsecase.NewRestoreTaskUseCase(taskRepo, eventRepo, txManager, options.clock)
+	retryTaskUseCase := taskUsecase.NewRetryTaskUseCase(taskRepo, attemptRepo, eventRepo, txManager, options.clock)
+	listTaskAttemptsUseCase := taskUsecase.NewListTaskAttemptsUseCase(taskRepo, attemptRepo, options.clock)
+	listReadySubtasksUseCase := taskUsecase.NewListReadySubtasksUseCase(taskRepo)
+	getTaskHistoryUseCase := taskUsecase.NewGetTaskHistoryUseCase(taskRepo, eventRepo)
+	recordHeartbeatUseCase := taskUsecase.NewRecordHeartbeatUseCase(taskRepo, txManager, options.clock)
+	appendTaskLogsUseCase := taskUsecase.NewAppendTaskLogsUseCase(taskRepo, logRepo, txManager, options.clock)
+	listTaskLogsUseCase := taskUsecase.NewListTaskLogsUseCase(taskRepo, logRepo)
+	idempotentCreateTaskUseCase := taskUsecase.NewIdempotentCreateTaskUseCase(
+		idempotencyRepo,
+		txManager,
+		taskUsecase.DefaultIdempotencyTTL,
+		options.clock,
+	)
 
 	// Inicializar casos de uso de subtareas
-	updateSubtaskUseCase := subtaskUsecase.NewUpdateSubtaskUseCase(subtaskRepo, taskRepo, stateMachine)
-	deleteSubtaskUseCase := subtaskUsecase.NewDeleteSubtaskUseCase(subtaskRepo)
+	updateSubtaskUseCase := subtaskUsecase.NewUpdateSubtaskUseCase(
+		subtaskRepo, taskRepo, eventRepo, txManager, workflows, options.maxPayloadBytes, options.clock,
+	)
+	deleteSubtaskUseCase := subtaskUsecase.NewDeleteSubtaskUseCase(subtaskRepo, eventRepo, txManager, options.clock)
+
+	// Inicializar casos de uso de automatismos
+	createAutomationUseCase := automationUsecase.NewCreateAutomationUseCase(automationRepo, options.clock)
+	getAutomationUseCase := automationUsecase.NewGetAutomationUseCase(automationRepo)
+	listAutomationsUseCase := automationUsecase.NewListAutomationsUseCase(automationRepo)
+	updateAutomationUseCase := automationUsecase.NewUpdateAutomationUseCase(automationRepo, options.clock)
+	deleteAutomationUseCase := automationUsecase.NewDeleteAutomationUseCase(automationRepo, options.clock)
+	listMissedRunsUseCase := automationUsecase.NewListMissedRunsUseCase(automationRepo)
 
 	// Inicializar handlers
 	healthHandler := NewHealthHandler(db)
-	taskHandler := NewTaskHandler(createTaskUseCase, getTaskUseCase, listTasksUseCase, updateTaskUseCase)
+	taskHandler := NewTaskHandler(
+		createTaskUseCase,
+		getTaskUseCase,
+		listTasksUseCase,
+		updateTaskUseCase,
+		deleteTaskUseCase,
+		restoreTaskUseCase,
+		idempotentCreateTaskUseCase,
+	)
 	subtaskHandler := NewSubtaskHandler(updateSubtaskUseCase, deleteSubtaskUseCase)
+	historyHandler := NewHistoryHandler(getTaskHistoryUseCase)
+	attemptHandler := NewAttemptHandler(retryTaskUseCase, listTaskAttemptsUseCase)
+	dependencyHandler := NewDependencyHandler(listReadySubtasksUseCase)
+	heartbeatHandler := NewHeartbeatHandler(recordHeartbeatUseCase)
+	logHandler := NewLogHandler(appendTaskLogsUseCase, listTaskLogsUseCase)
+	automationHandler := NewAutomationHandler(
+		createAutomationUseCase,
+		getAutomationUseCase,
+		listAutomationsUseCase,
+		updateAutomationUseCase,
+		deleteAutomationUseCase,
+		listMissedRunsUseCase,
+	)
 
 	// Health check endpoint
 	router.GET("/health", healthHandler.Check)
@@ -49,11 +155,42 @@ func SetupRouter(db *pgxpool.Pool, ginMode string) *gin.Engine {
 	router.POST("/Automatizacion", taskHandler.Create)
 	router.PUT("/Automatizacion", taskHandler.Update)
 	router.GET("/Automatizacion/:uuid", taskHandler.Get)
+	router.DELETE("/Automatizacion/:uuid", taskHandler.Delete)
+	router.POST("/Automatizacion/:uuid/restore", taskHandler.Restore)
+	router.GET("/Automatizacion/:uuid/history", historyHandler.List)
+	router.POST("/Automatizacion/:uuid/retry", attemptHandler.Retry)
+	router.GET("/Automatizacion/:uuid/attempts", attemptHandler.List)
+	router.GET("/Automatizacion/:uuid/ready", dependencyHandler.Ready)
+	router.POST("/Automatizacion/:uuid/heartbeat", heartbeatHandler.Record)
+	router.POST("/Automatizacion/:uuid/logs", logHandler.Append)
+	router.GET("/Automatizacion/:uuid/logs", logHandler.List)
 	router.GET("/AutomatizacionListado", taskHandler.List)
 
+	// Artifact endpoints
+	if options.blobStore != nil {
+		artifactRepo := postgres.NewArtifactRepository(db)
+		artifactHandler := NewArtifactHandler(
+			taskUsecase.NewUploadArtifactUseCase(taskRepo, artifactRepo, options.blobStore, options.maxArtifactBytes, options.clock),
+			taskUsecase.NewGetArtifactUseCase(taskRepo, artifactRepo, options.blobStore),
+			taskUsecase.NewListArtifactsUseCase(taskRepo, artifactRepo),
+			options.maxArtifactBytes,
+		)
+		router.POST("/Automatizacion/:uuid/artifacts", artifactHandler.Upload)
+		router.GET("/Automatizacion/:uuid/artifacts", artifactHandler.List)
+		router.GET("/Automatizacion/:uuid/artifacts/:id", artifactHandler.Download)
+	}
+
 	// Subtask endpoints
 	router.PUT("/Subtask/:uuid", subtaskHandler.Update)
 	router.DELETE("/Subtask/:uuid", subtaskHandler.Delete)
 
+	// Automation endpoints
+	router.POST("/Automatismo", automationHandler.Create)
+	router.GET("/Automatismo/:key", automationHandler.Get)
+	router.PUT("/Automatismo/:key", automationHandler.Update)
+	router.DELETE("/Automatismo/:key", automationHandler.Delete)
+	router.GET("/Automatismo/:key/missed", automationHandler.ListMissed)
+	router.GET("/AutomatismoListado", automationHandler.List)
+
 	return router
 }
@@ -1,10 +1,15 @@
 package http
 
+import "encoding/json"
+
 // UpdateSubtaskRequest representa el request para actualizar una subtarea
 type UpdateSubtaskRequest struct {
-	Name      *string `json:"name,omitempty"`
-	State     *string `json:"state,omitempty"`
-	UpdatedBy string  `json:"updated_by" binding:"required"`
+	Name      *string         `json:"name,omitempty"`
+	State     *string         `json:"state,omitempty"`
+	Failure   *FailureRequest `json:"failure,omitempty"` // Solo junto con state FAILED
+	Output    json.RawMessage `json:"output,omitempty"`  // Solo junto con un state final
+	UpdatedBy string          `json:"updated_by" binding:"required"`
+	ProgressRequest
 }
 
 // DeleteSubtaskRequest representa el request para eliminar una subtarea
@@ -38,6 +38,7 @@ func NewSubtaskHandler(
 }
 
 // Update maneja PUT /Subtask/{uuid}
+// Si se envía la cabecera If-Match, la actualización solo se aplica si coincide con la versión actual
 func (h *SubtaskHandler) Update(c *gin.Context) {
 	uuidStr := c.Param("uuid")
 	subtaskID, ok := parseUUIDOrError(c, uuidStr, entity.ErrSubtaskNotFound)
@@ -45,6 +46,11 @@ func (h *SubtaskHandler) Update(c *gin.Context) {
 		return
 	}
 
+	expectedVersion, ok := parseIfMatchOrError(c)
+	if !ok {
+		return
+	}
+
 	var req UpdateSubtaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
@@ -69,7 +75,12 @@ func (h *SubtaskHandler) Update(c *gin.Context) {
 		ID:        subtaskID,
 		Name:      req.Name,
 		State:     state,
+		Failure:   req.Failure.toFailure(),
+		Output:    req.Output,
+		Progress:  req.toProgressReport(),
 		UpdatedBy: req.UpdatedBy,
+
+		ExpectedVersion: expectedVersion,
 	}
 
 	output, err := h.updateUseCase.Execute(c.Request.Context(), input)
@@ -78,6 +89,7 @@ func (h *SubtaskHandler) Update(c *gin.Context) {
 		return
 	}
 
+	setETag(c, output.Subtask.Version)
 	c.JSON(http.StatusOK, ToSubtaskResponse(output.Subtask))
 }
 
@@ -16,6 +16,7 @@ import (
 
 	"github.com/grupoapi/proces-log/internal/domain/entity"
 	subtaskUsecase "github.com/grupoapi/proces-log/internal/usecase/subtask"
+	"github.com/grupoapi/proces-log/test/helpers"
 )
 
 // MockUpdateSubtaskUseCase es un mock del UpdateSubtaskUseCase
@@ -61,7 +62,7 @@ func TestSubtaskHandler_Update_Success(t *testing.T) {
 	router := setupSubtaskTestRouter(handler)
 
 	// Crear subtarea de prueba
-	subtask, err := entity.NewSubtask("Test Subtask")
+	subtask, err := entity.NewSubtask("Test Subtask", helpers.NewTestClock())
 	require.NoError(t, err)
 	subtaskID := subtask.ID
 	subtask.State = entity.StateCompleted
@@ -95,6 +96,120 @@ func TestSubtaskHandler_Update_Success(t *testing.T) {
 	mockUpdate.AssertExpectations(t)
 }
 
+func TestSubtaskHandler_Update_ReportProgress(t *testing.T) {
+	// Setup
+	mockUpdate := new(MockUpdateSubtaskUseCase)
+	mockDelete := new(MockDeleteSubtaskUseCase)
+
+	handler := NewSubtaskHandler(mockUpdate, mockDelete)
+	router := setupSubtaskTestRouter(handler)
+
+	// Crear subtarea de prueba con contadores reportados
+	subtask, err := entity.NewSubtask("Import", helpers.NewTestClock())
+	require.NoError(t, err)
+	processed, total := int64(250), int64(1000)
+	require.NoError(t, subtask.ReportProgress(entity.ProgressReport{ItemsProcessed: &processed, ItemsTotal: &total}))
+
+	// Configurar mock
+	mockUpdate.On("Execute", mock.Anything, mock.MatchedBy(func(input subtaskUsecase.UpdateSubtaskInput) bool {
+		return input.State == nil && input.Progress.Progress == nil &&
+			input.Progress.ItemsProcessed != nil && *input.Progress.ItemsProcessed == 250 &&
+			input.Progress.ItemsTotal != nil && *input.Progress.ItemsTotal == 1000
+	})).Return(&subtaskUsecase.UpdateSubtaskOutput{Subtask: subtask}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodPut, "/Subtask/"+subtask.ID.String(),
+		bytes.NewBufferString(`{"items_processed": 250, "items_total": 1000, "updated_by": "importer"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response SubtaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.NotNil(t, response.Progress)
+	assert.Equal(t, 25, *response.Progress)
+	require.NotNil(t, response.ItemsProcessed)
+	assert.Equal(t, int64(250), *response.ItemsProcessed)
+	mockUpdate.AssertExpectations(t)
+}
+
+func TestSubtaskHandler_Update_WithIfMatch(t *testing.T) {
+	// Setup
+	mockUpdate := new(MockUpdateSubtaskUseCase)
+	mockDelete := new(MockDeleteSubtaskUseCase)
+
+	handler := NewSubtaskHandler(mockUpdate, mockDelete)
+	router := setupSubtaskTestRouter(handler)
+
+	// Crear subtarea de prueba ya actualizada a la versión 3
+	subtask, err := entity.NewSubtask("Test Subtask", helpers.NewTestClock())
+	require.NoError(t, err)
+	subtask.Version = 3
+
+	// Configurar mock
+	mockUpdate.On("Execute", mock.Anything, mock.MatchedBy(func(input subtaskUsecase.UpdateSubtaskInput) bool {
+		return input.ExpectedVersion != nil && *input.ExpectedVersion == 2
+	})).Return(&subtaskUsecase.UpdateSubtaskOutput{Subtask: subtask}, nil)
+
+	// Request
+	reqBody := UpdateSubtaskRequest{
+		Name:      stringPtr("Renamed Subtask"),
+		UpdatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPut, "/Subtask/"+subtask.ID.String(), bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `W/"2"`)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	mockUpdate.AssertExpectations(t)
+}
+
+func TestSubtaskHandler_Update_VersionMismatch(t *testing.T) {
+	// Setup
+	mockUpdate := new(MockUpdateSubtaskUseCase)
+	mockDelete := new(MockDeleteSubtaskUseCase)
+
+	handler := NewSubtaskHandler(mockUpdate, mockDelete)
+	router := setupSubtaskTestRouter(handler)
+
+	// Configurar mock para retornar conflicto de versión
+	mockUpdate.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrVersionConflict)
+
+	// Request
+	reqBody := UpdateSubtaskRequest{
+		State:     stringPtr("COMPLETED"),
+		UpdatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPut, "/Subtask/"+uuid.New().String(), bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/version-conflict", response.Type)
+	mockUpdate.AssertExpectations(t)
+}
+
 func TestSubtaskHandler_Update_InvalidUUID(t *testing.T) {
 	// Setup
 	mockUpdate := new(MockUpdateSubtaskUseCase)
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,13 +14,85 @@ type CreateTaskRequest struct {
 	Name      string                 `json:"name" binding:"required"`
 	State     *string                `json:"state,omitempty"`
 	CreatedBy string                 `json:"created_by" binding:"required"`
+	Workflow  string                 `json:"workflow,omitempty"`
 	Subtasks  []CreateSubtaskRequest `json:"subtasks,omitempty"`
+
+	CompletionPolicy string `json:"completion_policy,omitempty"`
+	AutoStart        bool   `json:"auto_start,omitempty"`
+	HeartbeatTimeout int    `json:"heartbeat_timeout,omitempty"` // Segundos sin latidos antes de cerrar la tarea
+	ProgressRequest
+	SLARequest
+
+	Labels   map[string]string      `json:"labels,omitempty"`   // Etiquetas de la tarea (clave -> valor)
+	Metadata map[string]interface{} `json:"metadata,omitempty"` // Objeto JSON libre
+	Input    json.RawMessage        `json:"input,omitempty"`    // Parámetros de entrada, cualquier valor JSON
+
+	// Clave del automatismo del que la tarea es una ejecución; sin subtareas se crean las de su plantilla
+	Automation string `json:"automation,omitempty"`
+}
+
+// SLARequest son los campos del SLA que aceptan los requests de tareas
+type SLARequest struct {
+	ExpectedDuration *int       `json:"expected_duration,omitempty"` // Segundos comprometidos desde el arranque
+	Deadline         *time.Time `json:"deadline,omitempty"`          // Fecha límite en RFC 3339
+}
+
+// FailureRequest es el motivo del fallo que acompaña a un cambio de estado a FAILED
+type FailureRequest struct {
+	ErrorCode    string      `json:"error_code"`
+	ErrorMessage string      `json:"error_message"`
+	Details      interface{} `json:"details,omitempty"` // Valor JSON libre: contexto, traza de la pila...
+}
+
+// toFailure convierte el motivo del fallo del request a la entidad; nil si no se envió
+func (r *FailureRequest) toFailure() *entity.Failure {
+	if r == nil {
+		return nil
+	}
+	return &entity.Failure{Code: r.ErrorCode, Message: r.ErrorMessage, Details: r.Details}
+}
+
+// FailureResponse representa el motivo del fallo de una tarea o subtarea FAILED
+type FailureResponse struct {
+	ErrorCode    string      `json:"error_code"`
+	ErrorMessage string      `json:"error_message"`
+	Details      interface{} `json:"details,omitempty"`
+}
+
+// toFailureResponse convierte el motivo del fallo a su respuesta; nil si no se informó
+func toFailureResponse(failure *entity.Failure) *FailureResponse {
+	if failure == nil {
+		return nil
+	}
+	return &FailureResponse{ErrorCode: failure.Code, ErrorMessage: failure.Message, Details: failure.Details}
+}
+
+// ProgressRequest son los campos de progreso que aceptan los requests de tareas y subtareas
+// Los campos omitidos no se modifican
+type ProgressRequest struct {
+	Progress       *int   `json:"progress,omitempty"` // Porcentaje 0-100
+	ItemsProcessed *int64 `json:"items_processed,omitempty"`
+	ItemsTotal     *int64 `json:"items_total,omitempty"`
+}
+
+// toProgressReport convierte los campos de progreso del request al reporte de dominio
+func (r ProgressRequest) toProgressReport() entity.ProgressReport {
+	return entity.ProgressReport{
+		Progress:       r.Progress,
+		ItemsProcessed: r.ItemsProcessed,
+		ItemsTotal:     r.ItemsTotal,
+	}
 }
 
 // CreateSubtaskRequest representa una subtarea en el request de creación
 type CreateSubtaskRequest struct {
-	Name  string  `json:"name" binding:"required"`
-	State *string `json:"state,omitempty"`
+	Name      string   `json:"name" binding:"required"`
+	State     *string  `json:"state,omitempty"`
+	DependsOn []string `json:"depends_on,omitempty"` // IDs o nombres de otras subtareas de la tarea
+	// Subtareas anidadas bajo esta subtarea
+	Subtasks []CreateSubtaskRequest `json:"subtasks,omitempty"`
+	ProgressRequest
+	Input json.RawMessage `json:"input,omitempty"` // Parámetros de entrada, cualquier valor JSON
 }
 
 // UpdateTaskRequest representa el request para actualizar una tarea
@@ -27,17 +100,44 @@ type UpdateTaskRequest struct {
 	ID        string                     `json:"id" binding:"required"`
 	Name      *string                    `json:"name,omitempty"`
 	State     *string                    `json:"state,omitempty"`
+	Failure   *FailureRequest            `json:"failure,omitempty"` // Solo junto con state FAILED
+	Output    json.RawMessage            `json:"output,omitempty"`  // Solo junto con un state final
 	UpdatedBy string                     `json:"updated_by" binding:"required"`
 	Subtasks  []UpdateSubtaskItemRequest `json:"subtasks,omitempty"`
+	// Segundos sin latidos antes de cerrar la tarea (0 desactiva la supervisión)
+	HeartbeatTimeout *int `json:"heartbeat_timeout,omitempty"`
+	ProgressRequest
+	SLARequest
+	// Reemplazan todas las etiquetas o los metadatos; un objeto vacío los elimina
+	Labels   *map[string]string      `json:"labels,omitempty"`
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // UpdateSubtaskItemRequest representa una subtarea en el request de actualización
 // Si tiene ID, es una subtarea existente a actualizar
 // Si no tiene ID pero tiene Name, es una nueva subtarea a crear
+// DependsOn reemplaza las dependencias de la subtarea; si se omite no se modifican
+// Subtasks lista las subtareas anidadas bajo esta subtarea, con las mismas reglas
 type UpdateSubtaskItemRequest struct {
-	ID    *string `json:"id,omitempty"`
-	Name  *string `json:"name,omitempty"`
-	State *string `json:"state,omitempty"`
+	ID        *string                    `json:"id,omitempty"`
+	Name      *string                    `json:"name,omitempty"`
+	State     *string                    `json:"state,omitempty"`
+	Failure   *FailureRequest            `json:"failure,omitempty"` // Solo junto con state FAILED
+	Input     json.RawMessage            `json:"input,omitempty"`   // Solo al crear la subtarea
+	Output    json.RawMessage            `json:"output,omitempty"`  // Solo junto con un state final
+	DependsOn *[]string                  `json:"depends_on,omitempty"`
+	Subtasks  []UpdateSubtaskItemRequest `json:"subtasks,omitempty"`
+	ProgressRequest
+}
+
+// DeleteTaskRequest representa el request para eliminar una tarea
+type DeleteTaskRequest struct {
+	DeletedBy string `json:"deleted_by" binding:"required"`
+}
+
+// RestoreTaskRequest representa el request para restaurar una tarea eliminada
+type RestoreTaskRequest struct {
+	RestoredBy string `json:"restored_by" binding:"required"`
 }
 
 // TaskResponse representa la respuesta de una tarea
@@ -45,6 +145,7 @@ type TaskResponse struct {
 	ID        string            `json:"id"`
 	Name      string            `json:"name"`
 	State     string            `json:"state"`
+	Workflow  string            `json:"workflow"`
 	Subtasks  []SubtaskResponse `json:"subtasks"`
 	CreatedBy string            `json:"created_by"`
 	UpdatedBy *string           `json:"updated_by,omitempty"`
@@ -53,6 +154,41 @@ type TaskResponse struct {
 	CreatedAt time.Time         `json:"created_at"`
 	UpdatedAt time.Time         `json:"updated_at"`
 	DeletedAt *time.Time        `json:"deleted_at,omitempty"`
+	Version   int               `json:"version"`
+	// Contabilidad de pausas: la duración excluye el tiempo en PAUSED
+	PausedAt        *time.Time `json:"paused_at,omitempty"`
+	DurationSeconds *int64     `json:"duration_seconds,omitempty"`
+	PausedSeconds   int64      `json:"paused_seconds,omitempty"`
+	// Política con la que las subtareas cierran automáticamente la tarea
+	CompletionPolicy string `json:"completion_policy"`
+	// Si arrancar una subtarea pasa la tarea PENDING a IN_PROGRESS
+	AutoStart bool `json:"auto_start"`
+	// Progreso efectivo (reportado o derivado de las subtareas) y contadores reportados
+	Progress       *int   `json:"progress,omitempty"`
+	ItemsProcessed *int64 `json:"items_processed,omitempty"`
+	ItemsTotal     *int64 `json:"items_total,omitempty"`
+	// Supervisión por latidos: plazo en segundos y último latido recibido
+	HeartbeatTimeout *int64     `json:"heartbeat_timeout,omitempty"`
+	LastHeartbeatAt  *time.Time `json:"last_heartbeat_at,omitempty"`
+	// SLA comprometido y su estado en el momento de la respuesta (se omiten si la tarea no tiene SLA)
+	ExpectedDuration *int64     `json:"expected_duration,omitempty"`
+	Deadline         *time.Time `json:"deadline,omitempty"`
+	SLAStatus        *string    `json:"sla_status,omitempty"`
+	SLADueAt         *time.Time `json:"sla_due_at,omitempty"`
+	SLABreachedAt    *time.Time `json:"sla_breached_at,omitempty"`
+	// Etiquetas y metadatos libres (se omiten si están vacíos)
+	Labels   map[string]string      `json:"labels,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// Motivo del fallo informado al pasar a FAILED (se omite en otro caso)
+	Failure *FailureResponse `json:"failure,omitempty"`
+	// Parámetros de entrada y resultados (se omiten en los listados salvo include_payloads=true)
+	Input  json.RawMessage `json:"input,omitempty"`
+	Output json.RawMessage `json:"output,omitempty"`
+	// Intento en curso y resumen de intentos (este último solo en GET /Automatizacion/{uuid})
+	Attempt  int                   `json:"attempt"`
+	Attempts []TaskAttemptResponse `json:"attempts,omitempty"`
+	// Clave del automatismo del que la tarea es una ejecución (se omite en las tareas sueltas)
+	Automation string `json:"automation,omitempty"`
 }
 
 // SubtaskResponse representa la respuesta de una subtarea
@@ -65,6 +201,32 @@ type SubtaskResponse struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Version   int        `json:"version"`
+	// Contabilidad de pausas: la duración excluye el tiempo en PAUSED
+	PausedAt        *time.Time `json:"paused_at,omitempty"`
+	DurationSeconds *int64     `json:"duration_seconds,omitempty"`
+	PausedSeconds   int64      `json:"paused_seconds,omitempty"`
+	// IDs de las subtareas que deben completarse antes de arrancar esta
+	DependsOn []string `json:"depends_on,omitempty"`
+	// Progreso efectivo (reportado o derivado de sus contadores) y contadores reportados
+	Progress       *int   `json:"progress,omitempty"`
+	ItemsProcessed *int64 `json:"items_processed,omitempty"`
+	ItemsTotal     *int64 `json:"items_total,omitempty"`
+	// Motivo del fallo informado al pasar a FAILED (se omite en otro caso)
+	Failure *FailureResponse `json:"failure,omitempty"`
+	// Parámetros de entrada y resultados (se omiten en los listados salvo include_payloads=true)
+	Input  json.RawMessage `json:"input,omitempty"`
+	Output json.RawMessage `json:"output,omitempty"`
+	// Subtarea de la que cuelga esta y subtareas anidadas (estas solo dentro de TaskResponse)
+	ParentSubtaskID *string           `json:"parent_subtask_id,omitempty"`
+	Subtasks        []SubtaskResponse `json:"subtasks,omitempty"`
+}
+
+// ReadySubtasksResponse representa las subtareas de una tarea listas para arrancar
+type ReadySubtasksResponse struct {
+	TaskID    string            `json:"task_id"`
+	TaskState string            `json:"task_state"`
+	Subtasks  []SubtaskResponse `json:"subtasks"`
 }
 
 // TaskListResponse representa la respuesta del listado de tareas
@@ -82,23 +244,32 @@ type PaginationResponse struct {
 }
 
 // ToTaskResponse convierte una entidad Task a TaskResponse
+// Las subtareas se devuelven como árbol: las raíz en Subtasks y cada una con sus hijas anidadas
 func ToTaskResponse(task *entity.Task) TaskResponse {
-	subtasks := make([]SubtaskResponse, 0, len(task.Subtasks))
-	for _, st := range task.Subtasks {
-		if !st.IsDeleted() {
-			subtasks = append(subtasks, ToSubtaskResponse(st))
-		}
-	}
+	subtasks := toSubtaskTreeResponse(task, nil)
 
 	var updatedBy *string
 	if task.UpdatedBy != "" {
 		updatedBy = &task.UpdatedBy
 	}
 
+	now := task.Now()
+
+	var slaStatus *string
+	if status, ok := task.SLAStatus(now); ok {
+		value := status.String()
+		slaStatus = &value
+	}
+	var slaDueAt *time.Time
+	if due, ok := task.SLADueAt(); ok {
+		slaDueAt = &due
+	}
+
 	return TaskResponse{
 		ID:        task.ID.String(),
 		Name:      task.Name,
 		State:     task.State.String(),
+		Workflow:  task.Workflow,
 		Subtasks:  subtasks,
 		CreatedBy: task.CreatedBy,
 		UpdatedBy: updatedBy,
@@ -107,11 +278,45 @@ func ToTaskResponse(task *entity.Task) TaskResponse {
 		CreatedAt: task.CreatedAt,
 		UpdatedAt: task.UpdatedAt,
 		DeletedAt: task.DeletedAt,
+		Version:   task.Version,
+
+		PausedAt:        task.PausedAt,
+		DurationSeconds: durationSeconds(task.ActiveDuration(now)),
+		PausedSeconds:   pausedSeconds(task.PauseTracking, task.EndDate, now),
+
+		CompletionPolicy: task.CompletionPolicy.String(),
+		AutoStart:        task.AutoStart,
+		Attempt:          task.Attempt,
+
+		Progress:       task.EffectiveProgress(),
+		ItemsProcessed: task.ItemsProcessed,
+		ItemsTotal:     task.ItemsTotal,
+
+		HeartbeatTimeout: optionalSeconds(task.HeartbeatTimeout),
+		LastHeartbeatAt:  task.LastHeartbeatAt,
+
+		ExpectedDuration: optionalSeconds(task.ExpectedDuration),
+		Deadline:         task.Deadline,
+		SLAStatus:        slaStatus,
+		SLADueAt:         slaDueAt,
+		SLABreachedAt:    task.SLABreachedAt,
+
+		Labels:   task.Labels,
+		Metadata: task.Metadata,
+
+		Failure: toFailureResponse(task.Failure),
+
+		Automation: task.Automation,
+
+		Input:  task.Input,
+		Output: task.Output,
 	}
 }
 
 // ToSubtaskResponse convierte una entidad Subtask a SubtaskResponse
 func ToSubtaskResponse(subtask *entity.Subtask) SubtaskResponse {
+	now := subtask.Now()
+
 	return SubtaskResponse{
 		ID:        subtask.ID.String(),
 		Name:      subtask.Name,
@@ -121,7 +326,102 @@ func ToSubtaskResponse(subtask *entity.Subtask) SubtaskResponse {
 		CreatedAt: subtask.CreatedAt,
 		UpdatedAt: subtask.UpdatedAt,
 		DeletedAt: subtask.DeletedAt,
+		Version:   subtask.Version,
+
+		PausedAt:        subtask.PausedAt,
+		DurationSeconds: durationSeconds(subtask.ActiveDuration(now)),
+		PausedSeconds:   pausedSeconds(subtask.PauseTracking, subtask.EndDate, now),
+
+		DependsOn: dependsOnIDs(subtask.DependsOn),
+
+		Progress:       subtask.EffectiveProgress(),
+		ItemsProcessed: subtask.ItemsProcessed,
+		ItemsTotal:     subtask.ItemsTotal,
+
+		Failure: toFailureResponse(subtask.Failure),
+
+		Input:  subtask.Input,
+		Output: subtask.Output,
+
+		ParentSubtaskID: parentSubtaskID(subtask.ParentSubtaskID),
+	}
+}
+
+// toSubtaskTreeResponse convierte las subtareas no eliminadas que cuelgan de parent (nil para las raíz)
+// anidando recursivamente sus hijas
+func toSubtaskTreeResponse(task *entity.Task, parent *entity.Subtask) []SubtaskResponse {
+	children := task.ChildrenOf(parent)
+	subtasks := make([]SubtaskResponse, 0, len(children))
+	for _, st := range children {
+		response := ToSubtaskResponse(st)
+		if nested := toSubtaskTreeResponse(task, st); len(nested) > 0 {
+			response.Subtasks = nested
+		}
+		subtasks = append(subtasks, response)
+	}
+	return subtasks
+}
+
+// parentSubtaskID convierte el ID de la subtarea padre a string; nil si la subtarea es raíz
+func parentSubtaskID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	parent := id.String()
+	return &parent
+}
+
+// ToReadySubtasksResponse convierte las subtareas listas de una tarea a ReadySubtasksResponse
+func ToReadySubtasksResponse(task *entity.Task, ready []*entity.Subtask) ReadySubtasksResponse {
+	subtasks := make([]SubtaskResponse, 0, len(ready))
+	for _, st := range ready {
+		subtasks = append(subtasks, ToSubtaskResponse(st))
+	}
+
+	return ReadySubtasksResponse{
+		TaskID:    task.ID.String(),
+		TaskState: task.State.String(),
+		Subtasks:  subtasks,
+	}
+}
+
+// dependsOnIDs convierte los IDs de las dependencias a strings; nil si la subtarea no tiene dependencias
+func dependsOnIDs(ids []uuid.UUID) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result
+}
+
+// durationSeconds convierte una duración activa a segundos; nil si la ejecución no ha comenzado
+func durationSeconds(active time.Duration, started bool) *int64 {
+	if !started {
+		return nil
+	}
+	seconds := int64(active / time.Second)
+	return &seconds
+}
+
+// pausedSeconds retorna los segundos en pausa hasta la fecha de fin, o hasta now si sigue abierta
+func pausedSeconds(pauses entity.PauseTracking, endDate *time.Time, now time.Time) int64 {
+	until := now
+	if endDate != nil {
+		until = *endDate
+	}
+	return int64(pauses.TotalPaused(until) / time.Second)
+}
+
+// optionalSeconds convierte un plazo opcional (sin latidos, duración esperada) a segundos, nil si no se configuró
+func optionalSeconds(duration *time.Duration) *int64 {
+	if duration == nil {
+		return nil
 	}
+	seconds := int64(*duration / time.Second)
+	return &seconds
 }
 
 // ParseState convierte un string a entity.State
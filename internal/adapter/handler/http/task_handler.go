@@ -2,6 +2,8 @@ package http
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -32,12 +34,36 @@ type UpdateTaskUseCaseInterface interface {
 	Execute(ctx context.Context, input taskUsecase.UpdateTaskInput) (*taskUsecase.UpdateTaskOutput, error)
 }
 
+// DeleteTaskUseCaseInterface define la interfaz para eliminar tareas
+type DeleteTaskUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.DeleteTaskInput) (*taskUsecase.DeleteTaskOutput, error)
+}
+
+// RestoreTaskUseCaseInterface define la interfaz para restaurar tareas eliminadas
+type RestoreTaskUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.RestoreTaskInput) (*taskUsecase.RestoreTaskOutput, error)
+}
+
+// IdempotentCreateTaskUseCaseInterface define la interfaz para creaciones idempotentes
+type IdempotentCreateTaskUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.IdempotentCreateTaskInput, create taskUsecase.CreateTaskOperation) (*taskUsecase.IdempotentCreateTaskOutput, error)
+}
+
+// Cabeceras de idempotencia para POST /Automatizacion
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
 // TaskHandler maneja las peticiones HTTP relacionadas con tareas
 type TaskHandler struct {
-	createUseCase CreateTaskUseCaseInterface
-	getUseCase    GetTaskUseCaseInterface
-	listUseCase   ListTasksUseCaseInterface
-	updateUseCase UpdateTaskUseCaseInterface
+	createUseCase           CreateTaskUseCaseInterface
+	getUseCase              GetTaskUseCaseInterface
+	listUseCase             ListTasksUseCaseInterface
+	updateUseCase           UpdateTaskUseCaseInterface
+	deleteUseCase           DeleteTaskUseCaseInterface
+	restoreUseCase          RestoreTaskUseCaseInterface
+	idempotentCreateUseCase IdempotentCreateTaskUseCaseInterface
 }
 
 // NewTaskHandler crea una nueva instancia de TaskHandler
@@ -46,47 +72,104 @@ func NewTaskHandler(
 	getUseCase GetTaskUseCaseInterface,
 	listUseCase ListTasksUseCaseInterface,
 	updateUseCase UpdateTaskUseCaseInterface,
+	deleteUseCase DeleteTaskUseCaseInterface,
+	restoreUseCase RestoreTaskUseCaseInterface,
+	idempotentCreateUseCase IdempotentCreateTaskUseCaseInterface,
 ) *TaskHandler {
 	return &TaskHandler{
-		createUseCase: createUseCase,
-		getUseCase:    getUseCase,
-		listUseCase:   listUseCase,
-		updateUseCase: updateUseCase,
+		createUseCase:           createUseCase,
+		getUseCase:              getUseCase,
+		listUseCase:             listUseCase,
+		updateUseCase:           updateUseCase,
+		deleteUseCase:           deleteUseCase,
+		restoreUseCase:          restoreUseCase,
+		idempotentCreateUseCase: idempotentCreateUseCase,
 	}
 }
 
 // Create maneja POST /Automatizacion
+// Si se envía la cabecera Idempotency-Key, la creación se ejecuta una sola vez:
+// los reintentos con el mismo cuerpo reciben la respuesta 201 original
 func (h *TaskHandler) Create(c *gin.Context) {
 	req, ok := h.bindAndValidateCreateRequest(c)
 	if !ok {
 		return
 	}
 
-	initialState, ok := parseStateOrError(c, req.State)
+	input, ok := h.parseCreateInput(c, req)
 	if !ok {
 		return
 	}
 
-	input, ok := h.parseCreateInput(c, req)
-	if !ok {
+	create := func(ctx context.Context) (*entity.Task, error) {
+		output, err := h.createUseCase.Execute(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return output.Task, nil
+	}
+
+	idempotencyKey := c.GetHeader(idempotencyKeyHeader)
+	if idempotencyKey == "" {
+		task, err := create(c.Request.Context())
+		if err != nil {
+			MapErrorToProblemDetails(c, err)
+			return
+		}
+
+		setETag(c, task.Version)
+		c.JSON(http.StatusCreated, ToTaskResponse(task))
 		return
 	}
 
-	output, err := h.createUseCase.Execute(c.Request.Context(), input)
+	h.createIdempotently(c, req, idempotencyKey, create)
+}
+
+// createIdempotently ejecuta la creación asociada a una Idempotency-Key
+func (h *TaskHandler) createIdempotently(c *gin.Context, req *CreateTaskRequest, key string, create func(ctx context.Context) (*entity.Task, error)) {
+	requestHash, err := hashRequest(req)
 	if err != nil {
 		MapErrorToProblemDetails(c, err)
 		return
 	}
 
-	if !h.applyInitialState(c, output, initialState, req.CreatedBy) {
-		return
+	input := taskUsecase.IdempotentCreateTaskInput{
+		Key:         key,
+		CreatedBy:   req.CreatedBy,
+		RequestHash: requestHash,
 	}
 
-	if !h.applyInitialSubtaskStates(c, output, req.Subtasks, req.CreatedBy) {
+	output, err := h.idempotentCreateUseCase.Execute(c.Request.Context(), input, func(ctx context.Context) (*taskUsecase.IdempotentResponse, error) {
+		task, err := create(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := json.Marshal(ToTaskResponse(task))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode task response: %w", err)
+		}
+
+		return &taskUsecase.IdempotentResponse{
+			TaskID:     task.ID,
+			StatusCode: http.StatusCreated,
+			Body:       body,
+		}, nil
+	})
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, ToTaskResponse(output.Task))
+	response := output.Response
+	var taskResponse TaskResponse
+	if err := json.Unmarshal(response.Body, &taskResponse); err == nil {
+		setETag(c, taskResponse.Version)
+	}
+	if output.Replayed {
+		c.Header(idempotentReplayedHeader, "true")
+	}
+	c.Data(response.StatusCode, "application/json; charset=utf-8", response.Body)
 }
 
 // bindAndValidateCreateRequest realiza el binding del request y valida el nombre de la tarea
@@ -105,93 +188,80 @@ func (h *TaskHandler) bindAndValidateCreateRequest(c *gin.Context) (*CreateTaskR
 	return &req, true
 }
 
-// parseCreateInput parsea el request y crea el input para el use case, validando subtareas
+// parseCreateInput parsea el request y crea el input para el use case, validando subtareas y estados
 func (h *TaskHandler) parseCreateInput(c *gin.Context, req *CreateTaskRequest) (taskUsecase.CreateTaskInput, bool) {
-	input := taskUsecase.CreateTaskInput{
-		Name:         req.Name,
-		CreatedBy:    req.CreatedBy,
-		SubtaskNames: make([]string, 0, len(req.Subtasks)),
-	}
-
-	for _, stReq := range req.Subtasks {
-		if err := entity.ValidateName(stReq.Name); err != nil {
-			MapErrorToProblemDetails(c, err)
-			return taskUsecase.CreateTaskInput{}, false
-		}
-		input.SubtaskNames = append(input.SubtaskNames, stReq.Name)
+	state, ok := parseStateOrError(c, req.State)
+	if !ok {
+		return taskUsecase.CreateTaskInput{}, false
 	}
 
-	return input, true
-}
+	input := taskUsecase.CreateTaskInput{
+		Name:      req.Name,
+		State:     state,
+		CreatedBy: req.CreatedBy,
+		Workflow:  req.Workflow,
 
-// applyInitialState aplica el estado inicial de la tarea si se proporcionó uno diferente a PENDING
-func (h *TaskHandler) applyInitialState(c *gin.Context, output *taskUsecase.CreateTaskOutput, initialState *entity.State, updatedBy string) bool {
-	if initialState == nil || *initialState == entity.StatePending {
-		return true
+		CompletionPolicy: req.CompletionPolicy,
+		AutoStart:        req.AutoStart,
+		Progress:         req.toProgressReport(),
+		HeartbeatTimeout: req.HeartbeatTimeout,
+		Deadline:         req.Deadline,
+		Labels:           req.Labels,
+		Metadata:         req.Metadata,
+		Input:            req.Input,
+		Automation:       req.Automation,
 	}
-
-	updateInput := taskUsecase.UpdateTaskInput{
-		ID:        output.Task.ID,
-		State:     initialState,
-		UpdatedBy: updatedBy,
+	if req.ExpectedDuration != nil {
+		input.ExpectedDuration = *req.ExpectedDuration
 	}
 
-	updateOutput, err := h.updateUseCase.Execute(c.Request.Context(), updateInput)
-	if err != nil {
-		MapErrorToProblemDetails(c, err)
-		return false
+	input.Subtasks, ok = parseCreateSubtaskInputs(c, req.Subtasks)
+	if !ok {
+		return taskUsecase.CreateTaskInput{}, false
 	}
 
-	output.Task = updateOutput.Task
-	return true
+	return input, true
 }
 
-// applyInitialSubtaskStates aplica los estados iniciales de las subtareas si se proporcionaron
-func (h *TaskHandler) applyInitialSubtaskStates(c *gin.Context, output *taskUsecase.CreateTaskOutput, reqSubtasks []CreateSubtaskRequest, updatedBy string) bool {
-	if len(reqSubtasks) == 0 || len(output.Task.Subtasks) != len(reqSubtasks) {
-		return true
-	}
+// parseCreateSubtaskInputs convierte las subtareas del request de creación, incluidas las anidadas
+func parseCreateSubtaskInputs(c *gin.Context, reqs []CreateSubtaskRequest) ([]taskUsecase.CreateSubtaskItemInput, bool) {
+	inputs := make([]taskUsecase.CreateSubtaskItemInput, 0, len(reqs))
+	for _, stReq := range reqs {
+		if err := entity.ValidateName(stReq.Name); err != nil {
+			MapErrorToProblemDetails(c, err)
+			return nil, false
+		}
 
-	subtaskUpdates := make([]taskUsecase.UpdateSubtaskItemInput, 0, len(reqSubtasks))
-	for i, stReq := range reqSubtasks {
-		if stReq.State == nil || *stReq.State == "PENDING" {
-			continue
+		stState, ok := parseStateOrError(c, stReq.State)
+		if !ok {
+			return nil, false
 		}
 
-		parsedState, ok := parseStateOrError(c, stReq.State)
+		children, ok := parseCreateSubtaskInputs(c, stReq.Subtasks)
 		if !ok {
-			return false
+			return nil, false
 		}
 
-		subtaskID := output.Task.Subtasks[i].ID
-		subtaskUpdates = append(subtaskUpdates, taskUsecase.UpdateSubtaskItemInput{
-			ID:    &subtaskID,
-			State: parsedState,
+		inputs = append(inputs, taskUsecase.CreateSubtaskItemInput{
+			Name:      stReq.Name,
+			State:     stState,
+			DependsOn: stReq.DependsOn,
+			Subtasks:  children,
+			Progress:  stReq.toProgressReport(),
+			Input:     stReq.Input,
 		})
 	}
-
-	if len(subtaskUpdates) == 0 {
-		return true
-	}
-
-	updateInput := taskUsecase.UpdateTaskInput{
-		ID:        output.Task.ID,
-		UpdatedBy: updatedBy,
-		Subtasks:  subtaskUpdates,
-	}
-
-	updateOutput, err := h.updateUseCase.Execute(c.Request.Context(), updateInput)
-	if err != nil {
-		MapErrorToProblemDetails(c, err)
-		return false
-	}
-
-	output.Task = updateOutput.Task
-	return true
+	return inputs, true
 }
 
 // Update maneja PUT /Automatizacion
+// Si se envía la cabecera If-Match, la actualización solo se aplica si coincide con la versión actual
 func (h *TaskHandler) Update(c *gin.Context) {
+	expectedVersion, ok := parseIfMatchOrError(c)
+	if !ok {
+		return
+	}
+
 	var req UpdateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
@@ -219,13 +289,50 @@ func (h *TaskHandler) Update(c *gin.Context) {
 	}
 
 	// Convertir subtareas del request
-	subtaskInputs := make([]taskUsecase.UpdateSubtaskItemInput, 0, len(req.Subtasks))
-	for _, stReq := range req.Subtasks {
+	subtaskInputs, ok := parseUpdateSubtaskInputs(c, req.Subtasks)
+	if !ok {
+		return
+	}
+
+	// Crear input para el use case
+	input := taskUsecase.UpdateTaskInput{
+		ID:        taskID,
+		Name:      req.Name,
+		State:     state,
+		Failure:   req.Failure.toFailure(),
+		Output:    req.Output,
+		UpdatedBy: req.UpdatedBy,
+		Subtasks:  subtaskInputs,
+		Progress:  req.toProgressReport(),
+
+		HeartbeatTimeout: req.HeartbeatTimeout,
+		ExpectedDuration: req.ExpectedDuration,
+		Deadline:         req.Deadline,
+		Labels:           req.Labels,
+		Metadata:         req.Metadata,
+		ExpectedVersion:  expectedVersion,
+	}
+
+	// Ejecutar use case
+	output, err := h.updateUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	setETag(c, output.Task.Version)
+	c.JSON(http.StatusOK, ToTaskResponse(output.Task))
+}
+
+// parseUpdateSubtaskInputs convierte las subtareas del request de actualización, incluidas las anidadas
+func parseUpdateSubtaskInputs(c *gin.Context, reqs []UpdateSubtaskItemRequest) ([]taskUsecase.UpdateSubtaskItemInput, bool) {
+	inputs := make([]taskUsecase.UpdateSubtaskItemInput, 0, len(reqs))
+	for _, stReq := range reqs {
 		var stID *uuid.UUID
 		if stReq.ID != nil {
 			parsedID, ok := parseUUIDOrError(c, *stReq.ID, entity.ErrSubtaskNotFound)
 			if !ok {
-				return
+				return nil, false
 			}
 			stID = &parsedID
 		}
@@ -233,66 +340,121 @@ func (h *TaskHandler) Update(c *gin.Context) {
 		// Validar que tenga ID o Name
 		if stID == nil && stReq.Name == nil {
 			MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
-			return
+			return nil, false
 		}
 
 		// Validar nombre si se proporciona
 		if stReq.Name != nil {
 			if err := entity.ValidateName(*stReq.Name); err != nil {
 				MapErrorToProblemDetails(c, err)
-				return
+				return nil, false
 			}
 		}
 
 		stState, ok := parseStateOrError(c, stReq.State)
 		if !ok {
-			return
+			return nil, false
 		}
 
-		subtaskInputs = append(subtaskInputs, taskUsecase.UpdateSubtaskItemInput{
-			ID:    stID,
-			Name:  stReq.Name,
-			State: stState,
+		children, ok := parseUpdateSubtaskInputs(c, stReq.Subtasks)
+		if !ok {
+			return nil, false
+		}
+
+		inputs = append(inputs, taskUsecase.UpdateSubtaskItemInput{
+			ID:        stID,
+			Name:      stReq.Name,
+			State:     stState,
+			Failure:   stReq.Failure.toFailure(),
+			Input:     stReq.Input,
+			Output:    stReq.Output,
+			DependsOn: stReq.DependsOn,
+			Subtasks:  children,
+			Progress:  stReq.toProgressReport(),
 		})
 	}
+	return inputs, true
+}
+
+// Get maneja GET /Automatizacion/{uuid}
+func (h *TaskHandler) Get(c *gin.Context) {
+	uuidStr := c.Param("uuid")
+	taskID, ok := parseUUIDOrError(c, uuidStr, entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
 
-	// Crear input para el use case
-	input := taskUsecase.UpdateTaskInput{
+	input := taskUsecase.GetTaskInput{
+		ID: taskID,
+	}
+
+	output, err := h.getUseCase.Execute(c.Request.Context(), input)
+	if err != nil {
+		MapErrorToProblemDetails(c, err)
+		return
+	}
+
+	response := ToTaskResponse(output.Task)
+	response.Attempts = ToTaskAttemptsSummary(output.Task, output.Attempts)
+
+	setETag(c, output.Task.Version)
+	c.JSON(http.StatusOK, response)
+}
+
+// Delete maneja DELETE /Automatizacion/{uuid}
+func (h *TaskHandler) Delete(c *gin.Context) {
+	uuidStr := c.Param("uuid")
+	taskID, ok := parseUUIDOrError(c, uuidStr, entity.ErrTaskNotFound)
+	if !ok {
+		return
+	}
+
+	var req DeleteTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+		return
+	}
+
+	input := taskUsecase.DeleteTaskInput{
 		ID:        taskID,
-		Name:      req.Name,
-		State:     state,
-		UpdatedBy: req.UpdatedBy,
-		Subtasks:  subtaskInputs,
+		DeletedBy: req.DeletedBy,
 	}
 
-	// Ejecutar use case
-	output, err := h.updateUseCase.Execute(c.Request.Context(), input)
+	_, err := h.deleteUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
 		MapErrorToProblemDetails(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, ToTaskResponse(output.Task))
+	c.Status(http.StatusNoContent)
 }
 
-// Get maneja GET /Automatizacion/{uuid}
-func (h *TaskHandler) Get(c *gin.Context) {
+// Restore maneja POST /Automatizacion/{uuid}/restore
+func (h *TaskHandler) Restore(c *gin.Context) {
 	uuidStr := c.Param("uuid")
 	taskID, ok := parseUUIDOrError(c, uuidStr, entity.ErrTaskNotFound)
 	if !ok {
 		return
 	}
 
-	input := taskUsecase.GetTaskInput{
-		ID: taskID,
+	var req RestoreTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+		return
 	}
 
-	output, err := h.getUseCase.Execute(c.Request.Context(), input)
+	input := taskUsecase.RestoreTaskInput{
+		ID:         taskID,
+		RestoredBy: req.RestoredBy,
+	}
+
+	output, err := h.restoreUseCase.Execute(c.Request.Context(), input)
 	if err != nil {
 		MapErrorToProblemDetails(c, err)
 		return
 	}
 
+	setETag(c, output.Task.Version)
 	c.JSON(http.StatusOK, ToTaskResponse(output.Task))
 }
 
@@ -314,33 +476,74 @@ func (h *TaskHandler) List(c *gin.Context) {
 		name = &nameStr
 	}
 
-	// Parsear paginación
-	page := 1
-	if pageStr := c.Query("page"); pageStr != "" {
-		parsedPage, err := strconv.Atoi(pageStr)
-		if err != nil || parsedPage < 1 {
-			MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+	var minProgress *int
+	if minProgressStr := c.Query("min_progress"); minProgressStr != "" {
+		parsed, err := strconv.Atoi(minProgressStr)
+		if err != nil {
+			MapErrorToProblemDetails(c, fmt.Errorf("%w: min_progress must be an integer", entity.ErrInvalidProgress))
 			return
 		}
-		page = parsedPage
+		minProgress = &parsed
 	}
 
-	limit := 20
-	if limitStr := c.Query("limit"); limitStr != "" {
-		parsedLimit, err := strconv.Atoi(limitStr)
-		if err != nil || parsedLimit < 1 || parsedLimit > 100 {
-			MapErrorToProblemDetails(c, entity.ErrMissingRequiredFields)
+	var slaStatus *entity.SLAStatus
+	if slaStatusStr := c.Query("sla_status"); slaStatusStr != "" {
+		parsed, err := entity.ParseSLAStatus(slaStatusStr)
+		if err != nil {
+			MapErrorToProblemDetails(c, err)
 			return
 		}
-		limit = parsedLimit
+		slaStatus = &parsed
+	}
+
+	var labels map[string]string
+	if selector := c.Query("labels"); selector != "" {
+		parsed, err := entity.ParseLabelSelector(selector)
+		if err != nil {
+			MapErrorToProblemDetails(c, err)
+			return
+		}
+		labels = parsed
+	}
+
+	var errorCode *string
+	if errorCodeStr := c.Query("error_code"); errorCodeStr != "" {
+		errorCode = &errorCodeStr
+	}
+
+	var automation *string
+	if automationStr := c.Query("automation"); automationStr != "" {
+		automation = &automationStr
+	}
+
+	includePayloads := false
+	if includeStr := c.Query("include_payloads"); includeStr != "" {
+		parsed, err := strconv.ParseBool(includeStr)
+		if err != nil {
+			MapErrorToProblemDetails(c, fmt.Errorf("%w: include_payloads must be true or false", entity.ErrInvalidPayload))
+			return
+		}
+		includePayloads = parsed
+	}
+
+	// Parsear paginación
+	page, limit, ok := parsePaginationOrError(c, 20)
+	if !ok {
+		return
 	}
 
 	input := taskUsecase.ListTasksInput{
-		State:          state,
-		NameContains:   name,
-		Page:           page,
-		Limit:          limit,
-		IncludeDeleted: false,
+		State:           state,
+		NameContains:    name,
+		MinProgress:     minProgress,
+		SLAStatus:       slaStatus,
+		Labels:          labels,
+		ErrorCode:       errorCode,
+		Automation:      automation,
+		IncludePayloads: includePayloads,
+		Page:            page,
+		Limit:           limit,
+		IncludeDeleted:  false,
 	}
 
 	output, err := h.listUseCase.Execute(c.Request.Context(), input)
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/grupoapi/proces-log/internal/domain/entity"
 	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+	"github.com/grupoapi/proces-log/test/helpers"
 )
 
 // MockCreateTaskUseCase es un mock del CreateTaskUseCase
@@ -70,12 +73,61 @@ func (m *MockUpdateTaskUseCase) Execute(ctx context.Context, input taskUsecase.U
 	return args.Get(0).(*taskUsecase.UpdateTaskOutput), args.Error(1)
 }
 
+// MockDeleteTaskUseCase es un mock del DeleteTaskUseCase
+type MockDeleteTaskUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDeleteTaskUseCase) Execute(ctx context.Context, input taskUsecase.DeleteTaskInput) (*taskUsecase.DeleteTaskOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.DeleteTaskOutput), args.Error(1)
+}
+
+// MockRestoreTaskUseCase es un mock del RestoreTaskUseCase
+type MockRestoreTaskUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRestoreTaskUseCase) Execute(ctx context.Context, input taskUsecase.RestoreTaskInput) (*taskUsecase.RestoreTaskOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*taskUsecase.RestoreTaskOutput), args.Error(1)
+}
+
+// MockIdempotentCreateTaskUseCase es un mock del IdempotentCreateTaskUseCase
+// Si no se configura una salida, ejecuta la operación de creación como lo haría el caso de uso real
+type MockIdempotentCreateTaskUseCase struct {
+	mock.Mock
+}
+
+func (m *MockIdempotentCreateTaskUseCase) Execute(ctx context.Context, input taskUsecase.IdempotentCreateTaskInput, create taskUsecase.CreateTaskOperation) (*taskUsecase.IdempotentCreateTaskOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		if err := args.Error(1); err != nil {
+			return nil, err
+		}
+		response, err := create(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &taskUsecase.IdempotentCreateTaskOutput{Response: response}, nil
+	}
+	return args.Get(0).(*taskUsecase.IdempotentCreateTaskOutput), args.Error(1)
+}
+
 func setupTestRouter(handler *TaskHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	router.POST("/Automatizacion", handler.Create)
 	router.PUT("/Automatizacion", handler.Update)
 	router.GET("/Automatizacion/:uuid", handler.Get)
+	router.DELETE("/Automatizacion/:uuid", handler.Delete)
+	router.POST("/Automatizacion/:uuid/restore", handler.Restore)
 	router.GET("/AutomatizacionListado", handler.List)
 	return router
 }
@@ -87,11 +139,11 @@ func TestTaskHandler_Create_Success(t *testing.T) {
 	mockList := new(MockListTasksUseCase)
 	mockUpdate := new(MockUpdateTaskUseCase)
 
-	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate)
+	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
 	router := setupTestRouter(handler)
 
 	// Crear tarea de prueba
-	task, err := entity.NewTask("Test Task", "test-user")
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
 	require.NoError(t, err)
 
 	// Configurar mock
@@ -129,7 +181,7 @@ func TestTaskHandler_Create_InvalidName(t *testing.T) {
 	mockList := new(MockListTasksUseCase)
 	mockUpdate := new(MockUpdateTaskUseCase)
 
-	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate)
+	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
 	router := setupTestRouter(handler)
 
 	// Los mocks mockGet, mockList y mockUpdate no se usan en este test
@@ -166,7 +218,7 @@ func TestTaskHandler_Create_WithSubtasks(t *testing.T) {
 	mockList := new(MockListTasksUseCase)
 	mockUpdate := new(MockUpdateTaskUseCase)
 
-	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate)
+	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
 	router := setupTestRouter(handler)
 
 	// Los mocks mockGet, mockList y mockUpdate no se usan en este test
@@ -175,16 +227,16 @@ func TestTaskHandler_Create_WithSubtasks(t *testing.T) {
 	_ = mockUpdate
 
 	// Crear tarea con subtareas
-	task, err := entity.NewTask("Test Task", "test-user")
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
 	require.NoError(t, err)
-	subtask1, _ := entity.NewSubtask("Subtask 1")
-	subtask2, _ := entity.NewSubtask("Subtask 2")
+	subtask1, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
+	subtask2, _ := entity.NewSubtask("Subtask 2", helpers.NewTestClock())
 	task.AddSubtask(subtask1)
 	task.AddSubtask(subtask2)
 
 	// Configurar mock
 	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
-		return len(input.SubtaskNames) == 2
+		return len(input.Subtasks) == 2
 	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
 
 	// Request
@@ -213,208 +265,225 @@ func TestTaskHandler_Create_WithSubtasks(t *testing.T) {
 	mockCreate.AssertExpectations(t)
 }
 
-func TestTaskHandler_Get_Success(t *testing.T) {
+func TestTaskHandler_Create_WithInitialStates(t *testing.T) {
 	// Setup
 	mockCreate := new(MockCreateTaskUseCase)
-	mockGet := new(MockGetTaskUseCase)
-	mockList := new(MockListTasksUseCase)
 	mockUpdate := new(MockUpdateTaskUseCase)
 
-	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate)
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
 	router := setupTestRouter(handler)
 
-	// Los mocks mockCreate, mockList y mockUpdate no se usan en este test
-	_ = mockCreate
-	_ = mockList
-	_ = mockUpdate
-
-	// Crear tarea de prueba
-	task, err := entity.NewTask("Test Task", "test-user")
+	// Tarea ya creada en su estado inicial
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
 	require.NoError(t, err)
-	taskID := task.ID
+	subtask1, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
+	subtask2, _ := entity.NewSubtask("Subtask 2", helpers.NewTestClock())
+	task.AddSubtask(subtask1)
+	task.AddSubtask(subtask2)
+	require.NoError(t, task.UpdateState(entity.StateInProgress, "test-user"))
+	subtask1.State = entity.StateInProgress
 
-	// Configurar mock
-	mockGet.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.GetTaskInput) bool {
-		return input.ID == taskID
-	})).Return(&taskUsecase.GetTaskOutput{Task: task}, nil)
+	// Configurar mock: los estados iniciales viajan en el input de creación
+	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
+		return input.State != nil && *input.State == entity.StateInProgress &&
+			len(input.Subtasks) == 2 &&
+			input.Subtasks[0].State != nil && *input.Subtasks[0].State == entity.StateInProgress &&
+			input.Subtasks[1].State == nil
+	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
 
 	// Request
-	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String(), nil)
+	reqBody := CreateTaskRequest{
+		Name:      "Test Task",
+		State:     stringPtr("IN_PROGRESS"),
+		CreatedBy: "test-user",
+		Subtasks: []CreateSubtaskRequest{
+			{Name: "Subtask 1", State: stringPtr("IN_PROGRESS")},
+			{Name: "Subtask 2"},
+		},
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	// Execute
 	router.ServeHTTP(w, req)
 
-	// Assert
-	assert.Equal(t, http.StatusOK, w.Code)
+	// Assert: la creación es una sola operación, sin actualizaciones posteriores
+	assert.Equal(t, http.StatusCreated, w.Code)
 	var response TaskResponse
 	err = json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Equal(t, taskID.String(), response.ID)
-	assert.Equal(t, "Test Task", response.Name)
-	mockGet.AssertExpectations(t)
+	assert.Equal(t, "IN_PROGRESS", response.State)
+	assert.Equal(t, 2, len(response.Subtasks))
+	mockCreate.AssertExpectations(t)
+	mockUpdate.AssertNotCalled(t, "Execute")
 }
 
-func TestTaskHandler_Get_InvalidUUID(t *testing.T) {
+func TestTaskHandler_Create_InvalidSubtaskState(t *testing.T) {
 	// Setup
 	mockCreate := new(MockCreateTaskUseCase)
-	mockGet := new(MockGetTaskUseCase)
-	mockList := new(MockListTasksUseCase)
-	mockUpdate := new(MockUpdateTaskUseCase)
 
-	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate)
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
 	router := setupTestRouter(handler)
 
-	// Los mocks no se usan en este test ya que falla antes de llamar al use case
-	_ = mockCreate
-	_ = mockGet
-	_ = mockList
-	_ = mockUpdate
+	// Request con un estado de subtarea inválido
+	reqBody := CreateTaskRequest{
+		Name:      "Test Task",
+		CreatedBy: "test-user",
+		Subtasks: []CreateSubtaskRequest{
+			{Name: "Subtask 1", State: stringPtr("UNKNOWN")},
+		},
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
 
-	// Request con UUID inválido
-	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/invalid-uuid", nil)
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert: no se persiste nada
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockCreate.AssertNotCalled(t, "Execute")
+}
+
+func TestTaskHandler_Create_WithWorkflow(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	task.Workflow = "batch-etl"
+
+	// Configurar mock: el workflow viaja en el input de creación
+	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
+		return input.Workflow == "batch-etl"
+	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
+
+	// Request
+	reqBody := CreateTaskRequest{
+		Name:      "Test Task",
+		CreatedBy: "test-user",
+		Workflow:  "batch-etl",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	// Execute
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusNotFound, w.Code)
-	var response ProblemDetails
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Equal(t, "https://api.grupoapi.com/problems/task-not-found", response.Type)
-	mockGet.AssertNotCalled(t, "Execute")
+	assert.Equal(t, "batch-etl", response.Workflow)
+	mockCreate.AssertExpectations(t)
 }
 
-func TestTaskHandler_List_Success(t *testing.T) {
+func TestTaskHandler_Create_RunOfAutomation(t *testing.T) {
 	// Setup
 	mockCreate := new(MockCreateTaskUseCase)
-	mockGet := new(MockGetTaskUseCase)
-	mockList := new(MockListTasksUseCase)
-	mockUpdate := new(MockUpdateTaskUseCase)
 
-	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate)
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
 	router := setupTestRouter(handler)
 
-	// Los mocks mockCreate, mockGet y mockUpdate no se usan en este test
-	_ = mockCreate
-	_ = mockGet
-	_ = mockUpdate
-
-	// Crear tareas de prueba
-	task1, _ := entity.NewTask("Task 1", "user1")
-	task2, _ := entity.NewTask("Task 2", "user2")
+	task, err := entity.NewTask("Nightly export 2026-03-02", "scheduler", helpers.NewTestClock())
+	require.NoError(t, err)
+	task.Automation = "billing.nightly-export"
 
-	// Configurar mock
-	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
-		return input.Page == 1 && input.Limit == 20
-	})).Return(&taskUsecase.ListTasksOutput{
-		Tasks:      []*entity.Task{task1, task2},
-		Total:      2,
-		Page:       1,
-		Limit:      20,
-		TotalPages: 1,
-	}, nil)
+	// Configurar mock: la clave del automatismo viaja en el input de creación
+	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
+		return input.Automation == "billing.nightly-export" && len(input.Subtasks) == 0
+	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
 
 	// Request
-	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?page=1&limit=20", nil)
+	body := `{"name": "Nightly export 2026-03-02", "created_by": "scheduler", "automation": "billing.nightly-export"}`
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	// Execute
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusOK, w.Code)
-	var response TaskListResponse
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Equal(t, 2, len(response.Tasks))
-	assert.Equal(t, 2, response.Pagination.Total)
-	mockList.AssertExpectations(t)
+	assert.Equal(t, "billing.nightly-export", response.Automation)
+	mockCreate.AssertExpectations(t)
 }
 
-func TestTaskHandler_List_WithFilters(t *testing.T) {
+func TestTaskHandler_Create_WithCompletionPolicy(t *testing.T) {
 	// Setup
 	mockCreate := new(MockCreateTaskUseCase)
-	mockGet := new(MockGetTaskUseCase)
-	mockList := new(MockListTasksUseCase)
-	mockUpdate := new(MockUpdateTaskUseCase)
 
-	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate)
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
 	router := setupTestRouter(handler)
 
-	// Los mocks mockCreate, mockGet y mockUpdate no se usan en este test
-	_ = mockCreate
-	_ = mockGet
-	_ = mockUpdate
-
-	// Crear tarea de prueba
-	task, _ := entity.NewTask("Test Task", "user")
-	task.UpdateState(entity.StateInProgress, "user")
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	task.CompletionPolicy = entity.CompletionFailFast
 
-	// Configurar mock
-	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
-		return input.State != nil && *input.State == entity.StateInProgress
-	})).Return(&taskUsecase.ListTasksOutput{
-		Tasks:      []*entity.Task{task},
-		Total:      1,
-		Page:       1,
-		Limit:      20,
-		TotalPages: 1,
-	}, nil)
+	// Configurar mock: la política viaja en el input de creación
+	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
+		return input.CompletionPolicy == "fail-fast"
+	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
 
 	// Request
-	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?state=IN_PROGRESS", nil)
+	reqBody := CreateTaskRequest{
+		Name:             "Test Task",
+		CreatedBy:        "test-user",
+		CompletionPolicy: "fail-fast",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	// Execute
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusOK, w.Code)
-	var response TaskListResponse
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Equal(t, 1, len(response.Tasks))
-	assert.Equal(t, entity.StateInProgress.String(), response.Tasks[0].State)
-	mockList.AssertExpectations(t)
+	assert.Equal(t, "fail-fast", response.CompletionPolicy)
+	mockCreate.AssertExpectations(t)
 }
 
-func TestTaskHandler_Update_Success(t *testing.T) {
+func TestTaskHandler_Create_WithAutoStart(t *testing.T) {
 	// Setup
 	mockCreate := new(MockCreateTaskUseCase)
-	mockGet := new(MockGetTaskUseCase)
-	mockList := new(MockListTasksUseCase)
-	mockUpdate := new(MockUpdateTaskUseCase)
 
-	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate)
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
 	router := setupTestRouter(handler)
 
-	// Los mocks mockCreate, mockGet y mockList no se usan en este test
-	_ = mockCreate
-	_ = mockGet
-	_ = mockList
-
-	// Crear tarea de prueba
-	task, err := entity.NewTask("Test Task", "test-user")
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
 	require.NoError(t, err)
-	taskID := task.ID
-	task.UpdateState(entity.StateInProgress, "test-user")
+	task.AutoStart = true
 
-	// Configurar mock
-	mockUpdate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.UpdateTaskInput) bool {
-		return input.ID == taskID && input.State != nil && *input.State == entity.StateInProgress
-	})).Return(&taskUsecase.UpdateTaskOutput{Task: task}, nil)
+	// Configurar mock: el arranque automático viaja en el input de creación
+	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
+		return input.AutoStart
+	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
 
 	// Request
-	reqBody := UpdateTaskRequest{
-		ID:        taskID.String(),
-		State:     stringPtr("IN_PROGRESS"),
-		UpdatedBy: "test-user",
+	reqBody := CreateTaskRequest{
+		Name:      "Test Task",
+		CreatedBy: "test-user",
+		AutoStart: true,
 	}
 	bodyBytes, _ := json.Marshal(reqBody)
-	req := httptest.NewRequest(http.MethodPut, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -422,40 +491,38 @@ func TestTaskHandler_Update_Success(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusCreated, w.Code)
 	var response TaskResponse
 	err = json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Equal(t, entity.StateInProgress.String(), response.State)
-	mockUpdate.AssertExpectations(t)
+	assert.True(t, response.AutoStart)
+	mockCreate.AssertExpectations(t)
 }
 
-func TestTaskHandler_Update_NotFound(t *testing.T) {
+func TestTaskHandler_Create_WithHeartbeatTimeout(t *testing.T) {
 	// Setup
 	mockCreate := new(MockCreateTaskUseCase)
-	mockGet := new(MockGetTaskUseCase)
-	mockList := new(MockListTasksUseCase)
-	mockUpdate := new(MockUpdateTaskUseCase)
 
-	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate)
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
 	router := setupTestRouter(handler)
 
-	// Los mocks mockCreate, mockGet y mockList no se usan en este test
-	_ = mockCreate
-	_ = mockGet
-	_ = mockList
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	require.NoError(t, task.SetHeartbeatTimeout(120))
 
-	// Configurar mock para retornar error
-	mockUpdate.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrTaskNotFound)
+	// Configurar mock: el plazo sin latidos viaja en segundos en el input de creación
+	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
+		return input.HeartbeatTimeout == 120
+	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
 
 	// Request
-	reqBody := UpdateTaskRequest{
-		ID:        uuid.New().String(),
-		State:     stringPtr("IN_PROGRESS"),
-		UpdatedBy: "test-user",
+	reqBody := CreateTaskRequest{
+		Name:             "Test Task",
+		CreatedBy:        "test-user",
+		HeartbeatTimeout: 120,
 	}
 	bodyBytes, _ := json.Marshal(reqBody)
-	req := httptest.NewRequest(http.MethodPut, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -463,12 +530,1327 @@ func TestTaskHandler_Update_NotFound(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusNotFound, w.Code)
-	var response ProblemDetails
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Equal(t, "https://api.grupoapi.com/problems/task-not-found", response.Type)
-	mockUpdate.AssertExpectations(t)
+	require.NotNil(t, response.HeartbeatTimeout)
+	assert.Equal(t, int64(120), *response.HeartbeatTimeout)
+	assert.Nil(t, response.LastHeartbeatAt)
+	mockCreate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Create_WithLabelsAndMetadata(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	require.NoError(t, task.SetLabels(map[string]string{"env": "prod", "team": "finance"}))
+	require.NoError(t, task.SetMetadata(map[string]interface{}{"host": "etl-01", "retries": float64(3)}))
+
+	// Configurar mock: etiquetas y metadatos viajan tal cual en el input de creación
+	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
+		return input.Labels["env"] == "prod" && input.Labels["team"] == "finance" && input.Metadata["host"] == "etl-01"
+	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
+
+	// Request
+	body := `{"name": "Test Task", "created_by": "test-user", "labels": {"env": "prod", "team": "finance"},
+		"metadata": {"host": "etl-01", "retries": 3}}`
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "finance"}, response.Labels)
+	assert.Equal(t, map[string]interface{}{"host": "etl-01", "retries": float64(3)}, response.Metadata)
+	mockCreate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Create_WithInput(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	require.NoError(t, task.SetInput(entity.Payload(`{"from":"2026-03-01","to":"2026-03-31"}`), entity.DefaultMaxPayloadBytes))
+
+	// Configurar mock: los documentos input de la tarea y sus subtareas viajan sin interpretar
+	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
+		return len(input.Input) > 0 && len(input.Subtasks) == 1 && string(input.Subtasks[0].Input) == `{"file": "export.csv"}`
+	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
+
+	// Request
+	body := `{"name": "Test Task", "created_by": "test-user", "input": {"from": "2026-03-01", "to": "2026-03-31"},
+		"subtasks": [{"name": "Export", "input": {"file": "export.csv"}}]}`
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"from":"2026-03-01","to":"2026-03-31"}`, string(response.Input))
+	assert.Nil(t, response.Output)
+	mockCreate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Create_WithNestedSubtasks(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Crear tarea con una subtarea que agrupa dos subtareas anidadas
+	task, err := entity.NewTask("Deploy", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	build, _ := entity.NewSubtask("Build", helpers.NewTestClock())
+	test, _ := entity.NewSubtask("Test", helpers.NewTestClock())
+	unit, _ := entity.NewSubtask("Unit", helpers.NewTestClock())
+	integration, _ := entity.NewSubtask("Integration", helpers.NewTestClock())
+	task.AddSubtask(build)
+	task.AddSubtask(test)
+	task.AddChildSubtask(test, unit)
+	task.AddChildSubtask(test, integration)
+
+	// Configurar mock
+	mockCreate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.CreateTaskInput) bool {
+		return len(input.Subtasks) == 2 &&
+			len(input.Subtasks[0].Subtasks) == 0 &&
+			len(input.Subtasks[1].Subtasks) == 2 &&
+			input.Subtasks[1].Subtasks[1].Name == "Integration"
+	})).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
+
+	// Request
+	reqBody := CreateTaskRequest{
+		Name:      "Deploy",
+		CreatedBy: "test-user",
+		Subtasks: []CreateSubtaskRequest{
+			{Name: "Build"},
+			{Name: "Test", Subtasks: []CreateSubtaskRequest{{Name: "Unit"}, {Name: "Integration"}}},
+		},
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Subtasks, 2)
+	assert.Nil(t, response.Subtasks[0].ParentSubtaskID)
+	assert.Empty(t, response.Subtasks[0].Subtasks)
+	require.Len(t, response.Subtasks[1].Subtasks, 2)
+	assert.Equal(t, "Unit", response.Subtasks[1].Subtasks[0].Name)
+	require.NotNil(t, response.Subtasks[1].Subtasks[0].ParentSubtaskID)
+	assert.Equal(t, test.ID.String(), *response.Subtasks[1].Subtasks[0].ParentSubtaskID)
+	mockCreate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Create_InvalidNestedSubtaskName(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Request
+	reqBody := CreateTaskRequest{
+		Name:      "Deploy",
+		CreatedBy: "test-user",
+		Subtasks: []CreateSubtaskRequest{
+			{Name: "Test", Subtasks: []CreateSubtaskRequest{{Name: "Invalid@Name"}}},
+		},
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockCreate.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_Create_UnknownWorkflow(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	mockCreate.On("Execute", mock.Anything, mock.AnythingOfType("task.CreateTaskInput")).
+		Return(nil, fmt.Errorf("%w: rpa", entity.ErrUnknownWorkflow))
+
+	// Request
+	reqBody := CreateTaskRequest{
+		Name:      "Test Task",
+		CreatedBy: "test-user",
+		Workflow:  "rpa",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var problem ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &problem)
+	require.NoError(t, err)
+	assert.Equal(t, "Unknown Workflow", problem.Title)
+}
+
+func TestTaskHandler_Get_Success(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+	mockGet := new(MockGetTaskUseCase)
+	mockList := new(MockListTasksUseCase)
+	mockUpdate := new(MockUpdateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Los mocks mockCreate, mockList y mockUpdate no se usan en este test
+	_ = mockCreate
+	_ = mockList
+	_ = mockUpdate
+
+	// Crear tarea de prueba
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	taskID := task.ID
+
+	// Configurar mock
+	mockGet.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.GetTaskInput) bool {
+		return input.ID == taskID
+	})).Return(&taskUsecase.GetTaskOutput{Task: task}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+taskID.String(), nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, taskID.String(), response.ID)
+	assert.Equal(t, "Test Task", response.Name)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	assert.Equal(t, 1, response.Version)
+	assert.Equal(t, 1, response.Attempt)
+	require.Len(t, response.Attempts, 1)
+	assert.True(t, response.Attempts[0].Current)
+	mockGet.AssertExpectations(t)
+}
+
+func TestTaskHandler_Get_RetriedTaskIncludesAttempts(t *testing.T) {
+	// Setup
+	mockGet := new(MockGetTaskUseCase)
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), mockGet, new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	task, attempt := failedAndRetriedTask(t)
+
+	// Configurar mock
+	mockGet.On("Execute", mock.Anything, taskUsecase.GetTaskInput{ID: task.ID}).
+		Return(&taskUsecase.GetTaskOutput{Task: task, Attempts: []*entity.TaskAttempt{attempt}}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/"+task.ID.String(), nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Attempt)
+	require.Len(t, response.Attempts, 2)
+	assert.Equal(t, "FAILED", response.Attempts[0].State)
+	assert.Equal(t, "PENDING", response.Attempts[1].State)
+	assert.True(t, response.Attempts[1].Current)
+	mockGet.AssertExpectations(t)
+}
+
+func TestTaskHandler_Get_InvalidUUID(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+	mockGet := new(MockGetTaskUseCase)
+	mockList := new(MockListTasksUseCase)
+	mockUpdate := new(MockUpdateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Los mocks no se usan en este test ya que falla antes de llamar al use case
+	_ = mockCreate
+	_ = mockGet
+	_ = mockList
+	_ = mockUpdate
+
+	// Request con UUID inválido
+	req := httptest.NewRequest(http.MethodGet, "/Automatizacion/invalid-uuid", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/task-not-found", response.Type)
+	mockGet.AssertNotCalled(t, "Execute")
+}
+
+func TestTaskHandler_List_Success(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+	mockGet := new(MockGetTaskUseCase)
+	mockList := new(MockListTasksUseCase)
+	mockUpdate := new(MockUpdateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Los mocks mockCreate, mockGet y mockUpdate no se usan en este test
+	_ = mockCreate
+	_ = mockGet
+	_ = mockUpdate
+
+	// Crear tareas de prueba
+	task1, _ := entity.NewTask("Task 1", "user1", helpers.NewTestClock())
+	task2, _ := entity.NewTask("Task 2", "user2", helpers.NewTestClock())
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
+		return input.Page == 1 && input.Limit == 20
+	})).Return(&taskUsecase.ListTasksOutput{
+		Tasks:      []*entity.Task{task1, task2},
+		Total:      2,
+		Page:       1,
+		Limit:      20,
+		TotalPages: 1,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?page=1&limit=20", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(response.Tasks))
+	assert.Equal(t, 2, response.Pagination.Total)
+	mockList.AssertExpectations(t)
+}
+
+func TestTaskHandler_List_WithFilters(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+	mockGet := new(MockGetTaskUseCase)
+	mockList := new(MockListTasksUseCase)
+	mockUpdate := new(MockUpdateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Los mocks mockCreate, mockGet y mockUpdate no se usan en este test
+	_ = mockCreate
+	_ = mockGet
+	_ = mockUpdate
+
+	// Crear tarea de prueba
+	task, _ := entity.NewTask("Test Task", "user", helpers.NewTestClock())
+	task.UpdateState(entity.StateInProgress, "user")
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
+		return input.State != nil && *input.State == entity.StateInProgress
+	})).Return(&taskUsecase.ListTasksOutput{
+		Tasks:      []*entity.Task{task},
+		Total:      1,
+		Page:       1,
+		Limit:      20,
+		TotalPages: 1,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?state=IN_PROGRESS", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(response.Tasks))
+	assert.Equal(t, entity.StateInProgress.String(), response.Tasks[0].State)
+	mockList.AssertExpectations(t)
+}
+
+func TestTaskHandler_List_WithMinProgress(t *testing.T) {
+	// Setup
+	mockList := new(MockListTasksUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), mockList, new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Crear tarea de prueba con progreso derivado de sus subtareas
+	task, _ := entity.NewTask("Test Task", "user", helpers.NewTestClock())
+	task.UpdateState(entity.StateInProgress, "user")
+	done, _ := entity.NewSubtask("Done", helpers.NewTestClock())
+	pending, _ := entity.NewSubtask("Pending", helpers.NewTestClock())
+	task.AddSubtask(done)
+	task.AddSubtask(pending)
+	done.UpdateState(entity.StateCompleted)
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
+		return input.MinProgress != nil && *input.MinProgress == 50
+	})).Return(&taskUsecase.ListTasksOutput{
+		Tasks:      []*entity.Task{task},
+		Total:      1,
+		Page:       1,
+		Limit:      20,
+		TotalPages: 1,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?min_progress=50", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Tasks, 1)
+	require.NotNil(t, response.Tasks[0].Progress)
+	assert.Equal(t, 50, *response.Tasks[0].Progress)
+	mockList.AssertExpectations(t)
+}
+
+func TestTaskHandler_List_InvalidMinProgress(t *testing.T) {
+	// Setup
+	mockList := new(MockListTasksUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), mockList, new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?min_progress=half", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-progress", response.Type)
+	mockList.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_List_WithSLAStatus(t *testing.T) {
+	// Setup
+	mockList := new(MockListTasksUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), mockList, new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Crear tarea de prueba con la fecha límite ya vencida cuando se lista
+	clock := helpers.NewTestClock()
+	task, _ := entity.NewTask("Test Task", "user", clock)
+	require.NoError(t, task.SetDeadline(clock.Now().Add(time.Hour)))
+	clock.Advance(2 * time.Hour)
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
+		return input.SLAStatus != nil && *input.SLAStatus == entity.SLABreached
+	})).Return(&taskUsecase.ListTasksOutput{
+		Tasks:      []*entity.Task{task},
+		Total:      1,
+		Page:       1,
+		Limit:      20,
+		TotalPages: 1,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?sla_status=breached", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Tasks, 1)
+	require.NotNil(t, response.Tasks[0].SLAStatus)
+	assert.Equal(t, "breached", *response.Tasks[0].SLAStatus)
+	require.NotNil(t, response.Tasks[0].SLADueAt)
+	assert.True(t, response.Tasks[0].SLADueAt.Equal(*task.Deadline))
+	mockList.AssertExpectations(t)
+}
+
+func TestTaskHandler_List_InvalidSLAStatus(t *testing.T) {
+	// Setup
+	mockList := new(MockListTasksUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), mockList, new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?sla_status=late", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-sla", response.Type)
+	mockList.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_List_WithLabelSelector(t *testing.T) {
+	// Setup
+	mockList := new(MockListTasksUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), mockList, new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
+		return len(input.Labels) == 2 && input.Labels["env"] == "prod" && input.Labels["team"] == "finance"
+	})).Return(&taskUsecase.ListTasksOutput{
+		Tasks:      []*entity.Task{},
+		Total:      0,
+		Page:       1,
+		Limit:      20,
+		TotalPages: 0,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?labels=env%3Dprod,team%3Dfinance", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockList.AssertExpectations(t)
+}
+
+func TestTaskHandler_List_InvalidLabelSelector(t *testing.T) {
+	// Setup
+	mockList := new(MockListTasksUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), mockList, new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?labels=env", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-labels", response.Type)
+	mockList.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_List_WithErrorCode(t *testing.T) {
+	// Setup
+	mockList := new(MockListTasksUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), mockList, new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
+		return input.ErrorCode != nil && *input.ErrorCode == "HTTP_TIMEOUT"
+	})).Return(&taskUsecase.ListTasksOutput{
+		Tasks:      []*entity.Task{},
+		Total:      0,
+		Page:       1,
+		Limit:      20,
+		TotalPages: 0,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?error_code=HTTP_TIMEOUT", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockList.AssertExpectations(t)
+}
+
+func TestTaskHandler_List_WithAutomation(t *testing.T) {
+	// Setup
+	mockList := new(MockListTasksUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), mockList, new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
+		return input.Automation != nil && *input.Automation == "billing.nightly-export"
+	})).Return(&taskUsecase.ListTasksOutput{
+		Tasks:      []*entity.Task{},
+		Total:      0,
+		Page:       1,
+		Limit:      20,
+		TotalPages: 0,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?automation=billing.nightly-export", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockList.AssertExpectations(t)
+}
+
+func TestTaskHandler_List_WithIncludePayloads(t *testing.T) {
+	// Setup
+	mockList := new(MockListTasksUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), mockList, new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Configurar mock
+	mockList.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.ListTasksInput) bool {
+		return input.IncludePayloads
+	})).Return(&taskUsecase.ListTasksOutput{
+		Tasks:      []*entity.Task{},
+		Total:      0,
+		Page:       1,
+		Limit:      20,
+		TotalPages: 0,
+	}, nil)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?include_payloads=true", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockList.AssertExpectations(t)
+}
+
+func TestTaskHandler_List_InvalidIncludePayloads(t *testing.T) {
+	// Setup
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), new(MockListTasksUseCase), new(MockUpdateTaskUseCase), new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Request
+	req := httptest.NewRequest(http.MethodGet, "/AutomatizacionListado?include_payloads=maybe", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/invalid-payload", response.Type)
+}
+
+func TestTaskHandler_Update_Success(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+	mockGet := new(MockGetTaskUseCase)
+	mockList := new(MockListTasksUseCase)
+	mockUpdate := new(MockUpdateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Los mocks mockCreate, mockGet y mockList no se usan en este test
+	_ = mockCreate
+	_ = mockGet
+	_ = mockList
+
+	// Crear tarea de prueba
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	taskID := task.ID
+	task.UpdateState(entity.StateInProgress, "test-user")
+
+	// Configurar mock
+	mockUpdate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.UpdateTaskInput) bool {
+		return input.ID == taskID && input.State != nil && *input.State == entity.StateInProgress
+	})).Return(&taskUsecase.UpdateTaskOutput{Task: task}, nil)
+
+	// Request
+	reqBody := UpdateTaskRequest{
+		ID:        taskID.String(),
+		State:     stringPtr("IN_PROGRESS"),
+		UpdatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPut, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, entity.StateInProgress.String(), response.State)
+	mockUpdate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Update_WithFailure(t *testing.T) {
+	// Setup
+	mockUpdate := new(MockUpdateTaskUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), new(MockListTasksUseCase), mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Crear tarea fallida de prueba
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	failure := &entity.Failure{Code: "HTTP_TIMEOUT", Message: "upstream timed out", Details: map[string]interface{}{"url": "https://example.com"}}
+	require.NoError(t, task.UpdateStateWithFailure(entity.StateFailed, "test-user", failure))
+
+	// Configurar mock
+	mockUpdate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.UpdateTaskInput) bool {
+		return input.Failure != nil && input.Failure.Code == "HTTP_TIMEOUT" &&
+			input.Failure.Message == "upstream timed out" && input.Failure.Details != nil
+	})).Return(&taskUsecase.UpdateTaskOutput{Task: task}, nil)
+
+	// Request
+	body := `{"id": "` + task.ID.String() + `", "state": "FAILED", "updated_by": "test-user",
+		"failure": {"error_code": "HTTP_TIMEOUT", "error_message": "upstream timed out", "details": {"url": "https://example.com"}}}`
+	req := httptest.NewRequest(http.MethodPut, "/Automatizacion", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.NotNil(t, response.Failure)
+	assert.Equal(t, "HTTP_TIMEOUT", response.Failure.ErrorCode)
+	assert.Equal(t, "upstream timed out", response.Failure.ErrorMessage)
+	assert.Equal(t, map[string]interface{}{"url": "https://example.com"}, response.Failure.Details)
+	mockUpdate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Update_WithOutput(t *testing.T) {
+	// Setup
+	mockUpdate := new(MockUpdateTaskUseCase)
+
+	handler := NewTaskHandler(new(MockCreateTaskUseCase), new(MockGetTaskUseCase), new(MockListTasksUseCase), mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Crear tarea completada de prueba
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	task.UpdateState(entity.StateInProgress, "test-user")
+	task.UpdateState(entity.StateCompleted, "test-user")
+	require.NoError(t, task.SetOutput(entity.Payload(`{"rows":1200,"path":"s3://exports/march.csv"}`), entity.DefaultMaxPayloadBytes))
+
+	// Configurar mock
+	mockUpdate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.UpdateTaskInput) bool {
+		return input.State != nil && *input.State == entity.StateCompleted && len(input.Output) > 0
+	})).Return(&taskUsecase.UpdateTaskOutput{Task: task}, nil)
+
+	// Request
+	body := `{"id": "` + task.ID.String() + `", "state": "COMPLETED", "updated_by": "test-user",
+		"output": {"rows": 1200, "path": "s3://exports/march.csv"}}`
+	req := httptest.NewRequest(http.MethodPut, "/Automatizacion", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rows":1200,"path":"s3://exports/march.csv"}`, string(response.Output))
+	mockUpdate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Update_NotFound(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+	mockGet := new(MockGetTaskUseCase)
+	mockList := new(MockListTasksUseCase)
+	mockUpdate := new(MockUpdateTaskUseCase)
+
+	handler := NewTaskHandler(mockCreate, mockGet, mockList, mockUpdate, new(MockDeleteTaskUseCase), new(MockRestoreTaskUseCase), new(MockIdempotentCreateTaskUseCase))
+	router := setupTestRouter(handler)
+
+	// Los mocks mockCreate, mockGet y mockList no se usan en este test
+	_ = mockCreate
+	_ = mockGet
+	_ = mockList
+
+	// Configurar mock para retornar error
+	mockUpdate.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrTaskNotFound)
+
+	// Request
+	reqBody := UpdateTaskRequest{
+		ID:        uuid.New().String(),
+		State:     stringPtr("IN_PROGRESS"),
+		UpdatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPut, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/task-not-found", response.Type)
+	mockUpdate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Delete_Success(t *testing.T) {
+	// Setup
+	mockDelete := new(MockDeleteTaskUseCase)
+	handler := NewTaskHandler(
+		new(MockCreateTaskUseCase),
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		new(MockUpdateTaskUseCase),
+		mockDelete,
+		new(MockRestoreTaskUseCase),
+		new(MockIdempotentCreateTaskUseCase),
+	)
+	router := setupTestRouter(handler)
+
+	taskID := uuid.New()
+
+	// Configurar mock
+	mockDelete.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.DeleteTaskInput) bool {
+		return input.ID == taskID && input.DeletedBy == "test-user"
+	})).Return(&taskUsecase.DeleteTaskOutput{Success: true}, nil)
+
+	// Request
+	reqBody := DeleteTaskRequest{DeletedBy: "test-user"}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodDelete, "/Automatizacion/"+taskID.String(), bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockDelete.AssertExpectations(t)
+}
+
+func TestTaskHandler_Delete_MissingDeletedBy(t *testing.T) {
+	// Setup
+	mockDelete := new(MockDeleteTaskUseCase)
+	handler := NewTaskHandler(
+		new(MockCreateTaskUseCase),
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		new(MockUpdateTaskUseCase),
+		mockDelete,
+		new(MockRestoreTaskUseCase),
+		new(MockIdempotentCreateTaskUseCase),
+	)
+	router := setupTestRouter(handler)
+
+	// Request sin deleted_by
+	req := httptest.NewRequest(http.MethodDelete, "/Automatizacion/"+uuid.New().String(), bytes.NewBufferString("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/missing-required-fields", response.Type)
+	mockDelete.AssertNotCalled(t, "Execute")
+}
+
+func TestTaskHandler_Delete_NotFound(t *testing.T) {
+	// Setup
+	mockDelete := new(MockDeleteTaskUseCase)
+	handler := NewTaskHandler(
+		new(MockCreateTaskUseCase),
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		new(MockUpdateTaskUseCase),
+		mockDelete,
+		new(MockRestoreTaskUseCase),
+		new(MockIdempotentCreateTaskUseCase),
+	)
+	router := setupTestRouter(handler)
+
+	// Configurar mock para retornar error
+	mockDelete.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrTaskNotFound)
+
+	// Request
+	reqBody := DeleteTaskRequest{DeletedBy: "test-user"}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodDelete, "/Automatizacion/"+uuid.New().String(), bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/task-not-found", response.Type)
+	mockDelete.AssertExpectations(t)
+}
+
+func TestTaskHandler_Restore_Success(t *testing.T) {
+	// Setup
+	mockRestore := new(MockRestoreTaskUseCase)
+	handler := NewTaskHandler(
+		new(MockCreateTaskUseCase),
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		new(MockUpdateTaskUseCase),
+		new(MockDeleteTaskUseCase),
+		mockRestore,
+		new(MockIdempotentCreateTaskUseCase),
+	)
+	router := setupTestRouter(handler)
+
+	// Crear tarea de prueba
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
+	task.AddSubtask(subtask)
+	taskID := task.ID
+
+	// Configurar mock
+	mockRestore.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.RestoreTaskInput) bool {
+		return input.ID == taskID && input.RestoredBy == "test-user"
+	})).Return(&taskUsecase.RestoreTaskOutput{Task: task}, nil)
+
+	// Request
+	reqBody := RestoreTaskRequest{RestoredBy: "test-user"}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+taskID.String()+"/restore", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, taskID.String(), response.ID)
+	assert.Nil(t, response.DeletedAt)
+	assert.Len(t, response.Subtasks, 1)
+	mockRestore.AssertExpectations(t)
+}
+
+func TestTaskHandler_Restore_RetentionExpired(t *testing.T) {
+	// Setup
+	mockRestore := new(MockRestoreTaskUseCase)
+	handler := NewTaskHandler(
+		new(MockCreateTaskUseCase),
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		new(MockUpdateTaskUseCase),
+		new(MockDeleteTaskUseCase),
+		mockRestore,
+		new(MockIdempotentCreateTaskUseCase),
+	)
+	router := setupTestRouter(handler)
+
+	// Fuera de la ventana de retención el repositorio retorna ErrTaskNotFound
+	mockRestore.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrTaskNotFound)
+
+	// Request
+	reqBody := RestoreTaskRequest{RestoredBy: "test-user"}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+uuid.New().String()+"/restore", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/task-not-found", response.Type)
+	mockRestore.AssertExpectations(t)
+}
+
+func TestTaskHandler_Update_WithIfMatch(t *testing.T) {
+	// Setup
+	mockUpdate := new(MockUpdateTaskUseCase)
+	handler := NewTaskHandler(
+		new(MockCreateTaskUseCase),
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		mockUpdate,
+		new(MockDeleteTaskUseCase),
+		new(MockRestoreTaskUseCase),
+		new(MockIdempotentCreateTaskUseCase),
+	)
+	router := setupTestRouter(handler)
+
+	// Crear tarea de prueba ya actualizada a la versión 4
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	task.Version = 4
+
+	// Configurar mock
+	mockUpdate.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.UpdateTaskInput) bool {
+		return input.ExpectedVersion != nil && *input.ExpectedVersion == 3
+	})).Return(&taskUsecase.UpdateTaskOutput{Task: task}, nil)
+
+	// Request
+	reqBody := UpdateTaskRequest{
+		ID:        task.ID.String(),
+		Name:      stringPtr("Renamed Task"),
+		UpdatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPut, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	mockUpdate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Update_VersionMismatch(t *testing.T) {
+	// Setup
+	mockUpdate := new(MockUpdateTaskUseCase)
+	handler := NewTaskHandler(
+		new(MockCreateTaskUseCase),
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		mockUpdate,
+		new(MockDeleteTaskUseCase),
+		new(MockRestoreTaskUseCase),
+		new(MockIdempotentCreateTaskUseCase),
+	)
+	router := setupTestRouter(handler)
+
+	// Configurar mock para retornar conflicto de versión
+	mockUpdate.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrVersionConflict)
+
+	// Request
+	reqBody := UpdateTaskRequest{
+		ID:        uuid.New().String(),
+		State:     stringPtr("IN_PROGRESS"),
+		UpdatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPut, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/version-conflict", response.Type)
+	mockUpdate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Update_MalformedIfMatch(t *testing.T) {
+	// Setup
+	mockUpdate := new(MockUpdateTaskUseCase)
+	handler := NewTaskHandler(
+		new(MockCreateTaskUseCase),
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		mockUpdate,
+		new(MockDeleteTaskUseCase),
+		new(MockRestoreTaskUseCase),
+		new(MockIdempotentCreateTaskUseCase),
+	)
+	router := setupTestRouter(handler)
+
+	// Request
+	reqBody := UpdateTaskRequest{
+		ID:        uuid.New().String(),
+		State:     stringPtr("IN_PROGRESS"),
+		UpdatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPut, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"not-a-version"`)
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	mockUpdate.AssertNotCalled(t, "Execute")
+}
+
+func TestTaskHandler_Create_WithIdempotencyKey(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+	mockIdempotent := new(MockIdempotentCreateTaskUseCase)
+	handler := NewTaskHandler(
+		mockCreate,
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		new(MockUpdateTaskUseCase),
+		new(MockDeleteTaskUseCase),
+		new(MockRestoreTaskUseCase),
+		mockIdempotent,
+	)
+	router := setupTestRouter(handler)
+
+	// Crear tarea de prueba
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+
+	// Configurar mocks: la creación se ejecuta a través del caso de uso idempotente
+	mockIdempotent.On("Execute", mock.Anything, mock.MatchedBy(func(input taskUsecase.IdempotentCreateTaskInput) bool {
+		return input.Key == "retry-123" && input.CreatedBy == "test-user" && len(input.RequestHash) == 64
+	})).Return(nil, nil)
+	mockCreate.On("Execute", mock.Anything, mock.Anything).Return(&taskUsecase.CreateTaskOutput{Task: task}, nil)
+
+	// Request
+	reqBody := CreateTaskRequest{
+		Name:      "Test Task",
+		CreatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "retry-123")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+	var response TaskResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID.String(), response.ID)
+	mockIdempotent.AssertExpectations(t)
+	mockCreate.AssertExpectations(t)
+}
+
+func TestTaskHandler_Create_IdempotentReplay(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+	mockIdempotent := new(MockIdempotentCreateTaskUseCase)
+	handler := NewTaskHandler(
+		mockCreate,
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		new(MockUpdateTaskUseCase),
+		new(MockDeleteTaskUseCase),
+		new(MockRestoreTaskUseCase),
+		mockIdempotent,
+	)
+	router := setupTestRouter(handler)
+
+	// Respuesta original guardada junto a la clave
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
+	require.NoError(t, err)
+	originalBody, err := json.Marshal(ToTaskResponse(task))
+	require.NoError(t, err)
+
+	mockIdempotent.On("Execute", mock.Anything, mock.Anything).Return(&taskUsecase.IdempotentCreateTaskOutput{
+		Response: &taskUsecase.IdempotentResponse{
+			TaskID:     task.ID,
+			StatusCode: http.StatusCreated,
+			Body:       originalBody,
+		},
+		Replayed: true,
+	}, nil)
+
+	// Request
+	reqBody := CreateTaskRequest{
+		Name:      "Test Task",
+		CreatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "retry-123")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert: se devuelve la respuesta original sin volver a crear la tarea
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+	assert.JSONEq(t, string(originalBody), w.Body.String())
+	mockCreate.AssertNotCalled(t, "Execute")
+	mockIdempotent.AssertExpectations(t)
+}
+
+func TestTaskHandler_Create_IdempotencyKeyReused(t *testing.T) {
+	// Setup
+	mockCreate := new(MockCreateTaskUseCase)
+	mockIdempotent := new(MockIdempotentCreateTaskUseCase)
+	handler := NewTaskHandler(
+		mockCreate,
+		new(MockGetTaskUseCase),
+		new(MockListTasksUseCase),
+		new(MockUpdateTaskUseCase),
+		new(MockDeleteTaskUseCase),
+		new(MockRestoreTaskUseCase),
+		mockIdempotent,
+	)
+	router := setupTestRouter(handler)
+
+	mockIdempotent.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrIdempotencyKeyReused)
+
+	// Request con la misma clave pero otro cuerpo
+	reqBody := CreateTaskRequest{
+		Name:      "Another Task",
+		CreatedBy: "test-user",
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "retry-123")
+	w := httptest.NewRecorder()
+
+	// Execute
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var response ProblemDetails
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.grupoapi.com/problems/idempotency-key-reused", response.Type)
+	mockCreate.AssertNotCalled(t, "Execute")
 }
 
 // Helper function
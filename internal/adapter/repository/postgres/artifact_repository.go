@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// artifactColumns son las columnas de task_artifacts en el orden que espera scanArtifact
+const artifactColumns = `id, task_id, name, content_type, size_bytes, sha256, storage_key, uploaded_by, created_at`
+
+// ArtifactRepository implementa el repositorio de artefactos usando PostgreSQL
+type ArtifactRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewArtifactRepository crea una nueva instancia del repositorio de artefactos
+func NewArtifactRepository(pool *pgxpool.Pool) repository.ArtifactRepository {
+	return &ArtifactRepository{pool: pool}
+}
+
+// Create guarda los metadatos de un artefacto
+func (r *ArtifactRepository) Create(ctx context.Context, artifact *entity.Artifact) error {
+	query := `
+		INSERT INTO task_artifacts (` + artifactColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
+		artifact.ID,
+		artifact.TaskID,
+		artifact.Name,
+		artifact.ContentType,
+		artifact.Size,
+		artifact.SHA256,
+		artifact.StorageKey,
+		artifact.UploadedBy,
+		artifact.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create artifact: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID busca un artefacto de la tarea
+func (r *ArtifactRepository) FindByID(ctx context.Context, taskID, id uuid.UUID) (*entity.Artifact, error) {
+	query := `SELECT ` + artifactColumns + ` FROM task_artifacts WHERE id = $1 AND task_id = $2`
+
+	artifact, err := scanArtifact(conn(ctx, r.pool).QueryRow(ctx, query, id, taskID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrArtifactNotFound
+		}
+		return nil, fmt.Errorf("failed to find artifact by ID: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// FindByTaskID retorna los artefactos de una tarea por orden de subida
+func (r *ArtifactRepository) FindByTaskID(ctx context.Context, taskID uuid.UUID) ([]*entity.Artifact, error) {
+	query := `SELECT ` + artifactColumns + ` FROM task_artifacts WHERE task_id = $1 ORDER BY created_at ASC, id ASC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query artifacts: %w", err)
+	}
+	defer rows.Close()
+
+	artifacts := make([]*entity.Artifact, 0)
+	for rows.Next() {
+		artifact, err := scanArtifact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan artifact: %w", err)
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating artifacts: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// FindOrphanedBlobKeys retorna hasta limit claves de contenido de artefactos purgados, las más antiguas primero
+func (r *ArtifactRepository) FindOrphanedBlobKeys(ctx context.Context, limit int) ([]string, error) {
+	query := `SELECT storage_key FROM orphaned_blobs ORDER BY orphaned_at ASC, storage_key ASC LIMIT $1`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orphaned blobs: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan orphaned blob: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orphaned blobs: %w", err)
+	}
+
+	return keys, nil
+}
+
+// DeleteOrphanedBlobKeys retira las claves cuyo contenido ya se borró del almacén de blobs
+func (r *ArtifactRepository) DeleteOrphanedBlobKeys(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM orphaned_blobs WHERE storage_key = ANY($1)`, keys)
+	if err != nil {
+		return fmt.Errorf("failed to delete orphaned blobs: %w", err)
+	}
+
+	return nil
+}
+
+// scanArtifact lee una fila con las columnas de artifactColumns
+func scanArtifact(row pgx.Row) (*entity.Artifact, error) {
+	var artifact entity.Artifact
+	err := row.Scan(
+		&artifact.ID,
+		&artifact.TaskID,
+		&artifact.Name,
+		&artifact.ContentType,
+		&artifact.Size,
+		&artifact.SHA256,
+		&artifact.StorageKey,
+		&artifact.UploadedBy,
+		&artifact.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &artifact, nil
+}
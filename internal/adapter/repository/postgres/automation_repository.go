@@ -0,0 +1,545 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// automationColumns son las columnas de automations en el orden que espera scanAutomation
+const automationColumns = `id, key, owner_team, description, subtask_template, schedule, schedule_timezone,
+		grace_period_seconds, schedule_checked_until, created_by, updated_by, created_at, updated_at, deleted_at, version`
+
+// AutomationRepository implementa el repositorio de automatismos usando PostgreSQL
+type AutomationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAutomationRepository crea una nueva instancia del repositorio de automatismos
+func NewAutomationRepository(pool *pgxpool.Pool) repository.AutomationRepository {
+	return &AutomationRepository{pool: pool}
+}
+
+// Create guarda un nuevo automatismo
+// La clave es única también entre los automatismos eliminados, que nunca se purgan
+func (r *AutomationRepository) Create(ctx context.Context, automation *entity.Automation) error {
+	template, err := encodeSubtaskTemplate(automation.SubtaskTemplate)
+	if err != nil {
+		return err
+	}
+	schedule, timezone := scheduleColumns(automation.Schedule)
+
+	query := `
+		INSERT INTO automations (` + automationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (key) DO NOTHING
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		automation.ID,
+		automation.Key,
+		automation.OwnerTeam,
+		automation.Description,
+		template,
+		schedule,
+		timezone,
+		int(automation.GracePeriod.Seconds()),
+		scheduleCheckedUntil(automation),
+		automation.CreatedBy,
+		automation.UpdatedBy,
+		automation.CreatedAt,
+		automation.UpdatedAt,
+		automation.DeletedAt,
+		automation.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create automation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", entity.ErrAutomationExists, automation.Key)
+	}
+
+	return nil
+}
+
+// Update guarda los cambios de un automatismo con control de concurrencia optimista
+// La clave no se modifica: las ejecuciones la referencian
+// Las activaciones revisadas solo se sobrescriben si cambia la planificación, para no deshacer el avance
+// que el detector registra sin cambiar la versión
+func (r *AutomationRepository) Update(ctx context.Context, automation *entity.Automation) error {
+	template, err := encodeSubtaskTemplate(automation.SubtaskTemplate)
+	if err != nil {
+		return err
+	}
+	schedule, timezone := scheduleColumns(automation.Schedule)
+
+	query := `
+		UPDATE automations
+		SET owner_team = $2, description = $3, subtask_template = $4, schedule = $5, schedule_timezone = $6,
+		    grace_period_seconds = $7,
+		    schedule_checked_until = CASE
+		        WHEN schedule IS DISTINCT FROM $5 OR schedule_timezone IS DISTINCT FROM $6 THEN $8
+		        ELSE schedule_checked_until
+		    END,
+		    updated_by = $9, updated_at = $10, version = version + 1
+		WHERE key = $1 AND deleted_at IS NULL AND version = $11
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		automation.Key,
+		automation.OwnerTeam,
+		automation.Description,
+		template,
+		schedule,
+		timezone,
+		int(automation.GracePeriod.Seconds()),
+		scheduleCheckedUntil(automation),
+		automation.UpdatedBy,
+		automation.UpdatedAt,
+		automation.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update automation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return r.resolveMissingAutomation(ctx, automation.Key)
+	}
+
+	automation.Version++
+	return nil
+}
+
+// resolveMissingAutomation determina por qué un UPDATE con control de versión no afectó filas:
+// el automatismo no existe (o está eliminado) o su versión cambió
+func (r *AutomationRepository) resolveMissingAutomation(ctx context.Context, key string) error {
+	var exists bool
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM automations WHERE key = $1 AND deleted_at IS NULL)", key).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check automation existence: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: automation %s was modified concurrently", entity.ErrVersionConflict, key)
+	}
+	return entity.ErrAutomationNotFound
+}
+
+// FindByKey busca un automatismo no eliminado por su clave
+func (r *AutomationRepository) FindByKey(ctx context.Context, key string) (*entity.Automation, error) {
+	query := `SELECT ` + automationColumns + ` FROM automations WHERE key = $1 AND deleted_at IS NULL`
+
+	automation, err := scanAutomation(conn(ctx, r.pool).QueryRow(ctx, query, key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrAutomationNotFound
+		}
+		return nil, fmt.Errorf("failed to find automation by key: %w", err)
+	}
+
+	return automation, nil
+}
+
+// FindAll retorna una lista paginada de automatismos no eliminados, ordenada por clave
+func (r *AutomationRepository) FindAll(ctx context.Context, filters repository.AutomationFilters) (*repository.AutomationListResult, error) {
+	where := ` WHERE deleted_at IS NULL`
+	args := []interface{}{}
+	if filters.OwnerTeam != nil {
+		args = append(args, *filters.OwnerTeam)
+		where += fmt.Sprintf(" AND owner_team = $%d", len(args))
+	}
+
+	var total int64
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM automations`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count automations: %w", err)
+	}
+
+	query := `SELECT ` + automationColumns + ` FROM automations` + where +
+		fmt.Sprintf(" ORDER BY key ASC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, append(args, filters.Limit, filters.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query automations: %w", err)
+	}
+	defer rows.Close()
+
+	automations := make([]*entity.Automation, 0)
+	for rows.Next() {
+		automation, err := scanAutomation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan automation: %w", err)
+		}
+		automations = append(automations, automation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating automations: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := int(total) / filters.Limit
+	if int(total)%filters.Limit > 0 {
+		totalPages++
+	}
+
+	return &repository.AutomationListResult{
+		Automations: automations,
+		Total:       int(total),
+		Page:        filters.Page,
+		Limit:       filters.Limit,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+// SummarizeRuns resume las ejecuciones no eliminadas y las perdidas de cada automatismo de keys
+// Cuenta las ejecuciones por estado y busca la última en dos consultas que usan idx_tasks_automation,
+// y cuenta las perdidas en una tercera
+func (r *AutomationRepository) SummarizeRuns(ctx context.Context, keys []string) (map[string]*entity.AutomationRunSummary, error) {
+	summaries := make(map[string]*entity.AutomationRunSummary, len(keys))
+	for _, key := range keys {
+		summaries[key] = entity.NewAutomationRunSummary()
+	}
+	if len(keys) == 0 {
+		return summaries, nil
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT automation_key, state, COUNT(*)
+		FROM tasks
+		WHERE automation_key = ANY($1) AND deleted_at IS NULL
+		GROUP BY automation_key, state
+	`, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count automation runs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, state string
+		var count int
+		if err := rows.Scan(&key, &state, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan automation run count: %w", err)
+		}
+		summaries[key].Total += count
+		summaries[key].ByState[entity.State(state)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating automation run counts: %w", err)
+	}
+
+	rows, err = conn(ctx, r.pool).Query(ctx, `
+		SELECT DISTINCT ON (automation_key) automation_key, id, state, created_at
+		FROM tasks
+		WHERE automation_key = ANY($1) AND deleted_at IS NULL
+		ORDER BY automation_key, created_at DESC, id DESC
+	`, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find last automation runs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, state string
+		var run entity.AutomationRun
+		if err := rows.Scan(&key, &run.TaskID, &state, &run.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan last automation run: %w", err)
+		}
+		run.State = entity.State(state)
+		summaries[key].LastRun = &run
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating last automation runs: %w", err)
+	}
+
+	rows, err = conn(ctx, r.pool).Query(ctx, `
+		SELECT automation_key, COUNT(*), MAX(expected_at)
+		FROM automation_missed_runs
+		WHERE automation_key = ANY($1)
+		GROUP BY automation_key
+	`, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count missed automation runs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var count int
+		var lastMissedAt time.Time
+		if err := rows.Scan(&key, &count, &lastMissedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan missed automation run count: %w", err)
+		}
+		summaries[key].Missed = count
+		summaries[key].LastMissedAt = &lastMissedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating missed automation run counts: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// FindScheduled retorna los automatismos no eliminados que tienen planificación, ordenados por clave
+func (r *AutomationRepository) FindScheduled(ctx context.Context) ([]*entity.Automation, error) {
+	query := `SELECT ` + automationColumns + ` FROM automations
+		WHERE deleted_at IS NULL AND schedule IS NOT NULL
+		ORDER BY key ASC`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled automations: %w", err)
+	}
+	defer rows.Close()
+
+	automations := make([]*entity.Automation, 0)
+	for rows.Next() {
+		automation, err := scanAutomation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan automation: %w", err)
+		}
+		automations = append(automations, automation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating scheduled automations: %w", err)
+	}
+
+	return automations, nil
+}
+
+// HasRunBetween verifica si el automatismo tiene alguna ejecución no eliminada creada en [from, to]
+func (r *AutomationRepository) HasRunBetween(ctx context.Context, key string, from, to time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM tasks
+			WHERE automation_key = $1 AND deleted_at IS NULL AND created_at BETWEEN $2 AND $3
+		)
+	`
+
+	var exists bool
+	if err := conn(ctx, r.pool).QueryRow(ctx, query, key, from, to).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check automation runs: %w", err)
+	}
+	return exists, nil
+}
+
+// RecordMissedRuns avanza las activaciones revisadas y guarda las ejecuciones perdidas
+// El avance se condiciona a que schedule_checked_until siga siendo el leído: si otra réplica lo avanzó o se
+// cambió la planificación, no se registra nada
+func (r *AutomationRepository) RecordMissedRuns(ctx context.Context, automation *entity.Automation, checkedUntil time.Time, missed []*entity.MissedRun) error {
+	query := `
+		UPDATE automations
+		SET schedule_checked_until = $2
+		WHERE key = $1 AND deleted_at IS NULL AND schedule_checked_until = $3
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, automation.Key, checkedUntil, automation.ScheduleCheckedUntil)
+	if err != nil {
+		return fmt.Errorf("failed to advance automation schedule check: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: schedule check of automation %s was modified concurrently",
+			entity.ErrVersionConflict, automation.Key)
+	}
+
+	for _, run := range missed {
+		_, err := conn(ctx, r.pool).Exec(ctx, `
+			INSERT INTO automation_missed_runs (id, automation_key, expected_at, deadline, detected_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (automation_key, expected_at) DO NOTHING
+		`, run.ID, run.AutomationKey, run.ExpectedAt, run.Deadline, run.DetectedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create missed run: %w", err)
+		}
+	}
+
+	automation.ScheduleCheckedUntil = checkedUntil
+	return nil
+}
+
+// FindMissedRuns retorna una lista paginada de las ejecuciones perdidas de un automatismo
+func (r *AutomationRepository) FindMissedRuns(ctx context.Context, key string, filters repository.MissedRunFilters) (*repository.MissedRunListResult, error) {
+	var total int64
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		`SELECT COUNT(*) FROM automation_missed_runs WHERE automation_key = $1`, key).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count missed runs: %w", err)
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT id, automation_key, expected_at, deadline, detected_at
+		FROM automation_missed_runs
+		WHERE automation_key = $1
+		ORDER BY expected_at DESC
+		LIMIT $2 OFFSET $3
+	`, key, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query missed runs: %w", err)
+	}
+	defer rows.Close()
+
+	missedRuns := make([]*entity.MissedRun, 0)
+	for rows.Next() {
+		var run entity.MissedRun
+		if err := rows.Scan(&run.ID, &run.AutomationKey, &run.ExpectedAt, &run.Deadline, &run.DetectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan missed run: %w", err)
+		}
+		missedRuns = append(missedRuns, &run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating missed runs: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := int(total) / filters.Limit
+	if int(total)%filters.Limit > 0 {
+		totalPages++
+	}
+
+	return &repository.MissedRunListResult{
+		MissedRuns: missedRuns,
+		Total:      int(total),
+		Page:       filters.Page,
+		Limit:      filters.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Delete marca un automatismo como eliminado (soft delete) en deletedAt
+func (r *AutomationRepository) Delete(ctx context.Context, key string, deletedBy string, deletedAt time.Time) error {
+	query := `
+		UPDATE automations
+		SET deleted_at = $3, updated_at = $3, updated_by = $2, version = version + 1
+		WHERE key = $1 AND deleted_at IS NULL
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, key, deletedBy, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete automation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrAutomationNotFound
+	}
+
+	return nil
+}
+
+// subtaskTemplateJSON es la representación de una subtarea de la plantilla en la columna subtask_template
+type subtaskTemplateJSON struct {
+	Name      string                `json:"name"`
+	DependsOn []string              `json:"depends_on,omitempty"`
+	Subtasks  []subtaskTemplateJSON `json:"subtasks,omitempty"`
+}
+
+// encodeSubtaskTemplate convierte la plantilla en el documento de la columna subtask_template
+func encodeSubtaskTemplate(template []entity.SubtaskTemplate) ([]byte, error) {
+	data, err := json.Marshal(toSubtaskTemplateJSON(template))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode subtask template: %w", err)
+	}
+	return data, nil
+}
+
+func toSubtaskTemplateJSON(template []entity.SubtaskTemplate) []subtaskTemplateJSON {
+	items := make([]subtaskTemplateJSON, 0, len(template))
+	for _, item := range template {
+		items = append(items, subtaskTemplateJSON{
+			Name:      item.Name,
+			DependsOn: item.DependsOn,
+			Subtasks:  toSubtaskTemplateJSON(item.Subtasks),
+		})
+	}
+	return items
+}
+
+func fromSubtaskTemplateJSON(items []subtaskTemplateJSON) []entity.SubtaskTemplate {
+	template := make([]entity.SubtaskTemplate, 0, len(items))
+	for _, item := range items {
+		template = append(template, entity.SubtaskTemplate{
+			Name:      item.Name,
+			DependsOn: item.DependsOn,
+			Subtasks:  fromSubtaskTemplateJSON(item.Subtasks),
+		})
+	}
+	return template
+}
+
+// scheduleColumns retorna los valores de las columnas schedule y schedule_timezone; NULL sin planificación
+func scheduleColumns(schedule *entity.Schedule) (*string, *string) {
+	if schedule == nil {
+		return nil, nil
+	}
+	return &schedule.Expression, &schedule.Timezone
+}
+
+// scheduleCheckedUntil retorna el valor de la columna schedule_checked_until; NULL sin planificación
+func scheduleCheckedUntil(automation *entity.Automation) *time.Time {
+	if automation.Schedule == nil || automation.ScheduleCheckedUntil.IsZero() {
+		return nil
+	}
+	return &automation.ScheduleCheckedUntil
+}
+
+// scanAutomation lee una fila con las columnas de automationColumns
+func scanAutomation(row pgx.Row) (*entity.Automation, error) {
+	var automation entity.Automation
+	var template []byte
+	var schedule, timezone *string
+	var gracePeriodSeconds int
+	var checkedUntil *time.Time
+	var updatedBy *string
+
+	err := row.Scan(
+		&automation.ID,
+		&automation.Key,
+		&automation.OwnerTeam,
+		&automation.Description,
+		&template,
+		&schedule,
+		&timezone,
+		&gracePeriodSeconds,
+		&checkedUntil,
+		&automation.CreatedBy,
+		&updatedBy,
+		&automation.CreatedAt,
+		&automation.UpdatedAt,
+		&automation.DeletedAt,
+		&automation.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []subtaskTemplateJSON
+	if err := json.Unmarshal(template, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode subtask template: %w", err)
+	}
+	automation.SubtaskTemplate = fromSubtaskTemplateJSON(items)
+
+	if schedule != nil {
+		// Se validó al guardarse; solo falla si la zona horaria desapareció de la base de datos de zonas
+		if automation.Schedule, err = entity.ParseSchedule(*schedule, *timezone); err != nil {
+			return nil, fmt.Errorf("failed to parse schedule of automation %s: %w", automation.Key, err)
+		}
+	}
+	automation.GracePeriod = time.Duration(gracePeriodSeconds) * time.Second
+	if checkedUntil != nil {
+		automation.ScheduleCheckedUntil = *checkedUntil
+	}
+	if updatedBy != nil {
+		automation.UpdatedBy = *updatedBy
+	}
+
+	return &automation, nil
+}
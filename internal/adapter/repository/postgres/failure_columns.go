@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// failureColumns son los valores de las columnas error_code, error_message y error_details de tasks y subtasks
+type failureColumns struct {
+	code    *string
+	message *string
+	details []byte
+}
+
+// newFailureColumns convierte el motivo del fallo de una tarea o subtarea en los valores de sus columnas
+func newFailureColumns(failure *entity.Failure) failureColumns {
+	if failure == nil {
+		return failureColumns{}
+	}
+
+	columns := failureColumns{code: &failure.Code, message: &failure.Message}
+	if failure.Details != nil {
+		// Los detalles ya se validaron como JSON serializable en entity.Failure.Validate
+		columns.details, _ = json.Marshal(failure.Details)
+	}
+	return columns
+}
+
+// failure reconstruye el motivo del fallo leído de las columnas; nil si no se informó
+func (c failureColumns) failure() (*entity.Failure, error) {
+	if c.code == nil {
+		return nil, nil
+	}
+
+	failure := &entity.Failure{Code: *c.code}
+	if c.message != nil {
+		failure.Message = *c.message
+	}
+	if c.details != nil {
+		if err := json.Unmarshal(c.details, &failure.Details); err != nil {
+			return nil, fmt.Errorf("failed to decode failure details: %w", err)
+		}
+	}
+	return failure, nil
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// IdempotencyRepository implementa el repositorio de claves de idempotencia usando PostgreSQL
+type IdempotencyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewIdempotencyRepository crea una nueva instancia del repositorio de claves de idempotencia
+func NewIdempotencyRepository(pool *pgxpool.Pool) repository.IdempotencyRepository {
+	return &IdempotencyRepository{pool: pool}
+}
+
+// Lock toma un advisory lock de transacción sobre la clave (created_by, key)
+// El lock se libera automáticamente con el commit o rollback de la transacción
+func (r *IdempotencyRepository) Lock(ctx context.Context, createdBy, key string) error {
+	_, err := conn(ctx, r.pool).Exec(ctx,
+		"SELECT pg_advisory_xact_lock(hashtextextended($1 || chr(0) || $2, 0))",
+		createdBy, key,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lock idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// Find busca una clave de idempotencia vigente en now
+func (r *IdempotencyRepository) Find(ctx context.Context, createdBy, key string, now time.Time) (*entity.IdempotencyRecord, error) {
+	query := `
+		SELECT key, created_by, request_hash, task_id, status_code, response_body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE created_by = $1 AND key = $2 AND expires_at > $3
+	`
+
+	var record entity.IdempotencyRecord
+	err := conn(ctx, r.pool).QueryRow(ctx, query, createdBy, key, now).Scan(
+		&record.Key,
+		&record.CreatedBy,
+		&record.RequestHash,
+		&record.TaskID,
+		&record.StatusCode,
+		&record.ResponseBody,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+
+	return &record, nil
+}
+
+// Save guarda una clave de idempotencia
+// Si ya existe una clave expirada en record.CreatedAt con el mismo (created_by, key), se reemplaza
+func (r *IdempotencyRepository) Save(ctx context.Context, record *entity.IdempotencyRecord) error {
+	query := `
+		INSERT INTO idempotency_keys (key, created_by, request_hash, task_id, status_code, response_body, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (created_by, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+		    task_id = EXCLUDED.task_id,
+		    status_code = EXCLUDED.status_code,
+		    response_body = EXCLUDED.response_body,
+		    created_at = EXCLUDED.created_at,
+		    expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
+		record.Key,
+		record.CreatedBy,
+		record.RequestHash,
+		record.TaskID,
+		record.StatusCode,
+		record.ResponseBody,
+		record.CreatedAt,
+		record.ExpiresAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+
+	// Solo ocurre si la clave sigue vigente, lo que Lock + Find ya deberían haber evitado
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: key %q is still active", entity.ErrIdempotencyKeyReused, record.Key)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// AdvisoryLockManager implementa los bloqueos con nombre usando advisory locks de PostgreSQL
+type AdvisoryLockManager struct {
+	pool *pgxpool.Pool
+}
+
+// NewAdvisoryLockManager crea una nueva instancia del gestor de bloqueos
+func NewAdvisoryLockManager(pool *pgxpool.Pool) repository.LockManager {
+	return &AdvisoryLockManager{pool: pool}
+}
+
+// TryLock toma un advisory lock de transacción sobre name sin esperar
+// El lock se libera automáticamente con el commit o rollback de la transacción
+func (m *AdvisoryLockManager) TryLock(ctx context.Context, name string) (bool, error) {
+	var acquired bool
+	err := conn(ctx, m.pool).QueryRow(ctx,
+		"SELECT pg_try_advisory_xact_lock(hashtextextended($1, 0))",
+		name,
+	).Scan(&acquired)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+
+	return acquired, nil
+}
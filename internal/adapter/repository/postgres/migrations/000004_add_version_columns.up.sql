@@ -0,0 +1,6 @@
+-- Optimistic concurrency control: cada escritura incrementa la versión de la fila
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1 CHECK (version > 0);
+ALTER TABLE subtasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1 CHECK (version > 0);
+
+COMMENT ON COLUMN tasks.version IS 'Row version for optimistic locking (exposed as ETag, incremented on every write)';
+COMMENT ON COLUMN subtasks.version IS 'Row version for optimistic locking (exposed as ETag, incremented on every write)';
@@ -0,0 +1,2 @@
+DROP FUNCTION IF EXISTS cleanup_expired_idempotency_keys();
+DROP TABLE IF EXISTS idempotency_keys;
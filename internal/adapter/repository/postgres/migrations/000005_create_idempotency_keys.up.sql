@@ -0,0 +1,41 @@
+-- Claves de idempotencia para POST /Automatizacion
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(255) NOT NULL CHECK (char_length(key) > 0),
+    created_by VARCHAR(256) NOT NULL CHECK (char_length(created_by) > 0),
+    request_hash CHAR(64) NOT NULL,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+
+    -- Respuesta original, devuelta tal cual en los reintentos
+    status_code INTEGER NOT NULL,
+    response_body BYTEA NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (created_by, key),
+    CONSTRAINT valid_expiry CHECK (expires_at > created_at)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+CREATE INDEX idx_idempotency_keys_task_id ON idempotency_keys(task_id);
+
+CREATE OR REPLACE FUNCTION cleanup_expired_idempotency_keys()
+RETURNS void AS $$
+DECLARE
+    deleted_keys_count INTEGER;
+BEGIN
+    DELETE FROM idempotency_keys
+    WHERE expires_at < NOW();
+    GET DIAGNOSTICS deleted_keys_count = ROW_COUNT;
+
+    RAISE NOTICE 'Cleanup completed: % idempotency keys deleted', deleted_keys_count;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON TABLE idempotency_keys IS 'Idempotency keys for task creation, unique per created_by';
+COMMENT ON COLUMN idempotency_keys.request_hash IS 'SHA-256 of the original request body, used to detect key reuse with a different body';
+COMMENT ON COLUMN idempotency_keys.task_id IS 'Task created by the original request';
+COMMENT ON COLUMN idempotency_keys.expires_at IS 'After this date the key can be reused';
+COMMENT ON FUNCTION cleanup_expired_idempotency_keys() IS
+    'Permanently deletes expired idempotency keys';
@@ -0,0 +1,27 @@
+-- Historial de cambios de tareas y subtareas
+CREATE TABLE IF NOT EXISTS task_events (
+    id BIGSERIAL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    -- Sin foreign key: el historial se conserva aunque la subtarea se purgue
+    subtask_id UUID,
+    event_type VARCHAR(50) NOT NULL CHECK (char_length(event_type) > 0),
+
+    -- Audit fields
+    actor VARCHAR(256) NOT NULL CHECK (char_length(actor) > 0),
+    old_value TEXT,
+    new_value TEXT,
+
+    -- Timestamps
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Index para el timeline paginado de una tarea
+CREATE INDEX idx_task_events_task_timeline ON task_events(task_id, occurred_at, id);
+CREATE INDEX idx_task_events_subtask_id ON task_events(subtask_id) WHERE subtask_id IS NOT NULL;
+
+COMMENT ON TABLE task_events IS 'State-change history of tasks and their subtasks';
+COMMENT ON COLUMN task_events.subtask_id IS 'Subtask affected by the event, NULL for task-level events';
+COMMENT ON COLUMN task_events.event_type IS 'TASK_CREATED, TASK_RENAMED, TASK_STATE_CHANGED, TASK_DELETED, TASK_RESTORED, SUBTASK_ADDED, SUBTASK_RENAMED, SUBTASK_STATE_CHANGED, SUBTASK_REMOVED';
+COMMENT ON COLUMN task_events.actor IS 'Team or person who made the change (updated_by)';
+COMMENT ON COLUMN task_events.old_value IS 'Previous name or state, NULL when not applicable';
+COMMENT ON COLUMN task_events.new_value IS 'New name or state, NULL when not applicable';
@@ -0,0 +1,11 @@
+ALTER TABLE subtasks DROP CONSTRAINT IF EXISTS valid_subtask_dates;
+ALTER TABLE subtasks ADD CONSTRAINT valid_subtask_dates CHECK (
+    (start_date IS NULL OR start_date >= created_at) AND
+    (end_date IS NULL OR (start_date IS NOT NULL AND end_date >= start_date))
+);
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS valid_dates;
+ALTER TABLE tasks ADD CONSTRAINT valid_dates CHECK (
+    (start_date IS NULL OR start_date >= created_at) AND
+    (end_date IS NULL OR (start_date IS NOT NULL AND end_date >= start_date))
+);
@@ -0,0 +1,14 @@
+-- La máquina de estados es configurable: una tarea puede llegar a un estado final sin haber
+-- pasado por IN_PROGRESS (p. ej. PENDING -> FAILED o PENDING -> CANCELLED), por lo que
+-- end_date puede existir sin start_date. Si ambas existen, end_date sigue sin poder ser anterior.
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS valid_dates;
+ALTER TABLE tasks ADD CONSTRAINT valid_dates CHECK (
+    (start_date IS NULL OR start_date >= created_at) AND
+    (end_date IS NULL OR start_date IS NULL OR end_date >= start_date)
+);
+
+ALTER TABLE subtasks DROP CONSTRAINT IF EXISTS valid_subtask_dates;
+ALTER TABLE subtasks ADD CONSTRAINT valid_subtask_dates CHECK (
+    (start_date IS NULL OR start_date >= created_at) AND
+    (end_date IS NULL OR start_date IS NULL OR end_date >= start_date)
+);
@@ -0,0 +1,5 @@
+-- Perfil de workflow de la tarea: determina la máquina de estados usada para validar sus transiciones
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS workflow VARCHAR(64) NOT NULL DEFAULT 'default'
+    CHECK (workflow ~ '^[a-z0-9][a-z0-9_-]*$');
+
+COMMENT ON COLUMN tasks.workflow IS 'Workflow profile whose state machine validates the task and subtask transitions';
@@ -0,0 +1,18 @@
+ALTER TABLE subtasks
+    DROP CONSTRAINT IF EXISTS valid_subtask_pause,
+    DROP COLUMN IF EXISTS paused_duration_ms,
+    DROP COLUMN IF EXISTS paused_at;
+
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS valid_pause,
+    DROP COLUMN IF EXISTS paused_duration_ms,
+    DROP COLUMN IF EXISTS paused_at;
+
+-- Las filas en PAUSED deben reanudarse o finalizarse antes de revertir
+ALTER TABLE subtasks DROP CONSTRAINT IF EXISTS subtasks_state_check;
+ALTER TABLE subtasks ADD CONSTRAINT subtasks_state_check
+    CHECK (state IN ('PENDING', 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'CANCELLED'));
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_state_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_state_check
+    CHECK (state IN ('PENDING', 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'CANCELLED'));
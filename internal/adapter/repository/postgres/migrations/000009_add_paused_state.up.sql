@@ -0,0 +1,25 @@
+-- Estado PAUSED (no final) para automatizaciones detenidas temporalmente
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_state_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_state_check
+    CHECK (state IN ('PENDING', 'IN_PROGRESS', 'PAUSED', 'COMPLETED', 'FAILED', 'CANCELLED'));
+
+ALTER TABLE subtasks DROP CONSTRAINT IF EXISTS subtasks_state_check;
+ALTER TABLE subtasks ADD CONSTRAINT subtasks_state_check
+    CHECK (state IN ('PENDING', 'IN_PROGRESS', 'PAUSED', 'COMPLETED', 'FAILED', 'CANCELLED'));
+
+-- Contabilidad de pausas: la duración reportada excluye el tiempo en PAUSED
+-- paused_at marca el inicio de la pausa en curso y solo existe mientras el estado es PAUSED
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS paused_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS paused_duration_ms BIGINT NOT NULL DEFAULT 0 CHECK (paused_duration_ms >= 0),
+    ADD CONSTRAINT valid_pause CHECK ((state = 'PAUSED') = (paused_at IS NOT NULL));
+
+ALTER TABLE subtasks
+    ADD COLUMN IF NOT EXISTS paused_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS paused_duration_ms BIGINT NOT NULL DEFAULT 0 CHECK (paused_duration_ms >= 0),
+    ADD CONSTRAINT valid_subtask_pause CHECK ((state = 'PAUSED') = (paused_at IS NOT NULL));
+
+COMMENT ON COLUMN tasks.paused_at IS 'Start of the current pause, set only while the task is PAUSED';
+COMMENT ON COLUMN tasks.paused_duration_ms IS 'Accumulated time spent in finished pauses, excluded from the reported duration';
+COMMENT ON COLUMN subtasks.paused_at IS 'Start of the current pause, set only while the subtask is PAUSED';
+COMMENT ON COLUMN subtasks.paused_duration_ms IS 'Accumulated time spent in finished pauses, excluded from the reported duration';
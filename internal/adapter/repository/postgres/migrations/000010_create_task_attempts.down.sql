@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS task_attempts;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS attempt;
+
+COMMENT ON COLUMN task_events.event_type IS 'TASK_CREATED, TASK_RENAMED, TASK_STATE_CHANGED, TASK_DELETED, TASK_RESTORED, SUBTASK_ADDED, SUBTASK_RENAMED, SUBTASK_STATE_CHANGED, SUBTASK_REMOVED';
@@ -0,0 +1,28 @@
+-- Reintentos: el intento en curso es la propia tarea y los intentos cerrados se conservan aquí
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS attempt INTEGER NOT NULL DEFAULT 1 CHECK (attempt > 0);
+
+CREATE TABLE IF NOT EXISTS task_attempts (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL CHECK (attempt > 0),
+    state VARCHAR(50) NOT NULL CHECK (state IN ('PENDING', 'IN_PROGRESS', 'PAUSED', 'COMPLETED', 'FAILED', 'CANCELLED')),
+
+    -- Fechas del intento
+    start_date TIMESTAMPTZ,
+    end_date TIMESTAMPTZ,
+    paused_duration_ms BIGINT NOT NULL DEFAULT 0 CHECK (paused_duration_ms >= 0),
+
+    -- Estado de las subtareas al cerrar el intento
+    subtasks JSONB NOT NULL DEFAULT '[]'::jsonb,
+
+    -- Audit fields
+    retried_by VARCHAR(256) NOT NULL CHECK (char_length(retried_by) > 0),
+    retried_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (task_id, attempt)
+);
+
+COMMENT ON COLUMN tasks.attempt IS 'Number of the current attempt, incremented on each retry';
+COMMENT ON TABLE task_attempts IS 'Closed attempts of retried tasks; the current attempt is the task row itself';
+COMMENT ON COLUMN task_attempts.subtasks IS 'Subtask states when the attempt was closed: [{id, name, state, start_date, end_date}]';
+COMMENT ON COLUMN task_attempts.retried_by IS 'Team or person who closed the attempt by retrying the task';
+COMMENT ON COLUMN task_events.event_type IS 'TASK_CREATED, TASK_RENAMED, TASK_STATE_CHANGED, TASK_DELETED, TASK_RESTORED, TASK_RETRIED, SUBTASK_ADDED, SUBTASK_RENAMED, SUBTASK_STATE_CHANGED, SUBTASK_REMOVED';
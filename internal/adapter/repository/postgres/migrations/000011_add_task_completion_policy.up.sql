@@ -0,0 +1,5 @@
+-- Política de finalización: cómo el estado de las subtareas cierra automáticamente la tarea padre
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completion_policy VARCHAR(32) NOT NULL DEFAULT 'all-must-complete'
+    CHECK (completion_policy IN ('all-must-complete', 'fail-fast', 'all-final', 'ignore-cancelled'));
+
+COMMENT ON COLUMN tasks.completion_policy IS 'How subtask states settle the task: all-must-complete, fail-fast, all-final or ignore-cancelled';
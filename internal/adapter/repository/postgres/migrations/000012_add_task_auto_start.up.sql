@@ -0,0 +1,4 @@
+-- Arranque automático: la primera subtarea que pasa a IN_PROGRESS arranca la tarea PENDING
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS auto_start BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN tasks.auto_start IS 'When true, starting a subtask moves the PENDING task to IN_PROGRESS';
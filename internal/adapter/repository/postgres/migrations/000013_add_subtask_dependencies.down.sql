@@ -0,0 +1 @@
+ALTER TABLE subtasks DROP COLUMN IF EXISTS depends_on;
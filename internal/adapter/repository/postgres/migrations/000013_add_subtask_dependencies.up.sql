@@ -0,0 +1,5 @@
+-- Dependencias entre subtareas de una misma tarea: IDs de las subtareas que deben completarse antes
+-- La aciclicidad y la pertenencia a la misma tarea se validan en el dominio
+ALTER TABLE subtasks ADD COLUMN IF NOT EXISTS depends_on UUID[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN subtasks.depends_on IS 'IDs of sibling subtasks that must be COMPLETED before this one can start';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_subtasks_parent_subtask_id;
+ALTER TABLE subtasks DROP CONSTRAINT IF EXISTS subtasks_parent_not_self;
+ALTER TABLE subtasks DROP CONSTRAINT IF EXISTS subtasks_parent_subtask_fkey;
+ALTER TABLE subtasks DROP COLUMN IF EXISTS parent_subtask_id;
+ALTER TABLE subtasks DROP CONSTRAINT IF EXISTS subtasks_task_id_id_key;
@@ -0,0 +1,17 @@
+-- Jerarquía de subtareas (lista de adyacencia): una subtarea puede colgar de otra subtarea de la misma tarea
+-- La clave foránea compuesta garantiza que el padre pertenece a la misma tarea; se difiere al commit
+-- para poder insertar o reordenar una rama completa dentro de una transacción
+ALTER TABLE subtasks ADD CONSTRAINT subtasks_task_id_id_key UNIQUE (task_id, id);
+
+ALTER TABLE subtasks ADD COLUMN IF NOT EXISTS parent_subtask_id UUID;
+
+ALTER TABLE subtasks ADD CONSTRAINT subtasks_parent_subtask_fkey
+    FOREIGN KEY (task_id, parent_subtask_id) REFERENCES subtasks (task_id, id)
+    ON DELETE CASCADE
+    DEFERRABLE INITIALLY DEFERRED;
+
+ALTER TABLE subtasks ADD CONSTRAINT subtasks_parent_not_self CHECK (parent_subtask_id IS NULL OR parent_subtask_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_subtasks_parent_subtask_id ON subtasks(parent_subtask_id) WHERE parent_subtask_id IS NOT NULL;
+
+COMMENT ON COLUMN subtasks.parent_subtask_id IS 'Parent subtask within the same task; NULL for top-level subtasks';
@@ -0,0 +1,11 @@
+ALTER TABLE subtasks
+    DROP CONSTRAINT IF EXISTS valid_subtask_items,
+    DROP COLUMN IF EXISTS items_total,
+    DROP COLUMN IF EXISTS items_processed,
+    DROP COLUMN IF EXISTS progress;
+
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS valid_items,
+    DROP COLUMN IF EXISTS items_total,
+    DROP COLUMN IF EXISTS items_processed,
+    DROP COLUMN IF EXISTS progress;
@@ -0,0 +1,20 @@
+-- Progreso reportado por el proceso de cada tarea y subtarea (todos los valores son opcionales)
+-- El progreso efectivo que se expone en la API se deriva de estos valores y, en las tareas, de sus subtareas
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS progress SMALLINT CHECK (progress BETWEEN 0 AND 100),
+    ADD COLUMN IF NOT EXISTS items_processed BIGINT CHECK (items_processed >= 0),
+    ADD COLUMN IF NOT EXISTS items_total BIGINT CHECK (items_total >= 0),
+    ADD CONSTRAINT valid_items CHECK (items_processed <= items_total);
+
+ALTER TABLE subtasks
+    ADD COLUMN IF NOT EXISTS progress SMALLINT CHECK (progress BETWEEN 0 AND 100),
+    ADD COLUMN IF NOT EXISTS items_processed BIGINT CHECK (items_processed >= 0),
+    ADD COLUMN IF NOT EXISTS items_total BIGINT CHECK (items_total >= 0),
+    ADD CONSTRAINT valid_subtask_items CHECK (items_processed <= items_total);
+
+COMMENT ON COLUMN tasks.progress IS 'Reported completion percentage (0-100), NULL if not reported';
+COMMENT ON COLUMN tasks.items_processed IS 'Reported number of processed items, NULL if not reported';
+COMMENT ON COLUMN tasks.items_total IS 'Reported total number of items to process, NULL if unknown';
+COMMENT ON COLUMN subtasks.progress IS 'Reported completion percentage (0-100), NULL if not reported';
+COMMENT ON COLUMN subtasks.items_processed IS 'Reported number of processed items, NULL if not reported';
+COMMENT ON COLUMN subtasks.items_total IS 'Reported total number of items to process, NULL if unknown';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_heartbeat;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS heartbeat_timeout_seconds,
+    DROP COLUMN IF EXISTS last_heartbeat_at;
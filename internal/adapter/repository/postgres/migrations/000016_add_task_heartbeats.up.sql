@@ -0,0 +1,12 @@
+-- Latidos: el proceso de una tarea supervisada debe dar señales de vida antes de que venza su plazo
+-- Las tareas IN_PROGRESS que lo superan las cierra como FAILED el reaper de la API
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS last_heartbeat_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS heartbeat_timeout_seconds INTEGER CHECK (heartbeat_timeout_seconds BETWEEN 1 AND 604800);
+
+-- El reaper solo recorre las tareas supervisadas en curso
+CREATE INDEX IF NOT EXISTS idx_tasks_heartbeat ON tasks(updated_at)
+    WHERE deleted_at IS NULL AND state = 'IN_PROGRESS' AND heartbeat_timeout_seconds IS NOT NULL;
+
+COMMENT ON COLUMN tasks.last_heartbeat_at IS 'Last heartbeat received from the task process, NULL if none';
+COMMENT ON COLUMN tasks.heartbeat_timeout_seconds IS 'Maximum seconds without heartbeats before an IN_PROGRESS task is failed, NULL if not supervised';
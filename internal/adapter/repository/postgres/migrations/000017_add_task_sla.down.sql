@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_tasks_sla_pending;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS sla_breached_at,
+    DROP COLUMN IF EXISTS deadline,
+    DROP COLUMN IF EXISTS expected_duration_seconds;
+
+COMMENT ON COLUMN task_events.event_type IS 'TASK_CREATED, TASK_RENAMED, TASK_STATE_CHANGED, TASK_DELETED, TASK_RESTORED, TASK_RETRIED, SUBTASK_ADDED, SUBTASK_RENAMED, SUBTASK_STATE_CHANGED, SUBTASK_REMOVED';
@@ -0,0 +1,16 @@
+-- SLA: duración esperada desde el arranque y/o fecha límite; el plazo vence en la que ocurra antes
+-- sla_breached_at lo fija una sola vez el monitor de SLA cuando el plazo vence sin que la tarea termine
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS expected_duration_seconds INTEGER CHECK (expected_duration_seconds BETWEEN 1 AND 31622400),
+    ADD COLUMN IF NOT EXISTS deadline TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS sla_breached_at TIMESTAMPTZ;
+
+-- El monitor de SLA solo recorre las tareas con SLA aún no incumplido
+CREATE INDEX IF NOT EXISTS idx_tasks_sla_pending ON tasks(deadline, start_date)
+    WHERE deleted_at IS NULL AND sla_breached_at IS NULL
+      AND (deadline IS NOT NULL OR expected_duration_seconds IS NOT NULL);
+
+COMMENT ON COLUMN tasks.expected_duration_seconds IS 'Committed maximum seconds from start to end, NULL if none';
+COMMENT ON COLUMN tasks.deadline IS 'Committed date by which the task must end, NULL if none';
+COMMENT ON COLUMN tasks.sla_breached_at IS 'When the SLA was breached (due date of the current attempt), NULL if not breached';
+COMMENT ON COLUMN task_events.event_type IS 'TASK_CREATED, TASK_RENAMED, TASK_STATE_CHANGED, TASK_DELETED, TASK_RESTORED, TASK_RETRIED, TASK_SLA_BREACHED, SUBTASK_ADDED, SUBTASK_RENAMED, SUBTASK_STATE_CHANGED, SUBTASK_REMOVED';
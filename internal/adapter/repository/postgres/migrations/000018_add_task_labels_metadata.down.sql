@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_labels;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS metadata,
+    DROP COLUMN IF EXISTS labels;
@@ -0,0 +1,10 @@
+-- Etiquetas (mapa string->string) para clasificar y filtrar tareas, y metadatos JSON libres
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '{}'::jsonb CHECK (jsonb_typeof(labels) = 'object'),
+    ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}'::jsonb CHECK (jsonb_typeof(metadata) = 'object');
+
+-- Los selectores de etiquetas del listado se resuelven por contención (labels @> selector)
+CREATE INDEX IF NOT EXISTS idx_tasks_labels ON tasks USING GIN (labels jsonb_path_ops);
+
+COMMENT ON COLUMN tasks.labels IS 'String to string labels used to classify and filter tasks';
+COMMENT ON COLUMN tasks.metadata IS 'Free-form JSON object stored and returned as is';
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_subtasks_error_code;
+DROP INDEX IF EXISTS idx_tasks_error_code;
+
+ALTER TABLE subtasks
+    DROP CONSTRAINT IF EXISTS subtask_failure_only_when_failed,
+    DROP COLUMN IF EXISTS error_details,
+    DROP COLUMN IF EXISTS error_message,
+    DROP COLUMN IF EXISTS error_code;
+
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS task_failure_only_when_failed,
+    DROP COLUMN IF EXISTS error_details,
+    DROP COLUMN IF EXISTS error_message,
+    DROP COLUMN IF EXISTS error_code;
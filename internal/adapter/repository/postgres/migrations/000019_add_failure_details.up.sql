@@ -0,0 +1,23 @@
+-- Motivo del fallo de tareas y subtareas FAILED: código, mensaje y detalles JSON opcionales
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS error_code VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS error_message TEXT,
+    ADD COLUMN IF NOT EXISTS error_details JSONB,
+    ADD CONSTRAINT task_failure_only_when_failed CHECK (error_code IS NULL OR state = 'FAILED');
+
+ALTER TABLE subtasks
+    ADD COLUMN IF NOT EXISTS error_code VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS error_message TEXT,
+    ADD COLUMN IF NOT EXISTS error_details JSONB,
+    ADD CONSTRAINT subtask_failure_only_when_failed CHECK (error_code IS NULL OR state = 'FAILED');
+
+-- El listado filtra por código de error de la tarea o de cualquiera de sus subtareas
+CREATE INDEX IF NOT EXISTS idx_tasks_error_code ON tasks(error_code) WHERE error_code IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_subtasks_error_code ON subtasks(error_code, task_id) WHERE error_code IS NOT NULL;
+
+COMMENT ON COLUMN tasks.error_code IS 'Error code reported when the task moved to FAILED, NULL if not reported';
+COMMENT ON COLUMN tasks.error_message IS 'Error message reported when the task moved to FAILED';
+COMMENT ON COLUMN tasks.error_details IS 'Optional JSON details (context, stack trace) of the failure';
+COMMENT ON COLUMN subtasks.error_code IS 'Error code reported when the subtask moved to FAILED, NULL if not reported';
+COMMENT ON COLUMN subtasks.error_message IS 'Error message reported when the subtask moved to FAILED';
+COMMENT ON COLUMN subtasks.error_details IS 'Optional JSON details (context, stack trace) of the failure';
@@ -0,0 +1,30 @@
+-- Restaurar la limpieza anterior a los logs
+CREATE OR REPLACE FUNCTION cleanup_soft_deleted_records()
+RETURNS void AS $$
+DECLARE
+    deleted_tasks_count INTEGER;
+    deleted_subtasks_count INTEGER;
+BEGIN
+    -- Delete tasks older than 30 days
+    DELETE FROM tasks
+    WHERE deleted_at IS NOT NULL
+      AND deleted_at < NOW() - INTERVAL '30 days';
+    GET DIAGNOSTICS deleted_tasks_count = ROW_COUNT;
+
+    -- Delete subtasks older than 30 days
+    DELETE FROM subtasks
+    WHERE deleted_at IS NOT NULL
+      AND deleted_at < NOW() - INTERVAL '30 days';
+    GET DIAGNOSTICS deleted_subtasks_count = ROW_COUNT;
+
+    -- Log the cleanup (optional, requires logging table or use RAISE NOTICE)
+    RAISE NOTICE 'Cleanup completed: % tasks and % subtasks deleted',
+        deleted_tasks_count, deleted_subtasks_count;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON FUNCTION cleanup_soft_deleted_records() IS
+    'Permanently deletes tasks and subtasks that have been soft-deleted for more than 30 days';
+
+DROP TABLE IF EXISTS task_logs;
+DROP FUNCTION IF EXISTS reject_task_log_update();
@@ -0,0 +1,72 @@
+-- Líneas de log enviadas por los procesos de las tareas
+-- Solo se insertan: no se modifican y se purgan junto con su tarea
+CREATE TABLE IF NOT EXISTS task_logs (
+    id BIGSERIAL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    -- Sin foreign key: los logs se conservan aunque la subtarea se purgue
+    subtask_id UUID,
+    level VARCHAR(10) NOT NULL CHECK (level IN ('DEBUG', 'INFO', 'WARN', 'ERROR')),
+    message TEXT NOT NULL CHECK (char_length(message) > 0),
+
+    -- Timestamps
+    logged_at TIMESTAMPTZ NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Index para la lectura paginada por cursor (logged_at, id) de los logs de una tarea
+CREATE INDEX IF NOT EXISTS idx_task_logs_task_timeline ON task_logs(task_id, logged_at, id);
+
+-- Las líneas guardadas no pueden modificarse
+CREATE OR REPLACE FUNCTION reject_task_log_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'task_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_logs_append_only
+    BEFORE UPDATE ON task_logs
+    FOR EACH ROW
+    EXECUTE FUNCTION reject_task_log_update();
+
+-- La limpieza borra los logs de las tareas purgadas en bloque, antes que las propias tareas
+CREATE OR REPLACE FUNCTION cleanup_soft_deleted_records()
+RETURNS void AS $$
+DECLARE
+    deleted_logs_count INTEGER;
+    deleted_tasks_count INTEGER;
+    deleted_subtasks_count INTEGER;
+BEGIN
+    -- Delete logs of tasks older than 30 days
+    DELETE FROM task_logs
+    WHERE task_id IN (
+        SELECT id FROM tasks
+        WHERE deleted_at IS NOT NULL
+          AND deleted_at < NOW() - INTERVAL '30 days'
+        FOR UPDATE
+    );
+    GET DIAGNOSTICS deleted_logs_count = ROW_COUNT;
+
+    -- Delete tasks older than 30 days
+    DELETE FROM tasks
+    WHERE deleted_at IS NOT NULL
+      AND deleted_at < NOW() - INTERVAL '30 days';
+    GET DIAGNOSTICS deleted_tasks_count = ROW_COUNT;
+
+    -- Delete subtasks older than 30 days
+    DELETE FROM subtasks
+    WHERE deleted_at IS NOT NULL
+      AND deleted_at < NOW() - INTERVAL '30 days';
+    GET DIAGNOSTICS deleted_subtasks_count = ROW_COUNT;
+
+    RAISE NOTICE 'Cleanup completed: % tasks, % subtasks and % log lines deleted',
+        deleted_tasks_count, deleted_subtasks_count, deleted_logs_count;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON TABLE task_logs IS 'Append-only log lines sent by the processes of each task';
+COMMENT ON COLUMN task_logs.subtask_id IS 'Subtask that emitted the line, NULL for task-level lines';
+COMMENT ON COLUMN task_logs.logged_at IS 'When the process emitted the line';
+COMMENT ON COLUMN task_logs.received_at IS 'When the API received the line';
+COMMENT ON FUNCTION cleanup_soft_deleted_records() IS
+    'Permanently deletes tasks (with their logs) and subtasks that have been soft-deleted for more than 30 days';
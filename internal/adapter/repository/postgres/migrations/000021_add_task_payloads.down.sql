@@ -0,0 +1,7 @@
+ALTER TABLE subtasks
+    DROP COLUMN IF EXISTS output,
+    DROP COLUMN IF EXISTS input;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS output,
+    DROP COLUMN IF EXISTS input;
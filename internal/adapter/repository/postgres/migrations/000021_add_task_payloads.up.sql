@@ -0,0 +1,14 @@
+-- Parámetros de entrada y resultados de tareas y subtareas: documentos JSON libres
+-- El tamaño máximo es configurable (PAYLOAD_MAX_BYTES) y lo valida la API
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS input JSONB,
+    ADD COLUMN IF NOT EXISTS output JSONB;
+
+ALTER TABLE subtasks
+    ADD COLUMN IF NOT EXISTS input JSONB,
+    ADD COLUMN IF NOT EXISTS output JSONB;
+
+COMMENT ON COLUMN tasks.input IS 'Input parameters the task was launched with, set at creation';
+COMMENT ON COLUMN tasks.output IS 'Results reported when the task reached a final state, cleared on retry';
+COMMENT ON COLUMN subtasks.input IS 'Input parameters the subtask was created with';
+COMMENT ON COLUMN subtasks.output IS 'Results reported when the subtask reached a final state, cleared on retry';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS task_artifacts;
+DROP FUNCTION IF EXISTS queue_orphaned_artifact_blob();
+DROP TABLE IF EXISTS orphaned_blobs;
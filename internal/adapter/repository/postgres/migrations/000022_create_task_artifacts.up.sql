@@ -0,0 +1,48 @@
+-- Metadatos de los ficheros adjuntos a las tareas; el contenido vive en el almacén de blobs
+-- No se modifican y se purgan junto con su tarea
+CREATE TABLE IF NOT EXISTS task_artifacts (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL CHECK (char_length(name) > 0),
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    sha256 CHAR(64) NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    uploaded_by VARCHAR(256) NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Index para listar los artefactos de una tarea por orden de subida
+CREATE INDEX IF NOT EXISTS idx_task_artifacts_task ON task_artifacts(task_id, created_at, id);
+
+-- Claves del contenido de los artefactos borrados, pendientes de borrar del almacén de blobs
+-- La base de datos no puede borrar el contenido: lo hace el purgador de la API en segundo plano
+CREATE TABLE IF NOT EXISTS orphaned_blobs (
+    storage_key TEXT PRIMARY KEY,
+    orphaned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_orphaned_blobs_orphaned_at ON orphaned_blobs(orphaned_at);
+
+-- Cualquier borrado de un artefacto, incluida la cascada al purgar su tarea desde la API o desde
+-- cleanup_soft_deleted_records(), deja su contenido pendiente de borrar
+CREATE OR REPLACE FUNCTION queue_orphaned_artifact_blob()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO orphaned_blobs (storage_key) VALUES (OLD.storage_key)
+    ON CONFLICT (storage_key) DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_artifacts_queue_orphaned_blob
+    AFTER DELETE ON task_artifacts
+    FOR EACH ROW
+    EXECUTE FUNCTION queue_orphaned_artifact_blob();
+
+COMMENT ON TABLE task_artifacts IS 'Files attached to tasks; the content lives in the blob store under storage_key';
+COMMENT ON COLUMN task_artifacts.sha256 IS 'Hex-encoded SHA-256 digest of the content';
+COMMENT ON COLUMN task_artifacts.storage_key IS 'Key of the content in the blob store';
+COMMENT ON TABLE orphaned_blobs IS 'Blob store keys of deleted artifacts, pending deletion by the API purger';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_automation;
+ALTER TABLE tasks DROP COLUMN IF EXISTS automation_key;
+DROP TABLE IF EXISTS automations;
@@ -0,0 +1,39 @@
+-- Definiciones de los automatismos: cada tarea es una ejecución de un automatismo
+-- Se eliminan con soft delete y no se purgan: sus claves no se reutilizan y sus ejecuciones las siguen referenciando
+CREATE TABLE IF NOT EXISTS automations (
+    id UUID PRIMARY KEY,
+    key VARCHAR(64) NOT NULL UNIQUE CHECK (key ~ '^[a-z0-9][a-z0-9._-]*$'),
+    owner_team VARCHAR(256) NOT NULL CHECK (char_length(owner_team) > 0),
+    description TEXT NOT NULL DEFAULT '',
+    subtask_template JSONB NOT NULL DEFAULT '[]'::jsonb CHECK (jsonb_typeof(subtask_template) = 'array'),
+    schedule VARCHAR(255),
+    schedule_timezone VARCHAR(64),
+
+    -- Audit fields
+    created_by VARCHAR(256) NOT NULL CHECK (char_length(created_by) > 0),
+    updated_by VARCHAR(256),
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    version INTEGER NOT NULL DEFAULT 1,
+
+    CONSTRAINT schedule_with_timezone CHECK ((schedule IS NULL) = (schedule_timezone IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_automations_owner_team ON automations(owner_team) WHERE deleted_at IS NULL;
+
+-- Automatismo del que la tarea es una ejecución; NULL en las tareas sueltas
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS automation_key VARCHAR(64) REFERENCES automations(key);
+
+-- Index para listar y resumir las ejecuciones de cada automatismo
+CREATE INDEX IF NOT EXISTS idx_tasks_automation ON tasks(automation_key, created_at DESC)
+    WHERE deleted_at IS NULL AND automation_key IS NOT NULL;
+
+COMMENT ON TABLE automations IS 'Definitions of recurring automations; each task row is one run of an automation';
+COMMENT ON COLUMN automations.key IS 'Stable identifier referenced by the runs; never reused, even after deletion';
+COMMENT ON COLUMN automations.subtask_template IS 'Subtasks created for every run that does not list its own';
+COMMENT ON COLUMN automations.schedule IS 'Expected schedule as a 5-field cron expression; NULL for on-demand automations';
+COMMENT ON COLUMN automations.schedule_timezone IS 'IANA timezone in which the schedule is evaluated';
+COMMENT ON COLUMN tasks.automation_key IS 'Automation this task is a run of; NULL for standalone tasks';
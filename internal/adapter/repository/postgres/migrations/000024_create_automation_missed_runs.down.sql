@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS automation_missed_runs;
+DROP INDEX IF EXISTS idx_automations_scheduled;
+ALTER TABLE automations DROP COLUMN IF EXISTS schedule_checked_until;
+ALTER TABLE automations DROP COLUMN IF EXISTS grace_period_seconds;
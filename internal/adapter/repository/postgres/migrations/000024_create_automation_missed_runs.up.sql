@@ -0,0 +1,29 @@
+-- Margen tras cada activación planificada para que se cree su ejecución
+ALTER TABLE automations ADD COLUMN IF NOT EXISTS grace_period_seconds INTEGER NOT NULL DEFAULT 900
+    CHECK (grace_period_seconds BETWEEN 1 AND 604800);
+
+-- Última activación revisada por el detector de ejecuciones perdidas; NULL si no hay planificación
+-- Los automatismos ya planificados empiezan a revisarse desde ahora, sin marcar activaciones pasadas
+ALTER TABLE automations ADD COLUMN IF NOT EXISTS schedule_checked_until TIMESTAMPTZ;
+UPDATE automations SET schedule_checked_until = NOW() WHERE schedule IS NOT NULL AND schedule_checked_until IS NULL;
+
+-- Index para recorrer los automatismos planificados
+CREATE INDEX IF NOT EXISTS idx_automations_scheduled ON automations(key)
+    WHERE deleted_at IS NULL AND schedule IS NOT NULL;
+
+-- Activaciones planificadas para las que no se creó ninguna ejecución dentro del margen
+CREATE TABLE IF NOT EXISTS automation_missed_runs (
+    id UUID PRIMARY KEY,
+    automation_key VARCHAR(64) NOT NULL REFERENCES automations(key),
+    expected_at TIMESTAMPTZ NOT NULL,
+    deadline TIMESTAMPTZ NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Cada activación se registra una sola vez aunque la detecten varias réplicas
+    CONSTRAINT automation_missed_runs_unique UNIQUE (automation_key, expected_at)
+);
+
+COMMENT ON TABLE automation_missed_runs IS 'Scheduled activations of an automation for which no run was created within the grace period';
+COMMENT ON COLUMN automation_missed_runs.deadline IS 'End of the grace period: expected_at plus the grace period in force when detected';
+COMMENT ON COLUMN automations.grace_period_seconds IS 'Seconds after each scheduled activation within which its run must be created';
+COMMENT ON COLUMN automations.schedule_checked_until IS 'Last scheduled activation checked for a missed run; reset when the schedule changes';
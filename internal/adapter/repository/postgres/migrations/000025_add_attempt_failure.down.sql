@@ -0,0 +1,6 @@
+COMMENT ON COLUMN task_attempts.subtasks IS 'Subtask states when the attempt was closed: [{id, name, state, start_date, end_date}]';
+
+ALTER TABLE task_attempts
+    DROP COLUMN IF EXISTS error_details,
+    DROP COLUMN IF EXISTS error_message,
+    DROP COLUMN IF EXISTS error_code;
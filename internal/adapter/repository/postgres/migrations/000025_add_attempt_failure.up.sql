@@ -0,0 +1,10 @@
+-- Motivo del fallo de cada intento cerrado: el reintento lo retira de la tarea y se conserva aquí
+ALTER TABLE task_attempts
+    ADD COLUMN IF NOT EXISTS error_code VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS error_message TEXT,
+    ADD COLUMN IF NOT EXISTS error_details JSONB;
+
+COMMENT ON COLUMN task_attempts.error_code IS 'Error code the task reported when the attempt failed, NULL if not reported';
+COMMENT ON COLUMN task_attempts.error_message IS 'Error message the task reported when the attempt failed';
+COMMENT ON COLUMN task_attempts.error_details IS 'Optional JSON details (context, stack trace) of the attempt failure';
+COMMENT ON COLUMN task_attempts.subtasks IS 'Subtask states when the attempt was closed: [{id, name, state, start_date, end_date, error_code, error_message, error_details}]';
@@ -0,0 +1,5 @@
+COMMENT ON COLUMN subtasks.output IS 'Results reported when the subtask reached a final state, cleared on retry';
+COMMENT ON COLUMN tasks.output IS 'Results reported when the task reached a final state, cleared on retry';
+COMMENT ON COLUMN task_attempts.subtasks IS 'Subtask states when the attempt was closed: [{id, name, state, start_date, end_date, error_code, error_message, error_details}]';
+
+ALTER TABLE task_attempts DROP COLUMN IF EXISTS output;
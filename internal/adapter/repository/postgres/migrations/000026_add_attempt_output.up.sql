@@ -0,0 +1,7 @@
+-- Resultados de cada intento cerrado: el reintento los retira de la tarea y se conservan aquí
+ALTER TABLE task_attempts ADD COLUMN IF NOT EXISTS output JSONB;
+
+COMMENT ON COLUMN task_attempts.output IS 'Results the task reported when the attempt reached a final state';
+COMMENT ON COLUMN task_attempts.subtasks IS 'Subtask states when the attempt was closed: [{id, name, state, start_date, end_date, error_code, error_message, error_details, output}]';
+COMMENT ON COLUMN tasks.output IS 'Results reported when the task reached a final state, moved to task_attempts on retry';
+COMMENT ON COLUMN subtasks.output IS 'Results reported when the subtask reached a final state, moved to task_attempts on retry';
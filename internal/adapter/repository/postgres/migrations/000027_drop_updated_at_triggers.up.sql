@@ -0,0 +1,5 @@
+-- La aplicación fija updated_at con su propio reloj en cada actualización (ver entity.Clock)
+-- El trigger lo sobrescribía con la hora de la base de datos, de modo que las fechas no se podían controlar
+DROP TRIGGER IF EXISTS update_subtasks_updated_at ON subtasks;
+DROP TRIGGER IF EXISTS update_tasks_updated_at ON tasks;
+DROP FUNCTION IF EXISTS update_updated_at_column();
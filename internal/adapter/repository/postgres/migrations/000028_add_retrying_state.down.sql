@@ -0,0 +1,8 @@
+-- Las filas en RETRYING deben volver a IN_PROGRESS o finalizarse antes de revertir
+ALTER TABLE subtasks DROP CONSTRAINT IF EXISTS subtasks_state_check;
+ALTER TABLE subtasks ADD CONSTRAINT subtasks_state_check
+    CHECK (state IN ('PENDING', 'IN_PROGRESS', 'PAUSED', 'COMPLETED', 'FAILED', 'CANCELLED'));
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_state_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_state_check
+    CHECK (state IN ('PENDING', 'IN_PROGRESS', 'PAUSED', 'COMPLETED', 'FAILED', 'CANCELLED'));
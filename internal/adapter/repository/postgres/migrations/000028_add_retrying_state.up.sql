@@ -0,0 +1,9 @@
+-- Estado RETRYING (no final) para tareas que repiten un paso tras un error transitorio
+-- Solo lo usan los workflows que lo declaran (p. ej. configs/workflows/rpa.yaml)
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_state_check;
+ALTER TABLE tasks ADD CONSTRAINT tasks_state_check
+    CHECK (state IN ('PENDING', 'IN_PROGRESS', 'PAUSED', 'RETRYING', 'COMPLETED', 'FAILED', 'CANCELLED'));
+
+ALTER TABLE subtasks DROP CONSTRAINT IF EXISTS subtasks_state_check;
+ALTER TABLE subtasks ADD CONSTRAINT subtasks_state_check
+    CHECK (state IN ('PENDING', 'IN_PROGRESS', 'PAUSED', 'RETRYING', 'COMPLETED', 'FAILED', 'CANCELLED'));
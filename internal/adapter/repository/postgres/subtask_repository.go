@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -26,11 +27,15 @@ func NewSubtaskRepository(pool *pgxpool.Pool) repository.SubtaskRepository {
 // Create crea una nueva subtarea
 func (r *SubtaskRepository) Create(ctx context.Context, taskID uuid.UUID, subtask *entity.Subtask) error {
 	query := `
-		INSERT INTO subtasks (id, task_id, name, state, start_date, end_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO subtasks (id, task_id, name, state, start_date, end_date, created_at, updated_at, version,
+		                      paused_at, paused_duration_ms, depends_on, parent_subtask_id,
+		                      progress, items_processed, items_total, error_code, error_message, error_details,
+		                      input, output)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	failure := newFailureColumns(subtask.Failure)
+	_, err := conn(ctx, r.pool).Exec(ctx, query,
 		subtask.ID,
 		taskID,
 		subtask.Name,
@@ -39,6 +44,19 @@ func (r *SubtaskRepository) Create(ctx context.Context, taskID uuid.UUID, subtas
 		subtask.EndDate,
 		subtask.CreatedAt,
 		subtask.UpdatedAt,
+		subtask.Version,
+		subtask.PausedAt,
+		subtask.PausedDuration.Milliseconds(),
+		dependsOn(subtask),
+		subtask.ParentSubtaskID,
+		subtask.Progress,
+		subtask.ItemsProcessed,
+		subtask.ItemsTotal,
+		failure.code,
+		failure.message,
+		failure.details,
+		subtask.Input,
+		subtask.Output,
 	)
 
 	if err != nil {
@@ -51,25 +69,12 @@ func (r *SubtaskRepository) Create(ctx context.Context, taskID uuid.UUID, subtas
 // FindByID busca una subtarea por su ID
 func (r *SubtaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Subtask, error) {
 	query := `
-		SELECT id, name, state, start_date, end_date, created_at, updated_at, deleted_at
+		SELECT ` + subtaskColumns + `
 		FROM subtasks
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	var subtask entity.Subtask
-	var state string
-
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&subtask.ID,
-		&subtask.Name,
-		&state,
-		&subtask.StartDate,
-		&subtask.EndDate,
-		&subtask.CreatedAt,
-		&subtask.UpdatedAt,
-		&subtask.DeletedAt,
-	)
-
+	subtask, err := scanSubtask(conn(ctx, r.pool).QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, entity.ErrSubtaskNotFound
@@ -77,9 +82,7 @@ func (r *SubtaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity
 		return nil, fmt.Errorf("failed to find subtask by ID: %w", err)
 	}
 
-	subtask.State = entity.State(state)
-
-	return &subtask, nil
+	return subtask, nil
 }
 
 // FindParentTaskID busca el UUID de la tarea padre de una subtarea
@@ -91,7 +94,7 @@ func (r *SubtaskRepository) FindParentTaskID(ctx context.Context, subtaskID uuid
 	`
 
 	var taskID uuid.UUID
-	err := r.pool.QueryRow(ctx, query, subtaskID).Scan(&taskID)
+	err := conn(ctx, r.pool).QueryRow(ctx, query, subtaskID).Scan(&taskID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return uuid.Nil, entity.ErrSubtaskNotFound
@@ -103,20 +106,35 @@ func (r *SubtaskRepository) FindParentTaskID(ctx context.Context, subtaskID uuid
 }
 
 // Update actualiza una subtarea existente
+// Aplica control de concurrencia optimista: retorna entity.ErrVersionConflict si la versión
+// de la fila ya no coincide con la versión cargada en la entidad
 func (r *SubtaskRepository) Update(ctx context.Context, subtask *entity.Subtask) error {
 	query := `
 		UPDATE subtasks
-		SET name = $2, state = $3, start_date = $4, end_date = $5, updated_at = $6
-		WHERE id = $1 AND deleted_at IS NULL
+		SET name = $2, state = $3, start_date = $4, end_date = $5, updated_at = $6,
+		    paused_at = $8, paused_duration_ms = $9, progress = $10, items_processed = $11, items_total = $12,
+		    error_code = $13, error_message = $14, error_details = $15, output = $16, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL AND version = $7
 	`
 
-	result, err := r.pool.Exec(ctx, query,
+	failure := newFailureColumns(subtask.Failure)
+	result, err := conn(ctx, r.pool).Exec(ctx, query,
 		subtask.ID,
 		subtask.Name,
 		subtask.State.String(),
 		subtask.StartDate,
 		subtask.EndDate,
 		subtask.UpdatedAt,
+		subtask.Version,
+		subtask.PausedAt,
+		subtask.PausedDuration.Milliseconds(),
+		subtask.Progress,
+		subtask.ItemsProcessed,
+		subtask.ItemsTotal,
+		failure.code,
+		failure.message,
+		failure.details,
+		subtask.Output,
 	)
 
 	if err != nil {
@@ -124,47 +142,77 @@ func (r *SubtaskRepository) Update(ctx context.Context, subtask *entity.Subtask)
 	}
 
 	if result.RowsAffected() == 0 {
+		var exists bool
+		err := conn(ctx, r.pool).QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM subtasks WHERE id = $1 AND deleted_at IS NULL)", subtask.ID).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to check subtask existence: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("%w: subtask %s was modified concurrently", entity.ErrVersionConflict, subtask.ID)
+		}
 		return entity.ErrSubtaskNotFound
 	}
 
+	subtask.Version++
+
 	return nil
 }
 
-// Delete marca una subtarea como eliminada (soft delete)
-func (r *SubtaskRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy string) error {
+// Delete marca una subtarea y todas sus subtareas anidadas como eliminadas (soft delete) en deletedAt
+// Retorna las subtareas eliminadas con su ID y nombre, de la raíz de la rama hacia abajo
+func (r *SubtaskRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy string, deletedAt time.Time) ([]*entity.Subtask, error) {
 	query := `
-		UPDATE subtasks
-		SET deleted_at = NOW(), updated_at = NOW()
-		WHERE id = $1 AND deleted_at IS NULL
+		WITH RECURSIVE branch AS (
+			SELECT id, 0 AS depth FROM subtasks WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT child.id, branch.depth + 1
+			FROM subtasks child
+			JOIN branch ON child.parent_subtask_id = branch.id
+			WHERE child.deleted_at IS NULL
+		),
+		removed AS (
+			UPDATE subtasks
+			SET deleted_at = $2, updated_at = $2, version = version + 1
+			WHERE id IN (SELECT id FROM branch)
+			RETURNING id, name, created_at
+		)
+		SELECT removed.id, removed.name
+		FROM removed
+		JOIN branch ON branch.id = removed.id
+		ORDER BY branch.depth, removed.created_at
 	`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, id, deletedAt)
 	if err != nil {
-		return fmt.Errorf("failed to delete subtask: %w", err)
+		return nil, fmt.Errorf("failed to delete subtask: %w", err)
 	}
+	defer rows.Close()
 
-	if result.RowsAffected() == 0 {
-		return entity.ErrSubtaskNotFound
+	var removed []*entity.Subtask
+	for rows.Next() {
+		subtask := &entity.Subtask{}
+		if err := rows.Scan(&subtask.ID, &subtask.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted subtask: %w", err)
+		}
+		removed = append(removed, subtask)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to delete subtask: %w", err)
 	}
 
-	return nil
+	if len(removed) == 0 {
+		return nil, entity.ErrSubtaskNotFound
+	}
+
+	return removed, nil
 }
 
-// FindByTaskID retorna todas las subtareas de una tarea específica
+// FindByTaskID retorna todas las subtareas de una tarea específica, a cualquier profundidad
+// Ver subtaskTreeQuery para el orden de las subtareas
 func (r *SubtaskRepository) FindByTaskID(ctx context.Context, taskID uuid.UUID, includeDeleted bool) ([]*entity.Subtask, error) {
-	query := `
-		SELECT id, name, state, start_date, end_date, created_at, updated_at, deleted_at
-		FROM subtasks
-		WHERE task_id = $1
-	`
-
-	if !includeDeleted {
-		query += " AND deleted_at IS NULL"
-	}
+	query := subtaskTreeQuery(includeDeleted, true)
 
-	query += " ORDER BY created_at ASC"
-
-	rows, err := r.pool.Query(ctx, query, taskID)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query subtasks: %w", err)
 	}
@@ -172,26 +220,11 @@ func (r *SubtaskRepository) FindByTaskID(ctx context.Context, taskID uuid.UUID,
 
 	var subtasks []*entity.Subtask
 	for rows.Next() {
-		var subtask entity.Subtask
-		var state string
-
-		err := rows.Scan(
-			&subtask.ID,
-			&subtask.Name,
-			&state,
-			&subtask.StartDate,
-			&subtask.EndDate,
-			&subtask.CreatedAt,
-			&subtask.UpdatedAt,
-			&subtask.DeletedAt,
-		)
-
+		subtask, err := scanSubtask(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan subtask: %w", err)
 		}
-
-		subtask.State = entity.State(state)
-		subtasks = append(subtasks, &subtask)
+		subtasks = append(subtasks, subtask)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -201,18 +234,115 @@ func (r *SubtaskRepository) FindByTaskID(ctx context.Context, taskID uuid.UUID,
 	return subtasks, nil
 }
 
-// DeleteByTaskID marca todas las subtareas de una tarea como eliminadas
-func (r *SubtaskRepository) DeleteByTaskID(ctx context.Context, taskID uuid.UUID, deletedBy string) error {
+// DeleteByTaskID marca todas las subtareas de una tarea como eliminadas en deletedAt
+func (r *SubtaskRepository) DeleteByTaskID(ctx context.Context, taskID uuid.UUID, deletedBy string, deletedAt time.Time) error {
 	query := `
 		UPDATE subtasks
-		SET deleted_at = NOW(), updated_at = NOW()
+		SET deleted_at = $2, updated_at = $2, version = version + 1
 		WHERE task_id = $1 AND deleted_at IS NULL
 	`
 
-	_, err := r.pool.Exec(ctx, query, taskID)
+	_, err := conn(ctx, r.pool).Exec(ctx, query, taskID, deletedAt)
 	if err != nil {
 		return fmt.Errorf("failed to delete subtasks by task ID: %w", err)
 	}
 
 	return nil
 }
+
+// subtaskColumns son las columnas de subtasks que lee scanSubtask, en el mismo orden
+const subtaskColumns = subtaskSummaryColumns + `, input, output`
+
+// subtaskSummaryColumns son las columnas de subtaskColumns salvo input y output (ver payloadColumns)
+const subtaskSummaryColumns = `id, name, state, start_date, end_date, created_at, updated_at, deleted_at, version,
+		paused_at, paused_duration_ms, depends_on, parent_subtask_id, progress, items_processed, items_total,
+		error_code, error_message, error_details`
+
+// payloadColumns retorna las columnas input y output con las que terminan taskColumns y subtaskColumns,
+// o NULL en su lugar si no se piden, para que los listados no lean documentos potencialmente grandes
+func payloadColumns(include bool) string {
+	if include {
+		return `, input, output`
+	}
+	return `, NULL::jsonb AS input, NULL::jsonb AS output`
+}
+
+// subtaskTreeQuery construye la consulta recursiva que carga la jerarquía de subtareas de una tarea ($1)
+// Recorre el árbol desde las subtareas raíz y retorna cada nivel antes que el siguiente, de modo que
+// cada subtarea aparece después de su padre; dentro de un nivel se ordena por fecha de creación.
+// Sin includeDeleted, las ramas que cuelgan de una subtarea eliminada tampoco se cargan.
+// Sin includePayloads, input y output se leen como NULL (ver payloadColumns)
+func subtaskTreeQuery(includeDeleted, includePayloads bool) string {
+	rootFilter, childFilter := "", ""
+	if !includeDeleted {
+		rootFilter = " AND deleted_at IS NULL"
+		childFilter = " AND child.deleted_at IS NULL"
+	}
+
+	return `
+		WITH RECURSIVE tree AS (
+			SELECT id, 0 AS depth
+			FROM subtasks
+			WHERE task_id = $1 AND parent_subtask_id IS NULL` + rootFilter + `
+			UNION ALL
+			SELECT child.id, tree.depth + 1
+			FROM subtasks child
+			JOIN tree ON child.parent_subtask_id = tree.id
+			WHERE child.task_id = $1` + childFilter + `
+		)
+		SELECT ` + subtaskSummaryColumns + payloadColumns(includePayloads) + `
+		FROM subtasks
+		JOIN tree USING (id)
+		ORDER BY tree.depth ASC, subtasks.created_at ASC
+	`
+}
+
+// scanSubtask lee una fila con las columnas de subtaskColumns
+func scanSubtask(row pgx.Row) (*entity.Subtask, error) {
+	var subtask entity.Subtask
+	var state string
+	var pausedMs int64
+	var failure failureColumns
+
+	err := row.Scan(
+		&subtask.ID,
+		&subtask.Name,
+		&state,
+		&subtask.StartDate,
+		&subtask.EndDate,
+		&subtask.CreatedAt,
+		&subtask.UpdatedAt,
+		&subtask.DeletedAt,
+		&subtask.Version,
+		&subtask.PausedAt,
+		&pausedMs,
+		&subtask.DependsOn,
+		&subtask.ParentSubtaskID,
+		&subtask.Progress,
+		&subtask.ItemsProcessed,
+		&subtask.ItemsTotal,
+		&failure.code,
+		&failure.message,
+		&failure.details,
+		&subtask.Input,
+		&subtask.Output,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if subtask.Failure, err = failure.failure(); err != nil {
+		return nil, err
+	}
+
+	subtask.State = entity.State(state)
+	subtask.PausedDuration = time.Duration(pausedMs) * time.Millisecond
+	return &subtask, nil
+}
+
+// dependsOn retorna las dependencias de la subtarea para la columna depends_on, que no admite NULL
+func dependsOn(subtask *entity.Subtask) []uuid.UUID {
+	if subtask.DependsOn == nil {
+		return []uuid.UUID{}
+	}
+	return subtask.DependsOn
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// TaskAttemptRepository implementa el repositorio de intentos de tareas usando PostgreSQL
+type TaskAttemptRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTaskAttemptRepository crea una nueva instancia del repositorio de intentos de tareas
+func NewTaskAttemptRepository(pool *pgxpool.Pool) repository.TaskAttemptRepository {
+	return &TaskAttemptRepository{pool: pool}
+}
+
+// subtaskAttemptRecord es la representación JSON de una subtarea en la columna task_attempts.subtasks
+type subtaskAttemptRecord struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	// Motivo del fallo de la subtarea, con los mismos valores que las columnas error_* de subtasks
+	ErrorCode    *string         `json:"error_code,omitempty"`
+	ErrorMessage *string         `json:"error_message,omitempty"`
+	ErrorDetails json.RawMessage `json:"error_details,omitempty"`
+	Output       json.RawMessage `json:"output,omitempty"`
+}
+
+// Create registra un intento cerrado
+func (r *TaskAttemptRepository) Create(ctx context.Context, attempt *entity.TaskAttempt) error {
+	records := make([]subtaskAttemptRecord, 0, len(attempt.Subtasks))
+	for _, subtask := range attempt.Subtasks {
+		failure := newFailureColumns(subtask.Failure)
+		records = append(records, subtaskAttemptRecord{
+			ID:           subtask.SubtaskID,
+			Name:         subtask.Name,
+			State:        subtask.State.String(),
+			StartDate:    subtask.StartDate,
+			EndDate:      subtask.EndDate,
+			ErrorCode:    failure.code,
+			ErrorMessage: failure.message,
+			ErrorDetails: failure.details,
+			Output:       subtask.Output,
+		})
+	}
+
+	subtasks, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to encode attempt subtasks: %w", err)
+	}
+
+	query := `
+		INSERT INTO task_attempts (task_id, attempt, state, start_date, end_date, paused_duration_ms, subtasks,
+		                           retried_by, retried_at, error_code, error_message, error_details, output)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	failure := newFailureColumns(attempt.Failure)
+	_, err = conn(ctx, r.pool).Exec(ctx, query,
+		attempt.TaskID,
+		attempt.Number,
+		attempt.State.String(),
+		attempt.StartDate,
+		attempt.EndDate,
+		attempt.PausedDuration.Milliseconds(),
+		subtasks,
+		attempt.RetriedBy,
+		attempt.RetriedAt,
+		failure.code,
+		failure.message,
+		failure.details,
+		attempt.Output,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create task attempt: %w", err)
+	}
+
+	return nil
+}
+
+// FindByTaskID retorna los intentos cerrados de una tarea ordenados por número de intento
+func (r *TaskAttemptRepository) FindByTaskID(ctx context.Context, taskID uuid.UUID) ([]*entity.TaskAttempt, error) {
+	query := `
+		SELECT task_id, attempt, state, start_date, end_date, paused_duration_ms, subtasks, retried_by, retried_at,
+		       error_code, error_message, error_details, output
+		FROM task_attempts
+		WHERE task_id = $1
+		ORDER BY attempt ASC
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := make([]*entity.TaskAttempt, 0)
+	for rows.Next() {
+		var attempt entity.TaskAttempt
+		var state string
+		var pausedMs int64
+		var subtasks []byte
+		var failure failureColumns
+
+		err := rows.Scan(
+			&attempt.TaskID,
+			&attempt.Number,
+			&state,
+			&attempt.StartDate,
+			&attempt.EndDate,
+			&pausedMs,
+			&subtasks,
+			&attempt.RetriedBy,
+			&attempt.RetriedAt,
+			&failure.code,
+			&failure.message,
+			&failure.details,
+			&attempt.Output,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task attempt: %w", err)
+		}
+
+		var records []subtaskAttemptRecord
+		if err := json.Unmarshal(subtasks, &records); err != nil {
+			return nil, fmt.Errorf("failed to decode attempt subtasks: %w", err)
+		}
+
+		if attempt.Failure, err = failure.failure(); err != nil {
+			return nil, err
+		}
+
+		attempt.State = entity.State(state)
+		attempt.PausedDuration = time.Duration(pausedMs) * time.Millisecond
+		attempt.Subtasks = make([]entity.SubtaskAttempt, 0, len(records))
+		for _, record := range records {
+			subtaskFailure := failureColumns{code: record.ErrorCode, message: record.ErrorMessage, details: record.ErrorDetails}
+			subtaskAttempt := entity.SubtaskAttempt{
+				SubtaskID: record.ID,
+				Name:      record.Name,
+				State:     entity.State(record.State),
+				StartDate: record.StartDate,
+				EndDate:   record.EndDate,
+				Output:    record.Output,
+			}
+			if subtaskAttempt.Failure, err = subtaskFailure.failure(); err != nil {
+				return nil, err
+			}
+			attempt.Subtasks = append(attempt.Subtasks, subtaskAttempt)
+		}
+
+		attempts = append(attempts, &attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task attempts: %w", err)
+	}
+
+	return attempts, nil
+}
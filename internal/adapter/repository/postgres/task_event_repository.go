@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// TaskEventRepository implementa el repositorio del historial de tareas usando PostgreSQL
+type TaskEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTaskEventRepository crea una nueva instancia del repositorio del historial de tareas
+func NewTaskEventRepository(pool *pgxpool.Pool) repository.TaskEventRepository {
+	return &TaskEventRepository{pool: pool}
+}
+
+// Create registra eventos del historial
+// Los IDs generados por la base de datos se asignan a los eventos
+func (r *TaskEventRepository) Create(ctx context.Context, events ...*entity.TaskEvent) error {
+	query := `
+		INSERT INTO task_events (task_id, subtask_id, event_type, actor, old_value, new_value, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	db := conn(ctx, r.pool)
+	for _, event := range events {
+		err := db.QueryRow(ctx, query,
+			event.TaskID,
+			event.SubtaskID,
+			event.Type.String(),
+			event.Actor,
+			event.OldValue,
+			event.NewValue,
+			event.OccurredAt,
+		).Scan(&event.ID)
+
+		if err != nil {
+			return fmt.Errorf("failed to create task event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FindByTaskID retorna el historial paginado de una tarea en orden cronológico
+func (r *TaskEventRepository) FindByTaskID(ctx context.Context, taskID uuid.UUID, filters repository.TaskEventFilters) (*repository.TaskEventListResult, error) {
+	db := conn(ctx, r.pool)
+
+	// Get total count
+	var total int
+	err := db.QueryRow(ctx, "SELECT COUNT(*) FROM task_events WHERE task_id = $1", taskID).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count task events: %w", err)
+	}
+
+	// Get events
+	query := `
+		SELECT id, task_id, subtask_id, event_type, actor, old_value, new_value, occurred_at
+		FROM task_events
+		WHERE task_id = $1
+		ORDER BY occurred_at ASC, id ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := db.Query(ctx, query, taskID, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*entity.TaskEvent, 0)
+	for rows.Next() {
+		var event entity.TaskEvent
+		var eventType string
+
+		err := rows.Scan(
+			&event.ID,
+			&event.TaskID,
+			&event.SubtaskID,
+			&eventType,
+			&event.Actor,
+			&event.OldValue,
+			&event.NewValue,
+			&event.OccurredAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task event: %w", err)
+		}
+
+		event.Type = entity.EventType(eventType)
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task events: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := total / filters.Limit
+	if total%filters.Limit > 0 {
+		totalPages++
+	}
+
+	return &repository.TaskEventListResult{
+		Events:     events,
+		Total:      total,
+		Page:       filters.Page,
+		Limit:      filters.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// TaskLogRepository implementa el repositorio de logs de tareas usando PostgreSQL
+type TaskLogRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTaskLogRepository crea una nueva instancia del repositorio de logs de tareas
+func NewTaskLogRepository(pool *pgxpool.Pool) repository.TaskLogRepository {
+	return &TaskLogRepository{pool: pool}
+}
+
+// Append guarda las líneas de log con un único INSERT sobre arrays de columnas
+// Los IDs generados no se asignan a las líneas: solo ordenan las lecturas
+func (r *TaskLogRepository) Append(ctx context.Context, lines ...*entity.TaskLogLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO task_logs (task_id, subtask_id, level, message, logged_at, received_at)
+		SELECT l.task_id::uuid, l.subtask_id::uuid, l.level, l.message, l.logged_at, l.received_at
+		FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::timestamptz[], $6::timestamptz[])
+		     AS l(task_id, subtask_id, level, message, logged_at, received_at)
+	`
+
+	taskIDs := make([]string, 0, len(lines))
+	subtaskIDs := make([]*string, 0, len(lines))
+	levels := make([]string, 0, len(lines))
+	messages := make([]string, 0, len(lines))
+	loggedAt := make([]time.Time, 0, len(lines))
+	receivedAt := make([]time.Time, 0, len(lines))
+	for _, line := range lines {
+		taskIDs = append(taskIDs, line.TaskID.String())
+		var subtaskID *string
+		if line.SubtaskID != nil {
+			id := line.SubtaskID.String()
+			subtaskID = &id
+		}
+		subtaskIDs = append(subtaskIDs, subtaskID)
+		levels = append(levels, line.Level.String())
+		messages = append(messages, line.Message)
+		loggedAt = append(loggedAt, line.LoggedAt)
+		receivedAt = append(receivedAt, line.ReceivedAt)
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, query, taskIDs, subtaskIDs, levels, messages, loggedAt, receivedAt)
+	if err != nil {
+		return fmt.Errorf("failed to append task logs: %w", err)
+	}
+
+	return nil
+}
+
+// CountByTaskID retorna el número de líneas guardadas de una tarea
+func (r *TaskLogRepository) CountByTaskID(ctx context.Context, taskID uuid.UUID) (int, error) {
+	var count int
+	err := conn(ctx, r.pool).QueryRow(ctx, "SELECT COUNT(*) FROM task_logs WHERE task_id = $1", taskID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count task logs: %w", err)
+	}
+	return count, nil
+}
+
+// FindByTaskID retorna las líneas de una tarea que cumplen los filtros en orden cronológico
+func (r *TaskLogRepository) FindByTaskID(ctx context.Context, taskID uuid.UUID, filters repository.TaskLogFilters) ([]*entity.TaskLogLine, error) {
+	query := `
+		SELECT id, task_id, subtask_id, level, message, logged_at, received_at
+		FROM task_logs
+		WHERE task_id = $1
+	`
+	args := []interface{}{taskID}
+	argIndex := 2
+
+	if filters.MinLevel != nil {
+		levels := make([]string, 0, len(filters.MinLevel.AndAbove()))
+		for _, level := range filters.MinLevel.AndAbove() {
+			levels = append(levels, level.String())
+		}
+		query += fmt.Sprintf(" AND level = ANY($%d)", argIndex)
+		args = append(args, levels)
+		argIndex++
+	}
+
+	if filters.SubtaskID != nil {
+		query += fmt.Sprintf(" AND subtask_id = $%d", argIndex)
+		args = append(args, *filters.SubtaskID)
+		argIndex++
+	}
+
+	if filters.Since != nil {
+		query += fmt.Sprintf(" AND logged_at >= $%d", argIndex)
+		args = append(args, *filters.Since)
+		argIndex++
+	}
+
+	if filters.Until != nil {
+		query += fmt.Sprintf(" AND logged_at < $%d", argIndex)
+		args = append(args, *filters.Until)
+		argIndex++
+	}
+
+	// Keyset pagination: las líneas posteriores al cursor en el orden (logged_at, id)
+	if filters.After != nil {
+		query += fmt.Sprintf(" AND (logged_at, id) > ($%d, $%d)", argIndex, argIndex+1)
+		args = append(args, filters.After.LoggedAt, filters.After.ID)
+		argIndex += 2
+	}
+
+	query += fmt.Sprintf(" ORDER BY logged_at ASC, id ASC LIMIT $%d", argIndex)
+	args = append(args, filters.Limit)
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task logs: %w", err)
+	}
+	defer rows.Close()
+
+	lines := make([]*entity.TaskLogLine, 0)
+	for rows.Next() {
+		var line entity.TaskLogLine
+		var level string
+
+		err := rows.Scan(
+			&line.ID,
+			&line.TaskID,
+			&line.SubtaskID,
+			&level,
+			&line.Message,
+			&line.LoggedAt,
+			&line.ReceivedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task log: %w", err)
+		}
+
+		line.Level = entity.LogLevel(level)
+		lines = append(lines, &line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task logs: %w", err)
+	}
+
+	return lines, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -25,7 +26,7 @@ func NewTaskRepository(pool *pgxpool.Pool) repository.TaskRepository {
 
 // Create crea una nueva tarea en la base de datos
 func (r *TaskRepository) Create(ctx context.Context, task *entity.Task) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := conn(ctx, r.pool).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -33,10 +34,16 @@ func (r *TaskRepository) Create(ctx context.Context, task *entity.Task) error {
 
 	// Insert task
 	queryTask := `
-		INSERT INTO tasks (id, name, state, created_by, updated_by, start_date, end_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO tasks (id, name, state, created_by, updated_by, start_date, end_date, created_at, updated_at, version, workflow,
+		                   paused_at, paused_duration_ms, attempt, completion_policy, auto_start,
+		                   progress, items_processed, items_total, heartbeat_timeout_seconds,
+		                   expected_duration_seconds, deadline, labels, metadata, error_code, error_message, error_details,
+		                   input, output, automation_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22,
+		        $23, $24, $25, $26, $27, $28, $29, $30)
 	`
 
+	failure := newFailureColumns(task.Failure)
 	_, err = tx.Exec(ctx, queryTask,
 		task.ID,
 		task.Name,
@@ -47,6 +54,27 @@ func (r *TaskRepository) Create(ctx context.Context, task *entity.Task) error {
 		task.EndDate,
 		task.CreatedAt,
 		task.UpdatedAt,
+		task.Version,
+		task.Workflow,
+		task.PausedAt,
+		task.PausedDuration.Milliseconds(),
+		task.Attempt,
+		task.CompletionPolicy.String(),
+		task.AutoStart,
+		task.Progress,
+		task.ItemsProcessed,
+		task.ItemsTotal,
+		heartbeatTimeoutSeconds(task),
+		expectedDurationSeconds(task),
+		task.Deadline,
+		taskLabels(task),
+		taskMetadata(task),
+		failure.code,
+		failure.message,
+		failure.details,
+		task.Input,
+		task.Output,
+		automationKey(task),
 	)
 
 	if err != nil {
@@ -56,11 +84,15 @@ func (r *TaskRepository) Create(ctx context.Context, task *entity.Task) error {
 	// Insert subtasks
 	if len(task.Subtasks) > 0 {
 		querySubtask := `
-			INSERT INTO subtasks (id, task_id, name, state, start_date, end_date, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			INSERT INTO subtasks (id, task_id, name, state, start_date, end_date, created_at, updated_at, version,
+			                      paused_at, paused_duration_ms, depends_on, parent_subtask_id,
+			                      progress, items_processed, items_total, error_code, error_message, error_details,
+			                      input, output)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 		`
 
 		for _, subtask := range task.Subtasks {
+			failure := newFailureColumns(subtask.Failure)
 			_, err = tx.Exec(ctx, querySubtask,
 				subtask.ID,
 				task.ID,
@@ -70,6 +102,19 @@ func (r *TaskRepository) Create(ctx context.Context, task *entity.Task) error {
 				subtask.EndDate,
 				subtask.CreatedAt,
 				subtask.UpdatedAt,
+				subtask.Version,
+				subtask.PausedAt,
+				subtask.PausedDuration.Milliseconds(),
+				dependsOn(subtask),
+				subtask.ParentSubtaskID,
+				subtask.Progress,
+				subtask.ItemsProcessed,
+				subtask.ItemsTotal,
+				failure.code,
+				failure.message,
+				failure.details,
+				subtask.Input,
+				subtask.Output,
 			)
 
 			if err != nil {
@@ -86,8 +131,10 @@ func (r *TaskRepository) Create(ctx context.Context, task *entity.Task) error {
 }
 
 // Update actualiza una tarea existente en la base de datos
+// Aplica control de concurrencia optimista: la fila solo se actualiza si su versión coincide
+// con la versión cargada en la entidad. Si otro escritor la modificó, retorna entity.ErrVersionConflict
 func (r *TaskRepository) Update(ctx context.Context, task *entity.Task) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := conn(ctx, r.pool).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -96,10 +143,17 @@ func (r *TaskRepository) Update(ctx context.Context, task *entity.Task) error {
 	// Update task
 	queryTask := `
 		UPDATE tasks
-		SET name = $2, state = $3, updated_by = $4, start_date = $5, end_date = $6, updated_at = $7
-		WHERE id = $1 AND deleted_at IS NULL
+		SET name = $2, state = $3, updated_by = $4, start_date = $5, end_date = $6, updated_at = $7,
+		    paused_at = $9, paused_duration_ms = $10, attempt = $11,
+		    progress = $12, items_processed = $13, items_total = $14,
+		    last_heartbeat_at = $15, heartbeat_timeout_seconds = $16,
+		    expected_duration_seconds = $17, deadline = $18, sla_breached_at = $19,
+		    labels = $20, metadata = $21, error_code = $22, error_message = $23, error_details = $24,
+		    output = $25, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL AND version = $8
 	`
 
+	failure := newFailureColumns(task.Failure)
 	result, err := tx.Exec(ctx, queryTask,
 		task.ID,
 		task.Name,
@@ -108,6 +162,24 @@ func (r *TaskRepository) Update(ctx context.Context, task *entity.Task) error {
 		task.StartDate,
 		task.EndDate,
 		task.UpdatedAt,
+		task.Version,
+		task.PausedAt,
+		task.PausedDuration.Milliseconds(),
+		task.Attempt,
+		task.Progress,
+		task.ItemsProcessed,
+		task.ItemsTotal,
+		task.LastHeartbeatAt,
+		heartbeatTimeoutSeconds(task),
+		expectedDurationSeconds(task),
+		task.Deadline,
+		task.SLABreachedAt,
+		taskLabels(task),
+		taskMetadata(task),
+		failure.code,
+		failure.message,
+		failure.details,
+		task.Output,
 	)
 
 	if err != nil {
@@ -115,10 +187,14 @@ func (r *TaskRepository) Update(ctx context.Context, task *entity.Task) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return entity.ErrTaskNotFound
+		return r.resolveMissingTask(ctx, tx, task.ID)
 	}
 
 	// Update/insert subtasks
+	// Las subtareas marcadas como eliminadas en la entidad persisten su deleted_at (soft delete)
+	// Las versiones en memoria solo se incrementan tras el commit para no dejar la entidad
+	// en un estado incoherente si la transacción se revierte
+	updatedSubtasks := make([]*entity.Subtask, 0, len(task.Subtasks))
 	if len(task.Subtasks) > 0 {
 		for _, subtask := range task.Subtasks {
 			// Check if subtask exists
@@ -128,23 +204,42 @@ func (r *TaskRepository) Update(ctx context.Context, task *entity.Task) error {
 				return fmt.Errorf("failed to check subtask existence: %w", err)
 			}
 
+			failure := newFailureColumns(subtask.Failure)
 			if exists {
 				// Update existing subtask
-				_, err = tx.Exec(ctx, `
+				result, err := tx.Exec(ctx, `
 					UPDATE subtasks
-					SET name = $2, state = $3, start_date = $4, end_date = $5, updated_at = $6
-					WHERE id = $1
-				`, subtask.ID, subtask.Name, subtask.State.String(), subtask.StartDate, subtask.EndDate, subtask.UpdatedAt)
+					SET name = $2, state = $3, start_date = $4, end_date = $5, updated_at = $6, deleted_at = $8,
+					    paused_at = $9, paused_duration_ms = $10, depends_on = $11, parent_subtask_id = $12,
+					    progress = $13, items_processed = $14, items_total = $15,
+					    error_code = $16, error_message = $17, error_details = $18, output = $19, version = version + 1
+					WHERE id = $1 AND version = $7
+				`, subtask.ID, subtask.Name, subtask.State.String(), subtask.StartDate, subtask.EndDate, subtask.UpdatedAt,
+					subtask.Version, subtask.DeletedAt, subtask.PausedAt, subtask.PausedDuration.Milliseconds(), dependsOn(subtask),
+					subtask.ParentSubtaskID, subtask.Progress, subtask.ItemsProcessed, subtask.ItemsTotal,
+					failure.code, failure.message, failure.details, subtask.Output)
+				if err != nil {
+					return fmt.Errorf("failed to update subtask: %w", err)
+				}
+				if result.RowsAffected() == 0 {
+					return fmt.Errorf("%w: subtask %s was modified concurrently", entity.ErrVersionConflict, subtask.ID)
+				}
+				updatedSubtasks = append(updatedSubtasks, subtask)
 			} else {
 				// Insert new subtask
 				_, err = tx.Exec(ctx, `
-					INSERT INTO subtasks (id, task_id, name, state, start_date, end_date, created_at, updated_at)
-					VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-				`, subtask.ID, task.ID, subtask.Name, subtask.State.String(), subtask.StartDate, subtask.EndDate, subtask.CreatedAt, subtask.UpdatedAt)
-			}
-
-			if err != nil {
-				return fmt.Errorf("failed to update/insert subtask: %w", err)
+					INSERT INTO subtasks (id, task_id, name, state, start_date, end_date, created_at, updated_at, version,
+					                      paused_at, paused_duration_ms, depends_on, parent_subtask_id,
+					                      progress, items_processed, items_total, error_code, error_message, error_details,
+					                      input, output)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+				`, subtask.ID, task.ID, subtask.Name, subtask.State.String(), subtask.StartDate, subtask.EndDate,
+					subtask.CreatedAt, subtask.UpdatedAt, subtask.Version, subtask.PausedAt, subtask.PausedDuration.Milliseconds(),
+					dependsOn(subtask), subtask.ParentSubtaskID, subtask.Progress, subtask.ItemsProcessed, subtask.ItemsTotal,
+					failure.code, failure.message, failure.details, subtask.Input, subtask.Output)
+				if err != nil {
+					return fmt.Errorf("failed to insert subtask: %w", err)
+				}
 			}
 		}
 	}
@@ -153,33 +248,52 @@ func (r *TaskRepository) Update(ctx context.Context, task *entity.Task) error {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	task.Version++
+	for _, subtask := range updatedSubtasks {
+		subtask.Version++
+	}
+
 	return nil
 }
 
+// resolveMissingTask determina por qué un UPDATE con control de versión no afectó filas:
+// la tarea no existe (o está eliminada) o su versión cambió
+func (r *TaskRepository) resolveMissingTask(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	var exists bool
+	err := tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check task existence: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: task %s was modified concurrently", entity.ErrVersionConflict, id)
+	}
+	return entity.ErrTaskNotFound
+}
+
 // FindByID busca una tarea por su ID
 func (r *TaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Task, error) {
+	return r.findByID(ctx, id, false)
+}
+
+// FindByIDForUpdate busca una tarea por su ID bloqueando su fila (SELECT ... FOR UPDATE)
+// Debe llamarse dentro de una transacción para que el bloqueo dure hasta el commit
+func (r *TaskRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Task, error) {
+	return r.findByID(ctx, id, true)
+}
+
+// findByID carga una tarea con sus subtareas, opcionalmente bloqueando la fila de la tarea
+func (r *TaskRepository) findByID(ctx context.Context, id uuid.UUID, forUpdate bool) (*entity.Task, error) {
 	query := `
-		SELECT id, name, state, created_by, updated_by, start_date, end_date, created_at, updated_at, deleted_at
+		SELECT ` + taskColumns + `
 		FROM tasks
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	var task entity.Task
-	var state string
-
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&task.ID,
-		&task.Name,
-		&state,
-		&task.CreatedBy,
-		&task.UpdatedBy,
-		&task.StartDate,
-		&task.EndDate,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-		&task.DeletedAt,
-	)
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
 
+	task, err := scanTask(conn(ctx, r.pool).QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, entity.ErrTaskNotFound
@@ -187,17 +301,14 @@ func (r *TaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Ta
 		return nil, fmt.Errorf("failed to find task by ID: %w", err)
 	}
 
-	// Parse state
-	task.State = entity.State(state)
-
 	// Load subtasks
-	subtasks, err := r.loadSubtasks(ctx, id)
+	subtasks, err := r.loadSubtasks(ctx, id, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load subtasks: %w", err)
 	}
 	task.Subtasks = subtasks
 
-	return &task, nil
+	return task, nil
 }
 
 // FindAll retorna todas las tareas con paginación y filtros opcionales
@@ -207,13 +318,13 @@ func (r *TaskRepository) FindAll(ctx context.Context, filters repository.TaskFil
 
 	// Get total count
 	var total int64
-	err := r.pool.QueryRow(ctx, countQuery, args[:len(args)-2]...).Scan(&total)
+	err := conn(ctx, r.pool).QueryRow(ctx, countQuery, args[:len(args)-2]...).Scan(&total)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count tasks: %w", err)
 	}
 
 	// Get tasks
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tasks: %w", err)
 	}
@@ -221,36 +332,19 @@ func (r *TaskRepository) FindAll(ctx context.Context, filters repository.TaskFil
 
 	var tasks []*entity.Task
 	for rows.Next() {
-		var task entity.Task
-		var state string
-
-		err := rows.Scan(
-			&task.ID,
-			&task.Name,
-			&state,
-			&task.CreatedBy,
-			&task.UpdatedBy,
-			&task.StartDate,
-			&task.EndDate,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-			&task.DeletedAt,
-		)
-
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 
-		task.State = entity.State(state)
-
 		// Load subtasks for each task
-		subtasks, err := r.loadSubtasks(ctx, task.ID)
+		subtasks, err := r.loadSubtasks(ctx, task.ID, filters.IncludePayloads)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load subtasks for task %s: %w", task.ID, err)
 		}
 		task.Subtasks = subtasks
 
-		tasks = append(tasks, &task)
+		tasks = append(tasks, task)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -272,17 +366,140 @@ func (r *TaskRepository) FindAll(ctx context.Context, filters repository.TaskFil
 	}, nil
 }
 
-// Delete marca una tarea como eliminada (soft delete)
-func (r *TaskRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy string) error {
+// Delete marca una tarea y sus subtareas como eliminadas (soft delete) en una única transacción
+// Las subtareas reciben la misma fecha de eliminación que la tarea, de forma que Restore las recupera
+func (r *TaskRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy string, deletedAt time.Time) error {
+	tx, err := conn(ctx, r.pool).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	result, err := tx.Exec(ctx, `
+		UPDATE tasks
+		SET deleted_at = $3, updated_at = $3, updated_by = $2, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, deletedBy, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrTaskNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE subtasks
+		SET deleted_at = $2, updated_at = $2, version = version + 1
+		WHERE task_id = $1 AND deleted_at IS NULL
+	`, id, deletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete subtasks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Restore revierte el soft delete de una tarea y de sus subtareas dentro de la ventana de 30 días anterior a restoredAt
+// Solo se restauran las subtareas eliminadas en el mismo momento o después que la tarea,
+// de forma que las subtareas eliminadas individualmente antes siguen eliminadas
+func (r *TaskRepository) Restore(ctx context.Context, id uuid.UUID, restoredBy string, restoredAt time.Time) error {
+	tx, err := conn(ctx, r.pool).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	var deletedAt time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT deleted_at
+		FROM tasks
+		WHERE id = $1
+		  AND deleted_at IS NOT NULL
+		  AND deleted_at >= $2::timestamptz - INTERVAL '30 days'
+		FOR UPDATE
+	`, id, restoredAt).Scan(&deletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrTaskNotFound
+		}
+		return fmt.Errorf("failed to find deleted task: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE tasks
+		SET deleted_at = NULL, updated_at = $3, updated_by = $2, version = version + 1
+		WHERE id = $1
+	`, id, restoredBy, restoredAt)
+	if err != nil {
+		return fmt.Errorf("failed to restore task: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE subtasks
+		SET deleted_at = NULL, updated_at = $3, version = version + 1
+		WHERE task_id = $1 AND deleted_at >= $2
+	`, id, deletedAt, restoredAt)
+	if err != nil {
+		return fmt.Errorf("failed to restore subtasks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// HardDelete elimina permanentemente tareas soft-deleted más de 30 días antes de now junto con sus logs y artefactos
+// Los logs se borran antes en un solo DELETE, en lugar de fila a fila por la cascada de la foreign key,
+// con las tareas bloqueadas para que no puedan restaurarse entre ambos borrados. Los artefactos, pocos por
+// tarea, se borran por la cascada, cuyo trigger deja su contenido pendiente de borrar en orphaned_blobs
+func (r *TaskRepository) HardDelete(ctx context.Context, now time.Time) (int, error) {
+	tx, err := conn(ctx, r.pool).Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM task_logs
+		WHERE task_id IN (SELECT id FROM tasks WHERE `+hardDeleteCondition+` FOR UPDATE)
+	`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to hard delete task logs: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM tasks WHERE `+hardDeleteCondition, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to hard delete tasks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// hardDeleteCondition selecciona las tareas que HardDelete purga: soft-deleted más de 30 días antes de $1
+const hardDeleteCondition = `deleted_at IS NOT NULL AND deleted_at < $1::timestamptz - INTERVAL '30 days'`
+
+// RecordHeartbeat guarda el último latido de la tarea
+// No incrementa la versión: los latidos no son cambios de la tarea y no deben invalidar los ETag de los clientes
+func (r *TaskRepository) RecordHeartbeat(ctx context.Context, task *entity.Task) error {
 	query := `
 		UPDATE tasks
-		SET deleted_at = NOW(), updated_by = $2
+		SET last_heartbeat_at = $2
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	result, err := r.pool.Exec(ctx, query, id, deletedBy)
+	result, err := conn(ctx, r.pool).Exec(ctx, query, task.ID, task.LastHeartbeatAt)
 	if err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+		return fmt.Errorf("failed to record task heartbeat: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -292,26 +509,148 @@ func (r *TaskRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy str
 	return nil
 }
 
-// HardDelete elimina permanentemente tareas soft-deleted hace más de 30 días
-func (r *TaskRepository) HardDelete(ctx context.Context) (int, error) {
+// FindStaleIDs retorna hasta limit tareas IN_PROGRESS o RETRYING cuya última señal de vida es anterior a su
+// plazo sin latidos en now, con las mismas reglas que entity.Task.HeartbeatExpired
+// Omite las tareas bloqueadas por otra transacción: se revisarán en la siguiente pasada
+func (r *TaskRepository) FindStaleIDs(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
 	query := `
-		DELETE FROM tasks
-		WHERE deleted_at IS NOT NULL
-		  AND deleted_at < NOW() - INTERVAL '30 days'
+		SELECT id
+		FROM tasks
+		WHERE deleted_at IS NULL
+		  AND state IN ('IN_PROGRESS', 'RETRYING')
+		  AND heartbeat_timeout_seconds IS NOT NULL
+		  AND GREATEST(last_heartbeat_at, updated_at) + heartbeat_timeout_seconds * INTERVAL '1 second' < $1
+		ORDER BY updated_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
 	`
 
-	result, err := r.pool.Exec(ctx, query)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, now, limit)
 	if err != nil {
-		return 0, fmt.Errorf("failed to hard delete tasks: %w", err)
+		return nil, fmt.Errorf("failed to query stale tasks: %w", err)
 	}
+	defer rows.Close()
 
-	return int(result.RowsAffected()), nil
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan stale task: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stale tasks: %w", err)
+	}
+
+	return ids, nil
+}
+
+// RecordSLABreach guarda el momento en que se incumplió el SLA de la tarea
+// No incrementa la versión: el incumplimiento lo detecta el sistema y no debe invalidar los ETag de los clientes
+func (r *TaskRepository) RecordSLABreach(ctx context.Context, task *entity.Task) error {
+	query := `
+		UPDATE tasks
+		SET sla_breached_at = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	result, err := conn(ctx, r.pool).Exec(ctx, query, task.ID, task.SLABreachedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record task SLA breach: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// FindSLABreachIDs retorna hasta limit tareas cuyo plazo venció en now (o antes de su fin) sin que se
+// haya registrado el incumplimiento, con las mismas reglas que entity.Task.DetectSLABreach
+// Omite las tareas bloqueadas por otra transacción: se revisarán en la siguiente pasada
+func (r *TaskRepository) FindSLABreachIDs(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM tasks
+		WHERE deleted_at IS NULL
+		  AND sla_breached_at IS NULL
+		  AND COALESCE(end_date, $1) > ` + taskSLADueExpr + `
+		ORDER BY created_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SLA breaches: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan SLA breach: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating SLA breaches: %w", err)
+	}
+
+	return ids, nil
+}
+
+// heartbeatTimeoutSeconds convierte el plazo sin latidos de la tarea a la columna heartbeat_timeout_seconds
+func heartbeatTimeoutSeconds(task *entity.Task) *int {
+	if task.HeartbeatTimeout == nil {
+		return nil
+	}
+	seconds := int(*task.HeartbeatTimeout / time.Second)
+	return &seconds
+}
+
+// expectedDurationSeconds convierte la duración esperada de la tarea a la columna expected_duration_seconds
+func expectedDurationSeconds(task *entity.Task) *int {
+	if task.ExpectedDuration == nil {
+		return nil
+	}
+	seconds := int(*task.ExpectedDuration / time.Second)
+	return &seconds
+}
+
+// automationKey retorna la clave del automatismo de la tarea, o nil si es una tarea suelta
+func automationKey(task *entity.Task) *string {
+	if task.Automation == "" {
+		return nil
+	}
+	return &task.Automation
+}
+
+// taskLabels retorna las etiquetas de la tarea para la columna labels, que nunca es NULL
+func taskLabels(task *entity.Task) map[string]string {
+	if task.Labels == nil {
+		return map[string]string{}
+	}
+	return task.Labels
+}
+
+// taskMetadata retorna los metadatos de la tarea para la columna metadata, que nunca es NULL
+func taskMetadata(task *entity.Task) map[string]interface{} {
+	if task.Metadata == nil {
+		return map[string]interface{}{}
+	}
+	return task.Metadata
 }
 
 // buildFindAllQuery construye la query de búsqueda con filtros
 func (r *TaskRepository) buildFindAllQuery(filters repository.TaskFilters) (string, string, []interface{}) {
 	baseQuery := `
-		SELECT id, name, state, created_by, updated_by, start_date, end_date, created_at, updated_at, deleted_at
+		SELECT ` + taskSummaryColumns + payloadColumns(filters.IncludePayloads) + `
 		FROM tasks
 		WHERE deleted_at IS NULL
 	`
@@ -343,6 +682,53 @@ func (r *TaskRepository) buildFindAllQuery(filters repository.TaskFilters) (stri
 		argIndex++
 	}
 
+	// Add progress filter (tasks whose progress is unknown never match)
+	if filters.MinProgress != nil {
+		filter := fmt.Sprintf(" AND "+taskProgressExpr+" >= $%d", argIndex)
+		baseQuery += filter
+		countQuery += filter
+		args = append(args, *filters.MinProgress)
+		argIndex++
+	}
+
+	// Add label selector (tasks must have every selected label with the selected value)
+	if len(filters.Labels) > 0 {
+		filter := fmt.Sprintf(" AND labels @> $%d", argIndex)
+		baseQuery += filter
+		countQuery += filter
+		args = append(args, filters.Labels)
+		argIndex++
+	}
+
+	// Add error code filter (the task itself or any of its live subtasks failed with the code)
+	if filters.ErrorCode != nil {
+		filter := fmt.Sprintf(` AND (error_code = $%[1]d OR EXISTS (
+			SELECT 1 FROM subtasks s
+			WHERE s.task_id = tasks.id AND s.deleted_at IS NULL AND s.error_code = $%[1]d))`, argIndex)
+		baseQuery += filter
+		countQuery += filter
+		args = append(args, *filters.ErrorCode)
+		argIndex++
+	}
+
+	// Add automation filter
+	if filters.Automation != nil {
+		filter := fmt.Sprintf(" AND automation_key = $%d", argIndex)
+		baseQuery += filter
+		countQuery += filter
+		args = append(args, *filters.Automation)
+		argIndex++
+	}
+
+	// Add SLA status filter (tasks without SLA never match)
+	if filters.SLAStatus != nil {
+		filter := fmt.Sprintf(" AND "+taskSLAStatusExpr+" = $%d", argIndex, argIndex+1)
+		baseQuery += filter
+		countQuery += filter
+		args = append(args, filters.Now, filters.SLAStatus.String())
+		argIndex += 2
+	}
+
 	// Add ordering
 	baseQuery += " ORDER BY created_at DESC"
 
@@ -353,16 +739,120 @@ func (r *TaskRepository) buildFindAllQuery(filters repository.TaskFilters) (stri
 	return baseQuery, countQuery, args
 }
 
-// loadSubtasks carga las subtareas de una tarea
-func (r *TaskRepository) loadSubtasks(ctx context.Context, taskID uuid.UUID) ([]*entity.Subtask, error) {
-	query := `
-		SELECT id, name, state, start_date, end_date, created_at, updated_at, deleted_at
-		FROM subtasks
-		WHERE task_id = $1 AND deleted_at IS NULL
-		ORDER BY created_at ASC
-	`
+// taskProgressExpr calcula en SQL el progreso efectivo de una fila de tasks, con las mismas reglas que
+// entity.Task.EffectiveProgress: 100 si está COMPLETED, el progreso reportado, el deducido de los
+// contadores de elementos y, por último, el porcentaje de subtareas raíz en estado final
+const taskProgressExpr = `(CASE WHEN tasks.state = 'COMPLETED' THEN 100 ELSE COALESCE(
+		tasks.progress,
+		CASE WHEN tasks.items_total > 0 THEN tasks.items_processed * 100 / tasks.items_total END,
+		(SELECT COUNT(*) FILTER (WHERE s.state IN ('COMPLETED', 'FAILED', 'CANCELLED')) * 100 / NULLIF(COUNT(*), 0)
+		 FROM subtasks s
+		 WHERE s.task_id = tasks.id AND s.parent_subtask_id IS NULL AND s.deleted_at IS NULL)
+	) END)`
+
+// taskSLADueExpr calcula en SQL el vencimiento del plazo de una fila de tasks, con las mismas reglas que
+// entity.Task.SLADueAt: la fecha límite o el inicio más la duración esperada, lo que ocurra antes
+// Es NULL si no se conoce (LEAST ignora los NULL)
+const taskSLADueExpr = `LEAST(tasks.deadline, tasks.start_date + tasks.expected_duration_seconds * INTERVAL '1 second')`
+
+// taskSLAStatusExpr calcula en SQL el estado del SLA de una fila de tasks en el instante del parámetro %[1]d, con las
+// mismas reglas que entity.Task.SLAStatus. Es NULL si la tarea no tiene SLA
+const taskSLAStatusExpr = `(CASE
+		WHEN tasks.deadline IS NULL AND tasks.expected_duration_seconds IS NULL THEN NULL
+		WHEN tasks.sla_breached_at IS NOT NULL THEN 'breached'
+		WHEN ` + taskSLADueExpr + ` IS NULL THEN 'on_track'
+		WHEN COALESCE(tasks.end_date, $%[1]d) > ` + taskSLADueExpr + ` THEN 'breached'
+		WHEN tasks.end_date IS NULL AND $%[1]d >= COALESCE(tasks.start_date, tasks.created_at)
+		     + (` + taskSLADueExpr + ` - COALESCE(tasks.start_date, tasks.created_at)) * 0.8 THEN 'at_risk'
+		ELSE 'on_track'
+	END)`
+
+// taskColumns son las columnas de tasks que lee scanTask, en el mismo orden
+const taskColumns = taskSummaryColumns + `, input, output`
+
+// taskSummaryColumns son las columnas de taskColumns salvo input y output (ver payloadColumns)
+const taskSummaryColumns = `id, name, state, created_by, updated_by, start_date, end_date, created_at, updated_at, deleted_at,
+		version, workflow, paused_at, paused_duration_ms, attempt, completion_policy, auto_start,
+		progress, items_processed, items_total, last_heartbeat_at, heartbeat_timeout_seconds,
+		expected_duration_seconds, deadline, sla_breached_at, labels, metadata,
+		error_code, error_message, error_details, automation_key`
+
+// scanTask lee una fila con las columnas de taskColumns
+func scanTask(row pgx.Row) (*entity.Task, error) {
+	var task entity.Task
+	var state string
+	var pausedMs int64
+	var completionPolicy string
+	var heartbeatTimeout *int
+	var expectedDuration *int
+	var failure failureColumns
+	var automation *string
+
+	err := row.Scan(
+		&task.ID,
+		&task.Name,
+		&state,
+		&task.CreatedBy,
+		&task.UpdatedBy,
+		&task.StartDate,
+		&task.EndDate,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.DeletedAt,
+		&task.Version,
+		&task.Workflow,
+		&task.PausedAt,
+		&pausedMs,
+		&task.Attempt,
+		&completionPolicy,
+		&task.AutoStart,
+		&task.Progress,
+		&task.ItemsProcessed,
+		&task.ItemsTotal,
+		&task.LastHeartbeatAt,
+		&heartbeatTimeout,
+		&expectedDuration,
+		&task.Deadline,
+		&task.SLABreachedAt,
+		&task.Labels,
+		&task.Metadata,
+		&failure.code,
+		&failure.message,
+		&failure.details,
+		&automation,
+		&task.Input,
+		&task.Output,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if task.Failure, err = failure.failure(); err != nil {
+		return nil, err
+	}
 
-	rows, err := r.pool.Query(ctx, query, taskID)
+	if automation != nil {
+		task.Automation = *automation
+	}
+	if heartbeatTimeout != nil {
+		timeout := time.Duration(*heartbeatTimeout) * time.Second
+		task.HeartbeatTimeout = &timeout
+	}
+	if expectedDuration != nil {
+		duration := time.Duration(*expectedDuration) * time.Second
+		task.ExpectedDuration = &duration
+	}
+
+	task.State = entity.State(state)
+	task.CompletionPolicy = entity.CompletionPolicy(completionPolicy)
+	task.PausedDuration = time.Duration(pausedMs) * time.Millisecond
+	return &task, nil
+}
+
+// loadSubtasks carga las subtareas de una tarea, con sus documentos input y output si includePayloads
+func (r *TaskRepository) loadSubtasks(ctx context.Context, taskID uuid.UUID, includePayloads bool) ([]*entity.Subtask, error) {
+	query := subtaskTreeQuery(false, includePayloads)
+
+	rows, err := conn(ctx, r.pool).Query(ctx, query, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query subtasks: %w", err)
 	}
@@ -370,27 +860,11 @@ func (r *TaskRepository) loadSubtasks(ctx context.Context, taskID uuid.UUID) ([]
 
 	var subtasks []*entity.Subtask
 	for rows.Next() {
-		var subtask entity.Subtask
-		var state string
-
-		err := rows.Scan(
-			&subtask.ID,
-
-			&subtask.Name,
-			&state,
-			&subtask.StartDate,
-			&subtask.EndDate,
-			&subtask.CreatedAt,
-			&subtask.UpdatedAt,
-			&subtask.DeletedAt,
-		)
-
+		subtask, err := scanSubtask(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan subtask: %w", err)
 		}
-
-		subtask.State = entity.State(state)
-		subtasks = append(subtasks, &subtask)
+		subtasks = append(subtasks, subtask)
 	}
 
 	if err := rows.Err(); err != nil {
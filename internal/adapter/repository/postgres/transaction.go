@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// querier agrupa las operaciones comunes a pgxpool.Pool y pgx.Tx
+// Permite que los repositorios ejecuten sus queries sin saber si hay una transacción en curso
+type querier interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// txKey es la clave del contexto donde se guarda la transacción activa
+type txKey struct{}
+
+// TransactionManager implementa la unidad de trabajo usando transacciones de PostgreSQL
+type TransactionManager struct {
+	pool *pgxpool.Pool
+}
+
+// NewTransactionManager crea una nueva instancia del gestor de transacciones
+func NewTransactionManager(pool *pgxpool.Pool) repository.TransactionManager {
+	return &TransactionManager{pool: pool}
+}
+
+// WithinTransaction ejecuta fn dentro de una transacción que comparten todos los repositorios
+func (m *TransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Unirse a la transacción existente: quien la abrió es responsable del commit
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// conn retorna la transacción activa del contexto o, si no hay ninguna, el pool
+// Dentro de una transacción, Begin crea un savepoint, por lo que las operaciones
+// que abren su propia transacción siguen siendo atómicas y se unen a la externa
+func conn(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
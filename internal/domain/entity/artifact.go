@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultMaxArtifactBytes es el tamaño máximo por defecto del fichero de un artefacto (100 MiB)
+	DefaultMaxArtifactBytes int64 = 100 * 1024 * 1024
+
+	// MaxArtifactNameLength es la longitud máxima en bytes del nombre de un artefacto
+	MaxArtifactNameLength = 255
+
+	// DefaultArtifactContentType es el tipo de contenido de los artefactos subidos sin tipo
+	DefaultArtifactContentType = "application/octet-stream"
+)
+
+// Artifact representa un fichero (informe, captura de pantalla...) adjunto a una tarea
+// Los metadatos se guardan en la base de datos y el contenido en el almacén de blobs, bajo StorageKey
+// Los artefactos no se modifican: se purgan junto con su tarea
+type Artifact struct {
+	ID          uuid.UUID
+	TaskID      uuid.UUID
+	Name        string // Nombre del fichero, sin directorios
+	ContentType string
+	Size        int64  // Tamaño del contenido en bytes
+	SHA256      string // Digest SHA-256 del contenido, en hexadecimal
+	StorageKey  string // Clave del contenido en el almacén de blobs
+	UploadedBy  string
+	CreatedAt   time.Time
+}
+
+// NewArtifact crea y valida los metadatos de un artefacto de la tarea subido en createdAt
+// El nombre se reduce a su último segmento y un tipo de contenido vacío se guarda como
+// DefaultArtifactContentType. El digest se asigna con SetContentDigest una vez leído el contenido.
+func NewArtifact(taskID uuid.UUID, name, contentType, uploadedBy string, size int64, createdAt time.Time) (*Artifact, error) {
+	name, err := normalizeArtifactName(name)
+	if err != nil {
+		return nil, err
+	}
+	contentType, err = normalizeArtifactContentType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	if uploadedBy == "" {
+		return nil, fmt.Errorf("%w: uploaded_by is required", ErrMissingRequiredFields)
+	}
+	if len(uploadedBy) > 256 {
+		return nil, fmt.Errorf("%w: uploaded_by exceeds 256 characters", ErrInvalidArtifact)
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("%w: size cannot be negative", ErrInvalidArtifact)
+	}
+
+	id := uuid.New()
+	return &Artifact{
+		ID:          id,
+		TaskID:      taskID,
+		Name:        name,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  ArtifactStorageKey(taskID, id),
+		UploadedBy:  uploadedBy,
+		CreatedAt:   createdAt,
+	}, nil
+}
+
+// ArtifactStorageKey retorna la clave del contenido de un artefacto en el almacén de blobs
+// Agrupar por tarea permite localizar en el almacén todo lo que pertenece a una tarea
+func ArtifactStorageKey(taskID, artifactID uuid.UUID) string {
+	return "tasks/" + taskID.String() + "/artifacts/" + artifactID.String()
+}
+
+// SetContentDigest registra el digest del contenido leído y comprueba que su tamaño es el declarado
+func (a *Artifact) SetContentDigest(sha256Hex string, size int64) error {
+	if size != a.Size {
+		return fmt.Errorf("%w: received %d bytes, expected %d", ErrInvalidArtifact, size, a.Size)
+	}
+	a.SHA256 = sha256Hex
+	return nil
+}
+
+// normalizeArtifactName valida el nombre del fichero quedándose con su último segmento,
+// de forma que un nombre con rutas (incluidas las de Windows) no pueda salir de su directorio al descargarlo
+func normalizeArtifactName(name string) (string, error) {
+	name = path.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidArtifact)
+	}
+	if len(name) > MaxArtifactNameLength {
+		return "", fmt.Errorf("%w: name exceeds %d bytes", ErrInvalidArtifact, MaxArtifactNameLength)
+	}
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return "", fmt.Errorf("%w: name cannot contain control characters", ErrInvalidArtifact)
+	}
+	return name, nil
+}
+
+// normalizeArtifactContentType valida el tipo de contenido y lo normaliza a su forma canónica
+func normalizeArtifactContentType(contentType string) (string, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return DefaultArtifactContentType, nil
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("%w: content type %q is not a valid media type", ErrInvalidArtifact, contentType)
+	}
+	formatted := mime.FormatMediaType(mediaType, params)
+	if len(formatted) > 255 {
+		return "", fmt.Errorf("%w: content type exceeds 255 bytes", ErrInvalidArtifact)
+	}
+	return formatted, nil
+}
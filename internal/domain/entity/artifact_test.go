@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewArtifact(t *testing.T) {
+	taskID := uuid.New()
+	createdAt := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("valid artifact", func(t *testing.T) {
+		artifact, err := NewArtifact(taskID, "report.pdf", "application/pdf", "runner-01", 1024, createdAt)
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, artifact.ID)
+		assert.Equal(t, taskID, artifact.TaskID)
+		assert.Equal(t, "report.pdf", artifact.Name)
+		assert.Equal(t, "application/pdf", artifact.ContentType)
+		assert.Equal(t, int64(1024), artifact.Size)
+		assert.Equal(t, "tasks/"+taskID.String()+"/artifacts/"+artifact.ID.String(), artifact.StorageKey)
+		assert.Equal(t, createdAt, artifact.CreatedAt)
+		assert.Empty(t, artifact.SHA256)
+	})
+
+	t.Run("names keep only their last segment", func(t *testing.T) {
+		for input, want := range map[string]string{
+			"../../etc/passwd":          "passwd",
+			`C:\Users\runner\shot.png`:  "shot.png",
+			"  screenshots/login.png  ": "login.png",
+		} {
+			artifact, err := NewArtifact(taskID, input, "", "runner-01", 1, createdAt)
+			require.NoError(t, err, input)
+			assert.Equal(t, want, artifact.Name)
+		}
+	})
+
+	t.Run("content types are normalized", func(t *testing.T) {
+		artifact, err := NewArtifact(taskID, "out.csv", "Text/CSV; Charset=utf-8", "runner-01", 1, createdAt)
+		require.NoError(t, err)
+		assert.Equal(t, "text/csv; charset=utf-8", artifact.ContentType)
+
+		artifact, err = NewArtifact(taskID, "out.bin", "", "runner-01", 1, createdAt)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultArtifactContentType, artifact.ContentType)
+	})
+
+	t.Run("invalid artifacts", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			artifactName string
+			contentType  string
+			size         int64
+		}{
+			{name: "empty name", artifactName: "  "},
+			{name: "directory name", artifactName: "reports/.."},
+			{name: "name too long", artifactName: strings.Repeat("x", MaxArtifactNameLength+1)},
+			{name: "control characters", artifactName: "report\n.pdf"},
+			{name: "invalid content type", artifactName: "report.pdf", contentType: "not a media type"},
+			{name: "negative size", artifactName: "report.pdf", size: -1},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := NewArtifact(taskID, tt.artifactName, tt.contentType, "runner-01", tt.size, createdAt)
+				assert.ErrorIs(t, err, ErrInvalidArtifact)
+			})
+		}
+	})
+
+	t.Run("uploaded_by is required", func(t *testing.T) {
+		_, err := NewArtifact(taskID, "report.pdf", "", "", 1, createdAt)
+		assert.ErrorIs(t, err, ErrMissingRequiredFields)
+	})
+}
+
+func TestArtifact_SetContentDigest(t *testing.T) {
+	artifact, err := NewArtifact(uuid.New(), "report.pdf", "", "runner-01", 3, testTime)
+	require.NoError(t, err)
+
+	err = artifact.SetContentDigest("abc", 2)
+	assert.ErrorIs(t, err, ErrInvalidArtifact)
+	assert.Empty(t, artifact.SHA256)
+
+	require.NoError(t, artifact.SetContentDigest("abc", 3))
+	assert.Equal(t, "abc", artifact.SHA256)
+}
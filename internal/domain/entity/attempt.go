@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FirstAttempt es el número de intento con el que se crea una tarea
+const FirstAttempt = 1
+
+// TaskAttempt representa un intento ya cerrado de una tarea
+// Se registra al reintentar una tarea FAILED; el intento en curso es la propia tarea
+type TaskAttempt struct {
+	TaskID         uuid.UUID
+	Number         int // Número de intento, empezando en FirstAttempt
+	State          State
+	StartDate      *time.Time
+	EndDate        *time.Time
+	PausedDuration time.Duration
+	Failure        *Failure         // Motivo del fallo del intento, nil si no se informó
+	Output         Payload          // Resultados que registró el intento, nil si no se informaron
+	Subtasks       []SubtaskAttempt // Estado de las subtareas al cerrar el intento
+	RetriedBy      string           // Equipo/persona que cerró el intento al reintentar
+	RetriedAt      time.Time
+}
+
+// SubtaskAttempt captura el estado de una subtarea al cerrar un intento
+type SubtaskAttempt struct {
+	SubtaskID uuid.UUID
+	Name      string
+	State     State
+	StartDate *time.Time
+	EndDate   *time.Time
+	Failure   *Failure // Motivo del fallo de la subtarea, nil si no se informó
+	Output    Payload  // Resultados que registró la subtarea, nil si no se informaron
+}
+
+// ActiveDuration retorna el tiempo de ejecución del intento excluyendo las pausas
+// Retorna false si el intento no llegó a comenzar
+func (a *TaskAttempt) ActiveDuration() (time.Duration, bool) {
+	pauses := PauseTracking{PausedDuration: a.PausedDuration}
+	return pauses.activeDuration(a.StartDate, a.EndDate, a.RetriedAt)
+}
+
+// Retry cierra el intento actual de una tarea FAILED y abre uno nuevo
+// La tarea vuelve a PENDING con fechas, pausas, progreso, latidos, fallo, resultados e incumplimiento del SLA reiniciados, y sus
+// subtareas activas vuelven a PENDING. No es una transición de la máquina de estados: los estados finales siguen sin
+// poder abandonarse, el reintento crea una nueva ejecución de la misma tarea.
+// Retorna el intento cerrado para que se conserve en el historial de intentos, con el motivo del fallo y los
+// resultados de la tarea y de sus subtareas
+func (t *Task) Retry(retriedBy string) (*TaskAttempt, error) {
+	if retriedBy == "" {
+		return nil, fmt.Errorf("%w: retried_by is required", ErrMissingRequiredFields)
+	}
+	if t.State != StateFailed {
+		return nil, fmt.Errorf("%w: task is %s, only %s tasks can be retried", ErrTaskNotRetryable, t.State, StateFailed)
+	}
+
+	now := t.Now()
+	attempt := &TaskAttempt{
+		TaskID:         t.ID,
+		Number:         t.Attempt,
+		State:          t.State,
+		StartDate:      t.StartDate,
+		EndDate:        t.EndDate,
+		PausedDuration: t.PausedDuration,
+		Failure:        t.Failure,
+		Output:         t.Output,
+		Subtasks:       make([]SubtaskAttempt, 0, len(t.Subtasks)),
+		RetriedBy:      retriedBy,
+		RetriedAt:      now,
+	}
+
+	for _, subtask := range t.Subtasks {
+		if subtask.IsDeleted() {
+			continue
+		}
+		attempt.Subtasks = append(attempt.Subtasks, SubtaskAttempt{
+			SubtaskID: subtask.ID,
+			Name:      subtask.Name,
+			State:     subtask.State,
+			StartDate: subtask.StartDate,
+			EndDate:   subtask.EndDate,
+			Failure:   subtask.Failure,
+			Output:    subtask.Output,
+		})
+		subtask.reset(now)
+	}
+
+	t.Attempt++
+	t.State = StatePending
+	t.StartDate = nil
+	t.EndDate = nil
+	t.PauseTracking = PauseTracking{}
+	t.ProgressTracking = ProgressTracking{}
+	t.LastHeartbeatAt = nil
+	t.SLABreachedAt = nil
+	t.Failure = nil
+	t.Output = nil
+	t.UpdatedBy = retriedBy
+	t.UpdatedAt = now
+
+	return attempt, nil
+}
+
+// RetryEvent retorna el evento del historial que registra el paso al intento actual
+func (t *Task) RetryEvent(actor string) *TaskEvent {
+	return NewTaskEvent(t.ID, EventTaskRetried, actor,
+		stringRef(strconv.Itoa(t.Attempt-1)), stringRef(strconv.Itoa(t.Attempt)), t.Now())
+}
+
+// reset devuelve la subtarea a PENDING para un nuevo intento de su tarea
+func (s *Subtask) reset(now time.Time) {
+	s.State = StatePending
+	s.StartDate = nil
+	s.EndDate = nil
+	s.PauseTracking = PauseTracking{}
+	s.ProgressTracking = ProgressTracking{}
+	s.Failure = nil
+	s.Output = nil
+	s.UpdatedAt = now
+}
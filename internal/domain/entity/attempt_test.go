@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask_Retry(t *testing.T) {
+	tests := []struct {
+		name    string
+		states  []State
+		wantErr error
+	}{
+		{
+			name:   "failed task can be retried",
+			states: []State{StateInProgress, StateFailed},
+		},
+		{
+			name:    "pending task cannot be retried",
+			states:  nil,
+			wantErr: ErrTaskNotRetryable,
+		},
+		{
+			name:    "running task cannot be retried",
+			states:  []State{StateInProgress},
+			wantErr: ErrTaskNotRetryable,
+		},
+		{
+			name:    "completed task cannot be retried",
+			states:  []State{StateInProgress, StateCompleted},
+			wantErr: ErrTaskNotRetryable,
+		},
+		{
+			name:    "cancelled task cannot be retried",
+			states:  []State{StateCancelled},
+			wantErr: ErrTaskNotRetryable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := NewTask("Test Task", "test-user", newTestClock())
+			require.NoError(t, err)
+			for _, state := range tt.states {
+				require.NoError(t, task.UpdateState(state, "test-user"))
+			}
+
+			attempt, err := task.Retry("retry-user")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, attempt)
+				assert.Equal(t, FirstAttempt, task.Attempt)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, FirstAttempt, attempt.Number)
+			assert.Equal(t, FirstAttempt+1, task.Attempt)
+		})
+	}
+}
+
+func TestTask_Retry_ResetsCurrentAttempt(t *testing.T) {
+	task, err := NewTask("Test Task", "test-user", newTestClock())
+	require.NoError(t, err)
+	step, _ := NewSubtask("Step", newTestClock())
+	removed, _ := NewSubtask("Removed", newTestClock())
+	task.AddSubtask(step)
+	task.AddSubtask(removed)
+
+	require.NoError(t, task.UpdateState(StateInProgress, "test-user"))
+	step.UpdateState(StateInProgress)
+	removed.Delete()
+	require.NoError(t, task.UpdateState(StatePaused, "test-user"))
+	require.NoError(t, task.UpdateState(StateInProgress, "test-user"))
+	require.NoError(t, task.UpdateState(StateFailed, "test-user"))
+
+	startDate, endDate := task.StartDate, task.EndDate
+
+	attempt, err := task.Retry("retry-user")
+	require.NoError(t, err)
+
+	// El intento cerrado conserva el resultado de la ejecución
+	assert.Equal(t, task.ID, attempt.TaskID)
+	assert.Equal(t, StateFailed, attempt.State)
+	assert.Equal(t, startDate, attempt.StartDate)
+	assert.Equal(t, endDate, attempt.EndDate)
+	assert.Equal(t, "retry-user", attempt.RetriedBy)
+	require.Len(t, attempt.Subtasks, 1, "deleted subtasks are not part of the attempt")
+	assert.Equal(t, step.ID, attempt.Subtasks[0].SubtaskID)
+	assert.Equal(t, StateFailed, attempt.Subtasks[0].State, "subtasks inherit the final state of the task")
+	assert.NotNil(t, attempt.Subtasks[0].StartDate)
+	assert.NotNil(t, attempt.Subtasks[0].EndDate)
+
+	// El nuevo intento empieza desde cero
+	assert.Equal(t, StatePending, task.State)
+	assert.Nil(t, task.StartDate)
+	assert.Nil(t, task.EndDate)
+	assert.Zero(t, task.PausedDuration)
+	assert.Equal(t, "retry-user", task.UpdatedBy)
+	assert.Equal(t, StatePending, step.State)
+	assert.Nil(t, step.StartDate)
+	assert.Nil(t, step.EndDate)
+	assert.True(t, removed.IsDeleted())
+
+	// La tarea reiniciada vuelve a seguir el ciclo de vida normal
+	require.NoError(t, task.UpdateState(StateInProgress, "test-user"))
+	assert.NotNil(t, task.StartDate)
+}
+
+func TestTask_Retry_RequiresActor(t *testing.T) {
+	task, err := NewTask("Test Task", "test-user", newTestClock())
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateState(StateFailed, "test-user"))
+
+	_, err = task.Retry("")
+
+	assert.ErrorIs(t, err, ErrMissingRequiredFields)
+	assert.Equal(t, StateFailed, task.State)
+}
+
+func TestTask_RetryEvent(t *testing.T) {
+	task, err := NewTask("Test Task", "test-user", newTestClock())
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateState(StateFailed, "test-user"))
+	_, err = task.Retry("retry-user")
+	require.NoError(t, err)
+
+	event := task.RetryEvent("retry-user")
+
+	assert.Equal(t, EventTaskRetried, event.Type)
+	assert.Equal(t, "1", *event.OldValue)
+	assert.Equal(t, "2", *event.NewValue)
+	assert.Equal(t, "retry-user", event.Actor)
+}
+
+func TestTaskAttempt_ActiveDuration(t *testing.T) {
+	start := time.Date(2025, 11, 27, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	attempt := TaskAttempt{
+		StartDate:      &start,
+		EndDate:        &end,
+		PausedDuration: 15 * time.Minute,
+		RetriedAt:      end.Add(time.Hour),
+	}
+
+	active, started := attempt.ActiveDuration()
+
+	require.True(t, started)
+	assert.Equal(t, 45*time.Minute, active)
+}
@@ -0,0 +1,296 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxAutomationKeyLength es la longitud máxima de la clave de un automatismo
+	MaxAutomationKeyLength = 64
+
+	// MaxAutomationDescriptionLength es la longitud máxima en bytes de la descripción de un automatismo
+	MaxAutomationDescriptionLength = 4096
+
+	// MaxTemplateSubtasks es el número máximo de subtareas, anidadas incluidas, de una plantilla
+	MaxTemplateSubtasks = 500
+
+	// DefaultMissedRunGracePeriod es el margen por defecto para que se cree la ejecución de una activación
+	DefaultMissedRunGracePeriod = 15 * time.Minute
+
+	// MaxMissedRunGracePeriod es el margen máximo que admite un automatismo
+	MaxMissedRunGracePeriod = 7 * 24 * time.Hour
+)
+
+// automationKeyRegex valida las claves: minúsculas, dígitos, '.', '_' y '-', empezando por alfanumérico
+var automationKeyRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// SubtaskTemplate describe una subtarea que se crea en cada ejecución de un automatismo
+type SubtaskTemplate struct {
+	Name      string
+	DependsOn []string          // Nombres de otras subtareas de la plantilla
+	Subtasks  []SubtaskTemplate // Subtareas anidadas bajo esta
+}
+
+// Automation es la definición de un proceso automatizado recurrente; cada Task es una ejecución suya
+// La clave es estable: identifica al automatismo en las ejecuciones y no se reutiliza aunque se elimine
+type Automation struct {
+	ID          uuid.UUID
+	Key         string
+	OwnerTeam   string
+	Description string
+
+	// SubtaskTemplate son las subtareas con las que se crean sus ejecuciones si no indican otras
+	SubtaskTemplate []SubtaskTemplate
+
+	// Schedule es la planificación con la que se espera que se ejecute; nil si se lanza bajo demanda
+	Schedule *Schedule
+
+	// GracePeriod es el margen tras cada activación en el que debe crearse su ejecución para no darla por perdida
+	GracePeriod time.Duration
+
+	// ScheduleCheckedUntil es la última activación ya revisada por el detector de ejecuciones perdidas
+	// Se reinicia al cambiar la planificación; solo se revisan las activaciones posteriores
+	ScheduleCheckedUntil time.Time
+
+	CreatedBy string
+	UpdatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+	Version   int // Versión de la fila para control de concurrencia optimista
+
+	// clock es el reloj del que el automatismo toma las fechas (ver SetClock)
+	clock Clock
+}
+
+// NewAutomation crea un automatismo con validaciones
+// Las fechas del automatismo se toman de clock; nil equivale a SystemClock
+func NewAutomation(key, ownerTeam, createdBy string, clock Clock) (*Automation, error) {
+	if err := ValidateAutomationKey(key); err != nil {
+		return nil, err
+	}
+	if createdBy == "" {
+		return nil, fmt.Errorf("%w: created_by is required", ErrMissingRequiredFields)
+	}
+	if len(createdBy) > 256 {
+		return nil, fmt.Errorf("%w: created_by exceeds 256 characters", ErrInvalidAutomation)
+	}
+
+	clock = clockOrSystem(clock)
+	now := clock.Now()
+	automation := &Automation{
+		ID:              uuid.New(),
+		Key:             key,
+		SubtaskTemplate: make([]SubtaskTemplate, 0),
+		GracePeriod:     DefaultMissedRunGracePeriod,
+		CreatedBy:       createdBy,
+		UpdatedBy:       createdBy,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		Version:         1,
+		clock:           clock,
+	}
+	if err := automation.SetOwnerTeam(ownerTeam); err != nil {
+		return nil, err
+	}
+	return automation, nil
+}
+
+// SetClock asigna el reloj del que el automatismo toma las fechas
+// Los automatismos leídos del repositorio usan SystemClock hasta que el caso de uso les asigna el suyo
+func (a *Automation) SetClock(clock Clock) {
+	a.clock = clock
+}
+
+// Now retorna el instante actual según el reloj del automatismo
+func (a *Automation) Now() time.Time {
+	return clockOrSystem(a.clock).Now()
+}
+
+// ValidateAutomationKey verifica que la clave de un automatismo tenga un formato válido
+func ValidateAutomationKey(key string) error {
+	if len(key) > MaxAutomationKeyLength || !automationKeyRegex.MatchString(key) {
+		return fmt.Errorf("%w: key %q must be 1-%d lowercase alphanumeric characters, '.', '_' or '-', "+
+			"starting with an alphanumeric character", ErrInvalidAutomation, key, MaxAutomationKeyLength)
+	}
+	return nil
+}
+
+// SetOwnerTeam asigna el equipo responsable, que es obligatorio
+func (a *Automation) SetOwnerTeam(ownerTeam string) error {
+	if ownerTeam == "" {
+		return fmt.Errorf("%w: owner_team is required", ErrMissingRequiredFields)
+	}
+	if len(ownerTeam) > 256 {
+		return fmt.Errorf("%w: owner_team exceeds 256 characters", ErrInvalidAutomation)
+	}
+	a.OwnerTeam = ownerTeam
+	return nil
+}
+
+// SetDescription asigna la descripción; vacía la elimina
+func (a *Automation) SetDescription(description string) error {
+	if len(description) > MaxAutomationDescriptionLength {
+		return fmt.Errorf("%w: description exceeds %d bytes", ErrInvalidAutomation, MaxAutomationDescriptionLength)
+	}
+	a.Description = description
+	return nil
+}
+
+// SetSubtaskTemplate valida y asigna la plantilla de subtareas; nil o vacía crea las ejecuciones sin subtareas
+func (a *Automation) SetSubtaskTemplate(template []SubtaskTemplate) error {
+	if err := ValidateSubtaskTemplate(template); err != nil {
+		return err
+	}
+	if template == nil {
+		template = make([]SubtaskTemplate, 0)
+	}
+	a.SubtaskTemplate = template
+	return nil
+}
+
+// SetSchedule asigna la planificación esperada; nil indica que el automatismo se lanza bajo demanda
+// Si la planificación cambia, las activaciones anteriores a este momento ya no se revisan
+func (a *Automation) SetSchedule(schedule *Schedule) {
+	if sameSchedule(a.Schedule, schedule) {
+		return
+	}
+	a.Schedule = schedule
+	a.ScheduleCheckedUntil = time.Time{}
+	if schedule != nil {
+		a.ScheduleCheckedUntil = a.Now()
+	}
+}
+
+// sameSchedule verifica si dos planificaciones tienen la misma expresión y zona horaria
+func sameSchedule(a, b *Schedule) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Expression == b.Expression && a.Timezone == b.Timezone
+}
+
+// SetGracePeriod asigna, en segundos, el margen para que se cree la ejecución de cada activación
+func (a *Automation) SetGracePeriod(seconds int) error {
+	gracePeriod := time.Duration(seconds) * time.Second
+	if seconds < 1 || gracePeriod > MaxMissedRunGracePeriod {
+		return fmt.Errorf("%w: grace_period must be between 1 and %d seconds",
+			ErrInvalidSchedule, int(MaxMissedRunGracePeriod.Seconds()))
+	}
+	a.GracePeriod = gracePeriod
+	return nil
+}
+
+// DueActivations retorna, en orden y como máximo limit, las activaciones posteriores a ScheduleCheckedUntil
+// cuyo margen ya venció en now; son las que el detector debe comprobar que tienen ejecución
+func (a *Automation) DueActivations(now time.Time, limit int) []time.Time {
+	due := make([]time.Time, 0)
+	if a.Schedule == nil || a.IsDeleted() {
+		return due
+	}
+
+	activation := a.Schedule.Next(a.ScheduleCheckedUntil)
+	for !activation.IsZero() && len(due) < limit && !activation.Add(a.GracePeriod).After(now) {
+		due = append(due, activation)
+		activation = a.Schedule.Next(activation)
+	}
+	return due
+}
+
+// Touch registra quién modificó el automatismo y cuándo
+func (a *Automation) Touch(updatedBy string) error {
+	if updatedBy == "" {
+		return fmt.Errorf("%w: updated_by is required", ErrMissingRequiredFields)
+	}
+	a.UpdatedBy = updatedBy
+	a.UpdatedAt = a.Now()
+	return nil
+}
+
+// IsDeleted verifica si el automatismo está eliminado
+func (a *Automation) IsDeleted() bool {
+	return a.DeletedAt != nil
+}
+
+// ValidateSubtaskTemplate valida una plantilla de subtareas con las mismas reglas que las subtareas de una
+// tarea: nombres válidos y dependencias que referencian sin ambigüedad otras subtareas y no forman ciclos
+func ValidateSubtaskTemplate(template []SubtaskTemplate) error {
+	if count := countTemplateSubtasks(template); count > MaxTemplateSubtasks {
+		return fmt.Errorf("%w: subtask template has %d subtasks, the maximum is %d",
+			ErrInvalidAutomation, count, MaxTemplateSubtasks)
+	}
+
+	// Instanciar la plantilla en una tarea de prueba para aplicar las reglas de las subtareas
+	scratch := &Task{Subtasks: make([]*Subtask, 0)}
+	dependsOn := make(map[*Subtask][]string)
+	var add func(parent *Subtask, items []SubtaskTemplate) error
+	add = func(parent *Subtask, items []SubtaskTemplate) error {
+		for _, item := range items {
+			subtask, err := NewSubtask(item.Name, SystemClock)
+			if err != nil {
+				return err
+			}
+			if parent == nil {
+				scratch.AddSubtask(subtask)
+			} else {
+				scratch.AddChildSubtask(parent, subtask)
+			}
+			dependsOn[subtask] = item.DependsOn
+			if err := add(subtask, item.Subtasks); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := add(nil, template); err != nil {
+		return err
+	}
+
+	for _, subtask := range scratch.Subtasks {
+		if len(dependsOn[subtask]) == 0 {
+			continue
+		}
+		if err := scratch.SetDependencies(subtask, dependsOn[subtask]); err != nil {
+			return err
+		}
+	}
+	return scratch.ValidateDependencies()
+}
+
+// countTemplateSubtasks cuenta las subtareas de la plantilla, anidadas incluidas
+func countTemplateSubtasks(template []SubtaskTemplate) int {
+	count := len(template)
+	for _, item := range template {
+		count += countTemplateSubtasks(item.Subtasks)
+	}
+	return count
+}
+
+// AutomationRunSummary agrupa las ejecuciones de un automatismo: cuántas hay en cada estado y cuál es la última
+type AutomationRunSummary struct {
+	Total   int
+	ByState map[State]int
+
+	// LastRun es la ejecución creada más recientemente; nil si no tiene ejecuciones
+	LastRun *AutomationRun
+
+	// Missed es el número de activaciones planificadas sin ejecución; LastMissedAt, la más reciente de ellas
+	Missed       int
+	LastMissedAt *time.Time
+}
+
+// AutomationRun identifica una ejecución de un automatismo en su resumen
+type AutomationRun struct {
+	TaskID    uuid.UUID
+	State     State
+	CreatedAt time.Time
+}
+
+// NewAutomationRunSummary crea el resumen de un automatismo sin ejecuciones
+func NewAutomationRunSummary() *AutomationRunSummary {
+	return &AutomationRunSummary{ByState: make(map[State]int)}
+}
@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAutomation(t *testing.T) {
+	automation, err := NewAutomation("billing.monthly-export", "team-finance", "alice", newTestClock())
+	require.NoError(t, err)
+	assert.Equal(t, "billing.monthly-export", automation.Key)
+	assert.Equal(t, "team-finance", automation.OwnerTeam)
+	assert.Equal(t, "alice", automation.UpdatedBy)
+	assert.Equal(t, 1, automation.Version)
+	assert.Empty(t, automation.SubtaskTemplate)
+	assert.Nil(t, automation.Schedule)
+	assert.Equal(t, DefaultMissedRunGracePeriod, automation.GracePeriod)
+
+	tests := []struct {
+		name      string
+		key       string
+		ownerTeam string
+		createdBy string
+		wantErr   error
+	}{
+		{name: "uppercase key", key: "Billing", ownerTeam: "team", createdBy: "alice", wantErr: ErrInvalidAutomation},
+		{name: "key starting with separator", key: "-billing", ownerTeam: "team", createdBy: "alice", wantErr: ErrInvalidAutomation},
+		{name: "key with spaces", key: "monthly export", ownerTeam: "team", createdBy: "alice", wantErr: ErrInvalidAutomation},
+		{name: "key too long", key: strings.Repeat("k", MaxAutomationKeyLength+1), ownerTeam: "team", createdBy: "alice", wantErr: ErrInvalidAutomation},
+		{name: "missing owner team", key: "billing", createdBy: "alice", wantErr: ErrMissingRequiredFields},
+		{name: "missing created_by", key: "billing", ownerTeam: "team", wantErr: ErrMissingRequiredFields},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAutomation(tt.key, tt.ownerTeam, tt.createdBy, newTestClock())
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAutomation_SetDescription(t *testing.T) {
+	automation, err := NewAutomation("billing", "team-finance", "alice", newTestClock())
+	require.NoError(t, err)
+
+	require.NoError(t, automation.SetDescription("Exports the invoices of the month"))
+	assert.Equal(t, "Exports the invoices of the month", automation.Description)
+
+	err = automation.SetDescription(strings.Repeat("x", MaxAutomationDescriptionLength+1))
+	assert.ErrorIs(t, err, ErrInvalidAutomation)
+	assert.Equal(t, "Exports the invoices of the month", automation.Description)
+}
+
+func TestAutomation_SetSchedule(t *testing.T) {
+	automation, err := NewAutomation("billing", "team-finance", "alice", newTestClock())
+	require.NoError(t, err)
+	assert.True(t, automation.ScheduleCheckedUntil.IsZero())
+
+	hourly, err := ParseSchedule("@hourly", "")
+	require.NoError(t, err)
+	automation.SetSchedule(hourly)
+	assert.False(t, automation.ScheduleCheckedUntil.IsZero())
+
+	// Repetir la misma planificación no reinicia las activaciones revisadas
+	checked := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	automation.ScheduleCheckedUntil = checked
+	sameHourly, err := ParseSchedule("0 * * * *", "")
+	require.NoError(t, err)
+	automation.SetSchedule(hourly)
+	assert.Equal(t, checked, automation.ScheduleCheckedUntil)
+
+	automation.SetSchedule(sameHourly)
+	assert.NotEqual(t, checked, automation.ScheduleCheckedUntil, "a different expression resets the cursor")
+
+	automation.SetSchedule(nil)
+	assert.Nil(t, automation.Schedule)
+	assert.True(t, automation.ScheduleCheckedUntil.IsZero())
+}
+
+func TestAutomation_SetGracePeriod(t *testing.T) {
+	automation, err := NewAutomation("billing", "team-finance", "alice", newTestClock())
+	require.NoError(t, err)
+
+	require.NoError(t, automation.SetGracePeriod(3600))
+	assert.Equal(t, time.Hour, automation.GracePeriod)
+
+	assert.ErrorIs(t, automation.SetGracePeriod(0), ErrInvalidSchedule)
+	assert.ErrorIs(t, automation.SetGracePeriod(int(MaxMissedRunGracePeriod.Seconds())+1), ErrInvalidSchedule)
+	assert.Equal(t, time.Hour, automation.GracePeriod)
+}
+
+func TestAutomation_DueActivations(t *testing.T) {
+	automation, err := NewAutomation("billing", "team-finance", "alice", newTestClock())
+	require.NoError(t, err)
+	assert.Empty(t, automation.DueActivations(testTime, 10), "without schedule nothing is due")
+
+	hourly, err := ParseSchedule("0 * * * *", "")
+	require.NoError(t, err)
+	automation.SetSchedule(hourly)
+
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	automation.ScheduleCheckedUntil = start
+	at := func(hour, minute int) time.Time {
+		return start.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	// El reloj avanza: cada activación vence al terminar su margen de 15 minutos
+	assert.Empty(t, automation.DueActivations(at(0, 30), 10))
+	assert.Empty(t, automation.DueActivations(at(1, 14), 10))
+	assert.Equal(t, []time.Time{at(1, 0)}, automation.DueActivations(at(1, 15), 10))
+	assert.Equal(t, []time.Time{at(1, 0), at(2, 0), at(3, 0)}, automation.DueActivations(at(3, 20), 10))
+	assert.Equal(t, []time.Time{at(1, 0), at(2, 0)}, automation.DueActivations(at(3, 20), 2))
+
+	// Las activaciones ya revisadas no se repiten
+	automation.ScheduleCheckedUntil = at(2, 0)
+	assert.Equal(t, []time.Time{at(3, 0)}, automation.DueActivations(at(3, 20), 10))
+
+	deletedAt := at(3, 0)
+	automation.DeletedAt = &deletedAt
+	assert.Empty(t, automation.DueActivations(at(5, 0), 10))
+}
+
+func TestValidateSubtaskTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template []SubtaskTemplate
+		wantErr  error
+	}{
+		{name: "nil"},
+		{
+			name: "nested with dependencies",
+			template: []SubtaskTemplate{
+				{Name: "Extract"},
+				{Name: "Load", DependsOn: []string{"Extract"}, Subtasks: []SubtaskTemplate{
+					{Name: "Load customers"},
+					{Name: "Load invoices", DependsOn: []string{"Load customers"}},
+				}},
+			},
+		},
+		{name: "invalid name", template: []SubtaskTemplate{{Name: "Extract!"}}, wantErr: ErrInvalidName},
+		{name: "unknown dependency", template: []SubtaskTemplate{{Name: "Load", DependsOn: []string{"Extract"}}}, wantErr: ErrInvalidDependency},
+		{
+			name:     "ambiguous dependency",
+			template: []SubtaskTemplate{{Name: "Step"}, {Name: "Step"}, {Name: "Report", DependsOn: []string{"Step"}}},
+			wantErr:  ErrInvalidDependency,
+		},
+		{
+			name:     "cycle",
+			template: []SubtaskTemplate{{Name: "A", DependsOn: []string{"B"}}, {Name: "B", DependsOn: []string{"A"}}},
+			wantErr:  ErrDependencyCycle,
+		},
+		{name: "too many subtasks", template: make([]SubtaskTemplate, MaxTemplateSubtasks+1), wantErr: ErrInvalidAutomation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSubtaskTemplate(tt.template)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// Clock proporciona el instante actual a las entidades y casos de uso
+// Las fechas de creación, inicio, fin, eliminación y actualización se toman del reloj, de forma que los tests
+// pueden fijarlas y avanzarlas con un reloj controlable
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapta una función a la interfaz Clock
+type ClockFunc func() time.Time
+
+// Now retorna el instante que indica la función
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock es el reloj del sistema
+// Lo usan las entidades creadas sin reloj, como las leídas del repositorio, hasta que se les asigna otro
+var SystemClock Clock = ClockFunc(time.Now)
+
+// clockOrSystem retorna clock, o SystemClock si es nil
+func clockOrSystem(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock
+	}
+	return clock
+}
+
+// FixedClock retorna un reloj detenido en t
+// Lo usan las pasadas periódicas, que evalúan y registran todos sus cambios en el mismo instante
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testTime es el instante en el que arrancan los relojes de los tests del paquete
+var testTime = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+// testClock es un reloj que solo avanza cuando el test lo indica
+// Los tests de otros paquetes usan helpers.FakeClock, que este paquete no puede importar
+type testClock struct {
+	now time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: testTime}
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func (c *testClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestClockOrSystem(t *testing.T) {
+	assert.WithinDuration(t, time.Now(), clockOrSystem(nil).Now(), time.Minute)
+
+	clock := FixedClock(testTime)
+	assert.Equal(t, testTime, clockOrSystem(clock).Now())
+}
+
+func TestEntities_TakeDatesFromClock(t *testing.T) {
+	clock := newTestClock()
+
+	task, err := NewTask("Import", "team-a", clock)
+	assert.NoError(t, err)
+	assert.Equal(t, testTime, task.CreatedAt)
+
+	// Las subtareas añadidas a la tarea pasan a usar su reloj
+	subtask, err := NewSubtask("Extract", nil)
+	assert.NoError(t, err)
+	task.AddSubtask(subtask)
+	clock.Advance(time.Minute)
+	subtask.SetStartDate()
+	assert.Equal(t, testTime.Add(time.Minute), *subtask.StartDate)
+
+	// Una tarea sin reloj, como las leídas del repositorio, usa SystemClock hasta que se le asigna otro
+	loaded := &Task{Subtasks: []*Subtask{{}}}
+	assert.WithinDuration(t, time.Now(), loaded.Now(), time.Minute)
+	loaded.SetClock(clock)
+	assert.Equal(t, clock.Now(), loaded.Now())
+	assert.Equal(t, clock.Now(), loaded.Subtasks[0].Now())
+}
@@ -0,0 +1,109 @@
+package entity
+
+import "fmt"
+
+// CompletionPolicy determina cómo el estado de las subtareas cierra automáticamente la tarea padre
+type CompletionPolicy string
+
+const (
+	// CompletionAllMustComplete completa la tarea cuando todas sus subtareas están COMPLETED
+	CompletionAllMustComplete CompletionPolicy = "all-must-complete"
+
+	// CompletionFailFast falla la tarea en cuanto una subtarea falla; la completa cuando todas están COMPLETED
+	CompletionFailFast CompletionPolicy = "fail-fast"
+
+	// CompletionAllFinal cierra la tarea cuando todas sus subtareas están en estado final:
+	// FAILED si alguna falló, COMPLETED en otro caso
+	CompletionAllFinal CompletionPolicy = "all-final"
+
+	// CompletionIgnoreCancelled completa la tarea cuando todas sus subtareas no canceladas están COMPLETED
+	// Si todas las subtareas se cancelan la tarea no se cierra automáticamente
+	CompletionIgnoreCancelled CompletionPolicy = "ignore-cancelled"
+)
+
+// DefaultCompletionPolicy es la política asignada a las tareas que no indican ninguna
+const DefaultCompletionPolicy = CompletionAllMustComplete
+
+// String retorna la representación en string de la política
+func (p CompletionPolicy) String() string {
+	return string(p)
+}
+
+// IsValid verifica si la política es una de las soportadas
+func (p CompletionPolicy) IsValid() bool {
+	switch p {
+	case CompletionAllMustComplete, CompletionFailFast, CompletionAllFinal, CompletionIgnoreCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseCompletionPolicy convierte un string a CompletionPolicy
+// Un string vacío se interpreta como DefaultCompletionPolicy
+func ParseCompletionPolicy(s string) (CompletionPolicy, error) {
+	if s == "" {
+		return DefaultCompletionPolicy, nil
+	}
+
+	policy := CompletionPolicy(s)
+	if !policy.IsValid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCompletionPolicy, s)
+	}
+	return policy, nil
+}
+
+// Outcome calcula el estado final que las subtareas determinan para la tarea padre
+// Las subtareas eliminadas no se consideran. Retorna false si la política aún no permite cerrar la tarea
+func (p CompletionPolicy) Outcome(subtasks []*Subtask) (State, bool) {
+	var total, completed, failed, cancelled int
+	for _, subtask := range subtasks {
+		if subtask.IsDeleted() {
+			continue
+		}
+		total++
+
+		switch subtask.State {
+		case StateCompleted:
+			completed++
+		case StateFailed:
+			failed++
+		case StateCancelled:
+			cancelled++
+		}
+	}
+
+	if total == 0 {
+		return "", false
+	}
+
+	switch p {
+	case CompletionFailFast:
+		if failed > 0 {
+			return StateFailed, true
+		}
+		if completed == total {
+			return StateCompleted, true
+		}
+
+	case CompletionAllFinal:
+		if completed+failed+cancelled == total {
+			if failed > 0 {
+				return StateFailed, true
+			}
+			return StateCompleted, true
+		}
+
+	case CompletionIgnoreCancelled:
+		if completed > 0 && completed+cancelled == total {
+			return StateCompleted, true
+		}
+
+	default:
+		if completed == total {
+			return StateCompleted, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subtasksInStates crea una subtarea por estado indicado
+func subtasksInStates(t *testing.T, states ...State) []*Subtask {
+	t.Helper()
+
+	subtasks := make([]*Subtask, 0, len(states))
+	for _, state := range states {
+		subtask, err := NewSubtask("Subtask", newTestClock())
+		require.NoError(t, err)
+		subtask.State = state
+		subtasks = append(subtasks, subtask)
+	}
+	return subtasks
+}
+
+func TestCompletionPolicy_Outcome(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      CompletionPolicy
+		states      []State
+		wantState   State
+		wantSettled bool
+	}{
+		// all-must-complete
+		{"all-must-complete: all completed", CompletionAllMustComplete, []State{StateCompleted, StateCompleted}, StateCompleted, true},
+		{"all-must-complete: one still running", CompletionAllMustComplete, []State{StateCompleted, StateInProgress}, "", false},
+		{"all-must-complete: failed subtask keeps parent open", CompletionAllMustComplete, []State{StateCompleted, StateFailed}, "", false},
+		{"all-must-complete: cancelled subtask keeps parent open", CompletionAllMustComplete, []State{StateCompleted, StateCancelled}, "", false},
+		{"all-must-complete: no subtasks", CompletionAllMustComplete, nil, "", false},
+
+		// fail-fast
+		{"fail-fast: first failure fails parent", CompletionFailFast, []State{StateFailed, StateInProgress, StatePending}, StateFailed, true},
+		{"fail-fast: all completed", CompletionFailFast, []State{StateCompleted, StateCompleted}, StateCompleted, true},
+		{"fail-fast: still running", CompletionFailFast, []State{StateCompleted, StatePaused}, "", false},
+		{"fail-fast: cancelled subtask keeps parent open", CompletionFailFast, []State{StateCompleted, StateCancelled}, "", false},
+
+		// all-final
+		{"all-final: all completed", CompletionAllFinal, []State{StateCompleted, StateCompleted}, StateCompleted, true},
+		{"all-final: any failed", CompletionAllFinal, []State{StateCompleted, StateFailed, StateCancelled}, StateFailed, true},
+		{"all-final: completed and cancelled", CompletionAllFinal, []State{StateCompleted, StateCancelled}, StateCompleted, true},
+		{"all-final: waits for running subtasks", CompletionAllFinal, []State{StateFailed, StateInProgress}, "", false},
+
+		// ignore-cancelled
+		{"ignore-cancelled: completed and cancelled", CompletionIgnoreCancelled, []State{StateCompleted, StateCancelled}, StateCompleted, true},
+		{"ignore-cancelled: all completed", CompletionIgnoreCancelled, []State{StateCompleted}, StateCompleted, true},
+		{"ignore-cancelled: all cancelled", CompletionIgnoreCancelled, []State{StateCancelled, StateCancelled}, "", false},
+		{"ignore-cancelled: failed subtask keeps parent open", CompletionIgnoreCancelled, []State{StateCompleted, StateFailed}, "", false},
+		{"ignore-cancelled: still running", CompletionIgnoreCancelled, []State{StateCancelled, StateInProgress}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, settled := tt.policy.Outcome(subtasksInStates(t, tt.states...))
+
+			assert.Equal(t, tt.wantSettled, settled)
+			assert.Equal(t, tt.wantState, state)
+		})
+	}
+}
+
+func TestCompletionPolicy_Outcome_IgnoresDeletedSubtasks(t *testing.T) {
+	subtasks := subtasksInStates(t, StateCompleted, StateInProgress)
+	subtasks[1].Delete()
+
+	state, settled := CompletionAllMustComplete.Outcome(subtasks)
+
+	assert.True(t, settled)
+	assert.Equal(t, StateCompleted, state)
+}
+
+func TestParseCompletionPolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    CompletionPolicy
+		wantErr bool
+	}{
+		{"", DefaultCompletionPolicy, false},
+		{"all-must-complete", CompletionAllMustComplete, false},
+		{"fail-fast", CompletionFailFast, false},
+		{"all-final", CompletionAllFinal, false},
+		{"ignore-cancelled", CompletionIgnoreCancelled, false},
+		{"FAIL-FAST", "", true},
+		{"first-wins", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			policy, err := ParseCompletionPolicy(tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCompletionPolicy)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, policy)
+		})
+	}
+}
+
+func TestTask_SettleFromSubtasks(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       CompletionPolicy
+		states       []State
+		wantSettled  bool
+		wantState    State
+		wantSubtasks []State
+	}{
+		{
+			name:         "fail-fast closes open siblings and keeps finished ones",
+			policy:       CompletionFailFast,
+			states:       []State{StateCompleted, StateFailed, StateInProgress},
+			wantSettled:  true,
+			wantState:    StateFailed,
+			wantSubtasks: []State{StateCompleted, StateFailed, StateFailed},
+		},
+		{
+			name:         "all-final keeps every subtask state",
+			policy:       CompletionAllFinal,
+			states:       []State{StateCompleted, StateFailed, StateCancelled},
+			wantSettled:  true,
+			wantState:    StateFailed,
+			wantSubtasks: []State{StateCompleted, StateFailed, StateCancelled},
+		},
+		{
+			name:         "not settled leaves the task untouched",
+			policy:       CompletionAllMustComplete,
+			states:       []State{StateCompleted, StateInProgress},
+			wantSettled:  false,
+			wantState:    StateInProgress,
+			wantSubtasks: []State{StateCompleted, StateInProgress},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := NewTask("Test Task", "test-user", newTestClock())
+			require.NoError(t, err)
+			require.NoError(t, task.UpdateState(StateInProgress, "test-user"))
+			task.CompletionPolicy = tt.policy
+			task.Subtasks = subtasksInStates(t, tt.states...)
+
+			settled, err := task.SettleFromSubtasks("worker")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSettled, settled)
+			assert.Equal(t, tt.wantState, task.State)
+			for i, subtask := range task.Subtasks {
+				assert.Equal(t, tt.wantSubtasks[i], subtask.State)
+			}
+			if tt.wantSettled {
+				assert.NotNil(t, task.EndDate)
+				assert.Equal(t, "worker", task.UpdatedBy)
+			}
+		})
+	}
+}
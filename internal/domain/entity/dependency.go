@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SetDependencies resuelve las referencias de depends_on de una subtarea contra las subtareas de la tarea
+// Cada referencia puede ser el ID o el nombre de otra subtarea no eliminada de la misma tarea.
+// Reemplaza las dependencias anteriores; los ciclos se comprueban después con ValidateDependencies
+func (t *Task) SetDependencies(subtask *Subtask, refs []string) error {
+	dependsOn := make([]uuid.UUID, 0, len(refs))
+	seen := make(map[uuid.UUID]bool, len(refs))
+
+	for _, ref := range refs {
+		dependency, err := t.resolveSubtaskRef(ref)
+		if err != nil {
+			return err
+		}
+		if dependency.ID == subtask.ID {
+			return fmt.Errorf("%w: subtask %q cannot depend on itself", ErrDependencyCycle, subtask.Name)
+		}
+		if seen[dependency.ID] {
+			continue
+		}
+		seen[dependency.ID] = true
+		dependsOn = append(dependsOn, dependency.ID)
+	}
+
+	subtask.DependsOn = dependsOn
+	return nil
+}
+
+// resolveSubtaskRef busca una subtarea no eliminada por ID o, si no es un ID de la tarea, por nombre
+// Un nombre compartido por varias subtareas es ambiguo y se rechaza
+func (t *Task) resolveSubtaskRef(ref string) (*Subtask, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		for _, subtask := range t.Subtasks {
+			if subtask.ID == id && !subtask.IsDeleted() {
+				return subtask, nil
+			}
+		}
+	}
+
+	var found *Subtask
+	for _, subtask := range t.Subtasks {
+		if subtask.IsDeleted() || subtask.Name != ref {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("%w: name %q matches more than one subtask, use its id", ErrInvalidDependency, ref)
+		}
+		found = subtask
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("%w: %q is not a subtask of this task", ErrInvalidDependency, ref)
+	}
+	return found, nil
+}
+
+// ValidateDependencies verifica que las dependencias entre subtareas formen un grafo acíclico
+// Las dependencias hacia subtareas eliminadas no se consideran
+func (t *Task) ValidateDependencies() error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	subtasks := t.activeSubtasksByID()
+	marks := make(map[uuid.UUID]int, len(subtasks))
+
+	var visit func(subtask *Subtask) error
+	visit = func(subtask *Subtask) error {
+		switch marks[subtask.ID] {
+		case visiting:
+			return fmt.Errorf("%w: subtask %q depends on itself through its dependencies", ErrDependencyCycle, subtask.Name)
+		case visited:
+			return nil
+		}
+
+		marks[subtask.ID] = visiting
+		for _, id := range subtask.DependsOn {
+			if dependency, ok := subtasks[id]; ok {
+				if err := visit(dependency); err != nil {
+					return err
+				}
+			}
+		}
+		marks[subtask.ID] = visited
+		return nil
+	}
+
+	// Recorrer en el orden de la tarea para que el error sea determinista
+	for _, subtask := range t.Subtasks {
+		if subtask.IsDeleted() {
+			continue
+		}
+		if err := visit(subtask); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmetDependencies retorna las dependencias de la subtarea que aún no están COMPLETED
+// Las dependencias hacia subtareas eliminadas se consideran satisfechas
+func (t *Task) UnmetDependencies(subtask *Subtask) []*Subtask {
+	subtasks := t.activeSubtasksByID()
+
+	unmet := make([]*Subtask, 0)
+	for _, id := range subtask.DependsOn {
+		if dependency, ok := subtasks[id]; ok && dependency.State != StateCompleted {
+			unmet = append(unmet, dependency)
+		}
+	}
+	return unmet
+}
+
+// ReadySubtasks retorna las subtareas PENDING que pueden arrancar: sus dependencias están COMPLETED
+// y tanto la tarea como sus subtareas padre admiten hijos en ejecución (IN_PROGRESS, o PENDING con
+// AutoStart y sus propias dependencias completadas)
+func (t *Task) ReadySubtasks() []*Subtask {
+	ready := make([]*Subtask, 0)
+	if t.State != StateInProgress && !t.AutoStartsWith(StateInProgress) {
+		return ready
+	}
+
+	for _, subtask := range t.Subtasks {
+		if subtask.IsDeleted() || subtask.State != StatePending {
+			continue
+		}
+		if len(t.UnmetDependencies(subtask)) == 0 && t.ancestorsAllowStart(subtask) {
+			ready = append(ready, subtask)
+		}
+	}
+	return ready
+}
+
+// ancestorsAllowStart indica si las subtareas padre de subtask permiten que arranque
+func (t *Task) ancestorsAllowStart(subtask *Subtask) bool {
+	for _, ancestor := range t.Ancestors(subtask) {
+		if ancestor.State == StateInProgress {
+			continue
+		}
+		if ancestor.State == StatePending && t.AutoStart && len(t.UnmetDependencies(ancestor)) == 0 {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// activeSubtasksByID indexa las subtareas no eliminadas de la tarea por su ID
+func (t *Task) activeSubtasksByID() map[uuid.UUID]*Subtask {
+	subtasks := make(map[uuid.UUID]*Subtask, len(t.Subtasks))
+	for _, subtask := range t.Subtasks {
+		if !subtask.IsDeleted() {
+			subtasks[subtask.ID] = subtask
+		}
+	}
+	return subtasks
+}
@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taskWithSubtasks crea una tarea con una subtarea PENDING por cada nombre
+func taskWithSubtasks(t *testing.T, names ...string) *Task {
+	t.Helper()
+
+	task, err := NewTask("Pipeline", "Team A", newTestClock())
+	require.NoError(t, err)
+	for _, name := range names {
+		subtask, err := NewSubtask(name, newTestClock())
+		require.NoError(t, err)
+		task.AddSubtask(subtask)
+	}
+	return task
+}
+
+func TestTask_SetDependencies(t *testing.T) {
+	t.Run("resolves names and ids", func(t *testing.T) {
+		task := taskWithSubtasks(t, "Extract", "Transform", "Load")
+		extract, transform, load := task.Subtasks[0], task.Subtasks[1], task.Subtasks[2]
+
+		err := task.SetDependencies(load, []string{"Extract", transform.ID.String(), "Extract"})
+		require.NoError(t, err)
+
+		// Las referencias repetidas se ignoran
+		assert.Equal(t, []uuid.UUID{extract.ID, transform.ID}, load.DependsOn)
+	})
+
+	t.Run("replaces previous dependencies", func(t *testing.T) {
+		task := taskWithSubtasks(t, "Extract", "Load")
+		load := task.Subtasks[1]
+
+		require.NoError(t, task.SetDependencies(load, []string{"Extract"}))
+		require.NoError(t, task.SetDependencies(load, []string{}))
+
+		assert.Empty(t, load.DependsOn)
+	})
+
+	t.Run("unknown subtask", func(t *testing.T) {
+		task := taskWithSubtasks(t, "Extract", "Load")
+
+		err := task.SetDependencies(task.Subtasks[1], []string{"Transform"})
+		assert.ErrorIs(t, err, ErrInvalidDependency)
+	})
+
+	t.Run("deleted subtask cannot be referenced", func(t *testing.T) {
+		task := taskWithSubtasks(t, "Extract", "Load")
+		task.Subtasks[0].Delete()
+
+		err := task.SetDependencies(task.Subtasks[1], []string{task.Subtasks[0].ID.String()})
+		assert.ErrorIs(t, err, ErrInvalidDependency)
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		task := taskWithSubtasks(t, "Extract", "Extract", "Load")
+
+		err := task.SetDependencies(task.Subtasks[2], []string{"Extract"})
+		assert.ErrorIs(t, err, ErrInvalidDependency)
+	})
+
+	t.Run("self dependency", func(t *testing.T) {
+		task := taskWithSubtasks(t, "Extract")
+
+		err := task.SetDependencies(task.Subtasks[0], []string{"Extract"})
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+	})
+}
+
+func TestTask_ValidateDependencies(t *testing.T) {
+	t.Run("acyclic graph", func(t *testing.T) {
+		task := taskWithSubtasks(t, "A", "B", "C", "D")
+		require.NoError(t, task.SetDependencies(task.Subtasks[1], []string{"A"}))
+		require.NoError(t, task.SetDependencies(task.Subtasks[2], []string{"A"}))
+		require.NoError(t, task.SetDependencies(task.Subtasks[3], []string{"B", "C"}))
+
+		assert.NoError(t, task.ValidateDependencies())
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		task := taskWithSubtasks(t, "A", "B", "C")
+		require.NoError(t, task.SetDependencies(task.Subtasks[0], []string{"C"}))
+		require.NoError(t, task.SetDependencies(task.Subtasks[1], []string{"A"}))
+		require.NoError(t, task.SetDependencies(task.Subtasks[2], []string{"B"}))
+
+		assert.ErrorIs(t, task.ValidateDependencies(), ErrDependencyCycle)
+	})
+
+	t.Run("deleted subtasks break cycles", func(t *testing.T) {
+		task := taskWithSubtasks(t, "A", "B")
+		require.NoError(t, task.SetDependencies(task.Subtasks[0], []string{"B"}))
+		require.NoError(t, task.SetDependencies(task.Subtasks[1], []string{"A"}))
+		task.Subtasks[1].Delete()
+
+		assert.NoError(t, task.ValidateDependencies())
+	})
+}
+
+func TestTask_ReadySubtasks(t *testing.T) {
+	task := taskWithSubtasks(t, "Extract", "Transform", "Load")
+	extract, transform, load := task.Subtasks[0], task.Subtasks[1], task.Subtasks[2]
+	require.NoError(t, task.SetDependencies(transform, []string{"Extract"}))
+	require.NoError(t, task.SetDependencies(load, []string{"Transform"}))
+
+	t.Run("nothing is ready while the task is pending", func(t *testing.T) {
+		assert.Empty(t, task.ReadySubtasks())
+	})
+
+	t.Run("pending task with auto start", func(t *testing.T) {
+		task.AutoStart = true
+		defer func() { task.AutoStart = false }()
+
+		assert.Equal(t, []*Subtask{extract}, task.ReadySubtasks())
+	})
+
+	require.NoError(t, task.UpdateState(StateInProgress, "Team A"))
+
+	t.Run("only subtasks without pending dependencies", func(t *testing.T) {
+		assert.Equal(t, []*Subtask{extract}, task.ReadySubtasks())
+		assert.Equal(t, []*Subtask{extract}, task.UnmetDependencies(transform))
+	})
+
+	t.Run("running subtasks are not ready", func(t *testing.T) {
+		extract.UpdateState(StateInProgress)
+
+		assert.Empty(t, task.ReadySubtasks())
+	})
+
+	t.Run("completing a dependency unblocks the next step", func(t *testing.T) {
+		extract.UpdateState(StateCompleted)
+
+		assert.Equal(t, []*Subtask{transform}, task.ReadySubtasks())
+		assert.Empty(t, task.UnmetDependencies(transform))
+	})
+
+	t.Run("deleted dependencies are satisfied", func(t *testing.T) {
+		transform.Delete()
+
+		assert.Equal(t, []*Subtask{load}, task.ReadySubtasks())
+	})
+}
@@ -19,6 +19,97 @@ var (
 	// ErrSubtaskNotFound indica que la subtarea no existe o fue eliminada
 	ErrSubtaskNotFound = errors.New("subtask not found or deleted")
 
+	// ErrVersionConflict indica que la versión esperada no coincide con la versión actual del recurso
+	ErrVersionConflict = errors.New("resource version mismatch")
+
+	// ErrInvalidIdempotencyKey indica que la cabecera Idempotency-Key no tiene un formato válido
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be between 1 and 255 printable characters")
+
+	// ErrIdempotencyKeyReused indica que la clave de idempotencia ya se usó con una petición diferente
+	ErrIdempotencyKeyReused = errors.New("idempotency key already used with a different request")
+
+	// ErrInvalidWorkflow indica que el nombre del workflow no tiene un formato válido
+	ErrInvalidWorkflow = errors.New("workflow must be 1-64 lowercase alphanumeric characters, hyphens, or underscores")
+
+	// ErrUnknownWorkflow indica que el workflow solicitado no está configurado
+	ErrUnknownWorkflow = errors.New("unknown workflow")
+
+	// ErrInvalidCompletionPolicy indica que la política de finalización no es una de las soportadas
+	ErrInvalidCompletionPolicy = errors.New("completion policy must be one of all-must-complete, fail-fast, all-final, ignore-cancelled")
+
+	// ErrTaskNotRetryable indica que la tarea no está en un estado que permita reintentarla
+	ErrTaskNotRetryable = errors.New("task cannot be retried")
+
+	// ErrInvalidProgress indica que el progreso o los contadores de elementos están fuera de rango
+	ErrInvalidProgress = errors.New("progress must be between 0 and 100 and item counters non-negative, with items_processed not exceeding items_total")
+
+	// ErrInvalidHeartbeatTimeout indica que el plazo sin latidos no es un número válido de segundos
+	ErrInvalidHeartbeatTimeout = errors.New("heartbeat_timeout must be a whole number of seconds between 1 and 604800, or 0 to disable it")
+
+	// ErrTaskNotActive indica que la tarea ya está en un estado final y no admite señales de su proceso
+	ErrTaskNotActive = errors.New("task is no longer active")
+
+	// ErrInvalidSLA indica que la duración esperada, la fecha límite o el estado de SLA no son válidos
+	ErrInvalidSLA = errors.New("invalid SLA")
+
+	// ErrInvalidFailure indica que los detalles del fallo no son válidos o acompañan a un estado distinto de FAILED
+	ErrInvalidFailure = errors.New("invalid failure details")
+
+	// ErrInvalidLogLine indica que una línea de log o el envío que la contiene no son válidos
+	ErrInvalidLogLine = errors.New("invalid log line")
+
+	// ErrInvalidLogQuery indica que los filtros o el cursor de la consulta de logs no son válidos
+	ErrInvalidLogQuery = errors.New("invalid log query")
+
+	// ErrLogLimitExceeded indica que el envío superaría el máximo de líneas de log guardadas por tarea
+	ErrLogLimitExceeded = errors.New("task log limit exceeded")
+
+	// ErrInvalidLabels indica que las etiquetas o el selector de etiquetas no son válidos
+	ErrInvalidLabels = errors.New("invalid labels")
+
+	// ErrInvalidMetadata indica que los metadatos no son un objeto JSON válido dentro del tamaño permitido
+	ErrInvalidMetadata = errors.New("invalid metadata")
+
+	// ErrInvalidPayload indica que un documento input u output no es JSON válido, supera el tamaño
+	// permitido o acompaña a un estado que no lo admite
+	ErrInvalidPayload = errors.New("invalid payload")
+
+	// ErrArtifactNotFound indica que el artefacto no existe o no pertenece a la tarea
+	ErrArtifactNotFound = errors.New("artifact not found")
+
+	// ErrInvalidArtifact indica que el fichero subido o sus datos (nombre, tipo de contenido) no son válidos
+	ErrInvalidArtifact = errors.New("invalid artifact")
+
+	// ErrArtifactTooLarge indica que el fichero subido supera el tamaño máximo de los artefactos
+	ErrArtifactTooLarge = errors.New("artifact too large")
+
+	// ErrBlobNotFound indica que el almacén de blobs no tiene contenido para la clave pedida
+	ErrBlobNotFound = errors.New("blob not found")
+
+	// ErrAutomationNotFound indica que el automatismo no existe o fue eliminado
+	ErrAutomationNotFound = errors.New("automation not found or deleted")
+
+	// ErrAutomationExists indica que ya existe, o existió, un automatismo con la misma clave
+	ErrAutomationExists = errors.New("automation key already in use")
+
+	// ErrInvalidAutomation indica que los datos del automatismo o su plantilla de subtareas no son válidos
+	ErrInvalidAutomation = errors.New("invalid automation")
+
+	// ErrInvalidSchedule indica que la expresión cron o la zona horaria de la planificación no son válidas
+	ErrInvalidSchedule = errors.New("invalid schedule")
+
+	// ErrInvalidSubtaskParent indica que la subtarea padre indicada no es válida para la jerarquía
+	ErrInvalidSubtaskParent = errors.New("invalid parent subtask")
+
+	// ErrInvalidDependency indica que una dependencia no referencia una subtarea de la misma tarea
+	ErrInvalidDependency = errors.New("invalid subtask dependency")
+
+	// ErrDependencyCycle indica que las dependencias entre subtareas forman un ciclo
+	ErrDependencyCycle = errors.New("subtask dependencies form a cycle")
+
+	// ErrDependenciesNotMet indica que una subtarea no puede arrancar porque alguna dependencia no está COMPLETED
+	ErrDependenciesNotMet = errors.New("subtask dependencies are not completed")
+
 	// ErrMissingRequiredFields indica que faltan campos requeridos
 	ErrMissingRequiredFields = errors.New("missing required fields")
 
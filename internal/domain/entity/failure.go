@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// MaxFailureMessageLength es la longitud máxima del mensaje de error de un fallo
+	MaxFailureMessageLength = 4096
+
+	// MaxFailureDetailsBytes es el tamaño máximo de los detalles de un fallo serializados como JSON (64 KiB)
+	MaxFailureDetailsBytes = 64 * 1024
+)
+
+// failureCodeRegex valida los códigos de error: 1-64 caracteres alfanuméricos, '_', '.', ':' o '-'
+var failureCodeRegex = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,64}$`)
+
+// Failure describe el motivo por el que una tarea o subtarea pasó a FAILED
+type Failure struct {
+	Code    string      // Código de error estable, apto para búsquedas (p. ej. HTTP_TIMEOUT)
+	Message string      // Descripción legible del error
+	Details interface{} // Valor JSON adicional: contexto, traza de la pila... (opcional, nil si no hay)
+}
+
+// Validate valida el código, el mensaje y el tamaño de los detalles del fallo
+func (f *Failure) Validate() error {
+	if !failureCodeRegex.MatchString(f.Code) {
+		return fmt.Errorf("%w: error_code must be 1-64 alphanumeric characters, '_', '.', ':' or '-'", ErrInvalidFailure)
+	}
+	if f.Message == "" {
+		return fmt.Errorf("%w: error_message is required", ErrInvalidFailure)
+	}
+	if len(f.Message) > MaxFailureMessageLength {
+		return fmt.Errorf("%w: error_message exceeds %d characters", ErrInvalidFailure, MaxFailureMessageLength)
+	}
+	if f.Details != nil {
+		encoded, err := json.Marshal(f.Details)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFailure, err)
+		}
+		if len(encoded) > MaxFailureDetailsBytes {
+			return fmt.Errorf("%w: details exceed %d bytes", ErrInvalidFailure, MaxFailureDetailsBytes)
+		}
+	}
+	return nil
+}
+
+// validateFailureFor comprueba que un fallo solo acompañe a un paso a FAILED y que sea válido
+func validateFailureFor(newState State, failure *Failure) error {
+	if failure == nil {
+		return nil
+	}
+	if newState != StateFailed {
+		return fmt.Errorf("%w: failure details can only be provided when moving to %s, got %s",
+			ErrInvalidFailure, StateFailed, newState)
+	}
+	return failure.Validate()
+}
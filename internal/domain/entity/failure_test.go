@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailure_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure Failure
+		wantErr bool
+	}{
+		{name: "code and message", failure: Failure{Code: "HTTP_TIMEOUT", Message: "upstream timed out"}},
+		{name: "namespaced code", failure: Failure{Code: "db.conn:refused-1", Message: "refused"}},
+		{name: "with details", failure: Failure{Code: "E1", Message: "boom", Details: map[string]interface{}{"stack": "main.go:12"}}},
+		{name: "string details", failure: Failure{Code: "E1", Message: "boom", Details: "goroutine 1 [running]"}},
+		{name: "missing code", failure: Failure{Message: "boom"}, wantErr: true},
+		{name: "invalid code", failure: Failure{Code: "HTTP TIMEOUT", Message: "boom"}, wantErr: true},
+		{name: "code too long", failure: Failure{Code: strings.Repeat("E", 65), Message: "boom"}, wantErr: true},
+		{name: "missing message", failure: Failure{Code: "E1"}, wantErr: true},
+		{name: "message too long", failure: Failure{Code: "E1", Message: strings.Repeat("x", MaxFailureMessageLength+1)}, wantErr: true},
+		{name: "details too large", failure: Failure{Code: "E1", Message: "boom", Details: strings.Repeat("x", MaxFailureDetailsBytes)}, wantErr: true},
+		{name: "details not serializable", failure: Failure{Code: "E1", Message: "boom", Details: func() {}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.failure.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidFailure)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTask_UpdateStateWithFailure(t *testing.T) {
+	failure := &Failure{Code: "HTTP_TIMEOUT", Message: "upstream timed out"}
+
+	t.Run("records the failure when moving to FAILED", func(t *testing.T) {
+		task := taskWithSubtasks(t)
+		require.NoError(t, task.UpdateState(StateInProgress, "team-a"))
+
+		require.NoError(t, task.UpdateStateWithFailure(StateFailed, "team-a", failure))
+		assert.Equal(t, StateFailed, task.State)
+		assert.Equal(t, failure, task.Failure)
+	})
+
+	t.Run("rejects a failure on other transitions", func(t *testing.T) {
+		task := taskWithSubtasks(t)
+
+		err := task.UpdateStateWithFailure(StateInProgress, "team-a", failure)
+		assert.ErrorIs(t, err, ErrInvalidFailure)
+		assert.Equal(t, StatePending, task.State)
+		assert.Nil(t, task.Failure)
+	})
+
+	t.Run("rejects an invalid failure without changing state", func(t *testing.T) {
+		task := taskWithSubtasks(t)
+		require.NoError(t, task.UpdateState(StateInProgress, "team-a"))
+
+		err := task.UpdateStateWithFailure(StateFailed, "team-a", &Failure{Code: "HTTP_TIMEOUT"})
+		assert.ErrorIs(t, err, ErrInvalidFailure)
+		assert.Equal(t, StateInProgress, task.State)
+	})
+
+	t.Run("failure is optional", func(t *testing.T) {
+		task := taskWithSubtasks(t)
+		require.NoError(t, task.UpdateStateWithFailure(StateFailed, "team-a", nil))
+		assert.Nil(t, task.Failure)
+	})
+
+	t.Run("retry moves the failure to the closed attempt", func(t *testing.T) {
+		task := taskWithSubtasks(t, "Extract")
+		extract := task.Subtasks[0]
+		subtaskFailure := &Failure{Code: "ROW_REJECTED", Message: "row 42 rejected"}
+		require.NoError(t, extract.UpdateStateWithFailure(StateFailed, subtaskFailure))
+		require.NoError(t, task.UpdateStateWithFailure(StateFailed, "team-a", failure))
+
+		attempt, err := task.Retry("team-a")
+		require.NoError(t, err)
+		assert.Equal(t, failure, attempt.Failure)
+		require.Len(t, attempt.Subtasks, 1)
+		assert.Equal(t, subtaskFailure, attempt.Subtasks[0].Failure)
+
+		// El nuevo intento empieza sin motivo de fallo
+		assert.Nil(t, task.Failure)
+		assert.Nil(t, extract.Failure)
+	})
+}
+
+func TestSubtask_UpdateStateWithFailure(t *testing.T) {
+	failure := &Failure{Code: "ROW_REJECTED", Message: "row 42 rejected", Details: map[string]interface{}{"row": 42}}
+
+	t.Run("records the failure and keeps it on propagation", func(t *testing.T) {
+		task := taskWithSubtasks(t, "Extract", "Load")
+		extract := task.Subtasks[0]
+
+		require.NoError(t, extract.UpdateStateWithFailure(StateFailed, failure))
+		assert.Equal(t, failure, extract.Failure)
+
+		// La tarea falla a su vez y propaga FAILED a sus subtareas: la subtarea conserva su motivo
+		require.NoError(t, task.UpdateState(StateFailed, "team-a"))
+		assert.Equal(t, failure, extract.Failure)
+		assert.Nil(t, task.Subtasks[1].Failure)
+	})
+
+	t.Run("rejects a failure on other transitions", func(t *testing.T) {
+		subtask, err := NewSubtask("Extract", newTestClock())
+		require.NoError(t, err)
+
+		err = subtask.UpdateStateWithFailure(StateCompleted, failure)
+		assert.ErrorIs(t, err, ErrInvalidFailure)
+		assert.Equal(t, StatePending, subtask.State)
+	})
+
+	t.Run("leaving FAILED clears the failure", func(t *testing.T) {
+		subtask, err := NewSubtask("Extract", newTestClock())
+		require.NoError(t, err)
+		require.NoError(t, subtask.UpdateStateWithFailure(StateFailed, failure))
+
+		subtask.UpdateState(StateCancelled)
+		assert.Nil(t, subtask.Failure)
+	})
+}
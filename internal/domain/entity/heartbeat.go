@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// SystemActor es el actor que figura en los cambios que la API aplica por sí misma, sin petición de un cliente
+const SystemActor = "system"
+
+// MaxHeartbeatTimeoutSeconds es el mayor plazo sin latidos que puede configurarse en una tarea (7 días)
+const MaxHeartbeatTimeoutSeconds = 7 * 24 * 60 * 60
+
+// HeartbeatTracking guarda los latidos con los que el proceso de una tarea indica que sigue vivo
+type HeartbeatTracking struct {
+	LastHeartbeatAt  *time.Time     // Último latido recibido, nil si no ha enviado ninguno
+	HeartbeatTimeout *time.Duration // Plazo máximo sin señales de vida, nil si la tarea no se supervisa
+}
+
+// SetHeartbeatTimeout configura en segundos el plazo sin latidos tras el que la tarea se considera
+// abandonada. Un plazo de cero desactiva la supervisión
+func (h *HeartbeatTracking) SetHeartbeatTimeout(seconds int) error {
+	if seconds == 0 {
+		h.HeartbeatTimeout = nil
+		return nil
+	}
+	if seconds < 0 || seconds > MaxHeartbeatTimeoutSeconds {
+		return fmt.Errorf("%w: got %d", ErrInvalidHeartbeatTimeout, seconds)
+	}
+	timeout := time.Duration(seconds) * time.Second
+	h.HeartbeatTimeout = &timeout
+	return nil
+}
+
+// RecordHeartbeat registra un latido del proceso de la tarea
+// Las tareas en estado final ya no aceptan latidos: su proceso debe dejar de ejecutarse
+func (t *Task) RecordHeartbeat(now time.Time) error {
+	if t.State.IsFinal() {
+		return fmt.Errorf("%w: task is %s", ErrTaskNotActive, t.State)
+	}
+	t.LastHeartbeatAt = &now
+	return nil
+}
+
+// LastSeenAt retorna la última señal de vida de la tarea: su último latido o, si es posterior,
+// su última modificación (arrancar o reanudar la tarea también cuenta como señal de vida)
+func (t *Task) LastSeenAt() time.Time {
+	if t.LastHeartbeatAt != nil && t.LastHeartbeatAt.After(t.UpdatedAt) {
+		return *t.LastHeartbeatAt
+	}
+	return t.UpdatedAt
+}
+
+// HeartbeatExpired indica si la tarea sigue en ejecución (IN_PROGRESS o RETRYING) sin señales de vida
+// desde hace más de su HeartbeatTimeout. Las tareas sin plazo configurado nunca expiran
+func (t *Task) HeartbeatExpired(now time.Time) bool {
+	if t.HeartbeatTimeout == nil || (t.State != StateInProgress && t.State != StateRetrying) {
+		return false
+	}
+	return now.Sub(t.LastSeenAt()) > *t.HeartbeatTimeout
+}
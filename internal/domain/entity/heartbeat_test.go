@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestHeartbeatTracking_SetHeartbeatTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds int
+		want    *time.Duration
+		wantErr bool
+	}{
+		{name: "minimum", seconds: 1, want: durationPtr(time.Second)},
+		{name: "maximum", seconds: MaxHeartbeatTimeoutSeconds, want: durationPtr(7 * 24 * time.Hour)},
+		{name: "zero disables supervision", seconds: 0},
+		{name: "negative", seconds: -1, wantErr: true},
+		{name: "above maximum", seconds: MaxHeartbeatTimeoutSeconds + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := HeartbeatTracking{HeartbeatTimeout: durationPtr(time.Minute)}
+
+			err := h.SetHeartbeatTimeout(tt.seconds)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidHeartbeatTimeout)
+				assert.Equal(t, time.Minute, *h.HeartbeatTimeout)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, h.HeartbeatTimeout)
+		})
+	}
+}
+
+func TestTask_RecordHeartbeat(t *testing.T) {
+	task, err := NewTask("Import", "team-a", newTestClock())
+	require.NoError(t, err)
+	now := testTime.Add(time.Minute)
+
+	require.NoError(t, task.RecordHeartbeat(now))
+	assert.Equal(t, now, *task.LastHeartbeatAt)
+
+	// Una tarea en estado final ya no acepta latidos
+	require.NoError(t, task.UpdateState(StateCancelled, "team-a"))
+	err = task.RecordHeartbeat(now.Add(time.Second))
+	assert.ErrorIs(t, err, ErrTaskNotActive)
+	assert.Equal(t, now, *task.LastHeartbeatAt)
+}
+
+func TestTask_HeartbeatExpired(t *testing.T) {
+	supervisedTask := func(t *testing.T) (*Task, time.Time) {
+		task, err := NewTask("Import", "team-a", newTestClock())
+		require.NoError(t, err)
+		require.NoError(t, task.SetHeartbeatTimeout(60))
+		require.NoError(t, task.UpdateState(StateInProgress, "team-a"))
+		return task, task.UpdatedAt
+	}
+
+	t.Run("measured from the last change without heartbeats", func(t *testing.T) {
+		task, startedAt := supervisedTask(t)
+
+		assert.False(t, task.HeartbeatExpired(startedAt.Add(time.Minute)))
+		assert.True(t, task.HeartbeatExpired(startedAt.Add(time.Minute+time.Second)))
+	})
+
+	t.Run("heartbeats extend the deadline", func(t *testing.T) {
+		task, startedAt := supervisedTask(t)
+		require.NoError(t, task.RecordHeartbeat(startedAt.Add(50*time.Second)))
+
+		assert.False(t, task.HeartbeatExpired(startedAt.Add(100*time.Second)))
+		assert.True(t, task.HeartbeatExpired(startedAt.Add(111*time.Second)))
+	})
+
+	t.Run("paused tasks never expire", func(t *testing.T) {
+		task, startedAt := supervisedTask(t)
+		require.NoError(t, task.UpdateState(StatePaused, "team-a"))
+
+		assert.False(t, task.HeartbeatExpired(startedAt.Add(time.Hour)))
+	})
+
+	t.Run("retrying tasks are still supervised", func(t *testing.T) {
+		task, startedAt := supervisedTask(t)
+		require.NoError(t, task.UpdateState(StateRetrying, "team-a"))
+
+		assert.True(t, task.HeartbeatExpired(startedAt.Add(time.Hour)))
+	})
+
+	t.Run("unsupervised tasks never expire", func(t *testing.T) {
+		task, startedAt := supervisedTask(t)
+		require.NoError(t, task.SetHeartbeatTimeout(0))
+
+		assert.False(t, task.HeartbeatExpired(startedAt.Add(time.Hour)))
+	})
+}
+
+func TestTask_RetryClearsHeartbeat(t *testing.T) {
+	task, err := NewTask("Import", "team-a", newTestClock())
+	require.NoError(t, err)
+	require.NoError(t, task.SetHeartbeatTimeout(30))
+	require.NoError(t, task.UpdateState(StateInProgress, "team-a"))
+	require.NoError(t, task.RecordHeartbeat(testTime))
+	require.NoError(t, task.UpdateState(StateFailed, SystemActor))
+
+	_, err = task.Retry("team-a")
+	require.NoError(t, err)
+
+	assert.Nil(t, task.LastHeartbeatAt)
+	assert.Equal(t, 30*time.Second, *task.HeartbeatTimeout)
+}
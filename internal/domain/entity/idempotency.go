@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxIdempotencyKeyLength es la longitud máxima permitida para una clave de idempotencia
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyRecord representa una clave de idempotencia asociada a la creación de una tarea
+// Guarda la respuesta original para devolverla tal cual cuando el cliente reintenta la petición
+type IdempotencyRecord struct {
+	Key          string
+	CreatedBy    string    // Las claves son únicas por creador, no globales
+	RequestHash  string    // Huella SHA-256 de la petición original
+	TaskID       uuid.UUID // Tarea creada por la petición original
+	StatusCode   int
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// MatchesRequest indica si la huella de una petición coincide con la de la petición original
+func (r *IdempotencyRecord) MatchesRequest(requestHash string) bool {
+	return r.RequestHash == requestHash
+}
+
+// ValidateIdempotencyKey valida el formato de una clave de idempotencia
+// Debe tener entre 1 y 255 caracteres ASCII imprimibles
+func ValidateIdempotencyKey(key string) error {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return ErrInvalidIdempotencyKey
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return ErrInvalidIdempotencyKey
+		}
+	}
+
+	return nil
+}
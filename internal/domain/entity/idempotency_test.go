@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{
+			name:    "uuid key",
+			key:     "0b6a4c1e-7a53-4a4f-9a0e-4f5a2b1c9d10",
+			wantErr: false,
+		},
+		{
+			name:    "maximum length",
+			key:     strings.Repeat("k", MaxIdempotencyKeyLength),
+			wantErr: false,
+		},
+		{
+			name:    "empty key",
+			key:     "",
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			key:     strings.Repeat("k", MaxIdempotencyKeyLength+1),
+			wantErr: true,
+		},
+		{
+			name:    "control characters",
+			key:     "retry\n1",
+			wantErr: true,
+		},
+		{
+			name:    "non ascii",
+			key:     "reintentó",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIdempotencyKey(tt.key)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidIdempotencyKey)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIdempotencyRecord_MatchesRequest(t *testing.T) {
+	record := &IdempotencyRecord{RequestHash: "abc"}
+
+	assert.True(t, record.MatchesRequest("abc"))
+	assert.False(t, record.MatchesRequest("def"))
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaxLabels es el número máximo de etiquetas de una tarea
+	MaxLabels = 64
+
+	// MaxLabelKeyLength es la longitud máxima de la clave de una etiqueta
+	MaxLabelKeyLength = 63
+
+	// MaxLabelValueLength es la longitud máxima del valor de una etiqueta
+	MaxLabelValueLength = 256
+
+	// MaxMetadataBytes es el tamaño máximo de los metadatos de una tarea serializados como JSON (64 KiB)
+	MaxMetadataBytes = 64 * 1024
+)
+
+// labelKeyRegex valida las claves: minúsculas, dígitos, '.', '_', '/' y '-', empezando y terminando en alfanumérico
+var labelKeyRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9._/-]*[a-z0-9])?$`)
+
+// labelValueRegex valida los valores; excluye ',' y '=' para que cualquier etiqueta pueda usarse en un selector
+var labelValueRegex = regexp.MustCompile(`^[a-zA-Z0-9 ._:/@-]*$`)
+
+// ValidateLabels valida las claves, los valores y el número de etiquetas
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("%w: at most %d labels are allowed, got %d", ErrInvalidLabels, MaxLabels, len(labels))
+	}
+	for key, value := range labels {
+		if err := validateLabel(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLabel valida una etiqueta
+func validateLabel(key, value string) error {
+	if len(key) > MaxLabelKeyLength || !labelKeyRegex.MatchString(key) {
+		return fmt.Errorf("%w: key %q must be 1-%d lowercase alphanumeric characters, '.', '_', '/' or '-', "+
+			"starting and ending with an alphanumeric character", ErrInvalidLabels, key, MaxLabelKeyLength)
+	}
+	if len(value) > MaxLabelValueLength || !labelValueRegex.MatchString(value) {
+		return fmt.Errorf("%w: value of %q must be at most %d alphanumeric characters, spaces, '.', '_', ':', '/', '@' or '-'",
+			ErrInvalidLabels, key, MaxLabelValueLength)
+	}
+	return nil
+}
+
+// ValidateMetadata valida que los metadatos puedan guardarse como objeto JSON dentro de MaxMetadataBytes
+func ValidateMetadata(metadata map[string]interface{}) error {
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
+	}
+	if len(encoded) > MaxMetadataBytes {
+		return fmt.Errorf("%w: metadata exceeds %d bytes", ErrInvalidMetadata, MaxMetadataBytes)
+	}
+	return nil
+}
+
+// SetLabels reemplaza las etiquetas de la tarea; nil o un mapa vacío las elimina
+func (t *Task) SetLabels(labels map[string]string) error {
+	if err := ValidateLabels(labels); err != nil {
+		return err
+	}
+	t.Labels = labels
+	return nil
+}
+
+// SetMetadata reemplaza los metadatos de la tarea; nil o un objeto vacío los elimina
+func (t *Task) SetMetadata(metadata map[string]interface{}) error {
+	if err := ValidateMetadata(metadata); err != nil {
+		return err
+	}
+	t.Metadata = metadata
+	return nil
+}
+
+// ParseLabelSelector convierte un selector de la forma "env=prod,team=finance" en las etiquetas que
+// deben tener las tareas seleccionadas (todas ellas, con esos valores)
+func ParseLabelSelector(selector string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, requirement := range strings.Split(selector, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(requirement), "=")
+		if !found {
+			return nil, fmt.Errorf("%w: selector requirement %q must have the form key=value", ErrInvalidLabels, requirement)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if err := validateLabel(key, value); err != nil {
+			return nil, err
+		}
+		if previous, ok := labels[key]; ok && previous != value {
+			return nil, fmt.Errorf("%w: selector requires %q to be both %q and %q", ErrInvalidLabels, key, previous, value)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLabels(t *testing.T) {
+	tooMany := make(map[string]string, MaxLabels+1)
+	for i := 0; i <= MaxLabels; i++ {
+		tooMany[fmt.Sprintf("key-%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr bool
+	}{
+		{name: "nil", labels: nil},
+		{name: "valid", labels: map[string]string{"env": "prod", "app.kubernetes.io/name": "billing", "owner": "ops@grupoapi.com"}},
+		{name: "empty value", labels: map[string]string{"canary": ""}},
+		{name: "empty key", labels: map[string]string{"": "prod"}, wantErr: true},
+		{name: "uppercase key", labels: map[string]string{"Env": "prod"}, wantErr: true},
+		{name: "key ending in separator", labels: map[string]string{"env-": "prod"}, wantErr: true},
+		{name: "key too long", labels: map[string]string{strings.Repeat("k", MaxLabelKeyLength+1): "v"}, wantErr: true},
+		{name: "value with comma", labels: map[string]string{"team": "finance,ops"}, wantErr: true},
+		{name: "value with equals", labels: map[string]string{"team": "a=b"}, wantErr: true},
+		{name: "value too long", labels: map[string]string{"team": strings.Repeat("v", MaxLabelValueLength+1)}, wantErr: true},
+		{name: "too many labels", labels: tooMany, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLabels(tt.labels)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLabels)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTask_SetMetadata(t *testing.T) {
+	task, err := NewTask("Import", "team-a", newTestClock())
+	require.NoError(t, err)
+
+	metadata := map[string]interface{}{"host": "etl-01", "batch": map[string]interface{}{"size": float64(500)}}
+	require.NoError(t, task.SetMetadata(metadata))
+	assert.Equal(t, metadata, task.Metadata)
+
+	// Unos metadatos demasiado grandes no reemplazan los actuales
+	err = task.SetMetadata(map[string]interface{}{"blob": strings.Repeat("x", MaxMetadataBytes)})
+	assert.ErrorIs(t, err, ErrInvalidMetadata)
+	assert.Equal(t, metadata, task.Metadata)
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{name: "single", selector: "env=prod", want: map[string]string{"env": "prod"}},
+		{name: "multiple with spaces", selector: "env=prod, team = finance", want: map[string]string{"env": "prod", "team": "finance"}},
+		{name: "empty value", selector: "canary=", want: map[string]string{"canary": ""}},
+		{name: "repeated with same value", selector: "env=prod,env=prod", want: map[string]string{"env": "prod"}},
+		{name: "missing value", selector: "env", wantErr: true},
+		{name: "empty requirement", selector: "env=prod,", wantErr: true},
+		{name: "invalid key", selector: "Env=prod", wantErr: true},
+		{name: "conflicting values", selector: "env=prod,env=dev", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, err := ParseLabelSelector(tt.selector)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLabels)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, labels)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MissedRun registra una activación planificada de un automatismo para la que no se creó ninguna ejecución
+// dentro de su margen
+type MissedRun struct {
+	ID            uuid.UUID
+	AutomationKey string
+	ExpectedAt    time.Time // Activación según la planificación
+	Deadline      time.Time // Fin del margen: ExpectedAt más el GracePeriod vigente al detectarla
+	DetectedAt    time.Time
+}
+
+// NewMissedRun crea el registro de la activación perdida expectedAt de un automatismo
+func NewMissedRun(automation *Automation, expectedAt, detectedAt time.Time) *MissedRun {
+	return &MissedRun{
+		ID:            uuid.New(),
+		AutomationKey: automation.Key,
+		ExpectedAt:    expectedAt,
+		Deadline:      expectedAt.Add(automation.GracePeriod),
+		DetectedAt:    detectedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// PauseTracking acumula el tiempo que una tarea o subtarea pasa en PAUSED,
+// de forma que las duraciones reportadas excluyan las pausas
+type PauseTracking struct {
+	PausedAt       *time.Time    // Inicio de la pausa en curso, nil si no está en PAUSED
+	PausedDuration time.Duration // Tiempo acumulado en pausas ya finalizadas
+}
+
+// trackStateChange cierra la pausa en curso al salir de PAUSED y abre una nueva al entrar
+func (p *PauseTracking) trackStateChange(from, to State, now time.Time) {
+	if from == to {
+		return
+	}
+
+	if from == StatePaused && p.PausedAt != nil {
+		p.PausedDuration += now.Sub(*p.PausedAt)
+		p.PausedAt = nil
+	}
+
+	if to == StatePaused {
+		p.PausedAt = &now
+	}
+}
+
+// TotalPaused retorna el tiempo total en pausa hasta at, incluida la pausa en curso
+func (p PauseTracking) TotalPaused(at time.Time) time.Duration {
+	total := p.PausedDuration
+	if p.PausedAt != nil && at.After(*p.PausedAt) {
+		total += at.Sub(*p.PausedAt)
+	}
+	return total
+}
+
+// activeDuration retorna el tiempo transcurrido entre start y end (o now si no ha terminado)
+// descontando las pausas. Retorna false si la ejecución no ha comenzado
+func (p PauseTracking) activeDuration(start, end *time.Time, now time.Time) (time.Duration, bool) {
+	if start == nil {
+		return 0, false
+	}
+
+	until := now
+	if end != nil {
+		until = *end
+	}
+
+	active := until.Sub(*start) - p.TotalPaused(until)
+	if active < 0 {
+		active = 0
+	}
+	return active, true
+}
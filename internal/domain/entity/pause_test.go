@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pauseStep es una transición de estado en el minuto indicado desde el inicio
+type pauseStep struct {
+	to     State
+	minute int
+}
+
+func TestPauseTracking_ActiveDuration(t *testing.T) {
+	start := time.Date(2025, 11, 27, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	tests := []struct {
+		name        string
+		transitions []pauseStep
+		end         *int
+		now         int
+		wantActive  time.Duration
+		wantPaused  time.Duration
+	}{
+		{
+			name:        "never paused",
+			transitions: []pauseStep{{StateInProgress, 0}},
+			now:         30,
+			wantActive:  30 * time.Minute,
+		},
+		{
+			name:        "closed pause is excluded",
+			transitions: []pauseStep{{StateInProgress, 0}, {StatePaused, 10}, {StateInProgress, 40}},
+			now:         60,
+			wantActive:  30 * time.Minute,
+			wantPaused:  30 * time.Minute,
+		},
+		{
+			name:        "ongoing pause is excluded",
+			transitions: []pauseStep{{StateInProgress, 0}, {StatePaused, 20}},
+			now:         50,
+			wantActive:  20 * time.Minute,
+			wantPaused:  30 * time.Minute,
+		},
+		{
+			name: "several pauses are accumulated",
+			transitions: []pauseStep{
+				{StateInProgress, 0},
+				{StatePaused, 10}, {StateInProgress, 15},
+				{StatePaused, 30}, {StateInProgress, 45},
+				{StateCompleted, 60},
+			},
+			end:        intPtr(60),
+			now:        120,
+			wantActive: 40 * time.Minute,
+			wantPaused: 20 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p PauseTracking
+			from := StatePending
+			for _, tr := range tt.transitions {
+				p.trackStateChange(from, tr.to, at(tr.minute))
+				from = tr.to
+			}
+
+			startDate := at(0)
+			var endDate *time.Time
+			if tt.end != nil {
+				e := at(*tt.end)
+				endDate = &e
+			}
+
+			active, started := p.activeDuration(&startDate, endDate, at(tt.now))
+			require.True(t, started)
+			assert.Equal(t, tt.wantActive, active)
+
+			until := at(tt.now)
+			if endDate != nil {
+				until = *endDate
+			}
+			assert.Equal(t, tt.wantPaused, p.TotalPaused(until))
+		})
+	}
+}
+
+func TestPauseTracking_NotStarted(t *testing.T) {
+	var p PauseTracking
+
+	_, started := p.activeDuration(nil, nil, testTime)
+
+	assert.False(t, started)
+}
+
+func TestTask_UpdateState_TracksPause(t *testing.T) {
+	clock := newTestClock()
+	task, err := NewTask("Test Task", "test-user", clock)
+	require.NoError(t, err)
+	require.NoError(t, task.UpdateState(StateInProgress, "test-user"))
+
+	require.NoError(t, task.UpdateState(StatePaused, "test-user"))
+	require.NotNil(t, task.PausedAt)
+	assert.Equal(t, testTime, *task.PausedAt)
+
+	// Una pausa de un minuto
+	clock.Advance(time.Minute)
+
+	require.NoError(t, task.UpdateState(StateInProgress, "test-user"))
+	assert.Nil(t, task.PausedAt)
+	assert.Equal(t, time.Minute, task.PausedDuration)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
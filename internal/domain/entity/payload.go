@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultMaxPayloadBytes es el tamaño máximo por defecto de los documentos input y output (256 KiB)
+const DefaultMaxPayloadBytes = 256 * 1024
+
+// Payload es un documento JSON que la API guarda y devuelve sin interpretarlo: los parámetros de
+// entrada (input) de una tarea o subtarea o los resultados (output) que produjo
+type Payload = json.RawMessage
+
+// NormalizePayload compacta un documento input u output y valida que sea JSON dentro de maxBytes
+// El documento vacío o null se normaliza a nil (sin documento)
+func NormalizePayload(field string, payload Payload, maxBytes int) (Payload, error) {
+	trimmed := bytes.TrimSpace(payload)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, nil
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, trimmed); err != nil {
+		return nil, fmt.Errorf("%w: %s is not valid JSON", ErrInvalidPayload, field)
+	}
+	if compacted.Len() > maxBytes {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidPayload, field, maxBytes)
+	}
+	return compacted.Bytes(), nil
+}
+
+// validateOutputState comprueba que los resultados solo se registren con la tarea o subtarea en un estado final
+func validateOutputState(state State) error {
+	if !state.IsFinal() {
+		return fmt.Errorf("%w: output can only be provided when moving to a final state, got %s", ErrInvalidPayload, state)
+	}
+	return nil
+}
+
+// SetInput asigna los parámetros de entrada de la tarea; solo se informan al crearla
+func (t *Task) SetInput(input Payload, maxBytes int) error {
+	normalized, err := NormalizePayload("input", input, maxBytes)
+	if err != nil {
+		return err
+	}
+	t.Input = normalized
+	return nil
+}
+
+// SetOutput registra los resultados de la tarea, que debe haber pasado ya a un estado final
+// Un documento vacío no modifica los resultados registrados
+func (t *Task) SetOutput(output Payload, maxBytes int) error {
+	normalized, err := NormalizePayload("output", output, maxBytes)
+	if err != nil || normalized == nil {
+		return err
+	}
+	if err := validateOutputState(t.State); err != nil {
+		return err
+	}
+	t.Output = normalized
+	return nil
+}
+
+// SetInput asigna los parámetros de entrada de la subtarea; solo se informan al crearla
+func (s *Subtask) SetInput(input Payload, maxBytes int) error {
+	normalized, err := NormalizePayload("input", input, maxBytes)
+	if err != nil {
+		return err
+	}
+	s.Input = normalized
+	return nil
+}
+
+// SetOutput registra los resultados de la subtarea, que debe haber pasado ya a un estado final
+// Un documento vacío no modifica los resultados registrados
+func (s *Subtask) SetOutput(output Payload, maxBytes int) error {
+	normalized, err := NormalizePayload("output", output, maxBytes)
+	if err != nil || normalized == nil {
+		return err
+	}
+	if err := validateOutputState(s.State); err != nil {
+		return err
+	}
+	s.Output = normalized
+	return nil
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		maxBytes int
+		want     string
+		wantErr  bool
+	}{
+		{name: "object is compacted", payload: "{ \"rows\": 42,\n \"path\": \"out.csv\" }", maxBytes: 100, want: `{"rows":42,"path":"out.csv"}`},
+		{name: "scalar values are accepted", payload: " 42 ", maxBytes: 100, want: "42"},
+		{name: "empty payload", payload: "", maxBytes: 100},
+		{name: "null payload", payload: " null ", maxBytes: 100},
+		{name: "payload at the limit", payload: `"` + strings.Repeat("x", 8) + `"`, maxBytes: 10, want: `"xxxxxxxx"`},
+		{name: "payload over the limit", payload: `"` + strings.Repeat("x", 9) + `"`, maxBytes: 10, wantErr: true},
+		{name: "invalid JSON", payload: `{"rows": }`, maxBytes: 100, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePayload("input", Payload(tt.payload), tt.maxBytes)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPayload)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestTask_SetOutput(t *testing.T) {
+	output := Payload(`{"rows": 1200}`)
+
+	t.Run("requires a final state", func(t *testing.T) {
+		task, err := NewTask("Test Task", "test-user", newTestClock())
+		require.NoError(t, err)
+		require.NoError(t, task.UpdateState(StateInProgress, "test-user"))
+
+		err = task.SetOutput(output, DefaultMaxPayloadBytes)
+		assert.ErrorIs(t, err, ErrInvalidPayload)
+		assert.Nil(t, task.Output)
+	})
+
+	t.Run("stored once the task is finished", func(t *testing.T) {
+		task, err := NewTask("Test Task", "test-user", newTestClock())
+		require.NoError(t, err)
+		require.NoError(t, task.UpdateState(StateInProgress, "test-user"))
+		require.NoError(t, task.UpdateState(StateCompleted, "test-user"))
+
+		require.NoError(t, task.SetOutput(output, DefaultMaxPayloadBytes))
+		assert.Equal(t, `{"rows":1200}`, string(task.Output))
+
+		// Un documento vacío conserva los resultados registrados
+		require.NoError(t, task.SetOutput(nil, DefaultMaxPayloadBytes))
+		assert.Equal(t, `{"rows":1200}`, string(task.Output))
+	})
+
+	t.Run("moved to the closed attempt on retry while the input is kept", func(t *testing.T) {
+		task, err := NewTask("Test Task", "test-user", newTestClock())
+		require.NoError(t, err)
+		require.NoError(t, task.SetInput(Payload(`{"file":"in.csv"}`), DefaultMaxPayloadBytes))
+		subtask, err := NewSubtask("Export", newTestClock())
+		require.NoError(t, err)
+		task.AddSubtask(subtask)
+
+		require.NoError(t, task.UpdateState(StateInProgress, "test-user"))
+		subtask.UpdateState(StateInProgress)
+		subtask.UpdateState(StateFailed)
+		require.NoError(t, subtask.SetOutput(output, DefaultMaxPayloadBytes))
+		require.NoError(t, task.UpdateState(StateFailed, "test-user"))
+		require.NoError(t, task.SetOutput(output, DefaultMaxPayloadBytes))
+
+		attempt, err := task.Retry("retry-user")
+		require.NoError(t, err)
+		assert.Equal(t, `{"rows":1200}`, string(attempt.Output))
+		require.Len(t, attempt.Subtasks, 1)
+		assert.Equal(t, `{"rows":1200}`, string(attempt.Subtasks[0].Output))
+
+		// El nuevo intento empieza sin resultados
+		assert.Nil(t, task.Output)
+		assert.Nil(t, subtask.Output)
+		assert.Equal(t, `{"file":"in.csv"}`, string(task.Input))
+	})
+}
+
+func TestSubtask_SetOutput_RequiresFinalState(t *testing.T) {
+	subtask, err := NewSubtask("Export", newTestClock())
+	require.NoError(t, err)
+
+	err = subtask.SetOutput(Payload(`{"rows": 1}`), DefaultMaxPayloadBytes)
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+	assert.Nil(t, subtask.Output)
+}
@@ -0,0 +1,115 @@
+package entity
+
+import "fmt"
+
+// MaxProgress es el porcentaje de progreso de una ejecución terminada
+const MaxProgress = 100
+
+// ProgressTracking guarda el progreso que reporta el proceso de una tarea o subtarea
+// Todos los campos son opcionales: nil indica que el proceso no lo ha reportado
+type ProgressTracking struct {
+	Progress       *int   // Porcentaje reportado (0-100)
+	ItemsProcessed *int64 // Elementos procesados hasta ahora
+	ItemsTotal     *int64 // Total de elementos a procesar
+}
+
+// ProgressReport es una actualización del progreso: solo se aplican los campos no nil
+type ProgressReport struct {
+	Progress       *int
+	ItemsProcessed *int64
+	ItemsTotal     *int64
+}
+
+// IsEmpty indica si el reporte no contiene ningún valor
+func (r ProgressReport) IsEmpty() bool {
+	return r.Progress == nil && r.ItemsProcessed == nil && r.ItemsTotal == nil
+}
+
+// ReportProgress aplica un reporte de progreso
+// Valida el resultado combinado con los valores ya reportados; si no es válido no modifica nada
+func (p *ProgressTracking) ReportProgress(report ProgressReport) error {
+	next := *p
+	if report.Progress != nil {
+		next.Progress = report.Progress
+	}
+	if report.ItemsProcessed != nil {
+		next.ItemsProcessed = report.ItemsProcessed
+	}
+	if report.ItemsTotal != nil {
+		next.ItemsTotal = report.ItemsTotal
+	}
+
+	if err := next.validate(); err != nil {
+		return err
+	}
+	*p = next
+	return nil
+}
+
+// validate comprueba los rangos del progreso y de los contadores
+func (p ProgressTracking) validate() error {
+	if p.Progress != nil && (*p.Progress < 0 || *p.Progress > MaxProgress) {
+		return fmt.Errorf("%w: progress must be between 0 and %d, got %d", ErrInvalidProgress, MaxProgress, *p.Progress)
+	}
+	if p.ItemsProcessed != nil && *p.ItemsProcessed < 0 {
+		return fmt.Errorf("%w: items_processed cannot be negative", ErrInvalidProgress)
+	}
+	if p.ItemsTotal != nil && *p.ItemsTotal < 0 {
+		return fmt.Errorf("%w: items_total cannot be negative", ErrInvalidProgress)
+	}
+	if p.ItemsProcessed != nil && p.ItemsTotal != nil && *p.ItemsProcessed > *p.ItemsTotal {
+		return fmt.Errorf("%w: items_processed (%d) exceeds items_total (%d)",
+			ErrInvalidProgress, *p.ItemsProcessed, *p.ItemsTotal)
+	}
+	return nil
+}
+
+// reportedProgress retorna el progreso reportado o, si no lo hay, el que se deduce de los contadores
+func (p ProgressTracking) reportedProgress() (int, bool) {
+	if p.Progress != nil {
+		return *p.Progress, true
+	}
+	if p.ItemsProcessed != nil && p.ItemsTotal != nil && *p.ItemsTotal > 0 {
+		return int(*p.ItemsProcessed * MaxProgress / *p.ItemsTotal), true
+	}
+	return 0, false
+}
+
+// EffectiveProgress retorna el progreso de la subtarea: 100 si está COMPLETED y, si no, el reportado
+// (o el deducido de sus contadores). Retorna nil si no se conoce
+func (s *Subtask) EffectiveProgress() *int {
+	if s.State == StateCompleted {
+		completed := MaxProgress
+		return &completed
+	}
+	if progress, ok := s.reportedProgress(); ok {
+		return &progress
+	}
+	return nil
+}
+
+// EffectiveProgress retorna el progreso de la tarea: 100 si está COMPLETED; si no, el reportado
+// (o el deducido de sus contadores) y, en último término, el porcentaje de subtareas raíz en estado
+// final. Retorna nil si no se conoce
+func (t *Task) EffectiveProgress() *int {
+	if t.State == StateCompleted {
+		completed := MaxProgress
+		return &completed
+	}
+	if progress, ok := t.reportedProgress(); ok {
+		return &progress
+	}
+
+	roots := t.RootSubtasks()
+	if len(roots) == 0 {
+		return nil
+	}
+	finished := 0
+	for _, subtask := range roots {
+		if subtask.State.IsFinal() {
+			finished++
+		}
+	}
+	derived := finished * MaxProgress / len(roots)
+	return &derived
+}
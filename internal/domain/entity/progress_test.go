@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestProgressTracking_ReportProgress(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial ProgressReport
+		report  ProgressReport
+		wantErr bool
+	}{
+		{name: "progress in range", report: ProgressReport{Progress: intPtr(42)}},
+		{name: "progress bounds", report: ProgressReport{Progress: intPtr(100)}},
+		{name: "negative progress", report: ProgressReport{Progress: intPtr(-1)}, wantErr: true},
+		{name: "progress above 100", report: ProgressReport{Progress: intPtr(101)}, wantErr: true},
+		{name: "item counters", report: ProgressReport{ItemsProcessed: int64Ptr(10), ItemsTotal: int64Ptr(10)}},
+		{name: "negative items processed", report: ProgressReport{ItemsProcessed: int64Ptr(-1)}, wantErr: true},
+		{name: "negative items total", report: ProgressReport{ItemsTotal: int64Ptr(-5)}, wantErr: true},
+		{name: "processed exceeds total", report: ProgressReport{ItemsProcessed: int64Ptr(11), ItemsTotal: int64Ptr(10)}, wantErr: true},
+		{
+			name:    "processed exceeds previously reported total",
+			initial: ProgressReport{ItemsTotal: int64Ptr(10)},
+			report:  ProgressReport{ItemsProcessed: int64Ptr(11)},
+			wantErr: true,
+		},
+		{
+			name:    "total can grow with processed",
+			initial: ProgressReport{ItemsProcessed: int64Ptr(5), ItemsTotal: int64Ptr(10)},
+			report:  ProgressReport{ItemsProcessed: int64Ptr(15), ItemsTotal: int64Ptr(20)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p ProgressTracking
+			require.NoError(t, p.ReportProgress(tt.initial))
+			before := p
+
+			err := p.ReportProgress(tt.report)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidProgress)
+				// Un reporte inválido no modifica nada
+				assert.Equal(t, before, p)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProgressTracking_ReportProgressKeepsOmittedFields(t *testing.T) {
+	var p ProgressTracking
+	require.NoError(t, p.ReportProgress(ProgressReport{Progress: intPtr(10), ItemsTotal: int64Ptr(200)}))
+	require.NoError(t, p.ReportProgress(ProgressReport{ItemsProcessed: int64Ptr(50)}))
+
+	assert.Equal(t, 10, *p.Progress)
+	assert.Equal(t, int64(50), *p.ItemsProcessed)
+	assert.Equal(t, int64(200), *p.ItemsTotal)
+}
+
+func TestSubtask_EffectiveProgress(t *testing.T) {
+	subtask, err := NewSubtask("Import", newTestClock())
+	require.NoError(t, err)
+
+	// Sin reportes el progreso es desconocido
+	assert.Nil(t, subtask.EffectiveProgress())
+
+	// Los contadores determinan el progreso si no se reporta el porcentaje
+	require.NoError(t, subtask.ReportProgress(ProgressReport{ItemsProcessed: int64Ptr(1), ItemsTotal: int64Ptr(3)}))
+	assert.Equal(t, 33, *subtask.EffectiveProgress())
+
+	// El porcentaje reportado tiene prioridad sobre los contadores
+	require.NoError(t, subtask.ReportProgress(ProgressReport{Progress: intPtr(80)}))
+	assert.Equal(t, 80, *subtask.EffectiveProgress())
+
+	// Una subtarea completada está al 100% aunque el último reporte fuera menor
+	subtask.UpdateState(StateInProgress)
+	subtask.UpdateState(StateCompleted)
+	assert.Equal(t, 100, *subtask.EffectiveProgress())
+}
+
+func TestTask_EffectiveProgress(t *testing.T) {
+	t.Run("unknown without subtasks or reports", func(t *testing.T) {
+		task := taskWithSubtasks(t)
+		assert.Nil(t, task.EffectiveProgress())
+	})
+
+	t.Run("derived from root subtasks in a final state", func(t *testing.T) {
+		task, build, test, unit, _, _ := nestedTask(t)
+		unit.UpdateState(StateCompleted)
+		assert.Equal(t, 0, *task.EffectiveProgress())
+
+		build.UpdateState(StateFailed)
+		assert.Equal(t, 50, *task.EffectiveProgress())
+
+		test.UpdateState(StateCancelled)
+		assert.Equal(t, 100, *task.EffectiveProgress())
+	})
+
+	t.Run("deleted subtasks are ignored", func(t *testing.T) {
+		task := taskWithSubtasks(t, "A", "B", "C")
+		task.Subtasks[0].UpdateState(StateCompleted)
+		task.Subtasks[2].Delete()
+		assert.Equal(t, 50, *task.EffectiveProgress())
+	})
+
+	t.Run("reported progress takes precedence", func(t *testing.T) {
+		task := taskWithSubtasks(t, "A", "B")
+		task.Subtasks[0].UpdateState(StateCompleted)
+		require.NoError(t, task.ReportProgress(ProgressReport{ItemsProcessed: int64Ptr(9), ItemsTotal: int64Ptr(10)}))
+		assert.Equal(t, 90, *task.EffectiveProgress())
+	})
+
+	t.Run("completed task is at 100", func(t *testing.T) {
+		task := taskWithSubtasks(t, "A")
+		require.NoError(t, task.ReportProgress(ProgressReport{Progress: intPtr(30)}))
+		require.NoError(t, task.UpdateState(StateInProgress, "Team A"))
+		require.NoError(t, task.UpdateState(StateCompleted, "Team A"))
+		assert.Equal(t, 100, *task.EffectiveProgress())
+	})
+}
@@ -0,0 +1,237 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultScheduleTimezone es la zona horaria de las planificaciones que no indican ninguna
+	DefaultScheduleTimezone = "UTC"
+
+	// scheduleSearchYears es el horizonte en el que se buscan activaciones; cubre cualquier 29 de febrero
+	scheduleSearchYears = 8
+)
+
+// scheduleReference es el instante desde el que se comprueba al parsear que la planificación se activa alguna vez
+var scheduleReference = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// scheduleMacros son las abreviaturas de expresiones cron habituales
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// scheduleField describe uno de los cinco campos de una expresión cron
+type scheduleField struct {
+	name     string
+	min, max int
+	names    map[string]int // Nombres admitidos en lugar de números (meses y días de la semana)
+}
+
+var (
+	minuteField     = scheduleField{name: "minute", min: 0, max: 59}
+	hourField       = scheduleField{name: "hour", min: 0, max: 23}
+	dayOfMonthField = scheduleField{name: "day of month", min: 1, max: 31}
+	monthField      = scheduleField{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	// El 7 también es domingo; se normaliza a 0 al parsear
+	dayOfWeekField = scheduleField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// Schedule es la planificación esperada de un automatismo: una expresión cron estándar de cinco campos
+// (minuto, hora, día del mes, mes y día de la semana) evaluada en una zona horaria IANA
+// Admite '*', valores, rangos (1-5), pasos (*/15, 8-18/2), listas (1,15), nombres de meses y días
+// (JAN, MON) y las abreviaturas @yearly, @monthly, @weekly, @daily y @hourly
+type Schedule struct {
+	Expression string // Expresión normalizada (espacios simples)
+	Timezone   string
+
+	location    *time.Location
+	minutes     uint64 // Conjuntos de valores permitidos de cada campo, un bit por valor
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	// Como en cron, si ambos días están restringidos basta con que coincida uno de los dos
+	dayOfMonthAny bool
+	dayOfWeekAny  bool
+}
+
+// ParseSchedule parsea y valida una expresión cron en la zona horaria indicada
+// timezone vacío equivale a DefaultScheduleTimezone
+func ParseSchedule(expression, timezone string) (*Schedule, error) {
+	if timezone == "" {
+		timezone = DefaultScheduleTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "Local" {
+		return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, timezone)
+	}
+
+	normalized := strings.Join(strings.Fields(expression), " ")
+	fields := strings.Fields(normalized)
+	if macro, ok := scheduleMacros[strings.ToLower(normalized)]; ok {
+		normalized = strings.ToLower(normalized)
+		fields = strings.Fields(macro)
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: %q must have 5 fields (minute hour day-of-month month day-of-week)",
+			ErrInvalidSchedule, expression)
+	}
+
+	schedule := &Schedule{Expression: normalized, Timezone: timezone, location: location}
+	targets := []struct {
+		field scheduleField
+		set   *uint64
+	}{
+		{minuteField, &schedule.minutes},
+		{hourField, &schedule.hours},
+		{dayOfMonthField, &schedule.daysOfMonth},
+		{monthField, &schedule.months},
+		{dayOfWeekField, &schedule.daysOfWeek},
+	}
+	for i, target := range targets {
+		if *target.set, err = target.field.parse(fields[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// El domingo puede escribirse como 0 o como 7
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek = schedule.daysOfWeek&^(1<<7) | 1
+	}
+	schedule.dayOfMonthAny = fields[2] == "*"
+	schedule.dayOfWeekAny = fields[4] == "*"
+
+	// Expresiones como "0 0 30 2 *" son sintácticamente válidas pero nunca se activan
+	if schedule.Next(scheduleReference).IsZero() {
+		return nil, fmt.Errorf("%w: %q never fires", ErrInvalidSchedule, expression)
+	}
+
+	return schedule, nil
+}
+
+// Next retorna la primera activación estrictamente posterior a t, evaluada en la zona horaria de la planificación
+// Como en cron, las horas que no existen por un cambio de hora se saltan y las que se repiten se activan una vez
+// Retorna el instante cero si no hay ninguna activación en los próximos scheduleSearchYears años
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + scheduleSearchYears
+
+	for t.Year() <= limit {
+		year, month, day := t.Date()
+		switch {
+		case s.months&(1<<uint(month)) == 0:
+			t = time.Date(year, month+1, 1, 0, 0, 0, 0, s.location)
+		case !s.matchesDay(t):
+			t = time.Date(year, month, day+1, 0, 0, 0, 0, s.location)
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case s.minutes&(1<<uint(t.Minute())) == 0 || s.isRepeated(t):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay verifica si el día de t cumple los campos de día del mes y día de la semana
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.daysOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.dayOfMonthAny || s.dayOfWeekAny {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// isRepeated verifica si la hora local de t ya ocurrió antes porque se retrasó la hora
+func (s *Schedule) isRepeated(t time.Time) bool {
+	_, offset := t.Zone()
+	_, previous := t.Add(-2 * time.Hour).Zone()
+	if previous <= offset {
+		return false
+	}
+	earlier := t.Add(-time.Duration(previous-offset) * time.Second)
+	_, earlierOffset := earlier.Zone()
+	return earlierOffset == previous
+}
+
+// parse convierte un campo de la expresión en el conjunto de valores que permite
+func (f scheduleField) parse(field string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		from, to, step := f.min, f.max, 1
+
+		rangePart := part
+		if base, stepStr, ok := strings.Cut(part, "/"); ok {
+			parsedStep, err := strconv.Atoi(stepStr)
+			if err != nil || parsedStep < 1 {
+				return 0, f.invalid(part)
+			}
+			step = parsedStep
+			rangePart = base
+		}
+
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			lowStr, highStr, _ := strings.Cut(rangePart, "-")
+			low, err := f.value(lowStr)
+			if err != nil {
+				return 0, f.invalid(part)
+			}
+			high, err := f.value(highStr)
+			if err != nil || high < low {
+				return 0, f.invalid(part)
+			}
+			from, to = low, high
+		default:
+			value, err := f.value(rangePart)
+			if err != nil {
+				return 0, f.invalid(part)
+			}
+			// Un valor con paso (5/15) recorre desde el valor hasta el máximo
+			from = value
+			if step == 1 {
+				to = value
+			}
+		}
+
+		for value := from; value <= to; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+// value parsea un valor numérico o un nombre del campo dentro de su rango
+func (f scheduleField) value(s string) (int, error) {
+	if value, ok := f.names[strings.ToUpper(s)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("out of range")
+	}
+	return value, nil
+}
+
+// invalid construye el error de un valor inválido del campo
+func (f scheduleField) invalid(part string) error {
+	return fmt.Errorf("%w: invalid %s %q (allowed %d-%d)", ErrInvalidSchedule, f.name, part, f.min, f.max)
+}
@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		timezone   string
+		wantExpr   string
+		wantTZ     string
+		wantErr    bool
+	}{
+		{name: "every minute", expression: "* * * * *", wantExpr: "* * * * *", wantTZ: "UTC"},
+		{name: "weekdays at 06:30", expression: "30 6 * * 1-5", timezone: "Europe/Madrid", wantExpr: "30 6 * * 1-5", wantTZ: "Europe/Madrid"},
+		{name: "steps lists and names", expression: "*/15  8-18/2 1,15 JAN-jun mon,FRI", wantExpr: "*/15 8-18/2 1,15 JAN-jun mon,FRI", wantTZ: "UTC"},
+		{name: "sunday as 7", expression: "0 0 * * 7", wantExpr: "0 0 * * 7", wantTZ: "UTC"},
+		{name: "value with step", expression: "5/20 * * * *", wantExpr: "5/20 * * * *", wantTZ: "UTC"},
+		{name: "macro", expression: "@Daily", wantExpr: "@daily", wantTZ: "UTC"},
+		{name: "too few fields", expression: "0 6 * *", wantErr: true},
+		{name: "too many fields", expression: "0 0 6 * * *", wantErr: true},
+		{name: "minute out of range", expression: "60 * * * *", wantErr: true},
+		{name: "day of month zero", expression: "0 0 0 * *", wantErr: true},
+		{name: "reversed range", expression: "0 18-8 * * *", wantErr: true},
+		{name: "zero step", expression: "*/0 * * * *", wantErr: true},
+		{name: "unknown name", expression: "0 0 * FOO *", wantErr: true},
+		{name: "empty", expression: "", wantErr: true},
+		{name: "unknown timezone", expression: "0 0 * * *", timezone: "Mars/Olympus", wantErr: true},
+		{name: "local timezone", expression: "0 0 * * *", timezone: "Local", wantErr: true},
+		{name: "leap day", expression: "0 0 29 2 *", wantExpr: "0 0 29 2 *", wantTZ: "UTC"},
+		{name: "never fires", expression: "0 0 30 2 *", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expression, tt.timezone)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSchedule)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExpr, schedule.Expression)
+			assert.Equal(t, tt.wantTZ, schedule.Timezone)
+		})
+	}
+}
+
+func TestParseSchedule_Fields(t *testing.T) {
+	schedule, err := ParseSchedule("5/20 8-18/4 1,15 * SUN,7", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1<<5|1<<25|1<<45), schedule.minutes)
+	assert.Equal(t, uint64(1<<8|1<<12|1<<16), schedule.hours)
+	assert.Equal(t, uint64(1<<1|1<<15), schedule.daysOfMonth)
+	assert.Equal(t, uint64(1), schedule.daysOfWeek, "sunday is normalized to 0")
+	assert.False(t, schedule.dayOfMonthAny)
+	assert.False(t, schedule.dayOfWeekAny)
+}
+
+func TestSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		timezone   string
+		after      time.Time
+		want       time.Time
+	}{
+		{
+			name:       "next step of the hour",
+			expression: "*/15 * * * *",
+			after:      time.Date(2026, 3, 2, 10, 7, 30, 0, time.UTC),
+			want:       time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:       "strictly after an activation",
+			expression: "*/15 * * * *",
+			after:      time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC),
+			want:       time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "weekdays skip the weekend in the schedule timezone",
+			expression: "0 2 * * MON-FRI",
+			timezone:   "Europe/Madrid",
+			after:      time.Date(2026, 3, 6, 2, 0, 0, 0, time.UTC),
+			want:       time.Date(2026, 3, 9, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "restricted day of month or day of week",
+			expression: "0 0 1,15 * MON",
+			after:      time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+			want:       time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "leap day",
+			expression: "0 0 29 2 *",
+			after:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:       time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "half hour offset",
+			expression: "0 * * * *",
+			timezone:   "Asia/Kolkata",
+			after:      time.Date(2026, 3, 2, 4, 40, 0, 0, time.UTC),
+			want:       time.Date(2026, 3, 2, 5, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "hour skipped when the clock goes forward",
+			expression: "30 2 * * *",
+			timezone:   "Europe/Madrid",
+			after:      time.Date(2026, 3, 28, 12, 0, 0, 0, time.UTC),
+			want:       time.Date(2026, 3, 30, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "first occurrence of a repeated hour",
+			expression: "30 2 * * *",
+			timezone:   "Europe/Madrid",
+			after:      time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+			want:       time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "repeated hour fires once",
+			expression: "30 2 * * *",
+			timezone:   "Europe/Madrid",
+			after:      time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC),
+			want:       time.Date(2026, 10, 26, 1, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expression, tt.timezone)
+			require.NoError(t, err)
+
+			got := schedule.Next(tt.after)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// SLAStatus representa el cumplimiento del SLA de una tarea en un instante dado
+type SLAStatus string
+
+const (
+	// SLAOnTrack indica que la tarea va (o terminó) dentro de plazo
+	SLAOnTrack SLAStatus = "on_track"
+
+	// SLAAtRisk indica que la tarea sigue abierta y ha consumido al menos SLAAtRiskRatio de su plazo
+	SLAAtRisk SLAStatus = "at_risk"
+
+	// SLABreached indica que la tarea superó su plazo
+	SLABreached SLAStatus = "breached"
+)
+
+// SLAAtRiskRatio es la fracción del plazo consumida a partir de la que una tarea abierta está en riesgo
+const SLAAtRiskRatio = 0.8
+
+// MaxExpectedDurationSeconds es la mayor duración esperada que puede configurarse en una tarea (366 días)
+const MaxExpectedDurationSeconds = 366 * 24 * 60 * 60
+
+// String retorna la representación en string del estado del SLA
+func (s SLAStatus) String() string {
+	return string(s)
+}
+
+// ParseSLAStatus convierte un string a SLAStatus
+func ParseSLAStatus(s string) (SLAStatus, error) {
+	switch status := SLAStatus(s); status {
+	case SLAOnTrack, SLAAtRisk, SLABreached:
+		return status, nil
+	default:
+		return "", fmt.Errorf("%w: sla_status must be one of on_track, at_risk, breached, got %q", ErrInvalidSLA, s)
+	}
+}
+
+// SLATracking guarda el SLA comprometido para una tarea y el momento en que se incumplió
+// El plazo vence en Deadline o ExpectedDuration después del inicio de la tarea, lo que ocurra antes
+type SLATracking struct {
+	ExpectedDuration *time.Duration // Duración máxima desde que la tarea arranca, nil si no se compromete
+	Deadline         *time.Time     // Fecha límite para terminar la tarea, nil si no se compromete
+	SLABreachedAt    *time.Time     // Momento en que venció el plazo sin terminar, nil si no se ha incumplido
+}
+
+// SetExpectedDuration configura en segundos la duración esperada de la tarea; cero la elimina
+func (s *SLATracking) SetExpectedDuration(seconds int) error {
+	if seconds == 0 {
+		s.ExpectedDuration = nil
+		return nil
+	}
+	if seconds < 0 || seconds > MaxExpectedDurationSeconds {
+		return fmt.Errorf("%w: expected_duration must be between 1 and %d seconds, got %d",
+			ErrInvalidSLA, MaxExpectedDurationSeconds, seconds)
+	}
+	duration := time.Duration(seconds) * time.Second
+	s.ExpectedDuration = &duration
+	return nil
+}
+
+// HasSLA indica si la tarea tiene comprometida una duración esperada o una fecha límite
+func (s SLATracking) HasSLA() bool {
+	return s.ExpectedDuration != nil || s.Deadline != nil
+}
+
+// SetDeadline configura la fecha límite de la tarea, que debe ser posterior a su creación
+func (t *Task) SetDeadline(deadline time.Time) error {
+	if !deadline.After(t.CreatedAt) {
+		return fmt.Errorf("%w: deadline must be after the task creation (%s)", ErrInvalidSLA, t.CreatedAt.Format(time.RFC3339))
+	}
+	t.Deadline = &deadline
+	return nil
+}
+
+// SLADueAt retorna el vencimiento del plazo: la fecha límite o el inicio más la duración esperada,
+// lo que ocurra antes. Retorna false si no se conoce (sin SLA, o solo duración y sin arrancar)
+func (t *Task) SLADueAt() (time.Time, bool) {
+	var due time.Time
+	known := false
+
+	if t.Deadline != nil {
+		due, known = *t.Deadline, true
+	}
+	if t.ExpectedDuration != nil && t.StartDate != nil {
+		expected := t.StartDate.Add(*t.ExpectedDuration)
+		if !known || expected.Before(due) {
+			due, known = expected, true
+		}
+	}
+
+	return due, known
+}
+
+// SLAStatus retorna el cumplimiento del SLA de la tarea en now. Retorna false si la tarea no tiene SLA
+// Una tarea terminada se evalúa con su fecha de fin y un incumplimiento ya registrado es definitivo
+func (t *Task) SLAStatus(now time.Time) (SLAStatus, bool) {
+	if !t.HasSLA() {
+		return "", false
+	}
+	if t.SLABreachedAt != nil {
+		return SLABreached, true
+	}
+
+	due, known := t.SLADueAt()
+	if !known {
+		return SLAOnTrack, true
+	}
+	if t.slaFinish(now).After(due) {
+		return SLABreached, true
+	}
+	if t.EndDate != nil {
+		return SLAOnTrack, true
+	}
+
+	// El plazo se consume desde el arranque de la tarea o, si no ha arrancado, desde su creación
+	windowStart := t.CreatedAt
+	if t.StartDate != nil {
+		windowStart = *t.StartDate
+	}
+	window := due.Sub(windowStart)
+	if now.Sub(windowStart) >= time.Duration(float64(window)*SLAAtRiskRatio) {
+		return SLAAtRisk, true
+	}
+	return SLAOnTrack, true
+}
+
+// DetectSLABreach registra el incumplimiento del SLA si el plazo venció en now (o antes del fin de la
+// tarea) y aún no se había registrado. El incumplimiento se fecha en el vencimiento del plazo.
+// Retorna true solo la primera vez
+func (t *Task) DetectSLABreach(now time.Time) bool {
+	if t.SLABreachedAt != nil {
+		return false
+	}
+
+	due, known := t.SLADueAt()
+	if !known || !t.slaFinish(now).After(due) {
+		return false
+	}
+
+	t.SLABreachedAt = &due
+	return true
+}
+
+// slaFinish retorna el instante con el que se evalúa el plazo: la fecha de fin o now si sigue abierta
+func (t *Task) slaFinish(now time.Time) time.Time {
+	if t.EndDate != nil {
+		return *t.EndDate
+	}
+	return now
+}
@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func timeRef(t time.Time) *time.Time {
+	return &t
+}
+
+// slaTask crea una tarea creada en created con la duración esperada y la fecha límite indicadas
+func slaTask(t *testing.T, created time.Time, expected time.Duration, deadline *time.Time) *Task {
+	t.Helper()
+
+	task, err := NewTask("Nightly Import", "team-a", newTestClock())
+	require.NoError(t, err)
+	task.CreatedAt = created
+	require.NoError(t, task.SetExpectedDuration(int(expected/time.Second)))
+	if deadline != nil {
+		require.NoError(t, task.SetDeadline(*deadline))
+	}
+	return task
+}
+
+func TestParseSLAStatus(t *testing.T) {
+	for _, status := range []SLAStatus{SLAOnTrack, SLAAtRisk, SLABreached} {
+		parsed, err := ParseSLAStatus(status.String())
+		require.NoError(t, err)
+		assert.Equal(t, status, parsed)
+	}
+
+	_, err := ParseSLAStatus("late")
+	assert.ErrorIs(t, err, ErrInvalidSLA)
+}
+
+func TestSLATracking_SetExpectedDuration(t *testing.T) {
+	var s SLATracking
+	require.NoError(t, s.SetExpectedDuration(3600))
+	assert.Equal(t, time.Hour, *s.ExpectedDuration)
+	assert.True(t, s.HasSLA())
+
+	assert.ErrorIs(t, s.SetExpectedDuration(-1), ErrInvalidSLA)
+	assert.ErrorIs(t, s.SetExpectedDuration(MaxExpectedDurationSeconds+1), ErrInvalidSLA)
+	assert.Equal(t, time.Hour, *s.ExpectedDuration)
+
+	// Cero elimina el compromiso
+	require.NoError(t, s.SetExpectedDuration(0))
+	assert.Nil(t, s.ExpectedDuration)
+	assert.False(t, s.HasSLA())
+}
+
+func TestTask_SetDeadline(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	task := slaTask(t, created, 0, nil)
+
+	assert.ErrorIs(t, task.SetDeadline(created), ErrInvalidSLA)
+	assert.ErrorIs(t, task.SetDeadline(created.Add(-time.Hour)), ErrInvalidSLA)
+	assert.Nil(t, task.Deadline)
+
+	require.NoError(t, task.SetDeadline(created.Add(time.Hour)))
+	assert.Equal(t, created.Add(time.Hour), *task.Deadline)
+}
+
+func TestTask_SLAStatus(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	start := created.Add(10 * time.Minute)
+	deadline := created.Add(2 * time.Hour)
+
+	tests := []struct {
+		name     string
+		expected time.Duration
+		deadline *time.Time
+		start    *time.Time
+		end      *time.Time
+		now      time.Time
+		want     SLAStatus
+	}{
+		{name: "duration not started", expected: time.Hour, now: created.Add(5 * time.Hour), want: SLAOnTrack},
+		{name: "duration early", expected: time.Hour, start: &start, now: start.Add(30 * time.Minute), want: SLAOnTrack},
+		{name: "duration at risk", expected: time.Hour, start: &start, now: start.Add(48 * time.Minute), want: SLAAtRisk},
+		{name: "duration breached", expected: time.Hour, start: &start, now: start.Add(61 * time.Minute), want: SLABreached},
+		{name: "deadline at risk before start", deadline: &deadline, now: created.Add(100 * time.Minute), want: SLAAtRisk},
+		{name: "deadline breached", deadline: &deadline, now: deadline.Add(time.Second), want: SLABreached},
+		{
+			name:     "earlier of deadline and duration",
+			expected: 3 * time.Hour, deadline: &deadline, start: &start,
+			now: deadline.Add(time.Minute), want: SLABreached,
+		},
+		{
+			name:     "ended in time stays on track",
+			expected: time.Hour, start: &start, end: timeRef(start.Add(59 * time.Minute)),
+			now: start.Add(5 * time.Hour), want: SLAOnTrack,
+		},
+		{
+			name:     "ended late is breached",
+			expected: time.Hour, start: &start, end: timeRef(start.Add(70 * time.Minute)),
+			now: start.Add(70 * time.Minute), want: SLABreached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := slaTask(t, created, tt.expected, tt.deadline)
+			task.StartDate = tt.start
+			task.EndDate = tt.end
+
+			status, ok := task.SLAStatus(tt.now)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+
+	t.Run("without SLA", func(t *testing.T) {
+		task := slaTask(t, created, 0, nil)
+		_, ok := task.SLAStatus(created.Add(time.Hour))
+		assert.False(t, ok)
+	})
+}
+
+func TestTask_DetectSLABreach(t *testing.T) {
+	created := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	task := slaTask(t, created, time.Hour, nil)
+	task.StartDate = timeRef(created)
+	due := created.Add(time.Hour)
+
+	assert.False(t, task.DetectSLABreach(due))
+	assert.Nil(t, task.SLABreachedAt)
+
+	// El incumplimiento se registra una sola vez, fechado en el vencimiento
+	before := task.Snapshot()
+	assert.True(t, task.DetectSLABreach(due.Add(time.Minute)))
+	assert.Equal(t, due, *task.SLABreachedAt)
+	assert.False(t, task.DetectSLABreach(due.Add(time.Hour)))
+
+	events := task.EventsSince(before, "team-a")
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTaskSLABreached, events[0].Type)
+	assert.Equal(t, SystemActor, events[0].Actor)
+	assert.Equal(t, due, events[0].OccurredAt)
+	require.NotNil(t, events[0].NewValue)
+	assert.Equal(t, due.Format(time.RFC3339), *events[0].NewValue)
+
+	// Una vez registrado el incumplimiento es definitivo aunque se amplíe el plazo
+	require.NoError(t, task.SetExpectedDuration(int((5 * time.Hour).Seconds())))
+	status, _ := task.SLAStatus(due.Add(time.Hour))
+	assert.Equal(t, SLABreached, status)
+	assert.Empty(t, task.EventsSince(task.Snapshot(), "team-a"))
+}
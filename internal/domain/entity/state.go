@@ -10,6 +10,14 @@ const (
 	// StateInProgress indica que la tarea está en ejecución
 	StateInProgress State = "IN_PROGRESS"
 
+	// StatePaused indica que la tarea está detenida temporalmente (p. ej. ventana de mantenimiento)
+	// El tiempo en este estado no cuenta en la duración de la tarea
+	StatePaused State = "PAUSED"
+
+	// StateRetrying indica que la tarea sigue en ejecución pero repite un paso tras un error transitorio
+	// Solo lo admiten los workflows que lo declaran (p. ej. RPA); el tiempo en este estado sí cuenta en la duración
+	StateRetrying State = "RETRYING"
+
 	// StateCompleted indica que la tarea se completó exitosamente (estado final)
 	StateCompleted State = "COMPLETED"
 
@@ -23,7 +31,7 @@ const (
 // IsValid verifica si el estado es válido
 func (s State) IsValid() bool {
 	switch s {
-	case StatePending, StateInProgress, StateCompleted, StateFailed, StateCancelled:
+	case StatePending, StateInProgress, StatePaused, StateRetrying, StateCompleted, StateFailed, StateCancelled:
 		return true
 	default:
 		return false
@@ -22,6 +22,16 @@ func TestState_IsValid(t *testing.T) {
 			state: StateInProgress,
 			want:  true,
 		},
+		{
+			name:  "PAUSED is valid",
+			state: StatePaused,
+			want:  true,
+		},
+		{
+			name:  "RETRYING is valid",
+			state: StateRetrying,
+			want:  true,
+		},
 		{
 			name:  "COMPLETED is valid",
 			state: StateCompleted,
@@ -73,6 +83,16 @@ func TestState_IsFinal(t *testing.T) {
 			state: StateInProgress,
 			want:  false,
 		},
+		{
+			name:  "PAUSED is not final",
+			state: StatePaused,
+			want:  false,
+		},
+		{
+			name:  "RETRYING is not final",
+			state: StateRetrying,
+			want:  false,
+		},
 		{
 			name:  "COMPLETED is final",
 			state: StateCompleted,
@@ -18,26 +18,63 @@ type Subtask struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
+	Version   int // Versión de la fila para control de concurrencia optimista
+
+	// ParentSubtaskID es la subtarea de la que cuelga esta; nil si cuelga directamente de la tarea
+	ParentSubtaskID *uuid.UUID
+
+	// DependsOn son los IDs de las subtareas de la misma tarea que deben completarse antes de arrancar esta
+	DependsOn []uuid.UUID
+
+	// Failure es el motivo del fallo si la subtarea está FAILED y se informó al cerrarla; nil en otro caso
+	Failure *Failure
+
+	// Input son los parámetros de entrada de la subtarea (ver SetInput); nil si no se informaron
+	Input Payload
+
+	// Output son los resultados que la subtarea registró al terminar (ver SetOutput); nil si no se informaron
+	Output Payload
+	PauseTracking
+	ProgressTracking
+
+	// clock es el reloj del que la subtarea toma las fechas; el de su tarea una vez añadida a ella
+	clock Clock
 }
 
 var nameRegex = regexp.MustCompile(`^[a-zA-Z0-9 _-]+$`)
 
 // NewSubtask crea una nueva subtarea con validaciones
-func NewSubtask(name string) (*Subtask, error) {
+// Las fechas de la subtarea se toman de clock; nil equivale a SystemClock
+func NewSubtask(name string, clock Clock) (*Subtask, error) {
 	if err := validateName(name); err != nil {
 		return nil, err
 	}
 
-	now := time.Now()
+	clock = clockOrSystem(clock)
+	now := clock.Now()
 	return &Subtask{
 		ID:        uuid.New(),
 		Name:      name,
 		State:     StatePending,
 		CreatedAt: now,
 		UpdatedAt: now,
+		Version:   1,
+		DependsOn: make([]uuid.UUID, 0),
+
+		clock: clock,
 	}, nil
 }
 
+// SetClock asigna el reloj del que la subtarea toma las fechas
+func (s *Subtask) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// Now retorna el instante actual según el reloj de la subtarea
+func (s *Subtask) Now() time.Time {
+	return clockOrSystem(s.clock).Now()
+}
+
 // ValidateName valida que el nombre cumpla con las reglas
 func ValidateName(name string) error {
 	if name == "" {
@@ -65,7 +102,7 @@ func (s *Subtask) IsDeleted() bool {
 // SetStartDate asigna la fecha de inicio si no está ya asignada
 func (s *Subtask) SetStartDate() {
 	if s.StartDate == nil {
-		now := time.Now()
+		now := s.Now()
 		s.StartDate = &now
 	}
 }
@@ -73,15 +110,55 @@ func (s *Subtask) SetStartDate() {
 // SetEndDate asigna la fecha de finalización si no está ya asignada
 func (s *Subtask) SetEndDate() {
 	if s.EndDate == nil {
-		now := time.Now()
+		now := s.Now()
 		s.EndDate = &now
 	}
 }
 
+// UpdateState actualiza el estado de la subtarea y gestiona fechas e intervalos de pausa
+// La validez de la transición debe comprobarse antes con la máquina de estados
+func (s *Subtask) UpdateState(newState State) {
+	if newState == StateInProgress {
+		s.SetStartDate()
+	}
+	if newState.IsFinal() {
+		s.SetEndDate()
+	}
+
+	// El motivo del fallo solo se conserva mientras la subtarea siga FAILED
+	if newState != s.State {
+		s.Failure = nil
+	}
+
+	now := s.Now()
+	s.trackStateChange(s.State, newState, now)
+	s.State = newState
+	s.UpdatedAt = now
+}
+
+// UpdateStateWithFailure actualiza el estado de la subtarea como UpdateState registrando el motivo del fallo
+// failure es opcional y solo se admite al pasar a FAILED; cualquier otro cambio de estado lo elimina
+func (s *Subtask) UpdateStateWithFailure(newState State, failure *Failure) error {
+	if err := validateFailureFor(newState, failure); err != nil {
+		return err
+	}
+	s.UpdateState(newState)
+	if failure != nil {
+		s.Failure = failure
+	}
+	return nil
+}
+
+// ActiveDuration retorna el tiempo de ejecución de la subtarea excluyendo las pausas,
+// medido hasta su fecha de fin o hasta now si sigue abierta. Retorna false si no ha comenzado
+func (s *Subtask) ActiveDuration(now time.Time) (time.Duration, bool) {
+	return s.activeDuration(s.StartDate, s.EndDate, now)
+}
+
 // Delete marca la subtarea como eliminada (soft delete)
 func (s *Subtask) Delete() {
 	if s.DeletedAt == nil {
-		now := time.Now()
+		now := s.Now()
 		s.DeletedAt = &now
 		s.UpdatedAt = now
 	}
@@ -75,7 +75,7 @@ func TestNewSubtask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			subtask, err := NewSubtask(tt.subtaskName)
+			subtask, err := NewSubtask(tt.subtaskName, newTestClock())
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -87,8 +87,8 @@ func TestNewSubtask(t *testing.T) {
 				assert.NotEqual(t, uuid.Nil, subtask.ID)
 				assert.Equal(t, tt.subtaskName, subtask.Name)
 				assert.Equal(t, StatePending, subtask.State)
-				assert.False(t, subtask.CreatedAt.IsZero())
-				assert.False(t, subtask.UpdatedAt.IsZero())
+				assert.Equal(t, testTime, subtask.CreatedAt)
+				assert.Equal(t, testTime, subtask.UpdatedAt)
 				assert.Nil(t, subtask.StartDate)
 				assert.Nil(t, subtask.EndDate)
 				assert.Nil(t, subtask.DeletedAt)
@@ -99,13 +99,13 @@ func TestNewSubtask(t *testing.T) {
 
 func TestSubtask_IsDeleted(t *testing.T) {
 	t.Run("not deleted", func(t *testing.T) {
-		subtask, _ := NewSubtask("Test")
+		subtask, _ := NewSubtask("Test", newTestClock())
 		assert.False(t, subtask.IsDeleted())
 	})
 
 	t.Run("is deleted", func(t *testing.T) {
-		subtask, _ := NewSubtask("Test")
-		now := time.Now()
+		subtask, _ := NewSubtask("Test", newTestClock())
+		now := testTime
 		subtask.DeletedAt = &now
 		assert.True(t, subtask.IsDeleted())
 	})
@@ -113,21 +113,20 @@ func TestSubtask_IsDeleted(t *testing.T) {
 
 func TestSubtask_SetStartDate(t *testing.T) {
 	t.Run("set start date when nil", func(t *testing.T) {
-		subtask, _ := NewSubtask("Test")
+		clock := newTestClock()
+		subtask, _ := NewSubtask("Test", clock)
 		assert.Nil(t, subtask.StartDate)
 
-		beforeSet := time.Now()
+		clock.Advance(time.Minute)
 		subtask.SetStartDate()
-		afterSet := time.Now()
 
 		require.NotNil(t, subtask.StartDate)
-		assert.True(t, subtask.StartDate.After(beforeSet) || subtask.StartDate.Equal(beforeSet))
-		assert.True(t, subtask.StartDate.Before(afterSet) || subtask.StartDate.Equal(afterSet))
+		assert.Equal(t, testTime.Add(time.Minute), *subtask.StartDate)
 	})
 
 	t.Run("does not override existing start date", func(t *testing.T) {
-		subtask, _ := NewSubtask("Test")
-		originalDate := time.Now().Add(-1 * time.Hour)
+		subtask, _ := NewSubtask("Test", newTestClock())
+		originalDate := testTime.Add(-1 * time.Hour)
 		subtask.StartDate = &originalDate
 
 		subtask.SetStartDate()
@@ -138,21 +137,20 @@ func TestSubtask_SetStartDate(t *testing.T) {
 
 func TestSubtask_SetEndDate(t *testing.T) {
 	t.Run("set end date when nil", func(t *testing.T) {
-		subtask, _ := NewSubtask("Test")
+		clock := newTestClock()
+		subtask, _ := NewSubtask("Test", clock)
 		assert.Nil(t, subtask.EndDate)
 
-		beforeSet := time.Now()
+		clock.Advance(time.Minute)
 		subtask.SetEndDate()
-		afterSet := time.Now()
 
 		require.NotNil(t, subtask.EndDate)
-		assert.True(t, subtask.EndDate.After(beforeSet) || subtask.EndDate.Equal(beforeSet))
-		assert.True(t, subtask.EndDate.Before(afterSet) || subtask.EndDate.Equal(afterSet))
+		assert.Equal(t, testTime.Add(time.Minute), *subtask.EndDate)
 	})
 
 	t.Run("does not override existing end date", func(t *testing.T) {
-		subtask, _ := NewSubtask("Test")
-		originalDate := time.Now().Add(-1 * time.Hour)
+		subtask, _ := NewSubtask("Test", newTestClock())
+		originalDate := testTime.Add(-1 * time.Hour)
 		subtask.EndDate = &originalDate
 
 		subtask.SetEndDate()
@@ -163,28 +161,28 @@ func TestSubtask_SetEndDate(t *testing.T) {
 
 func TestSubtask_Delete(t *testing.T) {
 	t.Run("delete subtask", func(t *testing.T) {
-		subtask, _ := NewSubtask("Test")
-		originalUpdatedAt := subtask.UpdatedAt
+		clock := newTestClock()
+		subtask, _ := NewSubtask("Test", clock)
 		assert.Nil(t, subtask.DeletedAt)
 
-		time.Sleep(1 * time.Millisecond) // Asegurar que UpdatedAt cambie
+		clock.Advance(time.Minute)
 		subtask.Delete()
 
 		require.NotNil(t, subtask.DeletedAt)
 		assert.True(t, subtask.IsDeleted())
-		assert.True(t, subtask.UpdatedAt.After(originalUpdatedAt))
+		assert.Equal(t, testTime.Add(time.Minute), *subtask.DeletedAt)
+		assert.Equal(t, testTime.Add(time.Minute), subtask.UpdatedAt)
 	})
 
 	t.Run("delete already deleted subtask does not change date", func(t *testing.T) {
-		subtask, _ := NewSubtask("Test")
+		clock := newTestClock()
+		subtask, _ := NewSubtask("Test", clock)
 		subtask.Delete()
-		originalDeletedAt := *subtask.DeletedAt
-		originalUpdatedAt := subtask.UpdatedAt
 
-		time.Sleep(1 * time.Millisecond)
+		clock.Advance(time.Minute)
 		subtask.Delete()
 
-		assert.Equal(t, originalDeletedAt, *subtask.DeletedAt)
-		assert.Equal(t, originalUpdatedAt, subtask.UpdatedAt)
+		assert.Equal(t, testTime, *subtask.DeletedAt)
+		assert.Equal(t, testTime, subtask.UpdatedAt)
 	})
 }
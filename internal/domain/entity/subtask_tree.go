@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// IsRoot indica si la subtarea cuelga directamente de la tarea
+func (s *Subtask) IsRoot() bool {
+	return s.ParentSubtaskID == nil
+}
+
+// AddChildSubtask añade una subtarea colgando de otra subtarea de la tarea
+func (t *Task) AddChildSubtask(parent, child *Subtask) {
+	parentID := parent.ID
+	child.ParentSubtaskID = &parentID
+	t.AddSubtask(child)
+}
+
+// ParentOf retorna la subtarea padre de una subtarea, o nil si cuelga directamente de la tarea
+// o si su padre está eliminado
+func (t *Task) ParentOf(subtask *Subtask) *Subtask {
+	if subtask.IsRoot() {
+		return nil
+	}
+	for _, candidate := range t.Subtasks {
+		if candidate.ID == *subtask.ParentSubtaskID && !candidate.IsDeleted() {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// ChildrenOf retorna las subtareas no eliminadas que cuelgan directamente de parent
+// Con parent nil retorna las subtareas raíz de la tarea
+func (t *Task) ChildrenOf(parent *Subtask) []*Subtask {
+	children := make([]*Subtask, 0)
+	for _, subtask := range t.Subtasks {
+		if subtask.IsDeleted() {
+			continue
+		}
+		if parent == nil && subtask.IsRoot() ||
+			parent != nil && !subtask.IsRoot() && *subtask.ParentSubtaskID == parent.ID {
+			children = append(children, subtask)
+		}
+	}
+	return children
+}
+
+// RootSubtasks retorna las subtareas no eliminadas que cuelgan directamente de la tarea
+func (t *Task) RootSubtasks() []*Subtask {
+	return t.ChildrenOf(nil)
+}
+
+// Ancestors retorna las subtareas padre de una subtarea, de la más cercana a la raíz
+func (t *Task) Ancestors(subtask *Subtask) []*Subtask {
+	ancestors := make([]*Subtask, 0)
+	for parent := t.ParentOf(subtask); parent != nil; parent = t.ParentOf(parent) {
+		ancestors = append(ancestors, parent)
+		if len(ancestors) > len(t.Subtasks) {
+			// Un ciclo en la jerarquía es un error de datos: cortar en lugar de iterar sin fin
+			break
+		}
+	}
+	return ancestors
+}
+
+// Descendants retorna todas las subtareas no eliminadas bajo una subtarea, a cualquier profundidad
+func (t *Task) Descendants(subtask *Subtask) []*Subtask {
+	descendants := make([]*Subtask, 0)
+	pending := t.ChildrenOf(subtask)
+	seen := make(map[uuid.UUID]bool)
+	for len(pending) > 0 {
+		next := pending[0]
+		pending = pending[1:]
+		if seen[next.ID] {
+			continue
+		}
+		seen[next.ID] = true
+		descendants = append(descendants, next)
+		pending = append(pending, t.ChildrenOf(next)...)
+	}
+	return descendants
+}
+
+// SetParentSubtask cuelga una subtarea de otra subtarea de la tarea, o de la tarea si parent es nil
+// Rechaza colgar una subtarea de sí misma o de uno de sus descendientes
+func (t *Task) SetParentSubtask(subtask, parent *Subtask) error {
+	if parent == nil {
+		subtask.ParentSubtaskID = nil
+		return nil
+	}
+
+	if parent.ID == subtask.ID {
+		return fmt.Errorf("%w: subtask %q cannot be its own parent", ErrInvalidSubtaskParent, subtask.Name)
+	}
+	for _, descendant := range t.Descendants(subtask) {
+		if descendant.ID == parent.ID {
+			return fmt.Errorf("%w: subtask %q cannot be nested under its own descendant %q",
+				ErrInvalidSubtaskParent, subtask.Name, parent.Name)
+		}
+	}
+
+	parentID := parent.ID
+	subtask.ParentSubtaskID = &parentID
+	return nil
+}
+
+// SubtaskSettlementState retorna el estado final que los hijos de una subtarea determinan para ella
+// según la política de finalización de la tarea. Retorna false si no tiene hijos o la política aún
+// no permite cerrarla
+func (t *Task) SubtaskSettlementState(subtask *Subtask) (State, bool) {
+	return t.CompletionPolicy.Outcome(t.ChildrenOf(subtask))
+}
+
+// SettleSubtaskFromChildren cierra una subtarea con el estado que determinan sus hijos
+// Como en SettleFromSubtasks, los descendientes ya finalizados conservan su estado y los abiertos
+// heredan el estado final. Retorna false si los hijos aún no permiten cerrarla
+// La validez de la transición debe comprobarse antes con la máquina de estados
+func (t *Task) SettleSubtaskFromChildren(subtask *Subtask) bool {
+	outcome, settled := t.SubtaskSettlementState(subtask)
+	if !settled {
+		return false
+	}
+
+	subtask.UpdateState(outcome)
+	for _, descendant := range t.Descendants(subtask) {
+		if !descendant.State.IsFinal() {
+			descendant.UpdateState(outcome)
+		}
+	}
+	return true
+}
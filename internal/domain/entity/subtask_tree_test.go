@@ -0,0 +1,197 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nestedTask crea una tarea con la jerarquía Build, Test > (Unit, Integration > Smoke)
+func nestedTask(t *testing.T) (task *Task, build, test, unit, integration, smoke *Subtask) {
+	t.Helper()
+
+	task = taskWithSubtasks(t, "Build", "Test")
+	build, test = task.Subtasks[0], task.Subtasks[1]
+
+	newChild := func(parent *Subtask, name string) *Subtask {
+		child, err := NewSubtask(name, newTestClock())
+		require.NoError(t, err)
+		task.AddChildSubtask(parent, child)
+		return child
+	}
+	unit = newChild(test, "Unit")
+	integration = newChild(test, "Integration")
+	smoke = newChild(integration, "Smoke")
+	return task, build, test, unit, integration, smoke
+}
+
+func subtaskNames(subtasks []*Subtask) []string {
+	names := make([]string, 0, len(subtasks))
+	for _, subtask := range subtasks {
+		names = append(names, subtask.Name)
+	}
+	return names
+}
+
+func TestTask_SubtaskTree(t *testing.T) {
+	task, build, test, unit, _, smoke := nestedTask(t)
+
+	t.Run("roots and children", func(t *testing.T) {
+		assert.True(t, build.IsRoot())
+		assert.False(t, unit.IsRoot())
+		assert.Equal(t, []string{"Build", "Test"}, subtaskNames(task.RootSubtasks()))
+		assert.Equal(t, []string{"Unit", "Integration"}, subtaskNames(task.ChildrenOf(test)))
+		assert.Empty(t, task.ChildrenOf(build))
+	})
+
+	t.Run("parent and ancestors", func(t *testing.T) {
+		assert.Nil(t, task.ParentOf(build))
+		assert.Equal(t, test, task.ParentOf(unit))
+		assert.Equal(t, []string{"Integration", "Test"}, subtaskNames(task.Ancestors(smoke)))
+	})
+
+	t.Run("descendants", func(t *testing.T) {
+		assert.Equal(t, []string{"Unit", "Integration", "Smoke"}, subtaskNames(task.Descendants(test)))
+		assert.Empty(t, task.Descendants(smoke))
+	})
+
+	t.Run("deleted subtasks are skipped", func(t *testing.T) {
+		task, _, test, unit, _, _ := nestedTask(t)
+		unit.Delete()
+
+		assert.Equal(t, []string{"Integration", "Smoke"}, subtaskNames(task.Descendants(test)))
+	})
+
+	t.Run("ancestors stop at a cycle in the data", func(t *testing.T) {
+		task, _, test, _, integration, _ := nestedTask(t)
+		integrationID := integration.ID
+		test.ParentSubtaskID = &integrationID
+
+		// Termina en lugar de iterar sin fin
+		assert.LessOrEqual(t, len(task.Ancestors(integration)), len(task.Subtasks)+1)
+	})
+}
+
+func TestTask_SetParentSubtask(t *testing.T) {
+	t.Run("moves a subtask under another", func(t *testing.T) {
+		task, build, test, _, _, _ := nestedTask(t)
+
+		require.NoError(t, task.SetParentSubtask(build, test))
+		assert.Equal(t, test.ID, *build.ParentSubtaskID)
+		assert.Equal(t, []string{"Test"}, subtaskNames(task.RootSubtasks()))
+	})
+
+	t.Run("nil moves it back to the task", func(t *testing.T) {
+		task, _, _, unit, _, _ := nestedTask(t)
+
+		require.NoError(t, task.SetParentSubtask(unit, nil))
+		assert.True(t, unit.IsRoot())
+	})
+
+	t.Run("cannot be its own parent", func(t *testing.T) {
+		task, build, _, _, _, _ := nestedTask(t)
+
+		err := task.SetParentSubtask(build, build)
+		assert.ErrorIs(t, err, ErrInvalidSubtaskParent)
+	})
+
+	t.Run("cannot be nested under a descendant", func(t *testing.T) {
+		task, _, test, _, _, smoke := nestedTask(t)
+
+		err := task.SetParentSubtask(test, smoke)
+		assert.ErrorIs(t, err, ErrInvalidSubtaskParent)
+		assert.True(t, test.IsRoot())
+	})
+}
+
+func TestTask_SettleSubtaskFromChildren(t *testing.T) {
+	t.Run("waits for every child", func(t *testing.T) {
+		task, _, test, unit, _, _ := nestedTask(t)
+		test.UpdateState(StateInProgress)
+		unit.UpdateState(StateCompleted)
+
+		assert.False(t, task.SettleSubtaskFromChildren(test))
+		assert.Equal(t, StateInProgress, test.State)
+	})
+
+	t.Run("completes when every child completes", func(t *testing.T) {
+		task, _, test, unit, integration, _ := nestedTask(t)
+		test.UpdateState(StateInProgress)
+		unit.UpdateState(StateCompleted)
+		integration.UpdateState(StateCompleted)
+
+		assert.True(t, task.SettleSubtaskFromChildren(test))
+		assert.Equal(t, StateCompleted, test.State)
+	})
+
+	t.Run("fail-fast closes open descendants", func(t *testing.T) {
+		task, _, test, unit, integration, smoke := nestedTask(t)
+		task.CompletionPolicy = CompletionFailFast
+		test.UpdateState(StateInProgress)
+		unit.UpdateState(StateFailed)
+
+		assert.True(t, task.SettleSubtaskFromChildren(test))
+		assert.Equal(t, StateFailed, test.State)
+		assert.Equal(t, StateFailed, integration.State)
+		assert.Equal(t, StateFailed, smoke.State)
+	})
+
+	t.Run("leaf subtasks are never settled", func(t *testing.T) {
+		task, build, _, _, _, _ := nestedTask(t)
+		build.UpdateState(StateInProgress)
+
+		assert.False(t, task.SettleSubtaskFromChildren(build))
+	})
+}
+
+func TestTask_SettlementState_OnlyRootSubtasks(t *testing.T) {
+	task, build, test, _, _, smoke := nestedTask(t)
+	task.State = StateInProgress
+	build.UpdateState(StateCompleted)
+	test.UpdateState(StateCompleted)
+
+	// Las subtareas anidadas las cierra su padre: no cuentan para la tarea
+	smoke.UpdateState(StateInProgress)
+
+	state, settled := task.SettlementState()
+	assert.True(t, settled)
+	assert.Equal(t, StateCompleted, state)
+}
+
+func TestTask_AncestorsToAutoStart(t *testing.T) {
+	t.Run("pending ancestors from the root down", func(t *testing.T) {
+		task, _, _, _, integration, smoke := nestedTask(t)
+		task.AutoStart = true
+
+		toStart := task.AncestorsToAutoStart(smoke, StateInProgress)
+		assert.Equal(t, []string{"Test", "Integration"}, subtaskNames(toStart))
+
+		integration.UpdateState(StateInProgress)
+		assert.Equal(t, []string{"Test"}, subtaskNames(task.AncestorsToAutoStart(smoke, StateInProgress)))
+	})
+
+	t.Run("only when starting with auto start", func(t *testing.T) {
+		task, _, _, _, _, smoke := nestedTask(t)
+
+		assert.Empty(t, task.AncestorsToAutoStart(smoke, StateInProgress))
+
+		task.AutoStart = true
+		assert.Empty(t, task.AncestorsToAutoStart(smoke, StateCompleted))
+	})
+}
+
+func TestTask_ReadySubtasks_Nested(t *testing.T) {
+	task, _, test, _, _, _ := nestedTask(t)
+	task.State = StateInProgress
+
+	// Las hijas de una subtarea PENDING no pueden arrancar sin AutoStart
+	assert.Equal(t, []string{"Build", "Test"}, subtaskNames(task.ReadySubtasks()))
+
+	test.UpdateState(StateInProgress)
+	assert.Equal(t, []string{"Build", "Unit", "Integration"}, subtaskNames(task.ReadySubtasks()))
+
+	task.AutoStart = true
+	test.UpdateState(StatePending)
+	assert.Equal(t, []string{"Build", "Test", "Unit", "Integration", "Smoke"}, subtaskNames(task.ReadySubtasks()))
+}
@@ -20,10 +20,45 @@ type Task struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
+	Version   int    // Versión de la fila para control de concurrencia optimista
+	Workflow  string // Perfil de workflow que define las transiciones permitidas
+	Attempt   int    // Número del intento en curso (ver Retry)
+
+	// Automation es la clave del automatismo del que la tarea es una ejecución; vacía en las tareas sueltas
+	Automation string
+
+	// CompletionPolicy determina cómo las subtareas cierran automáticamente la tarea (ver SettleFromSubtasks)
+	CompletionPolicy CompletionPolicy
+
+	// AutoStart hace que arrancar una subtarea pase la tarea PENDING a IN_PROGRESS (ver AutoStartsWith)
+	AutoStart bool
+
+	// Labels clasifican la tarea (entorno, equipo, unidad de negocio...) y permiten filtrar los listados
+	Labels map[string]string
+
+	// Metadata es un objeto JSON libre que la API guarda y devuelve sin interpretarlo
+	Metadata map[string]interface{}
+
+	// Failure es el motivo del fallo si la tarea está FAILED y se informó al cerrarla; nil en otro caso
+	Failure *Failure
+
+	// Input son los parámetros de entrada con los que se lanzó la tarea (ver SetInput); nil si no se informaron
+	Input Payload
+
+	// Output son los resultados que la tarea registró al terminar (ver SetOutput); nil si no se informaron
+	Output Payload
+	PauseTracking
+	ProgressTracking
+	HeartbeatTracking
+	SLATracking
+
+	// clock es el reloj del que la tarea y sus subtareas toman las fechas (ver SetClock)
+	clock Clock
 }
 
 // NewTask crea una nueva tarea con validaciones
-func NewTask(name, createdBy string) (*Task, error) {
+// Las fechas de la tarea se toman de clock; nil equivale a SystemClock
+func NewTask(name, createdBy string, clock Clock) (*Task, error) {
 	if err := validateName(name); err != nil {
 		return nil, err
 	}
@@ -34,7 +69,8 @@ func NewTask(name, createdBy string) (*Task, error) {
 		return nil, fmt.Errorf("%w: created_by exceeds 256 characters", ErrInvalidName)
 	}
 
-	now := time.Now()
+	clock = clockOrSystem(clock)
+	now := clock.Now()
 	return &Task{
 		ID:        uuid.New(),
 		Name:      name,
@@ -44,13 +80,37 @@ func NewTask(name, createdBy string) (*Task, error) {
 		UpdatedBy: createdBy,
 		CreatedAt: now,
 		UpdatedAt: now,
+		Version:   1,
+		Workflow:  DefaultWorkflow,
+		Attempt:   FirstAttempt,
+
+		CompletionPolicy: DefaultCompletionPolicy,
+
+		clock: clock,
 	}, nil
 }
 
-// AddSubtask añade una subtarea a la tarea
+// SetClock asigna el reloj del que la tarea y sus subtareas toman las fechas
+// Las tareas leídas del repositorio usan SystemClock hasta que el caso de uso les asigna el suyo
+func (t *Task) SetClock(clock Clock) {
+	t.clock = clock
+	for _, subtask := range t.Subtasks {
+		subtask.SetClock(clock)
+	}
+}
+
+// Now retorna el instante actual según el reloj de la tarea
+func (t *Task) Now() time.Time {
+	return clockOrSystem(t.clock).Now()
+}
+
+// AddSubtask añade una subtarea a la tarea, que pasa a usar el reloj de la tarea
 func (t *Task) AddSubtask(subtask *Subtask) {
+	if t.clock != nil {
+		subtask.SetClock(t.clock)
+	}
 	t.Subtasks = append(t.Subtasks, subtask)
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = t.Now()
 }
 
 // IsDeleted verifica si la tarea está eliminada
@@ -61,7 +121,7 @@ func (t *Task) IsDeleted() bool {
 // SetStartDate asigna la fecha de inicio si no está ya asignada
 func (t *Task) SetStartDate() {
 	if t.StartDate == nil {
-		now := time.Now()
+		now := t.Now()
 		t.StartDate = &now
 	}
 }
@@ -69,7 +129,7 @@ func (t *Task) SetStartDate() {
 // SetEndDate asigna la fecha de finalización si no está ya asignada
 func (t *Task) SetEndDate() {
 	if t.EndDate == nil {
-		now := time.Now()
+		now := t.Now()
 		t.EndDate = &now
 	}
 }
@@ -77,7 +137,7 @@ func (t *Task) SetEndDate() {
 // Delete marca la tarea como eliminada (soft delete)
 func (t *Task) Delete() {
 	if t.DeletedAt == nil {
-		now := time.Now()
+		now := t.Now()
 		t.DeletedAt = &now
 		t.UpdatedAt = now
 	}
@@ -94,21 +154,93 @@ func (t *Task) PropagateStateToSubtasks() {
 		if subtask.IsDeleted() {
 			continue
 		}
-		subtask.State = t.State
-		subtask.UpdatedAt = time.Now()
+		subtask.UpdateState(t.State)
+	}
+}
+
+// UpdateState actualiza el estado de la tarea y gestiona fechas
+func (t *Task) UpdateState(newState State, updatedBy string) error {
+	return t.UpdateStateWithFailure(newState, updatedBy, nil)
+}
 
-		// Asignar fechas si la subtarea no las tiene
-		if t.State == StateInProgress {
-			subtask.SetStartDate()
+// UpdateStateWithFailure actualiza el estado de la tarea como UpdateState registrando el motivo del fallo
+// failure es opcional y solo se admite al pasar a FAILED; cualquier otro cambio de estado lo elimina
+func (t *Task) UpdateStateWithFailure(newState State, updatedBy string, failure *Failure) error {
+	if err := validateFailureFor(newState, failure); err != nil {
+		return err
+	}
+	if err := t.applyState(newState, updatedBy); err != nil {
+		return err
+	}
+	if failure != nil {
+		t.Failure = failure
+	}
+
+	// Si el estado es final, propagar a subtareas
+	if newState.IsFinal() {
+		t.PropagateStateToSubtasks()
+	}
+
+	return nil
+}
+
+// SettleFromSubtasks cierra la tarea con el estado que sus subtareas determinan según su CompletionPolicy
+// A diferencia de UpdateState, las subtareas ya finalizadas conservan su estado (es el que decidió
+// el resultado) y solo las que siguen abiertas heredan el estado final de la tarea.
+// Retorna false si la política aún no permite cerrar la tarea
+// La validez de la transición debe comprobarse antes con la máquina de estados (ver SettlementState)
+func (t *Task) SettleFromSubtasks(updatedBy string) (bool, error) {
+	outcome, settled := t.SettlementState()
+	if !settled {
+		return false, nil
+	}
+
+	if err := t.applyState(outcome, updatedBy); err != nil {
+		return false, err
+	}
+
+	for _, subtask := range t.Subtasks {
+		if subtask.IsDeleted() || subtask.State.IsFinal() {
+			continue
 		}
-		if t.State.IsFinal() {
-			subtask.SetEndDate()
+		subtask.UpdateState(outcome)
+	}
+
+	return true, nil
+}
+
+// SettlementState retorna el estado final que las subtareas determinan para la tarea según su política
+// Solo cuentan las subtareas raíz: cada subtarea con hijos resume el estado de su rama
+// Retorna false si la política aún no permite cerrar la tarea
+func (t *Task) SettlementState() (State, bool) {
+	return t.CompletionPolicy.Outcome(t.RootSubtasks())
+}
+
+// AutoStartsWith indica si llevar una subtarea a newState debe arrancar implícitamente la tarea
+// Solo ocurre en tareas con AutoStart que siguen PENDING cuando una subtarea pasa a IN_PROGRESS
+func (t *Task) AutoStartsWith(newState State) bool {
+	return t.AutoStart && t.State == StatePending && newState == StateInProgress
+}
+
+// AncestorsToAutoStart retorna las subtareas padre PENDING que deben arrancar implícitamente cuando
+// subtask pasa a newState, de la raíz hacia abajo. Solo aplica en tareas con AutoStart
+func (t *Task) AncestorsToAutoStart(subtask *Subtask, newState State) []*Subtask {
+	toStart := make([]*Subtask, 0)
+	if !t.AutoStart || newState != StateInProgress {
+		return toStart
+	}
+
+	ancestors := t.Ancestors(subtask)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if ancestors[i].State == StatePending {
+			toStart = append(toStart, ancestors[i])
 		}
 	}
+	return toStart
 }
 
-// UpdateState actualiza el estado de la tarea y gestiona fechas
-func (t *Task) UpdateState(newState State, updatedBy string) error {
+// applyState actualiza el estado de la tarea, sus fechas y sus intervalos de pausa sin tocar las subtareas
+func (t *Task) applyState(newState State, updatedBy string) error {
 	if !newState.IsValid() {
 		return fmt.Errorf("%w: invalid state %s", ErrInvalidStateTransition, newState)
 	}
@@ -125,14 +257,22 @@ func (t *Task) UpdateState(newState State, updatedBy string) error {
 		t.SetEndDate()
 	}
 
+	// El motivo del fallo solo se conserva mientras la tarea siga FAILED
+	if newState != t.State {
+		t.Failure = nil
+	}
+
+	now := t.Now()
+	t.trackStateChange(t.State, newState, now)
 	t.State = newState
 	t.UpdatedBy = updatedBy
-	t.UpdatedAt = time.Now()
-
-	// Si el estado es final, propagar a subtareas
-	if newState.IsFinal() {
-		t.PropagateStateToSubtasks()
-	}
+	t.UpdatedAt = now
 
 	return nil
 }
+
+// ActiveDuration retorna el tiempo de ejecución de la tarea excluyendo las pausas,
+// medido hasta su fecha de fin o hasta now si sigue abierta. Retorna false si no ha comenzado
+func (t *Task) ActiveDuration(now time.Time) (time.Duration, bool) {
+	return t.activeDuration(t.StartDate, t.EndDate, now)
+}
@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType representa el tipo de cambio registrado en el historial de una tarea
+type EventType string
+
+const (
+	// EventTaskCreated indica que la tarea fue creada
+	EventTaskCreated EventType = "TASK_CREATED"
+
+	// EventTaskRenamed indica que la tarea cambió de nombre
+	EventTaskRenamed EventType = "TASK_RENAMED"
+
+	// EventTaskStateChanged indica que la tarea cambió de estado
+	EventTaskStateChanged EventType = "TASK_STATE_CHANGED"
+
+	// EventTaskDeleted indica que la tarea fue eliminada (soft delete)
+	EventTaskDeleted EventType = "TASK_DELETED"
+
+	// EventTaskRestored indica que la tarea fue restaurada tras un soft delete
+	EventTaskRestored EventType = "TASK_RESTORED"
+
+	// EventTaskRetried indica que se abrió un nuevo intento de la tarea (valores: número de intento)
+	EventTaskRetried EventType = "TASK_RETRIED"
+
+	// EventTaskSLABreached indica que venció el plazo del SLA sin que la tarea terminase
+	// Se registra una vez por intento, fechado en el vencimiento y a nombre de SystemActor
+	// (valor nuevo: vencimiento en RFC 3339)
+	EventTaskSLABreached EventType = "TASK_SLA_BREACHED"
+
+	// EventSubtaskAdded indica que se añadió una subtarea
+	EventSubtaskAdded EventType = "SUBTASK_ADDED"
+
+	// EventSubtaskRenamed indica que una subtarea cambió de nombre
+	EventSubtaskRenamed EventType = "SUBTASK_RENAMED"
+
+	// EventSubtaskStateChanged indica que una subtarea cambió de estado
+	EventSubtaskStateChanged EventType = "SUBTASK_STATE_CHANGED"
+
+	// EventSubtaskRemoved indica que se eliminó una subtarea (soft delete)
+	EventSubtaskRemoved EventType = "SUBTASK_REMOVED"
+)
+
+// String retorna la representación en string del tipo de evento
+func (e EventType) String() string {
+	return string(e)
+}
+
+// TaskEvent representa una entrada del historial de una tarea
+// Los eventos de subtareas se registran en el historial de su tarea padre con SubtaskID informado
+type TaskEvent struct {
+	ID         int64 // Secuencial asignado por la base de datos; desempata eventos simultáneos
+	TaskID     uuid.UUID
+	SubtaskID  *uuid.UUID
+	Type       EventType
+	Actor      string  // Equipo/persona que provocó el cambio (updated_by)
+	OldValue   *string // Valor anterior (nombre o estado), nil si no aplica
+	NewValue   *string // Valor nuevo (nombre o estado), nil si no aplica
+	OccurredAt time.Time
+}
+
+// NewTaskEvent crea un evento del historial de una tarea ocurrido en occurredAt
+func NewTaskEvent(taskID uuid.UUID, eventType EventType, actor string, oldValue, newValue *string, occurredAt time.Time) *TaskEvent {
+	return &TaskEvent{
+		TaskID:     taskID,
+		Type:       eventType,
+		Actor:      actor,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+		OccurredAt: occurredAt,
+	}
+}
+
+// NewSubtaskEvent crea un evento del historial referido a una subtarea
+func NewSubtaskEvent(taskID, subtaskID uuid.UUID, eventType EventType, actor string, oldValue, newValue *string, occurredAt time.Time) *TaskEvent {
+	event := NewTaskEvent(taskID, eventType, actor, oldValue, newValue, occurredAt)
+	event.SubtaskID = &subtaskID
+	return event
+}
+
+// TaskSnapshot captura los valores auditables de una tarea y sus subtareas
+// Se toma antes de aplicar cambios para después calcular los eventos con EventsSince
+type TaskSnapshot struct {
+	name        string
+	state       State
+	slaBreached bool
+	subtasks    map[uuid.UUID]SubtaskSnapshot
+}
+
+// SubtaskSnapshot captura los valores auditables de una subtarea
+type SubtaskSnapshot struct {
+	name    string
+	state   State
+	deleted bool
+}
+
+// Snapshot captura el estado actual de la tarea y de sus subtareas
+func (t *Task) Snapshot() TaskSnapshot {
+	subtasks := make(map[uuid.UUID]SubtaskSnapshot, len(t.Subtasks))
+	for _, st := range t.Subtasks {
+		subtasks[st.ID] = st.Snapshot()
+	}
+
+	return TaskSnapshot{
+		name:        t.Name,
+		state:       t.State,
+		slaBreached: t.SLABreachedAt != nil,
+		subtasks:    subtasks,
+	}
+}
+
+// EventsSince retorna los eventos que describen los cambios de la tarea desde before
+// Incluye los cambios propagados a las subtareas (p. ej. al llegar la tarea a un estado final)
+func (t *Task) EventsSince(before TaskSnapshot, actor string) []*TaskEvent {
+	events := make([]*TaskEvent, 0)
+	now := t.Now()
+
+	if t.Name != before.name {
+		events = append(events, NewTaskEvent(t.ID, EventTaskRenamed, actor, stringRef(before.name), stringRef(t.Name), now))
+	}
+	if t.State != before.state {
+		events = append(events, NewTaskEvent(t.ID, EventTaskStateChanged, actor,
+			stringRef(before.state.String()), stringRef(t.State.String()), now))
+	}
+	if t.SLABreachedAt != nil && !before.slaBreached {
+		events = append(events, NewTaskEvent(t.ID, EventTaskSLABreached, SystemActor,
+			nil, stringRef(t.SLABreachedAt.Format(time.RFC3339)), *t.SLABreachedAt))
+	}
+
+	for _, st := range t.Subtasks {
+		previous, existed := before.subtasks[st.ID]
+		if !existed {
+			if st.IsDeleted() {
+				continue
+			}
+			events = append(events, NewSubtaskEvent(t.ID, st.ID, EventSubtaskAdded, actor, nil, stringRef(st.Name), now))
+			previous = SubtaskSnapshot{name: st.Name, state: StatePending}
+		}
+		events = append(events, st.EventsSince(t.ID, previous, actor)...)
+	}
+
+	return events
+}
+
+// CreationEvents retorna los eventos que describen la creación de la tarea,
+// incluyendo sus subtareas y los estados iniciales distintos de PENDING
+func (t *Task) CreationEvents(actor string) []*TaskEvent {
+	events := []*TaskEvent{
+		NewTaskEvent(t.ID, EventTaskCreated, actor, nil, stringRef(t.Name), t.Now()),
+	}
+
+	initial := TaskSnapshot{
+		name:     t.Name,
+		state:    StatePending,
+		subtasks: map[uuid.UUID]SubtaskSnapshot{},
+	}
+
+	return append(events, t.EventsSince(initial, actor)...)
+}
+
+// Snapshot captura el estado actual de la subtarea
+func (s *Subtask) Snapshot() SubtaskSnapshot {
+	return SubtaskSnapshot{
+		name:    s.Name,
+		state:   s.State,
+		deleted: s.IsDeleted(),
+	}
+}
+
+// EventsSince retorna los eventos que describen los cambios de la subtarea desde before
+func (s *Subtask) EventsSince(taskID uuid.UUID, before SubtaskSnapshot, actor string) []*TaskEvent {
+	events := make([]*TaskEvent, 0)
+
+	if before.deleted {
+		return events
+	}
+
+	now := s.Now()
+	if s.Name != before.name {
+		events = append(events, NewSubtaskEvent(taskID, s.ID, EventSubtaskRenamed, actor, stringRef(before.name), stringRef(s.Name), now))
+	}
+	if s.State != before.state {
+		events = append(events, NewSubtaskEvent(taskID, s.ID, EventSubtaskStateChanged, actor,
+			stringRef(before.state.String()), stringRef(s.State.String()), now))
+	}
+	if s.IsDeleted() {
+		events = append(events, NewSubtaskEvent(taskID, s.ID, EventSubtaskRemoved, actor, stringRef(s.Name), nil, now))
+	}
+
+	return events
+}
+
+// stringRef retorna un puntero a una copia del string
+func stringRef(s string) *string {
+	return &s
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventSummary resume un evento para comparar en los tests
+type eventSummary struct {
+	Type       EventType
+	HasSubtask bool
+	OldValue   string
+	NewValue   string
+}
+
+func summarize(events []*TaskEvent) []eventSummary {
+	summaries := make([]eventSummary, 0, len(events))
+	for _, e := range events {
+		s := eventSummary{Type: e.Type, HasSubtask: e.SubtaskID != nil}
+		if e.OldValue != nil {
+			s.OldValue = *e.OldValue
+		}
+		if e.NewValue != nil {
+			s.NewValue = *e.NewValue
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries
+}
+
+func TestTask_EventsSince(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(task *Task)
+		want   []eventSummary
+	}{
+		{
+			name:   "no changes",
+			mutate: func(task *Task) {},
+			want:   []eventSummary{},
+		},
+		{
+			name: "rename and state change",
+			mutate: func(task *Task) {
+				task.Name = "Renamed Task"
+				task.State = StateInProgress
+			},
+			want: []eventSummary{
+				{Type: EventTaskRenamed, OldValue: "Test Task", NewValue: "Renamed Task"},
+				{Type: EventTaskStateChanged, OldValue: "PENDING", NewValue: "IN_PROGRESS"},
+			},
+		},
+		{
+			name: "final state propagated to subtasks",
+			mutate: func(task *Task) {
+				task.State = StateInProgress
+				_ = task.UpdateState(StateCancelled, "test-user")
+			},
+			want: []eventSummary{
+				{Type: EventTaskStateChanged, OldValue: "PENDING", NewValue: "CANCELLED"},
+				{Type: EventSubtaskStateChanged, HasSubtask: true, OldValue: "PENDING", NewValue: "CANCELLED"},
+			},
+		},
+		{
+			name: "subtask added with initial state",
+			mutate: func(task *Task) {
+				subtask, _ := NewSubtask("New Subtask", newTestClock())
+				subtask.State = StateInProgress
+				task.AddSubtask(subtask)
+			},
+			want: []eventSummary{
+				{Type: EventSubtaskAdded, HasSubtask: true, NewValue: "New Subtask"},
+				{Type: EventSubtaskStateChanged, HasSubtask: true, OldValue: "PENDING", NewValue: "IN_PROGRESS"},
+			},
+		},
+		{
+			name: "subtask removed",
+			mutate: func(task *Task) {
+				task.Subtasks[0].Delete()
+			},
+			want: []eventSummary{
+				{Type: EventSubtaskRemoved, HasSubtask: true, OldValue: "Subtask 1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := NewTask("Test Task", "test-user", newTestClock())
+			require.NoError(t, err)
+			subtask, err := NewSubtask("Subtask 1", newTestClock())
+			require.NoError(t, err)
+			task.AddSubtask(subtask)
+
+			before := task.Snapshot()
+			tt.mutate(task)
+
+			events := task.EventsSince(before, "test-user")
+			assert.Equal(t, tt.want, summarize(events))
+			for _, e := range events {
+				assert.Equal(t, task.ID, e.TaskID)
+				assert.Equal(t, "test-user", e.Actor)
+			}
+		})
+	}
+}
+
+func TestTask_CreationEvents(t *testing.T) {
+	task, err := NewTask("Test Task", "test-user", newTestClock())
+	require.NoError(t, err)
+	pending, _ := NewSubtask("Subtask 1", newTestClock())
+	started, _ := NewSubtask("Subtask 2", newTestClock())
+	task.AddSubtask(pending)
+	task.AddSubtask(started)
+	task.State = StateInProgress
+	started.State = StateInProgress
+
+	events := task.CreationEvents("test-user")
+
+	assert.Equal(t, []eventSummary{
+		{Type: EventTaskCreated, NewValue: "Test Task"},
+		{Type: EventTaskStateChanged, OldValue: "PENDING", NewValue: "IN_PROGRESS"},
+		{Type: EventSubtaskAdded, HasSubtask: true, NewValue: "Subtask 1"},
+		{Type: EventSubtaskAdded, HasSubtask: true, NewValue: "Subtask 2"},
+		{Type: EventSubtaskStateChanged, HasSubtask: true, OldValue: "PENDING", NewValue: "IN_PROGRESS"},
+	}, summarize(events))
+}
@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LogLevel representa la severidad de una línea de log
+type LogLevel string
+
+const (
+	// LogLevelDebug es el nivel de las trazas de depuración
+	LogLevelDebug LogLevel = "DEBUG"
+
+	// LogLevelInfo es el nivel de los mensajes informativos
+	LogLevelInfo LogLevel = "INFO"
+
+	// LogLevelWarn es el nivel de los avisos
+	LogLevelWarn LogLevel = "WARN"
+
+	// LogLevelError es el nivel de los errores
+	LogLevelError LogLevel = "ERROR"
+)
+
+// logLevels son los niveles de log de menor a mayor severidad
+var logLevels = []LogLevel{LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError}
+
+const (
+	// MaxLogBatchSize es el número máximo de líneas que acepta un envío de logs
+	MaxLogBatchSize = 1000
+
+	// MaxLogMessageLength es la longitud máxima en bytes del mensaje de una línea de log
+	MaxLogMessageLength = 8 * 1024
+
+	// MaxLogLinesPerTask es el número máximo de líneas de log que se guardan por tarea
+	MaxLogLinesPerTask = 50000
+)
+
+// String retorna la representación en string del nivel de log
+func (l LogLevel) String() string {
+	return string(l)
+}
+
+// ParseLogLevel convierte un string a LogLevel sin distinguir mayúsculas; retorna false si no es un nivel conocido
+func ParseLogLevel(s string) (LogLevel, bool) {
+	level := LogLevel(strings.ToUpper(s))
+	for _, known := range logLevels {
+		if level == known {
+			return level, true
+		}
+	}
+	return "", false
+}
+
+// AndAbove retorna el nivel y todos los de mayor severidad
+func (l LogLevel) AndAbove() []LogLevel {
+	for i, known := range logLevels {
+		if l == known {
+			return logLevels[i:]
+		}
+	}
+	return nil
+}
+
+// TaskLogLine representa una línea de log enviada por el proceso de una tarea
+// Las líneas solo se añaden: no se modifican y se purgan junto con su tarea
+type TaskLogLine struct {
+	ID         int64 // Secuencial asignado por la base de datos; desempata líneas simultáneas
+	TaskID     uuid.UUID
+	SubtaskID  *uuid.UUID // Subtarea que emitió la línea, nil si es de la tarea
+	Level      LogLevel
+	Message    string
+	LoggedAt   time.Time // Momento en que el proceso emitió la línea
+	ReceivedAt time.Time // Momento en que la API recibió la línea
+}
+
+// NewTaskLogLine crea y valida una línea de log recibida en receivedAt
+// Si loggedAt es cero la línea se fecha en su recepción
+func NewTaskLogLine(taskID uuid.UUID, subtaskID *uuid.UUID, level, message string, loggedAt, receivedAt time.Time) (*TaskLogLine, error) {
+	parsed, ok := ParseLogLevel(level)
+	if !ok {
+		return nil, fmt.Errorf("%w: level must be one of DEBUG, INFO, WARN, ERROR, got %q", ErrInvalidLogLine, level)
+	}
+	if message == "" {
+		return nil, fmt.Errorf("%w: message is required", ErrInvalidLogLine)
+	}
+	if len(message) > MaxLogMessageLength {
+		return nil, fmt.Errorf("%w: message exceeds %d bytes", ErrInvalidLogLine, MaxLogMessageLength)
+	}
+	if loggedAt.IsZero() {
+		loggedAt = receivedAt
+	}
+
+	return &TaskLogLine{
+		TaskID:     taskID,
+		SubtaskID:  subtaskID,
+		Level:      parsed,
+		Message:    message,
+		LoggedAt:   loggedAt,
+		ReceivedAt: receivedAt,
+	}, nil
+}
+
+// TaskLogCursor identifica la última línea de una página de logs: la página siguiente empieza después de ella
+// Las líneas se ordenan por LoggedAt y, a igualdad, por ID
+type TaskLogCursor struct {
+	LoggedAt time.Time
+	ID       int64
+}
+
+// String codifica el cursor como un token opaco apto para query strings
+func (c TaskLogCursor) String() string {
+	raw := strconv.FormatInt(c.LoggedAt.UnixNano(), 10) + ":" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseTaskLogCursor decodifica un cursor generado por TaskLogCursor.String
+func ParseTaskLogCursor(s string) (TaskLogCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return TaskLogCursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidLogQuery)
+	}
+
+	nanos, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return TaskLogCursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidLogQuery)
+	}
+	loggedAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return TaskLogCursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidLogQuery)
+	}
+	lineID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || lineID < 1 {
+		return TaskLogCursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidLogQuery)
+	}
+
+	return TaskLogCursor{LoggedAt: time.Unix(0, loggedAt).UTC(), ID: lineID}, nil
+}
+
+// Cursor retorna el cursor que apunta a esta línea
+func (l *TaskLogLine) Cursor() TaskLogCursor {
+	return TaskLogCursor{LoggedAt: l.LoggedAt, ID: l.ID}
+}
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  LogLevel
+		ok    bool
+	}{
+		{input: "DEBUG", want: LogLevelDebug, ok: true},
+		{input: "info", want: LogLevelInfo, ok: true},
+		{input: "Warn", want: LogLevelWarn, ok: true},
+		{input: "ERROR", want: LogLevelError, ok: true},
+		{input: "TRACE"},
+		{input: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseLogLevel(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLogLevel_AndAbove(t *testing.T) {
+	assert.Equal(t, []LogLevel{LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError}, LogLevelDebug.AndAbove())
+	assert.Equal(t, []LogLevel{LogLevelWarn, LogLevelError}, LogLevelWarn.AndAbove())
+	assert.Equal(t, []LogLevel{LogLevelError}, LogLevelError.AndAbove())
+	assert.Nil(t, LogLevel("TRACE").AndAbove())
+}
+
+func TestNewTaskLogLine(t *testing.T) {
+	taskID := uuid.New()
+	receivedAt := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("valid line", func(t *testing.T) {
+		subtaskID := uuid.New()
+		loggedAt := receivedAt.Add(-time.Second)
+
+		line, err := NewTaskLogLine(taskID, &subtaskID, "warn", "disk almost full", loggedAt, receivedAt)
+		require.NoError(t, err)
+		assert.Equal(t, taskID, line.TaskID)
+		assert.Equal(t, &subtaskID, line.SubtaskID)
+		assert.Equal(t, LogLevelWarn, line.Level)
+		assert.Equal(t, "disk almost full", line.Message)
+		assert.Equal(t, loggedAt, line.LoggedAt)
+		assert.Equal(t, receivedAt, line.ReceivedAt)
+	})
+
+	t.Run("missing timestamp defaults to reception time", func(t *testing.T) {
+		line, err := NewTaskLogLine(taskID, nil, "INFO", "started", time.Time{}, receivedAt)
+		require.NoError(t, err)
+		assert.Equal(t, receivedAt, line.LoggedAt)
+	})
+
+	t.Run("invalid lines", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			level   string
+			message string
+		}{
+			{name: "unknown level", level: "TRACE", message: "hello"},
+			{name: "empty message", level: "INFO", message: ""},
+			{name: "message too long", level: "INFO", message: strings.Repeat("x", MaxLogMessageLength+1)},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := NewTaskLogLine(taskID, nil, tt.level, tt.message, time.Time{}, receivedAt)
+				assert.ErrorIs(t, err, ErrInvalidLogLine)
+			})
+		}
+	})
+}
+
+func TestTaskLogCursor_RoundTrip(t *testing.T) {
+	cursor := TaskLogCursor{LoggedAt: time.Date(2026, 3, 1, 8, 0, 0, 123456789, time.UTC), ID: 42}
+
+	parsed, err := ParseTaskLogCursor(cursor.String())
+	require.NoError(t, err)
+	assert.True(t, cursor.LoggedAt.Equal(parsed.LoggedAt))
+	assert.Equal(t, cursor.ID, parsed.ID)
+}
+
+func TestParseTaskLogCursor_Malformed(t *testing.T) {
+	inputs := []string{
+		"not:a:cursor",
+		"bm8tY29sb24", // "no-colon"
+		"YWJjOjQy",    // "abc:42"
+		"MTIzOmFiYw",  // "123:abc"
+		"MTIzOjA",     // "123:0"
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseTaskLogCursor(input)
+			assert.ErrorIs(t, err, ErrInvalidLogQuery)
+		})
+	}
+}
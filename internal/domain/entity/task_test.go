@@ -63,7 +63,7 @@ func TestNewTask(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			task, err := NewTask(tt.taskName, tt.createdBy)
+			task, err := NewTask(tt.taskName, tt.createdBy, newTestClock())
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -78,8 +78,8 @@ func TestNewTask(t *testing.T) {
 				assert.Equal(t, tt.createdBy, task.UpdatedBy)
 				assert.Equal(t, StatePending, task.State)
 				assert.Empty(t, task.Subtasks)
-				assert.False(t, task.CreatedAt.IsZero())
-				assert.False(t, task.UpdatedAt.IsZero())
+				assert.Equal(t, testTime, task.CreatedAt)
+				assert.Equal(t, testTime, task.UpdatedAt)
 				assert.Nil(t, task.StartDate)
 				assert.Nil(t, task.EndDate)
 				assert.Nil(t, task.DeletedAt)
@@ -89,17 +89,18 @@ func TestNewTask(t *testing.T) {
 }
 
 func TestTask_AddSubtask(t *testing.T) {
-	task, _ := NewTask("Test Task", "Team A")
-	subtask1, _ := NewSubtask("Subtask 1")
-	subtask2, _ := NewSubtask("Subtask 2")
+	clock := newTestClock()
+	task, _ := NewTask("Test Task", "Team A", clock)
+	subtask1, _ := NewSubtask("Subtask 1", nil)
+	subtask2, _ := NewSubtask("Subtask 2", nil)
 
-	originalUpdatedAt := task.UpdatedAt
-	time.Sleep(1 * time.Millisecond)
+	clock.Advance(time.Minute)
 
 	task.AddSubtask(subtask1)
 	assert.Len(t, task.Subtasks, 1)
 	assert.Equal(t, subtask1, task.Subtasks[0])
-	assert.True(t, task.UpdatedAt.After(originalUpdatedAt))
+	assert.Equal(t, testTime.Add(time.Minute), task.UpdatedAt)
+	assert.Equal(t, testTime.Add(time.Minute), subtask1.Now(), "the subtask takes the task clock")
 
 	task.AddSubtask(subtask2)
 	assert.Len(t, task.Subtasks, 2)
@@ -108,13 +109,13 @@ func TestTask_AddSubtask(t *testing.T) {
 
 func TestTask_IsDeleted(t *testing.T) {
 	t.Run("not deleted", func(t *testing.T) {
-		task, _ := NewTask("Test", "Team A")
+		task, _ := NewTask("Test", "Team A", newTestClock())
 		assert.False(t, task.IsDeleted())
 	})
 
 	t.Run("is deleted", func(t *testing.T) {
-		task, _ := NewTask("Test", "Team A")
-		now := time.Now()
+		task, _ := NewTask("Test", "Team A", newTestClock())
+		now := testTime
 		task.DeletedAt = &now
 		assert.True(t, task.IsDeleted())
 	})
@@ -122,21 +123,20 @@ func TestTask_IsDeleted(t *testing.T) {
 
 func TestTask_SetStartDate(t *testing.T) {
 	t.Run("set start date when nil", func(t *testing.T) {
-		task, _ := NewTask("Test", "Team A")
+		clock := newTestClock()
+		task, _ := NewTask("Test", "Team A", clock)
 		assert.Nil(t, task.StartDate)
 
-		beforeSet := time.Now()
+		clock.Advance(time.Minute)
 		task.SetStartDate()
-		afterSet := time.Now()
 
 		require.NotNil(t, task.StartDate)
-		assert.True(t, task.StartDate.After(beforeSet) || task.StartDate.Equal(beforeSet))
-		assert.True(t, task.StartDate.Before(afterSet) || task.StartDate.Equal(afterSet))
+		assert.Equal(t, testTime.Add(time.Minute), *task.StartDate)
 	})
 
 	t.Run("does not override existing start date", func(t *testing.T) {
-		task, _ := NewTask("Test", "Team A")
-		originalDate := time.Now().Add(-1 * time.Hour)
+		task, _ := NewTask("Test", "Team A", newTestClock())
+		originalDate := testTime.Add(-1 * time.Hour)
 		task.StartDate = &originalDate
 
 		task.SetStartDate()
@@ -146,21 +146,20 @@ func TestTask_SetStartDate(t *testing.T) {
 
 func TestTask_SetEndDate(t *testing.T) {
 	t.Run("set end date when nil", func(t *testing.T) {
-		task, _ := NewTask("Test", "Team A")
+		clock := newTestClock()
+		task, _ := NewTask("Test", "Team A", clock)
 		assert.Nil(t, task.EndDate)
 
-		beforeSet := time.Now()
+		clock.Advance(time.Minute)
 		task.SetEndDate()
-		afterSet := time.Now()
 
 		require.NotNil(t, task.EndDate)
-		assert.True(t, task.EndDate.After(beforeSet) || task.EndDate.Equal(beforeSet))
-		assert.True(t, task.EndDate.Before(afterSet) || task.EndDate.Equal(afterSet))
+		assert.Equal(t, testTime.Add(time.Minute), *task.EndDate)
 	})
 
 	t.Run("does not override existing end date", func(t *testing.T) {
-		task, _ := NewTask("Test", "Team A")
-		originalDate := time.Now().Add(-1 * time.Hour)
+		task, _ := NewTask("Test", "Team A", newTestClock())
+		originalDate := testTime.Add(-1 * time.Hour)
 		task.EndDate = &originalDate
 
 		task.SetEndDate()
@@ -170,37 +169,37 @@ func TestTask_SetEndDate(t *testing.T) {
 
 func TestTask_Delete(t *testing.T) {
 	t.Run("delete task", func(t *testing.T) {
-		task, _ := NewTask("Test", "Team A")
-		originalUpdatedAt := task.UpdatedAt
+		clock := newTestClock()
+		task, _ := NewTask("Test", "Team A", clock)
 		assert.Nil(t, task.DeletedAt)
 
-		time.Sleep(1 * time.Millisecond)
+		clock.Advance(time.Minute)
 		task.Delete()
 
 		require.NotNil(t, task.DeletedAt)
 		assert.True(t, task.IsDeleted())
-		assert.True(t, task.UpdatedAt.After(originalUpdatedAt))
+		assert.Equal(t, testTime.Add(time.Minute), *task.DeletedAt)
+		assert.Equal(t, testTime.Add(time.Minute), task.UpdatedAt)
 	})
 
 	t.Run("delete already deleted task does not change date", func(t *testing.T) {
-		task, _ := NewTask("Test", "Team A")
+		clock := newTestClock()
+		task, _ := NewTask("Test", "Team A", clock)
 		task.Delete()
-		originalDeletedAt := *task.DeletedAt
-		originalUpdatedAt := task.UpdatedAt
 
-		time.Sleep(1 * time.Millisecond)
+		clock.Advance(time.Minute)
 		task.Delete()
 
-		assert.Equal(t, originalDeletedAt, *task.DeletedAt)
-		assert.Equal(t, originalUpdatedAt, task.UpdatedAt)
+		assert.Equal(t, testTime, *task.DeletedAt)
+		assert.Equal(t, testTime, task.UpdatedAt)
 	})
 }
 
 func TestTask_PropagateStateToSubtasks(t *testing.T) {
 	t.Run("propagate COMPLETED state to all subtasks", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
-		subtask1, _ := NewSubtask("Subtask 1")
-		subtask2, _ := NewSubtask("Subtask 2")
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
+		subtask1, _ := NewSubtask("Subtask 1", newTestClock())
+		subtask2, _ := NewSubtask("Subtask 2", newTestClock())
 		subtask1.State = StateInProgress
 		subtask2.State = StatePending
 
@@ -217,8 +216,8 @@ func TestTask_PropagateStateToSubtasks(t *testing.T) {
 	})
 
 	t.Run("propagate FAILED state to all subtasks", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
-		subtask1, _ := NewSubtask("Subtask 1")
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
+		subtask1, _ := NewSubtask("Subtask 1", newTestClock())
 		task.AddSubtask(subtask1)
 
 		task.State = StateFailed
@@ -229,8 +228,8 @@ func TestTask_PropagateStateToSubtasks(t *testing.T) {
 	})
 
 	t.Run("does not propagate non-final states", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
-		subtask1, _ := NewSubtask("Subtask 1")
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
+		subtask1, _ := NewSubtask("Subtask 1", newTestClock())
 		task.AddSubtask(subtask1)
 
 		task.State = StateInProgress
@@ -240,8 +239,8 @@ func TestTask_PropagateStateToSubtasks(t *testing.T) {
 	})
 
 	t.Run("skips deleted subtasks", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
-		subtask1, _ := NewSubtask("Subtask 1")
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
+		subtask1, _ := NewSubtask("Subtask 1", newTestClock())
 		subtask1.Delete()
 		task.AddSubtask(subtask1)
 
@@ -255,7 +254,7 @@ func TestTask_PropagateStateToSubtasks(t *testing.T) {
 
 func TestTask_UpdateState(t *testing.T) {
 	t.Run("update to IN_PROGRESS sets start date", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
 		assert.Nil(t, task.StartDate)
 
 		err := task.UpdateState(StateInProgress, "Team B")
@@ -268,8 +267,8 @@ func TestTask_UpdateState(t *testing.T) {
 	})
 
 	t.Run("update to COMPLETED sets end date and propagates", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
-		subtask1, _ := NewSubtask("Subtask 1")
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
+		subtask1, _ := NewSubtask("Subtask 1", newTestClock())
 		task.AddSubtask(subtask1)
 
 		err := task.UpdateState(StateCompleted, "Team A")
@@ -282,8 +281,8 @@ func TestTask_UpdateState(t *testing.T) {
 	})
 
 	t.Run("update to FAILED sets end date and propagates", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
-		subtask1, _ := NewSubtask("Subtask 1")
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
+		subtask1, _ := NewSubtask("Subtask 1", newTestClock())
 		task.AddSubtask(subtask1)
 
 		err := task.UpdateState(StateFailed, "Team A")
@@ -295,7 +294,7 @@ func TestTask_UpdateState(t *testing.T) {
 	})
 
 	t.Run("invalid state returns error", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
 
 		err := task.UpdateState(State("INVALID"), "Team A")
 		require.Error(t, err)
@@ -303,7 +302,7 @@ func TestTask_UpdateState(t *testing.T) {
 	})
 
 	t.Run("empty updated_by returns error", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
 
 		err := task.UpdateState(StateInProgress, "")
 		require.Error(t, err)
@@ -311,13 +310,40 @@ func TestTask_UpdateState(t *testing.T) {
 	})
 
 	t.Run("updates updated_at timestamp", func(t *testing.T) {
-		task, _ := NewTask("Test Task", "Team A")
-		originalUpdatedAt := task.UpdatedAt
+		clock := newTestClock()
+		task, _ := NewTask("Test Task", "Team A", clock)
 
-		time.Sleep(1 * time.Millisecond)
+		clock.Advance(time.Minute)
 		err := task.UpdateState(StateInProgress, "Team B")
 		require.NoError(t, err)
 
-		assert.True(t, task.UpdatedAt.After(originalUpdatedAt))
+		assert.Equal(t, testTime.Add(time.Minute), task.UpdatedAt)
+		assert.Equal(t, testTime.Add(time.Minute), *task.StartDate)
+	})
+}
+
+func TestTask_AutoStartsWith(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
+
+		assert.False(t, task.AutoStart)
+		assert.False(t, task.AutoStartsWith(StateInProgress))
+	})
+
+	t.Run("pending task starts with its first subtask", func(t *testing.T) {
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
+		task.AutoStart = true
+
+		assert.True(t, task.AutoStartsWith(StateInProgress))
+		assert.False(t, task.AutoStartsWith(StateCancelled))
+	})
+
+	t.Run("only pending tasks are started", func(t *testing.T) {
+		task, _ := NewTask("Test Task", "Team A", newTestClock())
+		task.AutoStart = true
+		require.NoError(t, task.UpdateState(StateInProgress, "Team A"))
+		require.NoError(t, task.UpdateState(StatePaused, "Team A"))
+
+		assert.False(t, task.AutoStartsWith(StateInProgress))
 	})
 }
@@ -0,0 +1,12 @@
+package entity
+
+import "fmt"
+
+// ValidateVersion verifica que la versión esperada por el cliente coincida con la versión actual
+// Si no se espera ninguna versión (nil), la validación se omite
+func ValidateVersion(expected *int, current int) error {
+	if expected != nil && *expected != current {
+		return fmt.Errorf("%w: expected version %d, current version %d", ErrVersionConflict, *expected, current)
+	}
+	return nil
+}
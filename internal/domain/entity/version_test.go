@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVersion(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name     string
+		expected *int
+		current  int
+		wantErr  bool
+	}{
+		{
+			name:     "no expected version skips validation",
+			expected: nil,
+			current:  3,
+			wantErr:  false,
+		},
+		{
+			name:     "matching version",
+			expected: intPtr(3),
+			current:  3,
+			wantErr:  false,
+		},
+		{
+			name:     "stale version",
+			expected: intPtr(2),
+			current:  3,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVersion(tt.expected, tt.current)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrVersionConflict))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultWorkflow es el workflow asignado a las tareas que no indican ninguno
+const DefaultWorkflow = "default"
+
+// MaxWorkflowLength es la longitud máxima del nombre de un workflow
+const MaxWorkflowLength = 64
+
+var workflowRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateWorkflow verifica que el nombre de un workflow tenga un formato válido
+func ValidateWorkflow(workflow string) error {
+	if len(workflow) > MaxWorkflowLength || !workflowRegex.MatchString(workflow) {
+		return fmt.Errorf("%w: %q", ErrInvalidWorkflow, workflow)
+	}
+	return nil
+}
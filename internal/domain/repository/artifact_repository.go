@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// ArtifactRepository define el contrato para la persistencia de los metadatos de los artefactos
+// Al purgar una tarea (ver TaskRepository.HardDelete) sus artefactos se borran con ella y las claves de
+// su contenido quedan pendientes de borrar del BlobStore, hasta que DeleteOrphanedBlobKeys las retira
+type ArtifactRepository interface {
+	// Create guarda los metadatos de un artefacto
+	Create(ctx context.Context, artifact *entity.Artifact) error
+
+	// FindByID busca un artefacto de la tarea
+	// Retorna entity.ErrArtifactNotFound si no existe o pertenece a otra tarea
+	FindByID(ctx context.Context, taskID, id uuid.UUID) (*entity.Artifact, error)
+
+	// FindByTaskID retorna los artefactos de una tarea por orden de subida
+	FindByTaskID(ctx context.Context, taskID uuid.UUID) ([]*entity.Artifact, error)
+
+	// FindOrphanedBlobKeys retorna hasta limit claves de contenido de artefactos purgados, las más antiguas primero
+	FindOrphanedBlobKeys(ctx context.Context, limit int) ([]string, error)
+
+	// DeleteOrphanedBlobKeys retira las claves cuyo contenido ya se borró del BlobStore
+	DeleteOrphanedBlobKeys(ctx context.Context, keys []string) error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// AutomationFilters representa los filtros para listar automatismos
+type AutomationFilters struct {
+	OwnerTeam *string // Equipo responsable (opcional)
+	Page      int     // Número de página (1-indexed)
+	Limit     int     // Cantidad de resultados por página
+	Offset    int     // Offset calculado para paginación
+}
+
+// AutomationListResult representa el resultado paginado de automatismos
+type AutomationListResult struct {
+	Automations []*entity.Automation
+	Total       int // Total de resultados (sin paginación)
+	Page        int
+	Limit       int
+	TotalPages  int
+}
+
+// MissedRunFilters representa la paginación del listado de ejecuciones perdidas de un automatismo
+type MissedRunFilters struct {
+	Page   int // Número de página (1-indexed)
+	Limit  int // Cantidad de resultados por página
+	Offset int // Offset calculado para paginación
+}
+
+// MissedRunListResult representa el resultado paginado de ejecuciones perdidas
+type MissedRunListResult struct {
+	MissedRuns []*entity.MissedRun
+	Total      int // Total de resultados (sin paginación)
+	Page       int
+	Limit      int
+	TotalPages int
+}
+
+// AutomationRepository define el contrato para la persistencia de las definiciones de automatismos
+type AutomationRepository interface {
+	// Create guarda un nuevo automatismo
+	// Retorna entity.ErrAutomationExists si la clave ya la usa otro automatismo, aunque esté eliminado
+	Create(ctx context.Context, automation *entity.Automation) error
+
+	// Update guarda los cambios de un automatismo con control de concurrencia optimista
+	// Retorna entity.ErrAutomationNotFound si no existe o está eliminado, o entity.ErrVersionConflict
+	// si otro escritor lo modificó
+	Update(ctx context.Context, automation *entity.Automation) error
+
+	// FindByKey busca un automatismo por su clave
+	// Retorna entity.ErrAutomationNotFound si no existe o está eliminado
+	FindByKey(ctx context.Context, key string) (*entity.Automation, error)
+
+	// FindAll retorna una lista paginada de automatismos no eliminados, ordenada por clave
+	FindAll(ctx context.Context, filters AutomationFilters) (*AutomationListResult, error)
+
+	// SummarizeRuns resume las ejecuciones no eliminadas y las perdidas de cada automatismo de keys
+	// Los automatismos sin ejecuciones tienen un resumen vacío
+	SummarizeRuns(ctx context.Context, keys []string) (map[string]*entity.AutomationRunSummary, error)
+
+	// FindScheduled retorna los automatismos no eliminados que tienen planificación, ordenados por clave
+	FindScheduled(ctx context.Context) ([]*entity.Automation, error)
+
+	// HasRunBetween verifica si el automatismo tiene alguna ejecución no eliminada creada en [from, to]
+	HasRunBetween(ctx context.Context, key string, from, to time.Time) (bool, error)
+
+	// RecordMissedRuns avanza las activaciones revisadas del automatismo hasta checkedUntil y guarda las
+	// ejecuciones perdidas detectadas hasta ese momento; las ya registradas se ignoran
+	// Retorna entity.ErrVersionConflict si la planificación o las activaciones revisadas cambiaron desde que
+	// se leyó el automatismo
+	RecordMissedRuns(ctx context.Context, automation *entity.Automation, checkedUntil time.Time, missed []*entity.MissedRun) error
+
+	// FindMissedRuns retorna una lista paginada de las ejecuciones perdidas de un automatismo, de la más
+	// reciente a la más antigua
+	FindMissedRuns(ctx context.Context, key string, filters MissedRunFilters) (*MissedRunListResult, error)
+
+	// Delete marca un automatismo como eliminado (soft delete) en deletedAt; sus ejecuciones se conservan
+	// Retorna entity.ErrAutomationNotFound si no existe o ya está eliminado
+	Delete(ctx context.Context, key string, deletedBy string, deletedAt time.Time) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore define el contrato del almacén del contenido de los artefactos
+// Las claves las genera la API (ver entity.ArtifactStorageKey): segmentos separados por '/'
+type BlobStore interface {
+	// Put guarda bajo key los size bytes de content, reemplazando el contenido anterior si existía
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+
+	// Get abre el contenido guardado bajo key; quien llama debe cerrarlo
+	// Retorna entity.ErrBlobNotFound si no existe
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete borra el contenido guardado bajo key; borrar una clave que no existe no es un error
+	Delete(ctx context.Context, key string) error
+}
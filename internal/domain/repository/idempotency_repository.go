@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// IdempotencyRepository define el contrato para la persistencia de claves de idempotencia
+type IdempotencyRepository interface {
+	// Lock bloquea la clave (created_by, key) hasta el fin de la transacción en curso
+	// Serializa los reintentos concurrentes con la misma clave; debe llamarse dentro de TransactionManager
+	Lock(ctx context.Context, createdBy, key string) error
+
+	// Find busca una clave de idempotencia vigente en now
+	// Retorna nil sin error si la clave no existe o ya expiró
+	Find(ctx context.Context, createdBy, key string, now time.Time) (*entity.IdempotencyRecord, error)
+
+	// Save guarda una clave de idempotencia, reemplazando una anterior ya expirada en record.CreatedAt
+	Save(ctx context.Context, record *entity.IdempotencyRecord) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// LockManager define el contrato de los bloqueos con nombre compartidos entre réplicas de la API
+// Permite que los procesos en segundo plano se ejecuten en una sola réplica a la vez
+type LockManager interface {
+	// TryLock intenta tomar el bloqueo name hasta el fin de la transacción en curso sin esperar
+	// Retorna false si otra transacción ya lo tiene; debe llamarse dentro de TransactionManager
+	TryLock(ctx context.Context, name string) (bool, error)
+}
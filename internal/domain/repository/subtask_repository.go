@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -27,9 +28,11 @@ type SubtaskRepository interface {
 	// FindByTaskID retorna todas las subtareas de una tarea (incluyendo eliminadas si se especifica)
 	FindByTaskID(ctx context.Context, taskID uuid.UUID, includeDeleted bool) ([]*entity.Subtask, error)
 
-	// Delete marca una subtarea como eliminada (soft delete)
-	Delete(ctx context.Context, id uuid.UUID, deletedBy string) error
+	// Delete marca una subtarea y todas sus descendientes como eliminadas (soft delete) en deletedAt
+	// Retorna las subtareas eliminadas (solo ID y nombre), primero la indicada y después sus descendientes
+	// por profundidad, o entity.ErrSubtaskNotFound si no existe o ya está eliminada
+	Delete(ctx context.Context, id uuid.UUID, deletedBy string, deletedAt time.Time) ([]*entity.Subtask, error)
 
-	// DeleteByTaskID marca todas las subtareas de una tarea como eliminadas
-	DeleteByTaskID(ctx context.Context, taskID uuid.UUID, deletedBy string) error
+	// DeleteByTaskID marca todas las subtareas de una tarea como eliminadas en deletedAt
+	DeleteByTaskID(ctx context.Context, taskID uuid.UUID, deletedBy string, deletedAt time.Time) error
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// TaskAttemptRepository define el contrato para la persistencia de los intentos cerrados de una tarea
+type TaskAttemptRepository interface {
+	// Create registra un intento cerrado
+	// Debe ejecutarse en la misma transacción que el reintento que lo cierra (ver TransactionManager)
+	Create(ctx context.Context, attempt *entity.TaskAttempt) error
+
+	// FindByTaskID retorna los intentos cerrados de una tarea ordenados por número de intento
+	// El intento en curso no se incluye: es la propia tarea
+	FindByTaskID(ctx context.Context, taskID uuid.UUID) ([]*entity.TaskAttempt, error)
+}
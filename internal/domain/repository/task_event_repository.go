@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// TaskEventFilters representa la paginación del historial de una tarea
+type TaskEventFilters struct {
+	Page   int // Número de página (1-indexed)
+	Limit  int // Cantidad de resultados por página
+	Offset int // Offset calculado para paginación
+}
+
+// TaskEventListResult representa el resultado paginado del historial de una tarea
+type TaskEventListResult struct {
+	Events     []*entity.TaskEvent
+	Total      int // Total de eventos (sin paginación)
+	Page       int
+	Limit      int
+	TotalPages int
+}
+
+// TaskEventRepository define el contrato para la persistencia del historial de tareas
+type TaskEventRepository interface {
+	// Create registra uno o varios eventos
+	// Debe ejecutarse en la misma transacción que el cambio que describen (ver TransactionManager)
+	Create(ctx context.Context, events ...*entity.TaskEvent) error
+
+	// FindByTaskID retorna el historial paginado de una tarea y sus subtareas
+	// Ordena siempre cronológicamente (occurred_at ASC)
+	FindByTaskID(ctx context.Context, taskID uuid.UUID, filters TaskEventFilters) (*TaskEventListResult, error)
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// TaskLogFilters representa los filtros y el cursor de la consulta de logs de una tarea
+type TaskLogFilters struct {
+	MinLevel  *entity.LogLevel      // Nivel mínimo de las líneas (opcional)
+	SubtaskID *uuid.UUID            // Solo las líneas de esta subtarea (opcional)
+	Since     *time.Time            // Solo las líneas emitidas desde este instante, incluido (opcional)
+	Until     *time.Time            // Solo las líneas emitidas antes de este instante (opcional)
+	After     *entity.TaskLogCursor // Solo las líneas posteriores al cursor (opcional)
+	Limit     int                   // Cantidad máxima de líneas a retornar
+}
+
+// TaskLogRepository define el contrato para la persistencia de los logs de las tareas
+// Las líneas solo se añaden; se purgan junto con su tarea (ver TaskRepository.HardDelete)
+type TaskLogRepository interface {
+	// Append guarda las líneas de log en un solo envío
+	Append(ctx context.Context, lines ...*entity.TaskLogLine) error
+
+	// CountByTaskID retorna el número de líneas guardadas de una tarea
+	CountByTaskID(ctx context.Context, taskID uuid.UUID) (int, error)
+
+	// FindByTaskID retorna las líneas de una tarea que cumplen los filtros
+	// Ordena siempre cronológicamente (logged_at ASC, id ASC)
+	FindByTaskID(ctx context.Context, taskID uuid.UUID, filters TaskLogFilters) ([]*entity.TaskLogLine, error)
+}
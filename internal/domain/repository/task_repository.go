@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -10,12 +11,19 @@ import (
 
 // TaskFilters representa los filtros para listar tareas
 type TaskFilters struct {
-	State          *entity.State // Filtrar por estado (opcional)
-	Name           *string       // Búsqueda parcial en nombre (case-insensitive)
-	Page           int           // Número de página (1-indexed)
-	Limit          int           // Cantidad de resultados por página
-	Offset         int           // Offset calculado para paginación
-	IncludeDeleted bool          // Incluir tareas eliminadas (soft-deleted)
+	State           *entity.State     // Filtrar por estado (opcional)
+	Name            *string           // Búsqueda parcial en nombre (case-insensitive)
+	MinProgress     *int              // Progreso efectivo mínimo (ver entity.Task.EffectiveProgress)
+	SLAStatus       *entity.SLAStatus // Estado del SLA en Now (ver entity.Task.SLAStatus); excluye las tareas sin SLA
+	Now             time.Time         // Instante en que se evalúa el estado del SLA
+	Labels          map[string]string // Etiquetas que deben tener las tareas, con esos valores (opcional)
+	ErrorCode       *string           // Código de error del fallo de la tarea o de alguna de sus subtareas (opcional)
+	Automation      *string           // Clave del automatismo del que las tareas son ejecuciones (opcional)
+	IncludePayloads bool              // Cargar los documentos input y output de las tareas y sus subtareas
+	Page            int               // Número de página (1-indexed)
+	Limit           int               // Cantidad de resultados por página
+	Offset          int               // Offset calculado para paginación
+	IncludeDeleted  bool              // Incluir tareas eliminadas (soft-deleted)
 }
 
 // TaskListResult representa el resultado paginado de tareas
@@ -40,14 +48,43 @@ type TaskRepository interface {
 	// Retorna entity.ErrTaskNotFound si no existe o está eliminada
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Task, error)
 
+	// FindByIDForUpdate busca una tarea por su UUID bloqueando su fila hasta el fin de la transacción
+	// Serializa las operaciones concurrentes sobre la misma tarea (ver TransactionManager)
+	// Retorna entity.ErrTaskNotFound si no existe o está eliminada
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Task, error)
+
 	// FindAll retorna una lista paginada de tareas según los filtros
 	// Ordena siempre por created_at DESC
 	FindAll(ctx context.Context, filters TaskFilters) (*TaskListResult, error)
 
-	// Delete marca una tarea como eliminada (soft delete)
-	Delete(ctx context.Context, id uuid.UUID, deletedBy string) error
+	// Delete marca una tarea y sus subtareas como eliminadas (soft delete) en deletedAt de forma atómica
+	Delete(ctx context.Context, id uuid.UUID, deletedBy string, deletedAt time.Time) error
+
+	// Restore revierte el soft delete de una tarea y de las subtareas eliminadas junto a ella
+	// Solo es posible dentro de la ventana de retención de 30 días anterior a restoredAt
+	// Retorna entity.ErrTaskNotFound si la tarea no existe, no está eliminada o ya expiró
+	Restore(ctx context.Context, id uuid.UUID, restoredBy string, restoredAt time.Time) error
+
+	// RecordHeartbeat guarda el último latido de la tarea sin incrementar su versión
+	// Retorna entity.ErrTaskNotFound si no existe o está eliminada
+	RecordHeartbeat(ctx context.Context, task *entity.Task) error
+
+	// FindStaleIDs retorna hasta limit tareas IN_PROGRESS sin señales de vida dentro de su plazo en now
+	// (ver entity.Task.HeartbeatExpired), bloqueándolas hasta el fin de la transacción en curso
+	// Las tareas ya bloqueadas por otra transacción se omiten
+	FindStaleIDs(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+
+	// RecordSLABreach guarda el incumplimiento del SLA de la tarea sin incrementar su versión
+	// Retorna entity.ErrTaskNotFound si no existe o está eliminada
+	RecordSLABreach(ctx context.Context, task *entity.Task) error
+
+	// FindSLABreachIDs retorna hasta limit tareas con el plazo vencido en now y el incumplimiento sin
+	// registrar (ver entity.Task.DetectSLABreach), bloqueándolas hasta el fin de la transacción en curso
+	// Las tareas ya bloqueadas por otra transacción se omiten
+	FindSLABreachIDs(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
 
-	// HardDelete elimina permanentemente tareas soft-deleted hace más de 30 días
+	// HardDelete elimina permanentemente tareas soft-deleted más de 30 días antes de now junto con sus logs y artefactos
+	// El contenido de los artefactos queda pendiente de borrar del BlobStore (ver ArtifactRepository)
 	// Usado por el job de limpieza automática
-	HardDelete(ctx context.Context) (int, error)
+	HardDelete(ctx context.Context, now time.Time) (int, error)
 }
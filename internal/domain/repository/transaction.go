@@ -0,0 +1,13 @@
+package repository
+
+import "context"
+
+// TransactionManager define el contrato de la unidad de trabajo transaccional
+// Las operaciones de los repositorios ejecutadas con el contexto recibido por fn
+// participan en la misma transacción y se confirman o revierten juntas
+type TransactionManager interface {
+	// WithinTransaction ejecuta fn dentro de una transacción
+	// Si fn retorna error la transacción se revierte y el error se propaga
+	// Si el contexto ya contiene una transacción activa, fn se une a ella
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
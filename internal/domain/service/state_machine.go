@@ -2,6 +2,8 @@ package service
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/grupoapi/proces-log/internal/domain/entity"
 )
@@ -11,17 +13,38 @@ type StateMachine struct {
 	transitions map[entity.State][]entity.State
 }
 
-// NewStateMachine crea una nueva instancia de StateMachine con las reglas de transición
+// NewStateMachine crea una nueva instancia de StateMachine con las reglas de transición por defecto
 func NewStateMachine() *StateMachine {
-	return &StateMachine{
-		transitions: map[entity.State][]entity.State{
-			entity.StatePending:    {entity.StateInProgress, entity.StateCancelled},
-			entity.StateInProgress: {entity.StateCompleted, entity.StateFailed},
-			entity.StateCompleted:  {}, // Estado final, sin transiciones
-			entity.StateFailed:     {}, // Estado final, sin transiciones
-			entity.StateCancelled:  {}, // Estado final, sin transiciones
-		},
+	sm, err := NewStateMachineFromDefinition(DefaultStateMachineDefinition())
+	if err != nil {
+		// La definición por defecto siempre es válida; si no lo es, es un error de programación
+		panic(err)
 	}
+	return sm
+}
+
+// NewStateMachineFromDefinition crea una StateMachine a partir de una definición validada
+func NewStateMachineFromDefinition(def StateMachineDefinition) (*StateMachine, error) {
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	sm := &StateMachine{
+		transitions: make(map[entity.State][]entity.State, len(def.States)),
+	}
+	for state, stateDef := range def.States {
+		transitions := make([]entity.State, len(stateDef.Transitions))
+		copy(transitions, stateDef.Transitions)
+		sm.transitions[state] = transitions
+	}
+
+	return sm, nil
+}
+
+// IsFinal indica si el estado es final
+// Es igual en todas las máquinas de estados: las definiciones solo configuran las transiciones
+func (sm *StateMachine) IsFinal(state entity.State) bool {
+	return state.IsFinal()
 }
 
 // CanTransition verifica si una transición de estado es válida
@@ -32,7 +55,7 @@ func (sm *StateMachine) CanTransition(from, to entity.State) bool {
 	}
 
 	// Estados finales no permiten transiciones
-	if from.IsFinal() {
+	if sm.IsFinal(from) {
 		return false
 	}
 
@@ -54,7 +77,7 @@ func (sm *StateMachine) CanTransition(from, to entity.State) bool {
 // ValidateTransition valida una transición y retorna error descriptivo si no es válida
 func (sm *StateMachine) ValidateTransition(from, to entity.State) error {
 	// Verificar si es estado final
-	if from.IsFinal() {
+	if sm.IsFinal(from) {
 		return fmt.Errorf("%w: cannot transition from final state %s", entity.ErrInvalidStateTransition, from)
 	}
 
@@ -75,7 +98,7 @@ func (sm *StateMachine) ValidateTaskStateTransition(task *entity.Task, newState
 // considerando el estado de la tarea padre
 func (sm *StateMachine) ValidateSubtaskStateTransition(task *entity.Task, subtask *entity.Subtask, newState entity.State) error {
 	// Si la tarea padre está en estado final, la subtarea solo puede heredar ese estado
-	if task.State.IsFinal() {
+	if sm.IsFinal(task.State) {
 		if newState != task.State {
 			return fmt.Errorf("%w: subtask cannot transition when parent task is in final state %s",
 				entity.ErrInconsistentParentChildState, task.State)
@@ -90,6 +113,32 @@ func (sm *StateMachine) ValidateSubtaskStateTransition(task *entity.Task, subtas
 			entity.ErrInconsistentParentChildState)
 	}
 
+	// Una subtarea no puede arrancar ni reanudarse mientras el padre está en pausa
+	if task.State == entity.StatePaused && newState == entity.StateInProgress {
+		return fmt.Errorf("%w: subtask cannot have state IN_PROGRESS when parent is PAUSED",
+			entity.ErrInconsistentParentChildState)
+	}
+
+	// Las subtareas anidadas siguen las mismas reglas respecto de su subtarea padre
+	if parent := task.ParentOf(subtask); parent != nil {
+		if sm.IsFinal(parent.State) && newState != parent.State {
+			return fmt.Errorf("%w: subtask cannot transition when parent subtask %q is in final state %s",
+				entity.ErrInconsistentParentChildState, parent.Name, parent.State)
+		}
+		if newState == entity.StateInProgress && parent.State != entity.StateInProgress {
+			return fmt.Errorf("%w: subtask cannot have state IN_PROGRESS when parent subtask %q is %s",
+				entity.ErrInconsistentParentChildState, parent.Name, parent.State)
+		}
+	}
+
+	// Una subtarea no puede arrancar hasta que todas sus dependencias estén COMPLETED
+	if newState == entity.StateInProgress {
+		if unmet := task.UnmetDependencies(subtask); len(unmet) > 0 {
+			return fmt.Errorf("%w: subtask %q is waiting for %s",
+				entity.ErrDependenciesNotMet, subtask.Name, dependencyNames(unmet))
+		}
+	}
+
 	// Las subtareas SÍ PUEDEN completarse antes que la tarea padre
 	// Esta es la lógica correcta: subtareas → tarea padre
 
@@ -109,3 +158,12 @@ func (sm *StateMachine) GetAllowedTransitions(state entity.State) []entity.State
 	copy(result, allowedStates)
 	return result
 }
+
+// dependencyNames formatea los nombres de las dependencias pendientes para los mensajes de error
+func dependencyNames(subtasks []*entity.Subtask) string {
+	names := make([]string, 0, len(subtasks))
+	for _, subtask := range subtasks {
+		names = append(names, strconv.Quote(subtask.Name))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// ErrInvalidStateMachineDefinition indica que la definición de la máquina de estados no es válida
+var ErrInvalidStateMachineDefinition = errors.New("invalid state machine definition")
+
+// StateMachineDefinition describe el grafo de transiciones de la máquina de estados
+// Se puede cargar desde un fichero YAML o JSON (JSON es YAML válido):
+//
+//	initial: PENDING
+//	states:
+//	  PENDING:
+//	    transitions: [IN_PROGRESS, CANCELLED]
+//	  IN_PROGRESS:
+//	    transitions: [COMPLETED, FAILED]
+//	  COMPLETED: {}
+//
+// Qué estados son finales no se configura: es fijo (ver entity.State.IsFinal), porque el esquema de base de
+// datos y las reglas de fechas, propagación y progreso dependen de ello
+type StateMachineDefinition struct {
+	Initial entity.State                     `yaml:"initial" json:"initial"`
+	States  map[entity.State]StateDefinition `yaml:"states" json:"states"`
+}
+
+// StateDefinition describe las transiciones que salen de un estado; los estados finales no tienen ninguna
+type StateDefinition struct {
+	Transitions []entity.State `yaml:"transitions" json:"transitions"`
+}
+
+// DefaultStateMachineDefinition retorna la definición usada cuando no se configura ningún fichero
+func DefaultStateMachineDefinition() StateMachineDefinition {
+	return StateMachineDefinition{
+		Initial: entity.StatePending,
+		States: map[entity.State]StateDefinition{
+			entity.StatePending:    {Transitions: []entity.State{entity.StateInProgress, entity.StateCancelled}},
+			entity.StateInProgress: {Transitions: []entity.State{entity.StateCompleted, entity.StateFailed, entity.StatePaused}},
+			entity.StatePaused:     {Transitions: []entity.State{entity.StateInProgress}},
+			entity.StateCompleted:  {},
+			entity.StateFailed:     {},
+			entity.StateCancelled:  {},
+		},
+	}
+}
+
+// LoadStateMachineDefinition lee y parsea la definición desde un fichero YAML o JSON
+func LoadStateMachineDefinition(path string) (StateMachineDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return StateMachineDefinition{}, fmt.Errorf("failed to read state machine definition: %w", err)
+	}
+
+	return ParseStateMachineDefinition(data)
+}
+
+// ParseStateMachineDefinition parsea una definición en YAML o JSON
+// Los campos desconocidos se rechazan para detectar erratas en el fichero
+func ParseStateMachineDefinition(data []byte) (StateMachineDefinition, error) {
+	var def StateMachineDefinition
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&def); err != nil {
+		return StateMachineDefinition{}, fmt.Errorf("%w: %v", ErrInvalidStateMachineDefinition, err)
+	}
+
+	return def, nil
+}
+
+// Validate verifica que la definición sea coherente y compatible con el esquema de base de datos:
+//   - el estado inicial existe y es PENDING (las tareas y subtareas se crean en ese estado)
+//   - todos los estados son estados conocidos (CHECK de la columna state)
+//   - los estados finales (entity.State.IsFinal) no tienen transiciones salientes y los no finales tienen al menos una
+//   - las transiciones apuntan a estados definidos y no hay transiciones al mismo estado
+//   - todos los estados son alcanzables desde el estado inicial
+func (d StateMachineDefinition) Validate() error {
+	if len(d.States) == 0 {
+		return fmt.Errorf("%w: no states defined", ErrInvalidStateMachineDefinition)
+	}
+
+	if _, exists := d.States[d.Initial]; !exists {
+		return fmt.Errorf("%w: initial state %q is not defined", ErrInvalidStateMachineDefinition, d.Initial)
+	}
+	if d.Initial != entity.StatePending {
+		return fmt.Errorf("%w: initial state must be %s, got %s",
+			ErrInvalidStateMachineDefinition, entity.StatePending, d.Initial)
+	}
+
+	for _, state := range d.sortedStates() {
+		def := d.States[state]
+
+		if !state.IsValid() {
+			return fmt.Errorf("%w: unknown state %q", ErrInvalidStateMachineDefinition, state)
+		}
+		if state.IsFinal() && len(def.Transitions) > 0 {
+			return fmt.Errorf("%w: final state %s cannot have outgoing transitions", ErrInvalidStateMachineDefinition, state)
+		}
+		if !state.IsFinal() && len(def.Transitions) == 0 {
+			return fmt.Errorf("%w: non-final state %s has no outgoing transitions", ErrInvalidStateMachineDefinition, state)
+		}
+
+		for _, to := range def.Transitions {
+			if to == state {
+				return fmt.Errorf("%w: state %s cannot transition to itself", ErrInvalidStateMachineDefinition, state)
+			}
+			if _, exists := d.States[to]; !exists {
+				return fmt.Errorf("%w: transition from %s to undefined state %q", ErrInvalidStateMachineDefinition, state, to)
+			}
+		}
+	}
+
+	reachable := d.reachableStates()
+	for _, state := range d.sortedStates() {
+		if !reachable[state] {
+			return fmt.Errorf("%w: state %s is unreachable from initial state %s",
+				ErrInvalidStateMachineDefinition, state, d.Initial)
+		}
+	}
+
+	return nil
+}
+
+// reachableStates retorna los estados alcanzables desde el estado inicial
+func (d StateMachineDefinition) reachableStates() map[entity.State]bool {
+	reachable := map[entity.State]bool{d.Initial: true}
+	pending := []entity.State{d.Initial}
+
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+
+		for _, to := range d.States[current].Transitions {
+			if !reachable[to] {
+				reachable[to] = true
+				pending = append(pending, to)
+			}
+		}
+	}
+
+	return reachable
+}
+
+// sortedStates retorna los estados ordenados para que la validación sea determinista
+func (d StateMachineDefinition) sortedStates() []entity.State {
+	states := make([]entity.State, 0, len(d.States))
+	for state := range d.States {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i] < states[j] })
+	return states
+}
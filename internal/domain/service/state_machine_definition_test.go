@@ -0,0 +1,176 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+func TestStateMachineDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(def *StateMachineDefinition)
+		wantErr string
+	}{
+		{
+			name:   "default definition is valid",
+			mutate: func(def *StateMachineDefinition) {},
+		},
+		{
+			name: "extra transitions are valid",
+			mutate: func(def *StateMachineDefinition) {
+				def.States[entity.StatePending] = StateDefinition{Transitions: []entity.State{
+					entity.StateInProgress, entity.StateCancelled, entity.StateFailed,
+				}}
+			},
+		},
+		{
+			name: "no states",
+			mutate: func(def *StateMachineDefinition) {
+				def.States = nil
+			},
+			wantErr: "no states defined",
+		},
+		{
+			name: "initial state not defined",
+			mutate: func(def *StateMachineDefinition) {
+				def.Initial = "QUEUED"
+			},
+			wantErr: "initial state \"QUEUED\" is not defined",
+		},
+		{
+			name: "initial state other than PENDING",
+			mutate: func(def *StateMachineDefinition) {
+				def.Initial = entity.StateInProgress
+			},
+			wantErr: "initial state must be PENDING",
+		},
+		{
+			name: "unknown state",
+			mutate: func(def *StateMachineDefinition) {
+				def.States["QUEUED"] = StateDefinition{Transitions: []entity.State{entity.StateInProgress}}
+			},
+			wantErr: "unknown state \"QUEUED\"",
+		},
+		{
+			name: "final state with outgoing transitions",
+			mutate: func(def *StateMachineDefinition) {
+				def.States[entity.StateFailed] = StateDefinition{Transitions: []entity.State{entity.StatePending}}
+			},
+			wantErr: "final state FAILED cannot have outgoing transitions",
+		},
+		{
+			name: "non-final state without transitions",
+			mutate: func(def *StateMachineDefinition) {
+				def.States[entity.StateInProgress] = StateDefinition{}
+			},
+			wantErr: "non-final state IN_PROGRESS has no outgoing transitions",
+		},
+		{
+			name: "transition to undefined state",
+			mutate: func(def *StateMachineDefinition) {
+				delete(def.States, entity.StateFailed)
+			},
+			wantErr: "transition from IN_PROGRESS to undefined state \"FAILED\"",
+		},
+		{
+			name: "self transition",
+			mutate: func(def *StateMachineDefinition) {
+				def.States[entity.StateInProgress] = StateDefinition{Transitions: []entity.State{entity.StateInProgress, entity.StateCompleted}}
+			},
+			wantErr: "state IN_PROGRESS cannot transition to itself",
+		},
+		{
+			name: "unreachable state",
+			mutate: func(def *StateMachineDefinition) {
+				def.States[entity.StatePending] = StateDefinition{Transitions: []entity.State{entity.StateInProgress}}
+			},
+			wantErr: "state CANCELLED is unreachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := DefaultStateMachineDefinition()
+			tt.mutate(&def)
+
+			err := def.Validate()
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidStateMachineDefinition)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseStateMachineDefinition(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		data := []byte(`
+initial: PENDING
+states:
+  PENDING:
+    transitions: [IN_PROGRESS, FAILED]
+  IN_PROGRESS:
+    transitions: [COMPLETED, FAILED, CANCELLED]
+  COMPLETED: {}
+  FAILED: {}
+  CANCELLED: {}
+`)
+
+		def, err := ParseStateMachineDefinition(data)
+		require.NoError(t, err)
+		sm, err := NewStateMachineFromDefinition(def)
+		require.NoError(t, err)
+
+		assert.True(t, sm.CanTransition(entity.StatePending, entity.StateFailed))
+		assert.True(t, sm.CanTransition(entity.StateInProgress, entity.StateCancelled))
+		assert.False(t, sm.CanTransition(entity.StatePending, entity.StateCancelled))
+		assert.ElementsMatch(t, []entity.State{entity.StateInProgress, entity.StateFailed},
+			sm.GetAllowedTransitions(entity.StatePending))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data := []byte(`{
+			"initial": "PENDING",
+			"states": {
+				"PENDING": {"transitions": ["IN_PROGRESS"]},
+				"IN_PROGRESS": {"transitions": ["COMPLETED"]},
+				"COMPLETED": {}
+			}
+		}`)
+
+		def, err := ParseStateMachineDefinition(data)
+		require.NoError(t, err)
+		sm, err := NewStateMachineFromDefinition(def)
+		require.NoError(t, err)
+
+		assert.True(t, sm.IsFinal(entity.StateCompleted))
+		assert.False(t, sm.CanTransition(entity.StateInProgress, entity.StateFailed))
+		assert.Empty(t, sm.GetAllowedTransitions(entity.StateFailed))
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := ParseStateMachineDefinition([]byte("initial: PENDING\nstate: {}\n"))
+		assert.ErrorIs(t, err, ErrInvalidStateMachineDefinition)
+	})
+
+	t.Run("finality is not configurable", func(t *testing.T) {
+		_, err := ParseStateMachineDefinition([]byte("initial: PENDING\nstates:\n  COMPLETED:\n    final: true\n"))
+		assert.ErrorIs(t, err, ErrInvalidStateMachineDefinition)
+	})
+
+	t.Run("invalid definition is rejected", func(t *testing.T) {
+		def, err := ParseStateMachineDefinition([]byte("initial: PENDING\nstates:\n  PENDING: {}\n"))
+		require.NoError(t, err)
+
+		_, err = NewStateMachineFromDefinition(def)
+		assert.ErrorIs(t, err, ErrInvalidStateMachineDefinition)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/test/helpers"
 )
 
 func TestStateMachine_CanTransition(t *testing.T) {
@@ -63,6 +64,31 @@ func TestStateMachine_CanTransition(t *testing.T) {
 			to:       entity.StateFailed,
 			expected: true,
 		},
+		{
+			name:     "IN_PROGRESS to PAUSED is valid",
+			from:     entity.StateInProgress,
+			to:       entity.StatePaused,
+			expected: true,
+		},
+		// Transiciones desde PAUSED
+		{
+			name:     "PAUSED to IN_PROGRESS is valid",
+			from:     entity.StatePaused,
+			to:       entity.StateInProgress,
+			expected: true,
+		},
+		{
+			name:     "PAUSED to COMPLETED is invalid",
+			from:     entity.StatePaused,
+			to:       entity.StateCompleted,
+			expected: false,
+		},
+		{
+			name:     "PENDING to PAUSED is invalid",
+			from:     entity.StatePending,
+			to:       entity.StatePaused,
+			expected: false,
+		},
 		// Transiciones inválidas desde IN_PROGRESS
 		{
 			name:     "IN_PROGRESS to PENDING is invalid",
@@ -223,7 +249,7 @@ func TestStateMachine_ValidateTaskStateTransition(t *testing.T) {
 	sm := NewStateMachine()
 
 	t.Run("valid task state transition", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StatePending
 
 		err := sm.ValidateTaskStateTransition(task, entity.StateInProgress)
@@ -231,7 +257,7 @@ func TestStateMachine_ValidateTaskStateTransition(t *testing.T) {
 	})
 
 	t.Run("invalid task state transition", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StatePending
 
 		err := sm.ValidateTaskStateTransition(task, entity.StateCompleted)
@@ -240,7 +266,7 @@ func TestStateMachine_ValidateTaskStateTransition(t *testing.T) {
 	})
 
 	t.Run("transition from final state", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StateCompleted
 
 		err := sm.ValidateTaskStateTransition(task, entity.StatePending)
@@ -253,10 +279,10 @@ func TestStateMachine_ValidateSubtaskStateTransition(t *testing.T) {
 	sm := NewStateMachine()
 
 	t.Run("valid subtask state transition when parent allows", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StateInProgress
 
-		subtask, _ := entity.NewSubtask("Subtask 1")
+		subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
 		subtask.State = entity.StatePending
 		task.AddSubtask(subtask)
 
@@ -265,10 +291,10 @@ func TestStateMachine_ValidateSubtaskStateTransition(t *testing.T) {
 	})
 
 	t.Run("invalid: subtask IN_PROGRESS when parent PENDING", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StatePending
 
-		subtask, _ := entity.NewSubtask("Subtask 1")
+		subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
 		subtask.State = entity.StatePending
 		task.AddSubtask(subtask)
 
@@ -277,11 +303,38 @@ func TestStateMachine_ValidateSubtaskStateTransition(t *testing.T) {
 		assert.Contains(t, err.Error(), "subtask cannot have state IN_PROGRESS when parent is PENDING")
 	})
 
+	t.Run("invalid: subtask IN_PROGRESS when parent PAUSED", func(t *testing.T) {
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
+		task.State = entity.StatePaused
+
+		for _, from := range []entity.State{entity.StatePending, entity.StatePaused} {
+			subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
+			subtask.State = from
+			task.AddSubtask(subtask)
+
+			err := sm.ValidateSubtaskStateTransition(task, subtask, entity.StateInProgress)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, entity.ErrInconsistentParentChildState)
+		}
+	})
+
+	t.Run("valid: subtask can finish when parent PAUSED", func(t *testing.T) {
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
+		task.State = entity.StatePaused
+
+		subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
+		subtask.State = entity.StateInProgress
+		task.AddSubtask(subtask)
+
+		require.NoError(t, sm.ValidateSubtaskStateTransition(task, subtask, entity.StateCompleted))
+		require.NoError(t, sm.ValidateSubtaskStateTransition(task, subtask, entity.StatePaused))
+	})
+
 	t.Run("invalid: subtask active when parent in final state", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StateCompleted
 
-		subtask, _ := entity.NewSubtask("Subtask 1")
+		subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
 		subtask.State = entity.StatePending
 		task.AddSubtask(subtask)
 
@@ -291,10 +344,10 @@ func TestStateMachine_ValidateSubtaskStateTransition(t *testing.T) {
 	})
 
 	t.Run("valid: subtask inherits parent final state", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StateCompleted
 
-		subtask, _ := entity.NewSubtask("Subtask 1")
+		subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
 		subtask.State = entity.StateInProgress
 		task.AddSubtask(subtask)
 
@@ -303,10 +356,10 @@ func TestStateMachine_ValidateSubtaskStateTransition(t *testing.T) {
 	})
 
 	t.Run("valid: subtask can COMPLETE when parent IN_PROGRESS", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StateInProgress
 
-		subtask, _ := entity.NewSubtask("Subtask 1")
+		subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
 		subtask.State = entity.StateInProgress
 		task.AddSubtask(subtask)
 
@@ -315,10 +368,10 @@ func TestStateMachine_ValidateSubtaskStateTransition(t *testing.T) {
 	})
 
 	t.Run("valid: subtask can FAIL when parent IN_PROGRESS", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StateInProgress
 
-		subtask, _ := entity.NewSubtask("Subtask 1")
+		subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
 		subtask.State = entity.StateInProgress
 		task.AddSubtask(subtask)
 
@@ -327,10 +380,10 @@ func TestStateMachine_ValidateSubtaskStateTransition(t *testing.T) {
 	})
 
 	t.Run("valid: subtask transitions from PENDING to COMPLETED via IN_PROGRESS when parent allows", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StateInProgress
 
-		subtask, _ := entity.NewSubtask("Subtask 1")
+		subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
 		subtask.State = entity.StatePending
 		task.AddSubtask(subtask)
 
@@ -345,10 +398,10 @@ func TestStateMachine_ValidateSubtaskStateTransition(t *testing.T) {
 	})
 
 	t.Run("invalid: basic transition validation for subtask", func(t *testing.T) {
-		task, _ := entity.NewTask("Test Task", "Team A")
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
 		task.State = entity.StateInProgress
 
-		subtask, _ := entity.NewSubtask("Subtask 1")
+		subtask, _ := entity.NewSubtask("Subtask 1", helpers.NewTestClock())
 		subtask.State = entity.StateCompleted
 		task.AddSubtask(subtask)
 
@@ -356,6 +409,54 @@ func TestStateMachine_ValidateSubtaskStateTransition(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "cannot transition from final state")
 	})
+
+	t.Run("invalid: subtask IN_PROGRESS while a dependency is not COMPLETED", func(t *testing.T) {
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
+		task.State = entity.StateInProgress
+
+		extract, _ := entity.NewSubtask("Extract", helpers.NewTestClock())
+		load, _ := entity.NewSubtask("Load", helpers.NewTestClock())
+		task.AddSubtask(extract)
+		task.AddSubtask(load)
+		require.NoError(t, task.SetDependencies(load, []string{"Extract"}))
+
+		extract.State = entity.StateInProgress
+		err := sm.ValidateSubtaskStateTransition(task, load, entity.StateInProgress)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrDependenciesNotMet)
+		assert.Contains(t, err.Error(), `"Extract"`)
+
+		// La subtarea puede cancelarse aunque sus dependencias no hayan terminado
+		require.NoError(t, sm.ValidateSubtaskStateTransition(task, load, entity.StateCancelled))
+
+		extract.State = entity.StateCompleted
+		require.NoError(t, sm.ValidateSubtaskStateTransition(task, load, entity.StateInProgress))
+	})
+
+	t.Run("nested subtask follows its parent subtask", func(t *testing.T) {
+		task, _ := entity.NewTask("Test Task", "Team A", helpers.NewTestClock())
+		task.State = entity.StateInProgress
+
+		test, _ := entity.NewSubtask("Test", helpers.NewTestClock())
+		unit, _ := entity.NewSubtask("Unit", helpers.NewTestClock())
+		task.AddSubtask(test)
+		task.AddChildSubtask(test, unit)
+
+		// No puede arrancar mientras la subtarea padre no esté IN_PROGRESS
+		err := sm.ValidateSubtaskStateTransition(task, unit, entity.StateInProgress)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, entity.ErrInconsistentParentChildState)
+		assert.Contains(t, err.Error(), `parent subtask "Test" is PENDING`)
+
+		test.State = entity.StateInProgress
+		require.NoError(t, sm.ValidateSubtaskStateTransition(task, unit, entity.StateInProgress))
+
+		// Con la subtarea padre finalizada solo puede heredar su estado
+		test.State = entity.StateCancelled
+		err = sm.ValidateSubtaskStateTransition(task, unit, entity.StateCompleted)
+		assert.ErrorIs(t, err, entity.ErrInconsistentParentChildState)
+		require.NoError(t, sm.ValidateSubtaskStateTransition(task, unit, entity.StateCancelled))
+	})
 }
 
 func TestStateMachine_GetAllowedTransitions(t *testing.T) {
@@ -374,7 +475,12 @@ func TestStateMachine_GetAllowedTransitions(t *testing.T) {
 		{
 			name:     "allowed transitions from IN_PROGRESS",
 			state:    entity.StateInProgress,
-			expected: []entity.State{entity.StateCompleted, entity.StateFailed},
+			expected: []entity.State{entity.StateCompleted, entity.StateFailed, entity.StatePaused},
+		},
+		{
+			name:     "allowed transitions from PAUSED",
+			state:    entity.StatePaused,
+			expected: []entity.State{entity.StateInProgress},
 		},
 		{
 			name:     "no transitions from COMPLETED",
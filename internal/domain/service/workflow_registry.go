@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+// workflowFileExtensions son las extensiones reconocidas como definiciones de workflow
+var workflowFileExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// WorkflowRegistry mantiene los perfiles de workflow disponibles, cada uno con su máquina de estados
+// El perfil entity.DefaultWorkflow siempre existe y se usa cuando una tarea no indica ninguno
+type WorkflowRegistry struct {
+	machines map[string]*StateMachine
+}
+
+// NewWorkflowRegistry crea un registro con defaultMachine como perfil por defecto
+func NewWorkflowRegistry(defaultMachine *StateMachine) *WorkflowRegistry {
+	return &WorkflowRegistry{
+		machines: map[string]*StateMachine{entity.DefaultWorkflow: defaultMachine},
+	}
+}
+
+// LoadWorkflowRegistry crea un registro con defaultMachine como perfil por defecto y añade
+// un perfil por cada fichero YAML/JSON de dir, nombrado como el fichero sin extensión
+// (p. ej. batch-etl.yaml define el workflow "batch-etl"). Con dir vacío solo existe el perfil por defecto
+func LoadWorkflowRegistry(defaultMachine *StateMachine, dir string) (*WorkflowRegistry, error) {
+	registry := NewWorkflowRegistry(defaultMachine)
+	if dir == "" {
+		return registry, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflows directory: %w", err)
+	}
+
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || !workflowFileExtensions[ext] {
+			continue
+		}
+
+		name := strings.TrimSuffix(e.Name(), ext)
+		def, err := LoadStateMachineDefinition(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("workflow %q: %w", name, err)
+		}
+
+		stateMachine, err := NewStateMachineFromDefinition(def)
+		if err != nil {
+			return nil, fmt.Errorf("workflow %q: %w", name, err)
+		}
+
+		if err := registry.Register(name, stateMachine); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// Register añade un perfil de workflow
+// Retorna error si el nombre no es válido o ya está registrado
+func (r *WorkflowRegistry) Register(name string, stateMachine *StateMachine) error {
+	if err := entity.ValidateWorkflow(name); err != nil {
+		return err
+	}
+	if _, exists := r.machines[name]; exists {
+		return fmt.Errorf("workflow %q is already registered", name)
+	}
+
+	r.machines[name] = stateMachine
+	return nil
+}
+
+// Get retorna la máquina de estados del workflow indicado
+// Un nombre vacío se resuelve como entity.DefaultWorkflow
+func (r *WorkflowRegistry) Get(name string) (*StateMachine, error) {
+	if name == "" {
+		name = entity.DefaultWorkflow
+	}
+
+	stateMachine, exists := r.machines[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", entity.ErrUnknownWorkflow, name)
+	}
+
+	return stateMachine, nil
+}
+
+// Names retorna los nombres de los workflows registrados ordenados alfabéticamente
+func (r *WorkflowRegistry) Names() []string {
+	names := make([]string, 0, len(r.machines))
+	for name := range r.machines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
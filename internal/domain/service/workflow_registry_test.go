@@ -0,0 +1,115 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+)
+
+const batchETLWorkflow = `
+initial: PENDING
+states:
+  PENDING:
+    transitions: [IN_PROGRESS, FAILED]
+  IN_PROGRESS:
+    transitions: [COMPLETED, FAILED, CANCELLED]
+  COMPLETED: {}
+  FAILED: {}
+  CANCELLED: {}
+`
+
+func TestWorkflowRegistry_Get(t *testing.T) {
+	defaultMachine := NewStateMachine()
+	registry := NewWorkflowRegistry(defaultMachine)
+
+	custom, err := NewStateMachineFromDefinition(DefaultStateMachineDefinition())
+	require.NoError(t, err)
+	require.NoError(t, registry.Register("batch-etl", custom))
+
+	tests := []struct {
+		name     string
+		workflow string
+		want     *StateMachine
+		wantErr  error
+	}{
+		{name: "empty name resolves to default", workflow: "", want: defaultMachine},
+		{name: "default", workflow: entity.DefaultWorkflow, want: defaultMachine},
+		{name: "registered profile", workflow: "batch-etl", want: custom},
+		{name: "unknown profile", workflow: "rpa", wantErr: entity.ErrUnknownWorkflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.Get(tt.workflow)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, tt.want, got)
+		})
+	}
+}
+
+func TestWorkflowRegistry_Register(t *testing.T) {
+	registry := NewWorkflowRegistry(NewStateMachine())
+
+	assert.ErrorIs(t, registry.Register("Batch ETL", NewStateMachine()), entity.ErrInvalidWorkflow)
+	assert.Error(t, registry.Register(entity.DefaultWorkflow, NewStateMachine()))
+	assert.NoError(t, registry.Register("rpa", NewStateMachine()))
+	assert.Error(t, registry.Register("rpa", NewStateMachine()))
+	assert.Equal(t, []string{"default", "rpa"}, registry.Names())
+}
+
+func TestLoadWorkflowRegistry(t *testing.T) {
+	t.Run("one profile per file", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "batch-etl.yaml"), []byte(batchETLWorkflow), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o600))
+
+		registry, err := LoadWorkflowRegistry(NewStateMachine(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"batch-etl", "default"}, registry.Names())
+
+		batch, err := registry.Get("batch-etl")
+		require.NoError(t, err)
+		assert.True(t, batch.CanTransition(entity.StatePending, entity.StateFailed))
+
+		defaultMachine, err := registry.Get(entity.DefaultWorkflow)
+		require.NoError(t, err)
+		assert.False(t, defaultMachine.CanTransition(entity.StatePending, entity.StateFailed))
+	})
+
+	t.Run("shipped profiles are valid", func(t *testing.T) {
+		registry, err := LoadWorkflowRegistry(NewStateMachine(), filepath.Join("..", "..", "..", "configs", "workflows"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"batch-etl", "default", "rpa"}, registry.Names())
+
+		rpa, err := registry.Get("rpa")
+		require.NoError(t, err)
+		assert.True(t, rpa.CanTransition(entity.StateInProgress, entity.StateRetrying))
+		assert.True(t, rpa.CanTransition(entity.StateRetrying, entity.StateInProgress))
+		assert.False(t, rpa.IsFinal(entity.StateRetrying))
+	})
+
+	t.Run("invalid profile fails startup", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "rpa.json"), []byte(`{"initial": "PENDING", "states": {}}`), 0o600))
+
+		_, err := LoadWorkflowRegistry(NewStateMachine(), dir)
+		assert.ErrorIs(t, err, ErrInvalidStateMachineDefinition)
+		assert.Contains(t, err.Error(), `workflow "rpa"`)
+	})
+
+	t.Run("no directory", func(t *testing.T) {
+		registry, err := LoadWorkflowRegistry(NewStateMachine(), "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{entity.DefaultWorkflow}, registry.Names())
+	})
+}
@@ -5,11 +5,19 @@ import (
 	"os"
 	"strconv"
 	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	StateMachine StateMachineConfig
+	Reaper       JobConfig
+	SLAMonitor   JobConfig
+	MissedRuns   JobConfig
+	Payload      PayloadConfig
+	Artifacts    ArtifactsConfig
 }
 
 type ServerConfig struct {
@@ -17,6 +25,59 @@ type ServerConfig struct {
 	GinMode string
 }
 
+// StateMachineConfig configura el origen de las definiciones de las máquinas de estados
+type StateMachineConfig struct {
+	// DefinitionFile es la ruta a un fichero YAML/JSON con el grafo de estados del workflow por defecto.
+	// Si está vacío se usan las transiciones por defecto.
+	DefinitionFile string
+
+	// WorkflowsDir es un directorio con un fichero YAML/JSON por perfil de workflow adicional,
+	// nombrado como el perfil (p. ej. batch-etl.yaml). Si está vacío solo existe el workflow por defecto.
+	WorkflowsDir string
+}
+
+// JobConfig configura un proceso periódico en segundo plano: el reaper que cierra como FAILED las
+// tareas sin latidos, el monitor que registra los incumplimientos de SLA, el detector de ejecuciones
+// perdidas de los automatismos o el purgador de artefactos
+type JobConfig struct {
+	// Interval es el tiempo entre pasadas. Cero desactiva el proceso en esta réplica.
+	Interval time.Duration
+
+	// BatchSize es el número máximo de tareas que procesa cada pasada.
+	BatchSize int
+}
+
+// PayloadConfig limita los documentos JSON input y output de tareas y subtareas
+type PayloadConfig struct {
+	// MaxBytes es el tamaño máximo de cada documento, una vez compactado
+	MaxBytes int
+}
+
+// ArtifactsConfig configura el almacén del contenido de los artefactos de las tareas
+type ArtifactsConfig struct {
+	// Store es el tipo de almacén: "filesystem" o "s3"
+	Store string
+
+	// Dir es el directorio raíz del almacén filesystem
+	Dir string
+
+	// S3Endpoint, S3Region, S3Bucket, S3AccessKeyID y S3SecretAccessKey configuran el almacén s3
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+
+	// S3PathStyle direcciona el bucket en la ruta de la URL, como exigen MinIO y la mayoría de servicios compatibles
+	S3PathStyle bool
+
+	// MaxBytes es el tamaño máximo del fichero de cada artefacto
+	MaxBytes int64
+
+	// Purger configura el proceso que borra el contenido de los artefactos de las tareas purgadas
+	Purger JobConfig
+}
+
 type DatabaseConfig struct {
 	Host            string
 	Port            int
@@ -57,6 +118,31 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid DATABASE_MAX_CONN_IDLE_TIME: %w", err)
 	}
 
+	reaper, err := loadJobConfig("STALE_TASK_REAPER", "30s")
+	if err != nil {
+		return nil, err
+	}
+
+	slaMonitor, err := loadJobConfig("SLA_MONITOR", "30s")
+	if err != nil {
+		return nil, err
+	}
+
+	missedRuns, err := loadJobConfig("MISSED_RUN_DETECTOR", "1m")
+	if err != nil {
+		return nil, err
+	}
+
+	payloadMaxBytes, err := strconv.Atoi(getEnv("PAYLOAD_MAX_BYTES", strconv.Itoa(entity.DefaultMaxPayloadBytes)))
+	if err != nil || payloadMaxBytes < 1 {
+		return nil, fmt.Errorf("invalid PAYLOAD_MAX_BYTES: %q", os.Getenv("PAYLOAD_MAX_BYTES"))
+	}
+
+	artifacts, err := loadArtifactsConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port:    getEnv("PORT", "8080"),
@@ -74,9 +160,71 @@ func Load() (*Config, error) {
 			MaxConnLifetime: maxConnLifetime,
 			MaxConnIdleTime: maxConnIdleTime,
 		},
+		StateMachine: StateMachineConfig{
+			DefinitionFile: getEnv("STATE_MACHINE_FILE", ""),
+			WorkflowsDir:   getEnv("WORKFLOWS_DIR", ""),
+		},
+		Reaper:     reaper,
+		SLAMonitor: slaMonitor,
+		MissedRuns: missedRuns,
+		Payload:    PayloadConfig{MaxBytes: payloadMaxBytes},
+		Artifacts:  artifacts,
 	}, nil
 }
 
+// loadArtifactsConfig carga la configuración del almacén de artefactos desde las variables ARTIFACT_*
+func loadArtifactsConfig() (ArtifactsConfig, error) {
+	store := getEnv("ARTIFACT_STORE", "filesystem")
+	if store != "filesystem" && store != "s3" {
+		return ArtifactsConfig{}, fmt.Errorf("invalid ARTIFACT_STORE: %q (must be filesystem or s3)", store)
+	}
+
+	pathStyle, err := strconv.ParseBool(getEnv("ARTIFACT_S3_PATH_STYLE", "true"))
+	if err != nil {
+		return ArtifactsConfig{}, fmt.Errorf("invalid ARTIFACT_S3_PATH_STYLE: %q", os.Getenv("ARTIFACT_S3_PATH_STYLE"))
+	}
+
+	maxBytes, err := strconv.ParseInt(getEnv("ARTIFACT_MAX_BYTES", strconv.FormatInt(entity.DefaultMaxArtifactBytes, 10)), 10, 64)
+	if err != nil || maxBytes < 1 {
+		return ArtifactsConfig{}, fmt.Errorf("invalid ARTIFACT_MAX_BYTES: %q", os.Getenv("ARTIFACT_MAX_BYTES"))
+	}
+
+	purger, err := loadJobConfig("ARTIFACT_PURGER", "1m")
+	if err != nil {
+		return ArtifactsConfig{}, err
+	}
+
+	return ArtifactsConfig{
+		Store:             store,
+		Dir:               getEnv("ARTIFACT_STORE_DIR", "./data/artifacts"),
+		S3Endpoint:        getEnv("ARTIFACT_S3_ENDPOINT", ""),
+		S3Region:          getEnv("ARTIFACT_S3_REGION", "us-east-1"),
+		S3Bucket:          getEnv("ARTIFACT_S3_BUCKET", ""),
+		S3AccessKeyID:     getEnv("ARTIFACT_S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey: getEnv("ARTIFACT_S3_SECRET_ACCESS_KEY", ""),
+		S3PathStyle:       pathStyle,
+		MaxBytes:          maxBytes,
+		Purger:            purger,
+	}, nil
+}
+
+// loadJobConfig carga la configuración de un proceso periódico desde <prefix>_INTERVAL y <prefix>_BATCH_SIZE
+func loadJobConfig(prefix, defaultInterval string) (JobConfig, error) {
+	intervalKey, batchSizeKey := prefix+"_INTERVAL", prefix+"_BATCH_SIZE"
+
+	interval, err := time.ParseDuration(getEnv(intervalKey, defaultInterval))
+	if err != nil || interval < 0 {
+		return JobConfig{}, fmt.Errorf("invalid %s: %q", intervalKey, os.Getenv(intervalKey))
+	}
+
+	batchSize, err := strconv.Atoi(getEnv(batchSizeKey, "100"))
+	if err != nil || batchSize < 1 {
+		return JobConfig{}, fmt.Errorf("invalid %s: %q", batchSizeKey, os.Getenv(batchSizeKey))
+	}
+
+	return JobConfig{Interval: interval, BatchSize: batchSize}, nil
+}
+
 // ConnectionString genera la cadena de conexión a PostgreSQL
 func (c *DatabaseConfig) ConnectionString() string {
 	return fmt.Sprintf(
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// PurgeArtifactBlobsUseCaseInterface define la interfaz del caso de uso que ejecuta una pasada del purgador de artefactos
+type PurgeArtifactBlobsUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.PurgeArtifactBlobsInput) (*taskUsecase.PurgeArtifactBlobsOutput, error)
+}
+
+// ArtifactPurger borra en segundo plano, a intervalos regulares, el contenido de los artefactos de las tareas purgadas
+// Cada réplica de la API puede ejecutar el suyo: los borrados son idempotentes
+type ArtifactPurger struct {
+	useCase   PurgeArtifactBlobsUseCaseInterface
+	interval  time.Duration
+	batchSize int
+}
+
+// NewArtifactPurger crea un purgador que ejecuta una pasada cada interval borrando hasta batchSize contenidos
+func NewArtifactPurger(useCase PurgeArtifactBlobsUseCaseInterface, interval time.Duration, batchSize int) *ArtifactPurger {
+	return &ArtifactPurger{
+		useCase:   useCase,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run ejecuta pasadas del purgador hasta que ctx se cancela
+// Un error en una pasada se registra y no detiene las siguientes
+func (p *ArtifactPurger) Run(ctx context.Context) {
+	runPeriodically(ctx, p.interval, p.purge)
+}
+
+// purge ejecuta una pasada del purgador y registra su resultado
+func (p *ArtifactPurger) purge(ctx context.Context, _ time.Time) {
+	output, err := p.useCase.Execute(ctx, taskUsecase.PurgeArtifactBlobsInput{Limit: p.batchSize})
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Artifact purger failed: %v", err)
+		}
+		return
+	}
+
+	if len(output.Purged) > 0 {
+		log.Printf("Artifact purger: deleted the content of %d purged artifacts", len(output.Purged))
+	}
+	for key, err := range output.Failed {
+		log.Printf("Artifact purger: failed to delete %s: %v", key, err)
+	}
+}
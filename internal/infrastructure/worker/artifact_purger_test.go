@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// fakePurgeArtifactBlobsUseCase registra las pasadas que recibe y falla en la primera
+type fakePurgeArtifactBlobsUseCase struct {
+	calls chan taskUsecase.PurgeArtifactBlobsInput
+	runs  int
+}
+
+func (f *fakePurgeArtifactBlobsUseCase) Execute(_ context.Context, input taskUsecase.PurgeArtifactBlobsInput) (*taskUsecase.PurgeArtifactBlobsOutput, error) {
+	f.runs++
+	f.calls <- input
+	if f.runs == 1 {
+		return nil, errors.New("database unavailable")
+	}
+	return &taskUsecase.PurgeArtifactBlobsOutput{
+		Purged: []string{"tasks/1/artifacts/1"},
+		Failed: map[string]error{"tasks/1/artifacts/2": errors.New("access denied")},
+	}, nil
+}
+
+func TestArtifactPurger_Run(t *testing.T) {
+	useCase := &fakePurgeArtifactBlobsUseCase{calls: make(chan taskUsecase.PurgeArtifactBlobsInput, 10)}
+	purger := NewArtifactPurger(useCase, 5*time.Millisecond, 25)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		purger.Run(ctx)
+		close(done)
+	}()
+
+	// Un error en una pasada no detiene las siguientes
+	var inputs []taskUsecase.PurgeArtifactBlobsInput
+	for len(inputs) < 2 {
+		select {
+		case input := <-useCase.calls:
+			inputs = append(inputs, input)
+		case <-time.After(time.Second):
+			t.Fatal("purger did not run")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("purger did not stop after cancellation")
+	}
+
+	for _, input := range inputs {
+		assert.Equal(t, 25, input.Limit)
+	}
+}
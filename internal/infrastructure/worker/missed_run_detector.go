@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	automationUsecase "github.com/grupoapi/proces-log/internal/usecase/automation"
+)
+
+// DetectMissedRunsUseCaseInterface define la interfaz del caso de uso que ejecuta una pasada del detector
+// de ejecuciones perdidas
+type DetectMissedRunsUseCaseInterface interface {
+	Execute(ctx context.Context, input automationUsecase.DetectMissedRunsInput) (*automationUsecase.DetectMissedRunsOutput, error)
+}
+
+// MissedRunDetector ejecuta en segundo plano, a intervalos regulares, la detección de las ejecuciones
+// planificadas de los automatismos que nunca se crearon
+// Cada réplica de la API puede ejecutar el suyo: el caso de uso serializa las pasadas entre réplicas
+type MissedRunDetector struct {
+	useCase   DetectMissedRunsUseCaseInterface
+	interval  time.Duration
+	batchSize int
+}
+
+// NewMissedRunDetector crea un detector que ejecuta una pasada cada interval revisando hasta batchSize activaciones
+func NewMissedRunDetector(useCase DetectMissedRunsUseCaseInterface, interval time.Duration, batchSize int) *MissedRunDetector {
+	return &MissedRunDetector{
+		useCase:   useCase,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run ejecuta pasadas del detector hasta que ctx se cancela
+// Un error en una pasada se registra y no detiene las siguientes
+func (d *MissedRunDetector) Run(ctx context.Context) {
+	runPeriodically(ctx, d.interval, d.check)
+}
+
+// check ejecuta una pasada del detector y registra una alerta por cada ejecución perdida
+func (d *MissedRunDetector) check(ctx context.Context, now time.Time) {
+	output, err := d.useCase.Execute(ctx, automationUsecase.DetectMissedRunsInput{Now: now, Limit: d.batchSize})
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Missed run detector failed: %v", err)
+		}
+		return
+	}
+
+	for _, missed := range output.Missed {
+		log.Printf("ALERT missed run: automation %s did not run at %s (no run created before %s)",
+			missed.AutomationKey, missed.ExpectedAt.Format(time.RFC3339), missed.Deadline.Format(time.RFC3339))
+	}
+}
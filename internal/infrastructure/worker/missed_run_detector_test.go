@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	automationUsecase "github.com/grupoapi/proces-log/internal/usecase/automation"
+)
+
+// fakeMissedRunUseCase registra las pasadas que recibe y falla en la primera
+type fakeMissedRunUseCase struct {
+	calls chan automationUsecase.DetectMissedRunsInput
+	runs  int
+}
+
+func (f *fakeMissedRunUseCase) Execute(_ context.Context, input automationUsecase.DetectMissedRunsInput) (*automationUsecase.DetectMissedRunsOutput, error) {
+	f.runs++
+	f.calls <- input
+	if f.runs == 1 {
+		return nil, errors.New("database unavailable")
+	}
+	return &automationUsecase.DetectMissedRunsOutput{}, nil
+}
+
+func TestMissedRunDetector_Run(t *testing.T) {
+	useCase := &fakeMissedRunUseCase{calls: make(chan automationUsecase.DetectMissedRunsInput, 10)}
+	detector := NewMissedRunDetector(useCase, 5*time.Millisecond, 25)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		detector.Run(ctx)
+		close(done)
+	}()
+
+	// Un error en una pasada no detiene las siguientes
+	var inputs []automationUsecase.DetectMissedRunsInput
+	for len(inputs) < 2 {
+		select {
+		case input := <-useCase.calls:
+			inputs = append(inputs, input)
+		case <-time.After(time.Second):
+			t.Fatal("detector did not run")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("detector did not stop after cancellation")
+	}
+
+	for _, input := range inputs {
+		assert.Equal(t, 25, input.Limit)
+		assert.False(t, input.Now.IsZero())
+	}
+	require.True(t, inputs[1].Now.After(inputs[0].Now))
+}
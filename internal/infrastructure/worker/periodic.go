@@ -0,0 +1,21 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// runPeriodically ejecuta pass cada interval, con el instante del tick, hasta que ctx se cancela
+func runPeriodically(ctx context.Context, interval time.Duration, pass func(ctx context.Context, now time.Time)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			pass(ctx, now)
+		}
+	}
+}
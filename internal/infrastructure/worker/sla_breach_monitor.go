@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// RecordSLABreachesUseCaseInterface define la interfaz del caso de uso que ejecuta una pasada del monitor de SLA
+type RecordSLABreachesUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.RecordSLABreachesInput) (*taskUsecase.RecordSLABreachesOutput, error)
+}
+
+// SLABreachMonitor ejecuta en segundo plano, a intervalos regulares, el registro de incumplimientos de SLA
+// Cada réplica de la API puede ejecutar el suyo: el caso de uso serializa las pasadas entre réplicas
+type SLABreachMonitor struct {
+	useCase   RecordSLABreachesUseCaseInterface
+	interval  time.Duration
+	batchSize int
+}
+
+// NewSLABreachMonitor crea un monitor que ejecuta una pasada cada interval registrando hasta batchSize incumplimientos
+func NewSLABreachMonitor(useCase RecordSLABreachesUseCaseInterface, interval time.Duration, batchSize int) *SLABreachMonitor {
+	return &SLABreachMonitor{
+		useCase:   useCase,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run ejecuta pasadas del monitor hasta que ctx se cancela
+// Un error en una pasada se registra y no detiene las siguientes
+func (m *SLABreachMonitor) Run(ctx context.Context) {
+	runPeriodically(ctx, m.interval, m.check)
+}
+
+// check ejecuta una pasada del monitor y registra su resultado
+func (m *SLABreachMonitor) check(ctx context.Context, now time.Time) {
+	output, err := m.useCase.Execute(ctx, taskUsecase.RecordSLABreachesInput{Now: now, Limit: m.batchSize})
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("SLA breach monitor failed: %v", err)
+		}
+		return
+	}
+
+	for _, task := range output.Breached {
+		log.Printf("SLA breach monitor: task %s breached its SLA at %s", task.ID, task.SLABreachedAt.Format(time.RFC3339))
+	}
+}
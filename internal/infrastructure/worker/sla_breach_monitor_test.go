@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// fakeSLABreachUseCase registra las pasadas que recibe y falla en la primera
+type fakeSLABreachUseCase struct {
+	calls chan taskUsecase.RecordSLABreachesInput
+	runs  int
+}
+
+func (f *fakeSLABreachUseCase) Execute(_ context.Context, input taskUsecase.RecordSLABreachesInput) (*taskUsecase.RecordSLABreachesOutput, error) {
+	f.runs++
+	f.calls <- input
+	if f.runs == 1 {
+		return nil, errors.New("database unavailable")
+	}
+	return &taskUsecase.RecordSLABreachesOutput{}, nil
+}
+
+func TestSLABreachMonitor_Run(t *testing.T) {
+	useCase := &fakeSLABreachUseCase{calls: make(chan taskUsecase.RecordSLABreachesInput, 10)}
+	monitor := NewSLABreachMonitor(useCase, 5*time.Millisecond, 50)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		monitor.Run(ctx)
+		close(done)
+	}()
+
+	// Un error en una pasada no detiene las siguientes
+	var inputs []taskUsecase.RecordSLABreachesInput
+	for len(inputs) < 2 {
+		select {
+		case input := <-useCase.calls:
+			inputs = append(inputs, input)
+		case <-time.After(time.Second):
+			t.Fatal("monitor did not run")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("monitor did not stop after cancellation")
+	}
+
+	for _, input := range inputs {
+		assert.Equal(t, 50, input.Limit)
+		assert.False(t, input.Now.IsZero())
+	}
+	require.True(t, inputs[1].Now.After(inputs[0].Now))
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// ReapStaleTasksUseCaseInterface define la interfaz del caso de uso que ejecuta una pasada del reaper
+type ReapStaleTasksUseCaseInterface interface {
+	Execute(ctx context.Context, input taskUsecase.ReapStaleTasksInput) (*taskUsecase.ReapStaleTasksOutput, error)
+}
+
+// StaleTaskReaper ejecuta en segundo plano, a intervalos regulares, el reaper de tareas sin latidos
+// Cada réplica de la API puede ejecutar el suyo: el caso de uso serializa las pasadas entre réplicas
+type StaleTaskReaper struct {
+	useCase   ReapStaleTasksUseCaseInterface
+	interval  time.Duration
+	batchSize int
+}
+
+// NewStaleTaskReaper crea un reaper que ejecuta una pasada cada interval cerrando hasta batchSize tareas
+func NewStaleTaskReaper(useCase ReapStaleTasksUseCaseInterface, interval time.Duration, batchSize int) *StaleTaskReaper {
+	return &StaleTaskReaper{
+		useCase:   useCase,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run ejecuta pasadas del reaper hasta que ctx se cancela
+// Un error en una pasada se registra y no detiene las siguientes
+func (r *StaleTaskReaper) Run(ctx context.Context) {
+	runPeriodically(ctx, r.interval, r.reap)
+}
+
+// reap ejecuta una pasada del reaper y registra su resultado
+func (r *StaleTaskReaper) reap(ctx context.Context, now time.Time) {
+	output, err := r.useCase.Execute(ctx, taskUsecase.ReapStaleTasksInput{Now: now, Limit: r.batchSize})
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Stale task reaper failed: %v", err)
+		}
+		return
+	}
+
+	for _, task := range output.Failed {
+		log.Printf("Stale task reaper: task %s failed after %s without heartbeats", task.ID, *task.HeartbeatTimeout)
+	}
+	for _, skipped := range output.Skipped {
+		log.Printf("Stale task reaper: task %s cannot be failed: %v", skipped.TaskID, skipped.Reason)
+	}
+}
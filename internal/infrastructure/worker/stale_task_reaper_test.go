@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+)
+
+// fakeReapUseCase registra las pasadas que recibe y falla en la primera
+type fakeReapUseCase struct {
+	calls chan taskUsecase.ReapStaleTasksInput
+	runs  int
+}
+
+func (f *fakeReapUseCase) Execute(_ context.Context, input taskUsecase.ReapStaleTasksInput) (*taskUsecase.ReapStaleTasksOutput, error) {
+	f.runs++
+	f.calls <- input
+	if f.runs == 1 {
+		return nil, errors.New("database unavailable")
+	}
+	return &taskUsecase.ReapStaleTasksOutput{}, nil
+}
+
+func TestStaleTaskReaper_Run(t *testing.T) {
+	useCase := &fakeReapUseCase{calls: make(chan taskUsecase.ReapStaleTasksInput, 10)}
+	reaper := NewStaleTaskReaper(useCase, 5*time.Millisecond, 25)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		reaper.Run(ctx)
+		close(done)
+	}()
+
+	// Un error en una pasada no detiene las siguientes
+	var inputs []taskUsecase.ReapStaleTasksInput
+	for len(inputs) < 2 {
+		select {
+		case input := <-useCase.calls:
+			inputs = append(inputs, input)
+		case <-time.After(time.Second):
+			t.Fatal("reaper did not run")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reaper did not stop after cancellation")
+	}
+
+	for _, input := range inputs {
+		assert.Equal(t, 25, input.Limit)
+		assert.False(t, input.Now.IsZero())
+	}
+	require.True(t, inputs[1].Now.After(inputs[0].Now))
+}
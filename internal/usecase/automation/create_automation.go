@@ -0,0 +1,87 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// CreateAutomationInput representa los datos de entrada para registrar un automatismo
+type CreateAutomationInput struct {
+	Key             string
+	OwnerTeam       string
+	Description     string
+	SubtaskTemplate []entity.SubtaskTemplate // Subtareas de cada ejecución (opcional)
+	Schedule        *string                  // Expresión cron de la planificación esperada (opcional)
+	Timezone        string                   // Zona horaria de la planificación (opcional, UTC por defecto)
+	GracePeriod     *int                     // Segundos para que se cree cada ejecución planificada (opcional)
+	CreatedBy       string
+}
+
+// CreateAutomationOutput representa el resultado de registrar un automatismo
+type CreateAutomationOutput struct {
+	Automation *entity.Automation
+}
+
+// CreateAutomationUseCase maneja el registro de automatismos
+type CreateAutomationUseCase struct {
+	automationRepo repository.AutomationRepository
+	clock          entity.Clock
+}
+
+// NewCreateAutomationUseCase crea una nueva instancia del caso de uso
+func NewCreateAutomationUseCase(automationRepo repository.AutomationRepository, clock entity.Clock) *CreateAutomationUseCase {
+	return &CreateAutomationUseCase{
+		automationRepo: automationRepo,
+		clock:          clock,
+	}
+}
+
+// Execute ejecuta el caso de uso de registro de automatismos
+func (uc *CreateAutomationUseCase) Execute(ctx context.Context, input CreateAutomationInput) (*CreateAutomationOutput, error) {
+	if err := uc.validateInput(input); err != nil {
+		return nil, err
+	}
+
+	automation, err := entity.NewAutomation(input.Key, input.OwnerTeam, input.CreatedBy, uc.clock)
+	if err != nil {
+		return nil, err
+	}
+	if err := automation.SetDescription(input.Description); err != nil {
+		return nil, err
+	}
+	if err := automation.SetSubtaskTemplate(input.SubtaskTemplate); err != nil {
+		return nil, err
+	}
+	if input.Schedule != nil {
+		schedule, err := entity.ParseSchedule(*input.Schedule, input.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		automation.SetSchedule(schedule)
+	}
+	if input.GracePeriod != nil {
+		if err := automation.SetGracePeriod(*input.GracePeriod); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.automationRepo.Create(ctx, automation); err != nil {
+		return nil, fmt.Errorf("failed to persist automation: %w", err)
+	}
+
+	return &CreateAutomationOutput{Automation: automation}, nil
+}
+
+// validateInput valida los datos de entrada
+func (uc *CreateAutomationUseCase) validateInput(input CreateAutomationInput) error {
+	if input.Key == "" {
+		return fmt.Errorf("%w: key is required", entity.ErrMissingRequiredFields)
+	}
+	if input.Schedule == nil && input.Timezone != "" {
+		return fmt.Errorf("%w: timezone requires a schedule", entity.ErrInvalidSchedule)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// DeleteAutomationInput representa los datos de entrada para eliminar un automatismo
+type DeleteAutomationInput struct {
+	Key       string
+	DeletedBy string
+}
+
+// DeleteAutomationOutput representa el resultado de eliminar un automatismo
+type DeleteAutomationOutput struct {
+	Success bool
+}
+
+// DeleteAutomationUseCase maneja la eliminación (soft delete) de automatismos
+// Las ejecuciones existentes se conservan y siguen referenciando la clave, que no se puede reutilizar
+type DeleteAutomationUseCase struct {
+	automationRepo repository.AutomationRepository
+	clock          entity.Clock
+}
+
+// NewDeleteAutomationUseCase crea una nueva instancia del caso de uso
+func NewDeleteAutomationUseCase(automationRepo repository.AutomationRepository, clock entity.Clock) *DeleteAutomationUseCase {
+	return &DeleteAutomationUseCase{
+		automationRepo: automationRepo,
+		clock:          clock,
+	}
+}
+
+// Execute ejecuta el caso de uso de eliminación de automatismos
+func (uc *DeleteAutomationUseCase) Execute(ctx context.Context, input DeleteAutomationInput) (*DeleteAutomationOutput, error) {
+	if err := uc.validateInput(input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.automationRepo.Delete(ctx, input.Key, input.DeletedBy, uc.clock.Now()); err != nil {
+		return nil, fmt.Errorf("failed to delete automation: %w", err)
+	}
+
+	return &DeleteAutomationOutput{Success: true}, nil
+}
+
+// validateInput valida los datos de entrada
+func (uc *DeleteAutomationUseCase) validateInput(input DeleteAutomationInput) error {
+	if input.Key == "" {
+		return fmt.Errorf("%w: key is required", entity.ErrMissingRequiredFields)
+	}
+	if input.DeletedBy == "" {
+		return fmt.Errorf("%w: deleted_by is required", entity.ErrMissingRequiredFields)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// missedRunDetectorLock es el bloqueo que garantiza una sola pasada del detector a la vez entre réplicas
+const missedRunDetectorLock = "missed-run-detector"
+
+// DefaultMissedRunBatchSize es el número máximo de activaciones que revisa una pasada del detector
+const DefaultMissedRunBatchSize = 100
+
+// DetectMissedRunsInput representa los datos de entrada de una pasada del detector de ejecuciones perdidas
+type DetectMissedRunsInput struct {
+	Now   time.Time // Instante con el que se evalúan los márgenes
+	Limit int       // Máximo de activaciones a revisar (DefaultMissedRunBatchSize si es 0)
+}
+
+// DetectMissedRunsOutput representa el resultado de una pasada del detector de ejecuciones perdidas
+type DetectMissedRunsOutput struct {
+	Missed []*entity.MissedRun // Activaciones sin ejecución registradas en esta pasada
+	Locked bool                // Otra réplica estaba ejecutando su pasada: no se revisó ningún automatismo
+}
+
+// DetectMissedRunsUseCase registra las activaciones planificadas de los automatismos para las que no se creó
+// ninguna ejecución dentro de su margen
+// Cada activación se revisa una sola vez: el automatismo guarda hasta cuál se revisaron
+type DetectMissedRunsUseCase struct {
+	automationRepo repository.AutomationRepository
+	txManager      repository.TransactionManager
+	lockManager    repository.LockManager
+}
+
+// NewDetectMissedRunsUseCase crea una nueva instancia del caso de uso
+func NewDetectMissedRunsUseCase(
+	automationRepo repository.AutomationRepository,
+	txManager repository.TransactionManager,
+	lockManager repository.LockManager,
+) *DetectMissedRunsUseCase {
+	return &DetectMissedRunsUseCase{
+		automationRepo: automationRepo,
+		txManager:      txManager,
+		lockManager:    lockManager,
+	}
+}
+
+// Execute ejecuta una pasada del detector de ejecuciones perdidas
+// Una activación está perdida si no hay ninguna ejecución del automatismo creada entre la activación y el fin
+// de su margen. La pasada completa se confirma en una única transacción que mantiene el bloqueo del detector
+func (uc *DetectMissedRunsUseCase) Execute(ctx context.Context, input DetectMissedRunsInput) (*DetectMissedRunsOutput, error) {
+	if input.Now.IsZero() {
+		return nil, fmt.Errorf("%w: now is required", entity.ErrMissingRequiredFields)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultMissedRunBatchSize
+	}
+
+	output := &DetectMissedRunsOutput{Missed: make([]*entity.MissedRun, 0)}
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		acquired, err := uc.lockManager.TryLock(ctx, missedRunDetectorLock)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			output.Locked = true
+			return nil
+		}
+
+		automations, err := uc.automationRepo.FindScheduled(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to find scheduled automations: %w", err)
+		}
+
+		for _, automation := range automations {
+			if limit == 0 {
+				break
+			}
+			due := automation.DueActivations(input.Now, limit)
+			if len(due) == 0 {
+				continue
+			}
+			limit -= len(due)
+
+			missed := make([]*entity.MissedRun, 0)
+			for _, expectedAt := range due {
+				ran, err := uc.automationRepo.HasRunBetween(ctx, automation.Key, expectedAt, expectedAt.Add(automation.GracePeriod))
+				if err != nil {
+					return fmt.Errorf("failed to check runs of automation %s: %w", automation.Key, err)
+				}
+				if !ran {
+					missed = append(missed, entity.NewMissedRun(automation, expectedAt, input.Now))
+				}
+			}
+
+			err := uc.automationRepo.RecordMissedRuns(ctx, automation, due[len(due)-1], missed)
+			if errors.Is(err, entity.ErrVersionConflict) {
+				// La planificación cambió durante la pasada: la siguiente revisará la nueva
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to record missed runs of automation %s: %w", automation.Key, err)
+			}
+			output.Missed = append(output.Missed, missed...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,168 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+	"github.com/grupoapi/proces-log/test/helpers"
+)
+
+// fakeAutomationRepository guarda en memoria los automatismos, sus ejecuciones y las ejecuciones perdidas
+// Solo implementa los métodos que usa el detector
+type fakeAutomationRepository struct {
+	repository.AutomationRepository
+
+	automations map[string]*entity.Automation
+	runs        map[string][]time.Time
+	missed      []*entity.MissedRun
+}
+
+func newFakeAutomationRepository(automations ...*entity.Automation) *fakeAutomationRepository {
+	repo := &fakeAutomationRepository{
+		automations: make(map[string]*entity.Automation),
+		runs:        make(map[string][]time.Time),
+	}
+	for _, automation := range automations {
+		repo.automations[automation.Key] = automation
+	}
+	return repo
+}
+
+func (r *fakeAutomationRepository) FindScheduled(_ context.Context) ([]*entity.Automation, error) {
+	scheduled := make([]*entity.Automation, 0)
+	for _, automation := range r.automations {
+		if automation.Schedule != nil && !automation.IsDeleted() {
+			stored := *automation
+			scheduled = append(scheduled, &stored)
+		}
+	}
+	return scheduled, nil
+}
+
+func (r *fakeAutomationRepository) HasRunBetween(_ context.Context, key string, from, to time.Time) (bool, error) {
+	for _, createdAt := range r.runs[key] {
+		if !createdAt.Before(from) && !createdAt.After(to) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeAutomationRepository) RecordMissedRuns(_ context.Context, automation *entity.Automation, checkedUntil time.Time, missed []*entity.MissedRun) error {
+	stored := r.automations[automation.Key]
+	if !stored.ScheduleCheckedUntil.Equal(automation.ScheduleCheckedUntil) {
+		return fmt.Errorf("%w: schedule check modified", entity.ErrVersionConflict)
+	}
+	stored.ScheduleCheckedUntil = checkedUntil
+	automation.ScheduleCheckedUntil = checkedUntil
+	r.missed = append(r.missed, missed...)
+	return nil
+}
+
+type fakeTransactionManager struct{}
+
+func (fakeTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeLockManager struct {
+	held bool
+}
+
+func (l *fakeLockManager) TryLock(_ context.Context, _ string) (bool, error) {
+	return !l.held, nil
+}
+
+// newHourlyAutomation crea un automatismo planificado cada hora en punto, cuyas activaciones se revisan
+// desde el instante de clock en el que se planifica
+func newHourlyAutomation(t *testing.T, key string, gracePeriodSeconds int, clock entity.Clock) *entity.Automation {
+	t.Helper()
+
+	automation, err := entity.NewAutomation(key, "team-billing", "alice", clock)
+	require.NoError(t, err)
+	schedule, err := entity.ParseSchedule("0 * * * *", "")
+	require.NoError(t, err)
+	automation.SetSchedule(schedule)
+	require.NoError(t, automation.SetGracePeriod(gracePeriodSeconds))
+	return automation
+}
+
+// expectedTimes extrae las activaciones de las ejecuciones perdidas
+func expectedTimes(missed []*entity.MissedRun) []time.Time {
+	times := make([]time.Time, 0, len(missed))
+	for _, run := range missed {
+		times = append(times, run.ExpectedAt)
+	}
+	return times
+}
+
+func TestDetectMissedRunsUseCase_Execute(t *testing.T) {
+	clock := helpers.NewFakeClock(time.Date(2026, 3, 2, 8, 30, 0, 0, time.UTC))
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, time.UTC)
+	}
+
+	hourly := newHourlyAutomation(t, "billing.hourly", 600, clock)
+	onDemand, err := entity.NewAutomation("billing.manual", "team-billing", "alice", clock)
+	require.NoError(t, err)
+
+	repo := newFakeAutomationRepository(hourly, onDemand)
+	repo.runs["billing.hourly"] = []time.Time{at(9, 5)}
+	lockManager := &fakeLockManager{}
+	useCase := NewDetectMissedRunsUseCase(repo, fakeTransactionManager{}, lockManager)
+
+	pass := func(limit int) *DetectMissedRunsOutput {
+		output, err := useCase.Execute(context.Background(), DetectMissedRunsInput{Now: clock.Now(), Limit: limit})
+		require.NoError(t, err)
+		return output
+	}
+
+	// 09:05: la activación de las 09:00 todavía está dentro de su margen
+	clock.Advance(35 * time.Minute)
+	assert.Empty(t, pass(0).Missed)
+
+	// 09:10: vence el margen, pero la ejecución se creó a tiempo
+	clock.Advance(5 * time.Minute)
+	assert.Empty(t, pass(0).Missed)
+	assert.Equal(t, at(9, 0), repo.automations["billing.hourly"].ScheduleCheckedUntil)
+
+	// 10:10: la activación de las 10:00 no tuvo ejecución
+	clock.Advance(time.Hour)
+	output := pass(0)
+	require.Len(t, output.Missed, 1)
+	assert.Equal(t, "billing.hourly", output.Missed[0].AutomationKey)
+	assert.Equal(t, at(10, 0), output.Missed[0].ExpectedAt)
+	assert.Equal(t, at(10, 10), output.Missed[0].Deadline)
+	assert.Equal(t, at(10, 10), output.Missed[0].DetectedAt)
+
+	// Una pasada repetida con el mismo reloj no vuelve a registrarla
+	assert.Empty(t, pass(0).Missed)
+
+	// 13:30: el límite reparte las activaciones pendientes entre pasadas
+	clock.Advance(3*time.Hour + 20*time.Minute)
+	assert.Equal(t, []time.Time{at(11, 0), at(12, 0)}, expectedTimes(pass(2).Missed))
+	assert.Equal(t, []time.Time{at(13, 0)}, expectedTimes(pass(2).Missed))
+	assert.Equal(t, []time.Time{at(10, 0), at(11, 0), at(12, 0), at(13, 0)}, expectedTimes(repo.missed))
+
+	// Con el bloqueo tomado por otra réplica no se revisa nada
+	clock.Advance(time.Hour)
+	lockManager.held = true
+	output = pass(0)
+	assert.True(t, output.Locked)
+	assert.Empty(t, output.Missed)
+	assert.Equal(t, at(13, 0), repo.automations["billing.hourly"].ScheduleCheckedUntil)
+}
+
+func TestDetectMissedRunsUseCase_Execute_RequiresNow(t *testing.T) {
+	useCase := NewDetectMissedRunsUseCase(newFakeAutomationRepository(), fakeTransactionManager{}, &fakeLockManager{})
+
+	_, err := useCase.Execute(context.Background(), DetectMissedRunsInput{})
+	assert.ErrorIs(t, err, entity.ErrMissingRequiredFields)
+}
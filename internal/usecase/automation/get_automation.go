@@ -0,0 +1,51 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// GetAutomationInput representa los datos de entrada para obtener un automatismo
+type GetAutomationInput struct {
+	Key string
+}
+
+// GetAutomationOutput representa el automatismo con el resumen de sus ejecuciones
+type GetAutomationOutput struct {
+	Automation *entity.Automation
+	Runs       *entity.AutomationRunSummary
+}
+
+// GetAutomationUseCase maneja la consulta de un automatismo
+type GetAutomationUseCase struct {
+	automationRepo repository.AutomationRepository
+}
+
+// NewGetAutomationUseCase crea una nueva instancia del caso de uso
+func NewGetAutomationUseCase(automationRepo repository.AutomationRepository) *GetAutomationUseCase {
+	return &GetAutomationUseCase{
+		automationRepo: automationRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso de consulta de un automatismo
+func (uc *GetAutomationUseCase) Execute(ctx context.Context, input GetAutomationInput) (*GetAutomationOutput, error) {
+	if input.Key == "" {
+		return nil, fmt.Errorf("%w: key is required", entity.ErrMissingRequiredFields)
+	}
+
+	automation, err := uc.automationRepo.FindByKey(ctx, input.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find automation: %w", err)
+	}
+
+	runs, err := uc.automationRepo.SummarizeRuns(ctx, []string{automation.Key})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize automation runs: %w", err)
+	}
+
+	return &GetAutomationOutput{Automation: automation, Runs: runs[automation.Key]}, nil
+}
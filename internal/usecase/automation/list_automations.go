@@ -0,0 +1,85 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// ListAutomationsInput representa los datos de entrada para listar automatismos
+type ListAutomationsInput struct {
+	OwnerTeam *string // Filtro opcional por equipo responsable
+	Page      int     // Número de página (1-indexed)
+	Limit     int     // Cantidad de resultados por página
+}
+
+// ListAutomationsOutput representa el resultado de listar automatismos
+// Runs agrupa las ejecuciones de cada automatismo de la página por su clave
+type ListAutomationsOutput struct {
+	Automations []*entity.Automation
+	Runs        map[string]*entity.AutomationRunSummary
+	Total       int
+	Page        int
+	Limit       int
+	TotalPages  int
+}
+
+// ListAutomationsUseCase maneja el listado paginado de automatismos con el resumen de sus ejecuciones
+type ListAutomationsUseCase struct {
+	automationRepo repository.AutomationRepository
+}
+
+// NewListAutomationsUseCase crea una nueva instancia del caso de uso
+func NewListAutomationsUseCase(automationRepo repository.AutomationRepository) *ListAutomationsUseCase {
+	return &ListAutomationsUseCase{
+		automationRepo: automationRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso de listado de automatismos
+func (uc *ListAutomationsUseCase) Execute(ctx context.Context, input ListAutomationsInput) (*ListAutomationsOutput, error) {
+	uc.normalizeInput(&input)
+
+	result, err := uc.automationRepo.FindAll(ctx, repository.AutomationFilters{
+		OwnerTeam: input.OwnerTeam,
+		Page:      input.Page,
+		Limit:     input.Limit,
+		Offset:    (input.Page - 1) * input.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automations: %w", err)
+	}
+
+	keys := make([]string, 0, len(result.Automations))
+	for _, automation := range result.Automations {
+		keys = append(keys, automation.Key)
+	}
+	runs, err := uc.automationRepo.SummarizeRuns(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize automation runs: %w", err)
+	}
+
+	return &ListAutomationsOutput{
+		Automations: result.Automations,
+		Runs:        runs,
+		Total:       result.Total,
+		Page:        result.Page,
+		Limit:       result.Limit,
+		TotalPages:  result.TotalPages,
+	}, nil
+}
+
+// normalizeInput normaliza la paginación
+func (uc *ListAutomationsUseCase) normalizeInput(input *ListAutomationsInput) {
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.Limit < 1 {
+		input.Limit = 20 // Default
+	}
+	if input.Limit > 100 {
+		input.Limit = 100 // Máximo permitido
+	}
+}
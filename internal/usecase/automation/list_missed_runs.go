@@ -0,0 +1,80 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// ListMissedRunsInput representa los datos de entrada para listar las ejecuciones perdidas de un automatismo
+type ListMissedRunsInput struct {
+	Key   string
+	Page  int // Número de página (1-indexed)
+	Limit int // Cantidad de resultados por página
+}
+
+// ListMissedRunsOutput representa el resultado de listar las ejecuciones perdidas de un automatismo
+type ListMissedRunsOutput struct {
+	MissedRuns []*entity.MissedRun
+	Total      int
+	Page       int
+	Limit      int
+	TotalPages int
+}
+
+// ListMissedRunsUseCase maneja el listado paginado de las ejecuciones perdidas de un automatismo
+type ListMissedRunsUseCase struct {
+	automationRepo repository.AutomationRepository
+}
+
+// NewListMissedRunsUseCase crea una nueva instancia del caso de uso
+func NewListMissedRunsUseCase(automationRepo repository.AutomationRepository) *ListMissedRunsUseCase {
+	return &ListMissedRunsUseCase{
+		automationRepo: automationRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso de listado de ejecuciones perdidas
+// Retorna entity.ErrAutomationNotFound si el automatismo no existe o está eliminado
+func (uc *ListMissedRunsUseCase) Execute(ctx context.Context, input ListMissedRunsInput) (*ListMissedRunsOutput, error) {
+	if input.Key == "" {
+		return nil, fmt.Errorf("%w: key is required", entity.ErrMissingRequiredFields)
+	}
+	uc.normalizeInput(&input)
+
+	if _, err := uc.automationRepo.FindByKey(ctx, input.Key); err != nil {
+		return nil, fmt.Errorf("failed to find automation: %w", err)
+	}
+
+	result, err := uc.automationRepo.FindMissedRuns(ctx, input.Key, repository.MissedRunFilters{
+		Page:   input.Page,
+		Limit:  input.Limit,
+		Offset: (input.Page - 1) * input.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list missed runs: %w", err)
+	}
+
+	return &ListMissedRunsOutput{
+		MissedRuns: result.MissedRuns,
+		Total:      result.Total,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// normalizeInput normaliza la paginación
+func (uc *ListMissedRunsUseCase) normalizeInput(input *ListMissedRunsInput) {
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.Limit < 1 {
+		input.Limit = 20 // Default
+	}
+	if input.Limit > 100 {
+		input.Limit = 100 // Máximo permitido
+	}
+}
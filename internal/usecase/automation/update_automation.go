@@ -0,0 +1,133 @@
+package automation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// UpdateAutomationInput representa los datos de entrada para modificar un automatismo
+// Los campos nil no se modifican; la clave no puede cambiar porque la referencian sus ejecuciones
+type UpdateAutomationInput struct {
+	Key             string
+	OwnerTeam       *string
+	Description     *string
+	SubtaskTemplate *[]entity.SubtaskTemplate // Reemplaza la plantilla; vacía crea las ejecuciones sin subtareas
+	Schedule        *string                   // Reemplaza la expresión cron; vacía elimina la planificación
+	Timezone        *string                   // Reemplaza la zona horaria de la planificación
+	GracePeriod     *int                      // Reemplaza el margen, en segundos, de las ejecuciones planificadas
+	UpdatedBy       string
+
+	// ExpectedVersion es la versión que el cliente espera modificar (If-Match)
+	// Si es nil no se valida y solo aplica el control optimista del repositorio
+	ExpectedVersion *int
+}
+
+// UpdateAutomationOutput representa el resultado de modificar un automatismo
+type UpdateAutomationOutput struct {
+	Automation *entity.Automation
+}
+
+// UpdateAutomationUseCase maneja la modificación de automatismos
+// Los cambios solo afectan a las ejecuciones creadas después; las existentes conservan sus subtareas
+type UpdateAutomationUseCase struct {
+	automationRepo repository.AutomationRepository
+	clock          entity.Clock
+}
+
+// NewUpdateAutomationUseCase crea una nueva instancia del caso de uso
+func NewUpdateAutomationUseCase(automationRepo repository.AutomationRepository, clock entity.Clock) *UpdateAutomationUseCase {
+	return &UpdateAutomationUseCase{
+		automationRepo: automationRepo,
+		clock:          clock,
+	}
+}
+
+// Execute ejecuta el caso de uso de modificación de automatismos
+func (uc *UpdateAutomationUseCase) Execute(ctx context.Context, input UpdateAutomationInput) (*UpdateAutomationOutput, error) {
+	if err := uc.validateInput(input); err != nil {
+		return nil, err
+	}
+
+	automation, err := uc.automationRepo.FindByKey(ctx, input.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find automation: %w", err)
+	}
+	automation.SetClock(uc.clock)
+	if err := entity.ValidateVersion(input.ExpectedVersion, automation.Version); err != nil {
+		return nil, err
+	}
+
+	if input.OwnerTeam != nil {
+		if err := automation.SetOwnerTeam(*input.OwnerTeam); err != nil {
+			return nil, err
+		}
+	}
+	if input.Description != nil {
+		if err := automation.SetDescription(*input.Description); err != nil {
+			return nil, err
+		}
+	}
+	if input.SubtaskTemplate != nil {
+		if err := automation.SetSubtaskTemplate(*input.SubtaskTemplate); err != nil {
+			return nil, err
+		}
+	}
+	if input.Schedule != nil || input.Timezone != nil {
+		schedule, err := uc.resolveSchedule(automation.Schedule, input)
+		if err != nil {
+			return nil, err
+		}
+		automation.SetSchedule(schedule)
+	}
+	if input.GracePeriod != nil {
+		if err := automation.SetGracePeriod(*input.GracePeriod); err != nil {
+			return nil, err
+		}
+	}
+	if err := automation.Touch(input.UpdatedBy); err != nil {
+		return nil, err
+	}
+
+	if err := uc.automationRepo.Update(ctx, automation); err != nil {
+		return nil, fmt.Errorf("failed to update automation: %w", err)
+	}
+
+	return &UpdateAutomationOutput{Automation: automation}, nil
+}
+
+// resolveSchedule combina la planificación actual con la expresión y la zona horaria del request
+// Cambiar solo la zona horaria reevalúa la expresión actual en la nueva zona
+func (uc *UpdateAutomationUseCase) resolveSchedule(current *entity.Schedule, input UpdateAutomationInput) (*entity.Schedule, error) {
+	expression, timezone := "", ""
+	if current != nil {
+		expression, timezone = current.Expression, current.Timezone
+	}
+	if input.Schedule != nil {
+		expression = *input.Schedule
+	}
+	if input.Timezone != nil {
+		timezone = *input.Timezone
+	}
+
+	if expression == "" {
+		if input.Timezone != nil && *input.Timezone != "" {
+			return nil, fmt.Errorf("%w: timezone requires a schedule", entity.ErrInvalidSchedule)
+		}
+		return nil, nil
+	}
+	return entity.ParseSchedule(expression, timezone)
+}
+
+// validateInput valida los datos de entrada
+func (uc *UpdateAutomationUseCase) validateInput(input UpdateAutomationInput) error {
+	if input.Key == "" {
+		return fmt.Errorf("%w: key is required", entity.ErrMissingRequiredFields)
+	}
+	if input.UpdatedBy == "" {
+		return fmt.Errorf("%w: updated_by is required", entity.ErrMissingRequiredFields)
+	}
+	return nil
+}
@@ -24,12 +24,23 @@ type DeleteSubtaskOutput struct {
 // DeleteSubtaskUseCase maneja la eliminación (soft delete) de subtareas individuales
 type DeleteSubtaskUseCase struct {
 	subtaskRepo repository.SubtaskRepository
+	eventRepo   repository.TaskEventRepository
+	txManager   repository.TransactionManager
+	clock       entity.Clock
 }
 
 // NewDeleteSubtaskUseCase crea una nueva instancia del caso de uso
-func NewDeleteSubtaskUseCase(subtaskRepo repository.SubtaskRepository) *DeleteSubtaskUseCase {
+func NewDeleteSubtaskUseCase(
+	subtaskRepo repository.SubtaskRepository,
+	eventRepo repository.TaskEventRepository,
+	txManager repository.TransactionManager,
+	clock entity.Clock,
+) *DeleteSubtaskUseCase {
 	return &DeleteSubtaskUseCase{
 		subtaskRepo: subtaskRepo,
+		eventRepo:   eventRepo,
+		txManager:   txManager,
+		clock:       clock,
 	}
 }
 
@@ -40,9 +51,39 @@ func (uc *DeleteSubtaskUseCase) Execute(ctx context.Context, input DeleteSubtask
 		return nil, err
 	}
 
-	// Eliminar subtarea (soft delete)
-	if err := uc.subtaskRepo.Delete(ctx, input.ID, input.DeletedBy); err != nil {
-		return nil, fmt.Errorf("failed to delete subtask: %w", err)
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Cargar la subtarea y su tarea padre para registrar el historial
+		subtask, err := uc.subtaskRepo.FindByID(ctx, input.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete subtask: %w", err)
+		}
+
+		taskID, err := uc.subtaskRepo.FindParentTaskID(ctx, input.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete subtask: %w", err)
+		}
+
+		// Eliminar la subtarea y sus subtareas anidadas (soft delete)
+		now := uc.clock.Now()
+		removed, err := uc.subtaskRepo.Delete(ctx, subtask.ID, input.DeletedBy, now)
+		if err != nil {
+			return fmt.Errorf("failed to delete subtask: %w", err)
+		}
+
+		// Cada subtarea eliminada queda registrada en el historial de la tarea
+		events := make([]*entity.TaskEvent, 0, len(removed))
+		for _, st := range removed {
+			name := st.Name
+			events = append(events, entity.NewSubtaskEvent(taskID, st.ID, entity.EventSubtaskRemoved, input.DeletedBy, &name, nil, now))
+		}
+		if err := uc.eventRepo.Create(ctx, events...); err != nil {
+			return fmt.Errorf("failed to record subtask history: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &DeleteSubtaskOutput{Success: true}, nil
@@ -3,7 +3,6 @@ package subtask
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -15,9 +14,16 @@ import (
 // UpdateSubtaskInput representa los datos de entrada para actualizar una subtarea
 type UpdateSubtaskInput struct {
 	ID        uuid.UUID
-	Name      *string       // Opcional: nuevo nombre
-	State     *entity.State // Opcional: nuevo estado
+	Name      *string               // Opcional: nuevo nombre
+	State     *entity.State         // Opcional: nuevo estado
+	Failure   *entity.Failure       // Opcional: motivo del fallo, solo junto con State FAILED
+	Output    entity.Payload        // Opcional: resultados de la subtarea, solo junto con un State final
+	Progress  entity.ProgressReport // Opcional: progreso reportado (los campos nil no se modifican)
 	UpdatedBy string
+
+	// ExpectedVersion es la versión que el cliente espera modificar (If-Match)
+	// Si es nil no se valida y solo aplica el control optimista del repositorio
+	ExpectedVersion *int
 }
 
 // UpdateSubtaskOutput representa el resultado de actualizar una subtarea
@@ -27,36 +33,89 @@ type UpdateSubtaskOutput struct {
 
 // UpdateSubtaskUseCase maneja la actualización de subtareas individuales
 type UpdateSubtaskUseCase struct {
-	subtaskRepo  repository.SubtaskRepository
-	taskRepo     repository.TaskRepository
-	stateMachine *service.StateMachine
+	subtaskRepo     repository.SubtaskRepository
+	taskRepo        repository.TaskRepository
+	eventRepo       repository.TaskEventRepository
+	txManager       repository.TransactionManager
+	workflows       *service.WorkflowRegistry
+	maxPayloadBytes int
+	clock           entity.Clock
 }
 
 // NewUpdateSubtaskUseCase crea una nueva instancia del caso de uso
+// maxPayloadBytes es el tamaño máximo del documento output de la subtarea
 func NewUpdateSubtaskUseCase(
 	subtaskRepo repository.SubtaskRepository,
 	taskRepo repository.TaskRepository,
-	stateMachine *service.StateMachine,
+	eventRepo repository.TaskEventRepository,
+	txManager repository.TransactionManager,
+	workflows *service.WorkflowRegistry,
+	maxPayloadBytes int,
+	clock entity.Clock,
 ) *UpdateSubtaskUseCase {
 	return &UpdateSubtaskUseCase{
-		subtaskRepo:  subtaskRepo,
-		taskRepo:     taskRepo,
-		stateMachine: stateMachine,
+		subtaskRepo:     subtaskRepo,
+		taskRepo:        taskRepo,
+		eventRepo:       eventRepo,
+		txManager:       txManager,
+		workflows:       workflows,
+		maxPayloadBytes: maxPayloadBytes,
+		clock:           clock,
 	}
 }
 
 // Execute ejecuta el caso de uso de actualización de subtarea
+// La actualización de la subtarea, el arranque o la autocompletación de la tarea padre y su historial
+// se confirman en una única transacción: si cualquiera de los pasos falla no se persiste nada
 func (uc *UpdateSubtaskUseCase) Execute(ctx context.Context, input UpdateSubtaskInput) (*UpdateSubtaskOutput, error) {
 	// Validar input
 	if err := uc.validateInput(input); err != nil {
 		return nil, err
 	}
 
+	var subtask *entity.Subtask
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		subtask, err = uc.execute(ctx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateSubtaskOutput{Subtask: subtask}, nil
+}
+
+// execute aplica la actualización dentro de la transacción abierta por Execute
+func (uc *UpdateSubtaskUseCase) execute(ctx context.Context, input UpdateSubtaskInput) (*entity.Subtask, error) {
+	// Bloquear la tarea padre antes de leer la subtarea
+	// Todas las actualizaciones de subtareas de una misma tarea quedan serializadas, de modo que
+	// dos completaciones concurrentes no pueden dejar de ver la condición "todas completadas"
+	task, err := uc.lockParentTask(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Las transiciones se validan con la máquina de estados del workflow de la tarea padre
+	stateMachine, err := uc.workflows.Get(task.Workflow)
+	if err != nil {
+		return nil, err
+	}
+
 	// Buscar subtarea existente
 	subtask, err := uc.subtaskRepo.FindByID(ctx, input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find subtask: %w", err)
 	}
+	subtask.SetClock(uc.clock)
+
+	// Verificar la precondición de versión antes de aplicar cambios
+	if err := entity.ValidateVersion(input.ExpectedVersion, subtask.Version); err != nil {
+		return nil, err
+	}
+
+	// Capturar los valores actuales para registrar el historial
+	before := subtask.Snapshot()
 
 	// Actualizar nombre si se proporciona
 	if input.Name != nil {
@@ -64,33 +123,39 @@ func (uc *UpdateSubtaskUseCase) Execute(ctx context.Context, input UpdateSubtask
 			return nil, err
 		}
 		subtask.Name = *input.Name
-		subtask.UpdatedAt = time.Now()
+		subtask.UpdatedAt = subtask.Now()
+	}
+
+	// Actualizar el progreso reportado si se proporciona
+	if !input.Progress.IsEmpty() {
+		if err := subtask.ReportProgress(input.Progress); err != nil {
+			return nil, err
+		}
+		subtask.UpdatedAt = subtask.Now()
 	}
 
 	// Actualizar estado si se proporciona
 	if input.State != nil {
-		// Necesitamos la tarea padre para validar la transición
-		// Primero encontramos qué tarea contiene esta subtarea
-		task, err := uc.findParentTask(ctx, subtask.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to find parent task: %w", err)
+		// Arrancar la tarea padre y las subtareas padre si está configurada para hacerlo con su primera subtarea
+		if err := uc.autoStartParentTask(ctx, stateMachine, task, *input.State, input.UpdatedBy); err != nil {
+			return nil, err
+		}
+		if err := uc.autoStartParentSubtasks(ctx, stateMachine, task, subtask, *input.State, input.UpdatedBy); err != nil {
+			return nil, err
 		}
 
 		// Validar transición de estado considerando la tarea padre
-		if err := uc.stateMachine.ValidateSubtaskStateTransition(task, subtask, *input.State); err != nil {
+		if err := stateMachine.ValidateSubtaskStateTransition(task, subtask, *input.State); err != nil {
 			return nil, err
 		}
 
-		// Actualizar estado y fechas según el nuevo estado
-		if *input.State == entity.StateInProgress {
-			subtask.SetStartDate()
+		// Actualizar estado, fechas, intervalos de pausa y motivo del fallo según el nuevo estado
+		if err := subtask.UpdateStateWithFailure(*input.State, input.Failure); err != nil {
+			return nil, err
 		}
-		if input.State.IsFinal() {
-			subtask.SetEndDate()
+		if err := subtask.SetOutput(input.Output, uc.maxPayloadBytes); err != nil {
+			return nil, err
 		}
-
-		subtask.State = *input.State
-		subtask.UpdatedAt = time.Now()
 	}
 
 	// Persistir cambios
@@ -98,25 +163,20 @@ func (uc *UpdateSubtaskUseCase) Execute(ctx context.Context, input UpdateSubtask
 		return nil, fmt.Errorf("failed to persist subtask updates: %w", err)
 	}
 
-	// Si la subtarea pasó a estado final, verificar si todas las subtareas están completas
-	// para completar automáticamente la tarea padre
+	// Registrar el historial de los cambios aplicados
+	if err := uc.eventRepo.Create(ctx, subtask.EventsSince(task.ID, before, input.UpdatedBy)...); err != nil {
+		return nil, fmt.Errorf("failed to record subtask history: %w", err)
+	}
+
+	// Si la subtarea pasó a estado final, verificar si la política de finalización
+	// permite cerrar automáticamente sus subtareas padre y la tarea padre
 	if input.State != nil && input.State.IsFinal() {
-		// Obtener el ID de la tarea padre
-		parentTaskID, err := uc.subtaskRepo.FindParentTaskID(ctx, subtask.ID)
-		if err == nil {
-			// Recargar la tarea padre para tener subtareas actualizadas
-			parentTask, err := uc.taskRepo.FindByID(ctx, parentTaskID)
-			if err == nil {
-				if err := uc.checkAndCompleteParentTask(ctx, parentTask, input.UpdatedBy); err != nil {
-					// Log el error pero no fallar la operación principal
-					// La subtarea ya fue actualizada exitosamente
-					fmt.Printf("Warning: failed to auto-complete parent task: %v\n", err)
-				}
-			}
+		if err := uc.checkAndCompleteParentTask(ctx, stateMachine, task, subtask.ID, input.UpdatedBy); err != nil {
+			return nil, fmt.Errorf("failed to auto-complete parent task: %w", err)
 		}
 	}
 
-	return &UpdateSubtaskOutput{Subtask: subtask}, nil
+	return subtask, nil
 }
 
 // validateInput valida los datos de entrada
@@ -128,73 +188,220 @@ func (uc *UpdateSubtaskUseCase) validateInput(input UpdateSubtaskInput) error {
 		return fmt.Errorf("%w: updated_by is required", entity.ErrMissingRequiredFields)
 	}
 	// Al menos uno de los campos debe estar presente
-	if input.Name == nil && input.State == nil {
-		return fmt.Errorf("%w: at least one field (name or state) must be provided", entity.ErrMissingRequiredFields)
+	if input.Name == nil && input.State == nil && input.Progress.IsEmpty() {
+		return fmt.Errorf("%w: at least one field (name, state, or progress) must be provided", entity.ErrMissingRequiredFields)
+	}
+	if input.Failure != nil && input.State == nil {
+		return fmt.Errorf("%w: failure requires state %s", entity.ErrInvalidFailure, entity.StateFailed)
+	}
+	if input.Output != nil && input.State == nil {
+		return fmt.Errorf("%w: output requires a final state", entity.ErrInvalidPayload)
 	}
 	return nil
 }
 
-// findParentTask encuentra la tarea padre de una subtarea
+// lockParentTask encuentra la tarea padre de una subtarea y bloquea su fila
 // Utiliza la foreign key task_id en la tabla subtasks para una búsqueda O(1)
-func (uc *UpdateSubtaskUseCase) findParentTask(ctx context.Context, subtaskID uuid.UUID) (*entity.Task, error) {
+func (uc *UpdateSubtaskUseCase) lockParentTask(ctx context.Context, subtaskID uuid.UUID) (*entity.Task, error) {
 	// Obtener el task_id directamente de la subtarea (O(1))
 	taskID, err := uc.subtaskRepo.FindParentTaskID(ctx, subtaskID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find parent task ID: %w", err)
+		return nil, fmt.Errorf("failed to find subtask: %w", err)
 	}
 
-	// Cargar la tarea completa
-	task, err := uc.taskRepo.FindByID(ctx, taskID)
+	// Cargar la tarea completa con su fila bloqueada hasta el commit
+	task, err := uc.taskRepo.FindByIDForUpdate(ctx, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load parent task: %w", err)
 	}
+	task.SetClock(uc.clock)
 
 	return task, nil
 }
 
-// checkAndCompleteParentTask verifica si todas las subtareas están completas
-// y completa automáticamente la tarea padre si es necesario
-func (uc *UpdateSubtaskUseCase) checkAndCompleteParentTask(ctx context.Context, task *entity.Task, updatedBy string) error {
-	// Solo proceder si la tarea está en IN_PROGRESS
-	if task.State != entity.StateInProgress {
-		return nil // No hay nada que hacer
+// autoStartParentTask pasa la tarea padre PENDING a IN_PROGRESS cuando una de sus subtareas arranca
+// y la tarea tiene AutoStart. El cambio se registra en el historial con el actor que actualizó la subtarea
+func (uc *UpdateSubtaskUseCase) autoStartParentTask(
+	ctx context.Context,
+	stateMachine *service.StateMachine,
+	task *entity.Task,
+	newState entity.State,
+	updatedBy string,
+) error {
+	if !task.AutoStartsWith(newState) {
+		return nil
+	}
+
+	// Validar que la transición sea válida en el workflow de la tarea
+	if err := stateMachine.ValidateTaskStateTransition(task, entity.StateInProgress); err != nil {
+		return fmt.Errorf("invalid transition to auto-start parent task: %w", err)
+	}
+
+	before := task.Snapshot()
+
+	if err := task.UpdateState(entity.StateInProgress, updatedBy); err != nil {
+		return fmt.Errorf("failed to auto-start parent task: %w", err)
+	}
+
+	// Persistir solo la fila de la tarea: sus subtareas no cambian y la que arranca se persiste
+	// después con su propia versión, que no debe incrementarse aquí
+	parent := *task
+	parent.Subtasks = nil
+	if err := uc.taskRepo.Update(ctx, &parent); err != nil {
+		return fmt.Errorf("failed to update parent task: %w", err)
+	}
+	task.Version = parent.Version
+
+	if err := uc.eventRepo.Create(ctx, task.EventsSince(before, updatedBy)...); err != nil {
+		return fmt.Errorf("failed to record parent task history: %w", err)
 	}
 
-	// Obtener todas las subtareas de la tarea
+	return nil
+}
+
+// autoStartParentSubtasks pasa a IN_PROGRESS las subtareas padre PENDING de una subtarea que arranca
+// cuando la tarea tiene AutoStart, de la raíz hacia abajo y con el actor que actualizó la subtarea
+func (uc *UpdateSubtaskUseCase) autoStartParentSubtasks(
+	ctx context.Context,
+	stateMachine *service.StateMachine,
+	task *entity.Task,
+	subtask *entity.Subtask,
+	newState entity.State,
+	updatedBy string,
+) error {
+	for _, ancestor := range task.AncestorsToAutoStart(subtask, newState) {
+		if err := stateMachine.ValidateSubtaskStateTransition(task, ancestor, entity.StateInProgress); err != nil {
+			return fmt.Errorf("invalid transition to auto-start parent subtask: %w", err)
+		}
+
+		before := ancestor.Snapshot()
+		ancestor.UpdateState(entity.StateInProgress)
+
+		if err := uc.subtaskRepo.Update(ctx, ancestor); err != nil {
+			return fmt.Errorf("failed to update parent subtask: %w", err)
+		}
+		if err := uc.eventRepo.Create(ctx, ancestor.EventsSince(task.ID, before, updatedBy)...); err != nil {
+			return fmt.Errorf("failed to record parent subtask history: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkAndCompleteParentTask cierra automáticamente las subtareas padre de la subtarea actualizada y
+// después la tarea padre, cuando el estado de sus hijos lo determina según la política de finalización
+// de la tarea (ver entity.CompletionPolicy). Cada nivel solo se cierra si está IN_PROGRESS
+func (uc *UpdateSubtaskUseCase) checkAndCompleteParentTask(
+	ctx context.Context,
+	stateMachine *service.StateMachine,
+	task *entity.Task,
+	subtaskID uuid.UUID,
+	updatedBy string,
+) error {
+	// Con la tarea en estado final no queda nada abierto que cerrar
+	if stateMachine.IsFinal(task.State) {
+		return nil
+	}
+
+	// Trabajar con las subtareas recién leídas: las cargadas junto a la tarea
+	// no reflejan la actualización que acaba de persistirse
 	subtasks, err := uc.subtaskRepo.FindByTaskID(ctx, task.ID, false) // false = no incluir eliminadas
 	if err != nil {
 		return fmt.Errorf("failed to find subtasks: %w", err)
 	}
+	task.Subtasks = subtasks
+	task.SetClock(uc.clock)
+
+	if err := uc.settleParentSubtasks(ctx, stateMachine, task, subtaskID, updatedBy); err != nil {
+		return err
+	}
+
+	// Solo proceder con la tarea si está en IN_PROGRESS
+	if task.State != entity.StateInProgress {
+		return nil
+	}
 
-	// Si no hay subtareas, no hay nada que hacer
-	if len(subtasks) == 0 {
+	outcome, settled := task.SettlementState()
+	if !settled {
 		return nil
 	}
 
-	// Verificar si todas las subtareas están en estado final exitoso (COMPLETED)
-	allCompleted := true
-	for _, subtask := range subtasks {
-		if subtask.State != entity.StateCompleted {
-			allCompleted = false
-			break
+	// Validar que la transición sea válida en el workflow de la tarea
+	if err := stateMachine.ValidateTaskStateTransition(task, outcome); err != nil {
+		return fmt.Errorf("invalid transition to settle parent task: %w", err)
+	}
+
+	before := task.Snapshot()
+
+	if _, err := task.SettleFromSubtasks(updatedBy); err != nil {
+		return fmt.Errorf("failed to settle parent task: %w", err)
+	}
+
+	// Persistir los cambios
+	if err := uc.taskRepo.Update(ctx, task); err != nil {
+		return fmt.Errorf("failed to update parent task: %w", err)
+	}
+
+	if err := uc.eventRepo.Create(ctx, task.EventsSince(before, updatedBy)...); err != nil {
+		return fmt.Errorf("failed to record parent task history: %w", err)
+	}
+
+	return nil
+}
+
+// settleParentSubtasks cierra las subtareas padre de una subtarea, de la más cercana hacia la raíz,
+// mientras sus hijos lo determinen. Persiste cada subtarea cerrada y las abiertas que heredan su estado
+func (uc *UpdateSubtaskUseCase) settleParentSubtasks(
+	ctx context.Context,
+	stateMachine *service.StateMachine,
+	task *entity.Task,
+	subtaskID uuid.UUID,
+	updatedBy string,
+) error {
+	var updated *entity.Subtask
+	for _, st := range task.Subtasks {
+		if st.ID == subtaskID {
+			updated = st
 		}
 	}
+	if updated == nil {
+		return nil
+	}
+
+	for _, parent := range task.Ancestors(updated) {
+		if parent.State != entity.StateInProgress {
+			return nil
+		}
+
+		outcome, settled := task.SubtaskSettlementState(parent)
+		if !settled {
+			return nil
+		}
+
+		if err := stateMachine.ValidateTransition(parent.State, outcome); err != nil {
+			return fmt.Errorf("invalid transition to settle parent subtask: %w", err)
+		}
 
-	// Si todas las subtareas están completas, completar la tarea padre
-	if allCompleted {
-		// Validar que la transición sea válida
-		if err := uc.stateMachine.ValidateTaskStateTransition(task, entity.StateCompleted); err != nil {
-			return fmt.Errorf("invalid transition to complete parent task: %w", err)
+		// Capturar la rama antes de cerrarla: los descendientes abiertos heredan el estado final
+		branch := append([]*entity.Subtask{parent}, task.Descendants(parent)...)
+		before := make([]entity.SubtaskSnapshot, len(branch))
+		for i, st := range branch {
+			before[i] = st.Snapshot()
 		}
 
-		// Actualizar estado y fechas
-		task.State = entity.StateCompleted
-		task.SetEndDate()
-		task.UpdatedAt = time.Now()
+		task.SettleSubtaskFromChildren(parent)
 
-		// Persistir los cambios
-		if err := uc.taskRepo.Update(ctx, task); err != nil {
-			return fmt.Errorf("failed to update parent task: %w", err)
+		for i, st := range branch {
+			events := st.EventsSince(task.ID, before[i], updatedBy)
+			if len(events) == 0 {
+				continue
+			}
+			if err := uc.subtaskRepo.Update(ctx, st); err != nil {
+				return fmt.Errorf("failed to update parent subtask: %w", err)
+			}
+			if err := uc.eventRepo.Create(ctx, events...); err != nil {
+				return fmt.Errorf("failed to record parent subtask history: %w", err)
+			}
 		}
 	}
 
@@ -0,0 +1,139 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// AppendTaskLogLineInput representa una línea del envío de logs
+type AppendTaskLogLineInput struct {
+	LoggedAt  time.Time // Momento en que el proceso emitió la línea (opcional, cero usa la recepción)
+	Level     string    // DEBUG, INFO, WARN o ERROR
+	Message   string
+	SubtaskID *uuid.UUID // Subtarea de la tarea que emitió la línea (opcional)
+}
+
+// AppendTaskLogsInput representa los datos de entrada para guardar un envío de logs de una tarea
+type AppendTaskLogsInput struct {
+	TaskID uuid.UUID
+	Lines  []AppendTaskLogLineInput
+}
+
+// AppendTaskLogsOutput representa el resultado de guardar un envío de logs
+type AppendTaskLogsOutput struct {
+	Accepted   int // Líneas guardadas en este envío
+	TotalLines int // Líneas guardadas de la tarea tras el envío
+}
+
+// AppendTaskLogsUseCase maneja la recepción de los logs que envían los procesos de las tareas
+// Cada envío se guarda completo o se rechaza completo; los logs no modifican la versión de la tarea
+type AppendTaskLogsUseCase struct {
+	taskRepo  repository.TaskRepository
+	logRepo   repository.TaskLogRepository
+	txManager repository.TransactionManager
+	clock     entity.Clock
+}
+
+// NewAppendTaskLogsUseCase crea una nueva instancia del caso de uso
+func NewAppendTaskLogsUseCase(
+	taskRepo repository.TaskRepository,
+	logRepo repository.TaskLogRepository,
+	txManager repository.TransactionManager,
+	clock entity.Clock,
+) *AppendTaskLogsUseCase {
+	return &AppendTaskLogsUseCase{
+		taskRepo:  taskRepo,
+		logRepo:   logRepo,
+		txManager: txManager,
+		clock:     clock,
+	}
+}
+
+// Execute ejecuta el caso de uso de recepción de logs
+func (uc *AppendTaskLogsUseCase) Execute(ctx context.Context, input AppendTaskLogsInput) (*AppendTaskLogsOutput, error) {
+	if err := uc.validateInput(input); err != nil {
+		return nil, err
+	}
+
+	var output *AppendTaskLogsOutput
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Bloquear la tarea serializa los envíos concurrentes, de modo que el límite por tarea no se supera
+		task, err := uc.taskRepo.FindByIDForUpdate(ctx, input.TaskID)
+		if err != nil {
+			return fmt.Errorf("failed to find task: %w", err)
+		}
+
+		lines, err := uc.buildLines(task, input.Lines)
+		if err != nil {
+			return err
+		}
+
+		stored, err := uc.logRepo.CountByTaskID(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		if stored+len(lines) > entity.MaxLogLinesPerTask {
+			return fmt.Errorf("%w: task stores %d of %d lines, cannot append %d more",
+				entity.ErrLogLimitExceeded, stored, entity.MaxLogLinesPerTask, len(lines))
+		}
+
+		if err := uc.logRepo.Append(ctx, lines...); err != nil {
+			return err
+		}
+
+		output = &AppendTaskLogsOutput{Accepted: len(lines), TotalLines: stored + len(lines)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// validateInput valida los datos de entrada
+func (uc *AppendTaskLogsUseCase) validateInput(input AppendTaskLogsInput) error {
+	if input.TaskID == uuid.Nil {
+		return fmt.Errorf("%w: task id is required", entity.ErrMissingRequiredFields)
+	}
+	if len(input.Lines) == 0 {
+		return fmt.Errorf("%w: at least one line is required", entity.ErrInvalidLogLine)
+	}
+	if len(input.Lines) > entity.MaxLogBatchSize {
+		return fmt.Errorf("%w: a batch cannot exceed %d lines, got %d",
+			entity.ErrInvalidLogLine, entity.MaxLogBatchSize, len(input.Lines))
+	}
+	return nil
+}
+
+// buildLines valida las líneas del envío contra la tarea; las subtareas deben ser de la tarea y no estar eliminadas
+func (uc *AppendTaskLogsUseCase) buildLines(task *entity.Task, inputs []AppendTaskLogLineInput) ([]*entity.TaskLogLine, error) {
+	subtasks := make(map[uuid.UUID]bool, len(task.Subtasks))
+	for _, subtask := range task.Subtasks {
+		if !subtask.IsDeleted() {
+			subtasks[subtask.ID] = true
+		}
+	}
+
+	now := uc.clock.Now()
+	lines := make([]*entity.TaskLogLine, 0, len(inputs))
+	for i, lineInput := range inputs {
+		if lineInput.SubtaskID != nil && !subtasks[*lineInput.SubtaskID] {
+			return nil, fmt.Errorf("%w: line %d: subtask %s does not belong to the task",
+				entity.ErrInvalidLogLine, i, *lineInput.SubtaskID)
+		}
+
+		line, err := entity.NewTaskLogLine(task.ID, lineInput.SubtaskID, lineInput.Level, lineInput.Message, lineInput.LoggedAt, now)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i, err)
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
@@ -2,17 +2,69 @@ package task
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/grupoapi/proces-log/internal/domain/entity"
 	"github.com/grupoapi/proces-log/internal/domain/repository"
+	"github.com/grupoapi/proces-log/internal/domain/service"
 )
 
+// CreateSubtaskItemInput representa una subtarea en el request de creación
+type CreateSubtaskItemInput struct {
+	Name      string
+	State     *entity.State            // Estado inicial (opcional, PENDING por defecto)
+	DependsOn []string                 // IDs o nombres de otras subtareas de la tarea (opcional)
+	Subtasks  []CreateSubtaskItemInput // Subtareas anidadas bajo esta (opcional)
+	Progress  entity.ProgressReport    // Progreso inicial (opcional)
+	Input     entity.Payload           // Parámetros de entrada de la subtarea (opcional)
+}
+
+// createdSubtask asocia cada subtarea creada con el input que la describe
+type createdSubtask struct {
+	input   CreateSubtaskItemInput
+	subtask *entity.Subtask
+}
+
 // CreateTaskInput representa los datos de entrada para crear una tarea
 type CreateTaskInput struct {
-	Name         string
-	CreatedBy    string
-	SubtaskNames []string // Nombres de las subtareas (opcional)
+	Name      string
+	State     *entity.State // Estado inicial (opcional, PENDING por defecto)
+	CreatedBy string
+	Workflow  string                   // Perfil de workflow (opcional, entity.DefaultWorkflow por defecto)
+	Subtasks  []CreateSubtaskItemInput // Subtareas (opcional)
+
+	// CompletionPolicy determina cómo las subtareas cierran la tarea (opcional, entity.DefaultCompletionPolicy por defecto)
+	CompletionPolicy string
+
+	// AutoStart hace que la primera subtarea que arranca pase la tarea a IN_PROGRESS (opcional)
+	AutoStart bool
+
+	// Progress es el progreso inicial de la tarea (opcional)
+	Progress entity.ProgressReport
+
+	// HeartbeatTimeout son los segundos sin latidos tras los que el reaper cierra la tarea (opcional, 0 no la supervisa)
+	HeartbeatTimeout int
+
+	// ExpectedDuration son los segundos en los que la tarea se compromete a terminar desde que arranca (opcional)
+	ExpectedDuration int
+
+	// Deadline es la fecha límite en la que la tarea se compromete a terminar (opcional)
+	Deadline *time.Time
+
+	// Labels son las etiquetas de la tarea (opcional)
+	Labels map[string]string
+
+	// Metadata es un objeto JSON libre asociado a la tarea (opcional)
+	Metadata map[string]interface{}
+
+	// Input son los parámetros de entrada con los que se lanza la tarea (opcional)
+	Input entity.Payload
+
+	// Automation es la clave del automatismo del que la tarea es una ejecución (opcional)
+	// Si no se indican subtareas, se crean las de la plantilla del automatismo
+	Automation string
 }
 
 // CreateTaskOutput representa el resultado de crear una tarea
@@ -22,17 +74,40 @@ type CreateTaskOutput struct {
 
 // CreateTaskUseCase maneja la creación de nuevas tareas
 type CreateTaskUseCase struct {
-	taskRepo repository.TaskRepository
+	taskRepo        repository.TaskRepository
+	eventRepo       repository.TaskEventRepository
+	automationRepo  repository.AutomationRepository
+	txManager       repository.TransactionManager
+	workflows       *service.WorkflowRegistry
+	maxPayloadBytes int
+	clock           entity.Clock
 }
 
 // NewCreateTaskUseCase crea una nueva instancia del caso de uso
-func NewCreateTaskUseCase(taskRepo repository.TaskRepository) *CreateTaskUseCase {
+// maxPayloadBytes es el tamaño máximo de los documentos input de la tarea y sus subtareas
+func NewCreateTaskUseCase(
+	taskRepo repository.TaskRepository,
+	eventRepo repository.TaskEventRepository,
+	automationRepo repository.AutomationRepository,
+	txManager repository.TransactionManager,
+	workflows *service.WorkflowRegistry,
+	maxPayloadBytes int,
+	clock entity.Clock,
+) *CreateTaskUseCase {
 	return &CreateTaskUseCase{
-		taskRepo: taskRepo,
+		taskRepo:        taskRepo,
+		eventRepo:       eventRepo,
+		automationRepo:  automationRepo,
+		txManager:       txManager,
+		workflows:       workflows,
+		maxPayloadBytes: maxPayloadBytes,
+		clock:           clock,
 	}
 }
 
 // Execute ejecuta el caso de uso de creación de tarea
+// Los estados iniciales se validan contra la máquina de estados del workflow partiendo de PENDING y la
+// tarea se persiste con sus subtareas ya en su estado final y su historial, en una única transacción
 func (uc *CreateTaskUseCase) Execute(ctx context.Context, input CreateTaskInput) (*CreateTaskOutput, error) {
 	// Validar input
 	if err := uc.validateInput(input); err != nil {
@@ -40,25 +115,126 @@ func (uc *CreateTaskUseCase) Execute(ctx context.Context, input CreateTaskInput)
 	}
 
 	// Crear tarea usando constructor del dominio
-	task, err := entity.NewTask(input.Name, input.CreatedBy)
+	task, err := entity.NewTask(input.Name, input.CreatedBy, uc.clock)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create task entity: %w", err)
 	}
 
-	// Crear subtareas si se proporcionaron nombres
-	if len(input.SubtaskNames) > 0 {
-		for _, subtaskName := range input.SubtaskNames {
-			subtask, err := entity.NewSubtask(subtaskName)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create subtask entity: %w", err)
+	// Resolver el workflow que regirá las transiciones de la tarea durante toda su vida
+	if input.Workflow != "" {
+		if err := entity.ValidateWorkflow(input.Workflow); err != nil {
+			return nil, err
+		}
+		task.Workflow = input.Workflow
+	}
+	stateMachine, err := uc.workflows.Get(task.Workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	task.CompletionPolicy, err = entity.ParseCompletionPolicy(input.CompletionPolicy)
+	if err != nil {
+		return nil, err
+	}
+	task.AutoStart = input.AutoStart
+
+	if err := task.ReportProgress(input.Progress); err != nil {
+		return nil, err
+	}
+	if err := task.SetHeartbeatTimeout(input.HeartbeatTimeout); err != nil {
+		return nil, err
+	}
+	if err := task.SetExpectedDuration(input.ExpectedDuration); err != nil {
+		return nil, err
+	}
+	if input.Deadline != nil {
+		if err := task.SetDeadline(*input.Deadline); err != nil {
+			return nil, err
+		}
+	}
+	if err := task.SetLabels(input.Labels); err != nil {
+		return nil, err
+	}
+	if err := task.SetMetadata(input.Metadata); err != nil {
+		return nil, err
+	}
+	if err := task.SetInput(input.Input, uc.maxPayloadBytes); err != nil {
+		return nil, err
+	}
+
+	// Las ejecuciones de un automatismo sin subtareas explícitas se crean con las de su plantilla
+	subtaskInputs := input.Subtasks
+	if input.Automation != "" {
+		automation, err := uc.automationRepo.FindByKey(ctx, input.Automation)
+		if err != nil {
+			if errors.Is(err, entity.ErrAutomationNotFound) {
+				return nil, fmt.Errorf("%w: automation %q does not exist", entity.ErrInvalidAutomation, input.Automation)
 			}
-			task.AddSubtask(subtask)
+			return nil, fmt.Errorf("failed to find automation: %w", err)
+		}
+		task.Automation = automation.Key
+		if len(subtaskInputs) == 0 {
+			subtaskInputs = subtaskTemplateInputs(automation.SubtaskTemplate)
 		}
 	}
 
-	// Persistir en repositorio
-	if err := uc.taskRepo.Create(ctx, task); err != nil {
-		return nil, fmt.Errorf("failed to persist task: %w", err)
+	// Crear subtareas si se proporcionaron, cada padre antes que sus hijos
+	subtasks, err := addSubtasks(task, nil, subtaskInputs, uc.maxPayloadBytes, uc.clock)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolver las dependencias una vez creadas todas las subtareas, que pueden referenciarse por nombre
+	for _, created := range subtasks {
+		if len(created.input.DependsOn) == 0 {
+			continue
+		}
+		if err := task.SetDependencies(created.subtask, created.input.DependsOn); err != nil {
+			return nil, err
+		}
+	}
+	if err := task.ValidateDependencies(); err != nil {
+		return nil, err
+	}
+
+	// Aplicar el estado inicial de la tarea antes que el de las subtareas,
+	// ya que las transiciones de las subtareas dependen del estado del padre
+	if input.State != nil && *input.State != entity.StatePending {
+		if err := stateMachine.ValidateTaskStateTransition(task, *input.State); err != nil {
+			return nil, err
+		}
+
+		if err := task.UpdateState(*input.State, input.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to set initial task state: %w", err)
+		}
+	}
+
+	// Aplicar los estados iniciales de las subtareas; los padres se procesan antes que sus hijos
+	for _, created := range subtasks {
+		state := created.input.State
+		if state == nil || *state == entity.StatePending {
+			continue
+		}
+
+		if err := uc.applyInitialSubtaskState(stateMachine, task, created.subtask, *state); err != nil {
+			return nil, err
+		}
+	}
+
+	// Persistir la tarea y su historial de creación
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.taskRepo.Create(ctx, task); err != nil {
+			return fmt.Errorf("failed to persist task: %w", err)
+		}
+
+		if err := uc.eventRepo.Create(ctx, task.CreationEvents(input.CreatedBy)...); err != nil {
+			return fmt.Errorf("failed to record task history: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &CreateTaskOutput{Task: task}, nil
@@ -74,3 +250,66 @@ func (uc *CreateTaskUseCase) validateInput(input CreateTaskInput) error {
 	}
 	return nil
 }
+
+// applyInitialSubtaskState valida y aplica el estado inicial de una subtarea
+func (uc *CreateTaskUseCase) applyInitialSubtaskState(
+	stateMachine *service.StateMachine,
+	task *entity.Task,
+	subtask *entity.Subtask,
+	state entity.State,
+) error {
+	if err := stateMachine.ValidateSubtaskStateTransition(task, subtask, state); err != nil {
+		return err
+	}
+
+	// Actualizar estado y fechas según el nuevo estado
+	subtask.UpdateState(state)
+	subtask.UpdatedAt = task.UpdatedAt
+
+	return nil
+}
+
+// subtaskTemplateInputs convierte la plantilla de un automatismo en las subtareas a crear, todas PENDING
+func subtaskTemplateInputs(template []entity.SubtaskTemplate) []CreateSubtaskItemInput {
+	inputs := make([]CreateSubtaskItemInput, 0, len(template))
+	for _, item := range template {
+		inputs = append(inputs, CreateSubtaskItemInput{
+			Name:      item.Name,
+			DependsOn: item.DependsOn,
+			Subtasks:  subtaskTemplateInputs(item.Subtasks),
+		})
+	}
+	return inputs
+}
+
+// addSubtasks crea las subtareas de inputs colgando de parent (de la tarea si es nil) junto con sus
+// subtareas anidadas, y las retorna en profundidad con cada padre antes que sus hijos
+func addSubtasks(task *entity.Task, parent *entity.Subtask, inputs []CreateSubtaskItemInput, maxPayloadBytes int, clock entity.Clock) ([]createdSubtask, error) {
+	created := make([]createdSubtask, 0, len(inputs))
+	for _, stInput := range inputs {
+		subtask, err := entity.NewSubtask(stInput.Name, clock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create subtask entity: %w", err)
+		}
+		if err := subtask.ReportProgress(stInput.Progress); err != nil {
+			return nil, err
+		}
+		if err := subtask.SetInput(stInput.Input, maxPayloadBytes); err != nil {
+			return nil, err
+		}
+
+		if parent == nil {
+			task.AddSubtask(subtask)
+		} else {
+			task.AddChildSubtask(parent, subtask)
+		}
+		created = append(created, createdSubtask{input: stInput, subtask: subtask})
+
+		children, err := addSubtasks(task, subtask, stInput.Subtasks, maxPayloadBytes, clock)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, children...)
+	}
+	return created, nil
+}
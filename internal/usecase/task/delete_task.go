@@ -23,13 +23,24 @@ type DeleteTaskOutput struct {
 
 // DeleteTaskUseCase maneja la eliminación (soft delete) de tareas
 type DeleteTaskUseCase struct {
-	taskRepo repository.TaskRepository
+	taskRepo  repository.TaskRepository
+	eventRepo repository.TaskEventRepository
+	txManager repository.TransactionManager
+	clock     entity.Clock
 }
 
 // NewDeleteTaskUseCase crea una nueva instancia del caso de uso
-func NewDeleteTaskUseCase(taskRepo repository.TaskRepository) *DeleteTaskUseCase {
+func NewDeleteTaskUseCase(
+	taskRepo repository.TaskRepository,
+	eventRepo repository.TaskEventRepository,
+	txManager repository.TransactionManager,
+	clock entity.Clock,
+) *DeleteTaskUseCase {
 	return &DeleteTaskUseCase{
-		taskRepo: taskRepo,
+		taskRepo:  taskRepo,
+		eventRepo: eventRepo,
+		txManager: txManager,
+		clock:     clock,
 	}
 }
 
@@ -40,9 +51,22 @@ func (uc *DeleteTaskUseCase) Execute(ctx context.Context, input DeleteTaskInput)
 		return nil, err
 	}
 
-	// Eliminar tarea (soft delete)
-	if err := uc.taskRepo.Delete(ctx, input.ID, input.DeletedBy); err != nil {
-		return nil, fmt.Errorf("failed to delete task: %w", err)
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Eliminar tarea y subtareas (soft delete): el repositorio propaga la eliminación a las subtareas
+		now := uc.clock.Now()
+		if err := uc.taskRepo.Delete(ctx, input.ID, input.DeletedBy, now); err != nil {
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+
+		event := entity.NewTaskEvent(input.ID, entity.EventTaskDeleted, input.DeletedBy, nil, nil, now)
+		if err := uc.eventRepo.Create(ctx, event); err != nil {
+			return fmt.Errorf("failed to record task history: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &DeleteTaskOutput{Success: true}, nil
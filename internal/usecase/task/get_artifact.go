@@ -0,0 +1,69 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// GetArtifactInput representa los datos de entrada para descargar un artefacto
+type GetArtifactInput struct {
+	TaskID     uuid.UUID
+	ArtifactID uuid.UUID
+}
+
+// GetArtifactOutput representa un artefacto con su contenido
+type GetArtifactOutput struct {
+	Artifact *entity.Artifact
+	Content  io.ReadCloser // Contenido del artefacto; quien llama debe cerrarlo
+}
+
+// GetArtifactUseCase maneja la descarga de los artefactos de una tarea
+type GetArtifactUseCase struct {
+	taskRepo     repository.TaskRepository
+	artifactRepo repository.ArtifactRepository
+	blobStore    repository.BlobStore
+}
+
+// NewGetArtifactUseCase crea una nueva instancia del caso de uso
+func NewGetArtifactUseCase(
+	taskRepo repository.TaskRepository,
+	artifactRepo repository.ArtifactRepository,
+	blobStore repository.BlobStore,
+) *GetArtifactUseCase {
+	return &GetArtifactUseCase{
+		taskRepo:     taskRepo,
+		artifactRepo: artifactRepo,
+		blobStore:    blobStore,
+	}
+}
+
+// Execute ejecuta el caso de uso de descarga de artefactos
+func (uc *GetArtifactUseCase) Execute(ctx context.Context, input GetArtifactInput) (*GetArtifactOutput, error) {
+	if input.TaskID == uuid.Nil || input.ArtifactID == uuid.Nil {
+		return nil, fmt.Errorf("%w: task id and artifact id are required", entity.ErrMissingRequiredFields)
+	}
+
+	// Verificar que la tarea existe y no está eliminada
+	if _, err := uc.taskRepo.FindByID(ctx, input.TaskID); err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	artifact, err := uc.artifactRepo.FindByID(ctx, input.TaskID, input.ArtifactID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	// Un artefacto registrado sin contenido es una inconsistencia del almacén, no un 404 del cliente
+	content, err := uc.blobStore.Get(ctx, artifact.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content of artifact %s: %w", artifact.ID, err)
+	}
+
+	return &GetArtifactOutput{Artifact: artifact, Content: content}, nil
+}
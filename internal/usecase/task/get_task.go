@@ -17,18 +17,23 @@ type GetTaskInput struct {
 
 // GetTaskOutput representa el resultado de obtener una tarea
 type GetTaskOutput struct {
-	Task *entity.Task
+	Task     *entity.Task
+	Attempts []*entity.TaskAttempt // Intentos cerrados de la tarea, ordenados por número
 }
 
 // GetTaskUseCase maneja la obtención de una tarea por ID
 type GetTaskUseCase struct {
-	taskRepo repository.TaskRepository
+	taskRepo    repository.TaskRepository
+	attemptRepo repository.TaskAttemptRepository
+	clock       entity.Clock
 }
 
 // NewGetTaskUseCase crea una nueva instancia del caso de uso
-func NewGetTaskUseCase(taskRepo repository.TaskRepository) *GetTaskUseCase {
+func NewGetTaskUseCase(taskRepo repository.TaskRepository, attemptRepo repository.TaskAttemptRepository, clock entity.Clock) *GetTaskUseCase {
 	return &GetTaskUseCase{
-		taskRepo: taskRepo,
+		taskRepo:    taskRepo,
+		attemptRepo: attemptRepo,
+		clock:       clock,
 	}
 }
 
@@ -44,6 +49,16 @@ func (uc *GetTaskUseCase) Execute(ctx context.Context, input GetTaskInput) (*Get
 	if err != nil {
 		return nil, fmt.Errorf("failed to find task: %w", err)
 	}
+	task.SetClock(uc.clock)
 
-	return &GetTaskOutput{Task: task}, nil
+	// Solo las tareas reintentadas tienen intentos cerrados
+	attempts := make([]*entity.TaskAttempt, 0)
+	if task.Attempt > entity.FirstAttempt {
+		attempts, err = uc.attemptRepo.FindByTaskID(ctx, input.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find task attempts: %w", err)
+		}
+	}
+
+	return &GetTaskOutput{Task: task, Attempts: attempts}, nil
 }
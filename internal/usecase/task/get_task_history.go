@@ -0,0 +1,93 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// GetTaskHistoryInput representa los datos de entrada para obtener el historial de una tarea
+type GetTaskHistoryInput struct {
+	TaskID uuid.UUID
+	Page   int // Número de página (1-indexed)
+	Limit  int // Cantidad de resultados por página
+}
+
+// GetTaskHistoryOutput representa el historial paginado de una tarea
+type GetTaskHistoryOutput struct {
+	Events     []*entity.TaskEvent
+	Total      int
+	Page       int
+	Limit      int
+	TotalPages int
+}
+
+// GetTaskHistoryUseCase maneja la consulta del historial de cambios de una tarea
+type GetTaskHistoryUseCase struct {
+	taskRepo  repository.TaskRepository
+	eventRepo repository.TaskEventRepository
+}
+
+// NewGetTaskHistoryUseCase crea una nueva instancia del caso de uso
+func NewGetTaskHistoryUseCase(taskRepo repository.TaskRepository, eventRepo repository.TaskEventRepository) *GetTaskHistoryUseCase {
+	return &GetTaskHistoryUseCase{
+		taskRepo:  taskRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso de consulta del historial
+func (uc *GetTaskHistoryUseCase) Execute(ctx context.Context, input GetTaskHistoryInput) (*GetTaskHistoryOutput, error) {
+	// Validar y normalizar input
+	if err := uc.validateInput(&input); err != nil {
+		return nil, err
+	}
+
+	// Verificar que la tarea existe y no está eliminada
+	if _, err := uc.taskRepo.FindByID(ctx, input.TaskID); err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	filters := repository.TaskEventFilters{
+		Page:   input.Page,
+		Limit:  input.Limit,
+		Offset: (input.Page - 1) * input.Limit,
+	}
+
+	result, err := uc.eventRepo.FindByTaskID(ctx, input.TaskID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task history: %w", err)
+	}
+
+	return &GetTaskHistoryOutput{
+		Events:     result.Events,
+		Total:      result.Total,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// validateInput valida y normaliza los datos de entrada
+func (uc *GetTaskHistoryUseCase) validateInput(input *GetTaskHistoryInput) error {
+	if input.TaskID == uuid.Nil {
+		return fmt.Errorf("%w: task id is required", entity.ErrMissingRequiredFields)
+	}
+
+	// Validar y normalizar paginación
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.Limit < 1 {
+		input.Limit = 50 // Default
+	}
+	if input.Limit > 100 {
+		input.Limit = 100 // Máximo permitido
+	}
+
+	return nil
+}
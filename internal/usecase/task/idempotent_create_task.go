@@ -0,0 +1,145 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// DefaultIdempotencyTTL es el tiempo durante el que una clave de idempotencia permanece vigente
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotentCreateTaskInput representa los datos de entrada para una creación idempotente
+type IdempotentCreateTaskInput struct {
+	Key         string // Valor de la cabecera Idempotency-Key
+	CreatedBy   string
+	RequestHash string // Huella de la petición, para detectar reutilización con otro cuerpo
+}
+
+// IdempotentResponse representa la respuesta de la creación que se guarda junto a la clave
+type IdempotentResponse struct {
+	TaskID     uuid.UUID
+	StatusCode int
+	Body       []byte
+}
+
+// IdempotentCreateTaskOutput representa el resultado de una creación idempotente
+type IdempotentCreateTaskOutput struct {
+	Response *IdempotentResponse
+	Replayed bool // true si la respuesta proviene de una petición anterior con la misma clave
+}
+
+// CreateTaskOperation ejecuta la creación completa de la tarea
+// Recibe el contexto transaccional, de modo que todo lo que persista se confirma junto a la clave
+type CreateTaskOperation func(ctx context.Context) (*IdempotentResponse, error)
+
+// IdempotentCreateTaskUseCase garantiza que una creación de tarea con la misma
+// Idempotency-Key se ejecuta una única vez por creador
+type IdempotentCreateTaskUseCase struct {
+	idempotencyRepo repository.IdempotencyRepository
+	txManager       repository.TransactionManager
+	ttl             time.Duration
+	clock           entity.Clock
+}
+
+// NewIdempotentCreateTaskUseCase crea una nueva instancia del caso de uso
+func NewIdempotentCreateTaskUseCase(
+	idempotencyRepo repository.IdempotencyRepository,
+	txManager repository.TransactionManager,
+	ttl time.Duration,
+	clock entity.Clock,
+) *IdempotentCreateTaskUseCase {
+	return &IdempotentCreateTaskUseCase{
+		idempotencyRepo: idempotencyRepo,
+		txManager:       txManager,
+		ttl:             ttl,
+		clock:           clock,
+	}
+}
+
+// Execute ejecuta create una sola vez por clave
+// Si la clave ya se usó con la misma petición, retorna la respuesta original sin ejecutar create
+// Si se usó con una petición diferente, retorna entity.ErrIdempotencyKeyReused
+func (uc *IdempotentCreateTaskUseCase) Execute(ctx context.Context, input IdempotentCreateTaskInput, create CreateTaskOperation) (*IdempotentCreateTaskOutput, error) {
+	// Validar input
+	if err := uc.validateInput(input); err != nil {
+		return nil, err
+	}
+
+	var output *IdempotentCreateTaskOutput
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Serializar los reintentos concurrentes con la misma clave
+		if err := uc.idempotencyRepo.Lock(ctx, input.CreatedBy, input.Key); err != nil {
+			return err
+		}
+
+		now := uc.clock.Now()
+		record, err := uc.idempotencyRepo.Find(ctx, input.CreatedBy, input.Key, now)
+		if err != nil {
+			return err
+		}
+
+		if record != nil {
+			if !record.MatchesRequest(input.RequestHash) {
+				return fmt.Errorf("%w: key %q", entity.ErrIdempotencyKeyReused, input.Key)
+			}
+
+			output = &IdempotentCreateTaskOutput{
+				Response: &IdempotentResponse{
+					TaskID:     record.TaskID,
+					StatusCode: record.StatusCode,
+					Body:       record.ResponseBody,
+				},
+				Replayed: true,
+			}
+			return nil
+		}
+
+		response, err := create(ctx)
+		if err != nil {
+			return err
+		}
+
+		record = &entity.IdempotencyRecord{
+			Key:          input.Key,
+			CreatedBy:    input.CreatedBy,
+			RequestHash:  input.RequestHash,
+			TaskID:       response.TaskID,
+			StatusCode:   response.StatusCode,
+			ResponseBody: response.Body,
+			CreatedAt:    now,
+			ExpiresAt:    now.Add(uc.ttl),
+		}
+
+		if err := uc.idempotencyRepo.Save(ctx, record); err != nil {
+			return fmt.Errorf("failed to persist idempotency key: %w", err)
+		}
+
+		output = &IdempotentCreateTaskOutput{Response: response}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// validateInput valida los datos de entrada
+func (uc *IdempotentCreateTaskUseCase) validateInput(input IdempotentCreateTaskInput) error {
+	if err := entity.ValidateIdempotencyKey(input.Key); err != nil {
+		return err
+	}
+	if input.CreatedBy == "" {
+		return fmt.Errorf("%w: created_by is required", entity.ErrMissingRequiredFields)
+	}
+	if input.RequestHash == "" {
+		return fmt.Errorf("%w: request hash is required", entity.ErrMissingRequiredFields)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// ListArtifactsInput representa los datos de entrada para listar los artefactos de una tarea
+type ListArtifactsInput struct {
+	TaskID uuid.UUID
+}
+
+// ListArtifactsOutput representa los artefactos de una tarea
+type ListArtifactsOutput struct {
+	Artifacts []*entity.Artifact // Por orden de subida
+}
+
+// ListArtifactsUseCase maneja la consulta de los metadatos de los artefactos de una tarea
+type ListArtifactsUseCase struct {
+	taskRepo     repository.TaskRepository
+	artifactRepo repository.ArtifactRepository
+}
+
+// NewListArtifactsUseCase crea una nueva instancia del caso de uso
+func NewListArtifactsUseCase(taskRepo repository.TaskRepository, artifactRepo repository.ArtifactRepository) *ListArtifactsUseCase {
+	return &ListArtifactsUseCase{
+		taskRepo:     taskRepo,
+		artifactRepo: artifactRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso de consulta de artefactos
+func (uc *ListArtifactsUseCase) Execute(ctx context.Context, input ListArtifactsInput) (*ListArtifactsOutput, error) {
+	if input.TaskID == uuid.Nil {
+		return nil, fmt.Errorf("%w: task id is required", entity.ErrMissingRequiredFields)
+	}
+
+	// Verificar que la tarea existe y no está eliminada
+	if _, err := uc.taskRepo.FindByID(ctx, input.TaskID); err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	artifacts, err := uc.artifactRepo.FindByTaskID(ctx, input.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return &ListArtifactsOutput{Artifacts: artifacts}, nil
+}
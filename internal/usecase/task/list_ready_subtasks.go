@@ -0,0 +1,46 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// ListReadySubtasksInput representa los datos de entrada para consultar las subtareas listas para arrancar
+type ListReadySubtasksInput struct {
+	TaskID uuid.UUID
+}
+
+// ListReadySubtasksOutput representa las subtareas de una tarea que pueden arrancar
+type ListReadySubtasksOutput struct {
+	Task     *entity.Task
+	Subtasks []*entity.Subtask // Subtareas PENDING con sus dependencias COMPLETED (ver entity.Task.ReadySubtasks)
+}
+
+// ListReadySubtasksUseCase maneja la consulta de las subtareas listas para arrancar
+type ListReadySubtasksUseCase struct {
+	taskRepo repository.TaskRepository
+}
+
+// NewListReadySubtasksUseCase crea una nueva instancia del caso de uso
+func NewListReadySubtasksUseCase(taskRepo repository.TaskRepository) *ListReadySubtasksUseCase {
+	return &ListReadySubtasksUseCase{taskRepo: taskRepo}
+}
+
+// Execute ejecuta el caso de uso de consulta de subtareas listas
+func (uc *ListReadySubtasksUseCase) Execute(ctx context.Context, input ListReadySubtasksInput) (*ListReadySubtasksOutput, error) {
+	if input.TaskID == uuid.Nil {
+		return nil, fmt.Errorf("%w: task_id is required", entity.ErrMissingRequiredFields)
+	}
+
+	task, err := uc.taskRepo.FindByID(ctx, input.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	return &ListReadySubtasksOutput{Task: task, Subtasks: task.ReadySubtasks()}, nil
+}
@@ -0,0 +1,59 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// ListTaskAttemptsInput representa los datos de entrada para consultar los intentos de una tarea
+type ListTaskAttemptsInput struct {
+	TaskID uuid.UUID
+}
+
+// ListTaskAttemptsOutput representa los intentos de una tarea
+type ListTaskAttemptsOutput struct {
+	Task     *entity.Task          // Intento en curso
+	Attempts []*entity.TaskAttempt // Intentos cerrados, ordenados por número
+}
+
+// ListTaskAttemptsUseCase maneja la consulta de los intentos de una tarea
+type ListTaskAttemptsUseCase struct {
+	taskRepo    repository.TaskRepository
+	attemptRepo repository.TaskAttemptRepository
+	clock       entity.Clock
+}
+
+// NewListTaskAttemptsUseCase crea una nueva instancia del caso de uso
+func NewListTaskAttemptsUseCase(taskRepo repository.TaskRepository, attemptRepo repository.TaskAttemptRepository, clock entity.Clock) *ListTaskAttemptsUseCase {
+	return &ListTaskAttemptsUseCase{
+		taskRepo:    taskRepo,
+		attemptRepo: attemptRepo,
+		clock:       clock,
+	}
+}
+
+// Execute ejecuta el caso de uso de consulta de intentos
+func (uc *ListTaskAttemptsUseCase) Execute(ctx context.Context, input ListTaskAttemptsInput) (*ListTaskAttemptsOutput, error) {
+	if input.TaskID == uuid.Nil {
+		return nil, fmt.Errorf("%w: task_id is required", entity.ErrMissingRequiredFields)
+	}
+
+	// Verificar que la tarea existe y no está eliminada
+	task, err := uc.taskRepo.FindByID(ctx, input.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+	task.SetClock(uc.clock)
+
+	attempts, err := uc.attemptRepo.FindByTaskID(ctx, input.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task attempts: %w", err)
+	}
+
+	return &ListTaskAttemptsOutput{Task: task, Attempts: attempts}, nil
+}
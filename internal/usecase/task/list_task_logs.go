@@ -0,0 +1,99 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// ListTaskLogsInput representa los datos de entrada para consultar los logs de una tarea
+type ListTaskLogsInput struct {
+	TaskID    uuid.UUID
+	MinLevel  *entity.LogLevel      // Nivel mínimo de las líneas (opcional)
+	SubtaskID *uuid.UUID            // Solo las líneas de esta subtarea (opcional)
+	Since     *time.Time            // Solo las líneas emitidas desde este instante (opcional)
+	Until     *time.Time            // Solo las líneas emitidas antes de este instante (opcional)
+	After     *entity.TaskLogCursor // Cursor de la página anterior (opcional, nil para la primera página)
+	Limit     int                   // Cantidad máxima de líneas por página
+}
+
+// ListTaskLogsOutput representa una página de logs de una tarea
+type ListTaskLogsOutput struct {
+	Lines      []*entity.TaskLogLine
+	NextCursor *entity.TaskLogCursor // Cursor de la página siguiente, nil si no hay más líneas
+	Limit      int
+}
+
+// ListTaskLogsUseCase maneja la consulta paginada por cursor de los logs de una tarea
+type ListTaskLogsUseCase struct {
+	taskRepo repository.TaskRepository
+	logRepo  repository.TaskLogRepository
+}
+
+// NewListTaskLogsUseCase crea una nueva instancia del caso de uso
+func NewListTaskLogsUseCase(taskRepo repository.TaskRepository, logRepo repository.TaskLogRepository) *ListTaskLogsUseCase {
+	return &ListTaskLogsUseCase{
+		taskRepo: taskRepo,
+		logRepo:  logRepo,
+	}
+}
+
+// Execute ejecuta el caso de uso de consulta de logs
+func (uc *ListTaskLogsUseCase) Execute(ctx context.Context, input ListTaskLogsInput) (*ListTaskLogsOutput, error) {
+	// Validar y normalizar input
+	if err := uc.validateInput(&input); err != nil {
+		return nil, err
+	}
+
+	// Verificar que la tarea existe y no está eliminada
+	if _, err := uc.taskRepo.FindByID(ctx, input.TaskID); err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	// Pedir una línea más de las solicitadas indica si existe una página siguiente
+	lines, err := uc.logRepo.FindByTaskID(ctx, input.TaskID, repository.TaskLogFilters{
+		MinLevel:  input.MinLevel,
+		SubtaskID: input.SubtaskID,
+		Since:     input.Since,
+		Until:     input.Until,
+		After:     input.After,
+		Limit:     input.Limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task logs: %w", err)
+	}
+
+	output := &ListTaskLogsOutput{Lines: lines, Limit: input.Limit}
+	if len(lines) > input.Limit {
+		output.Lines = lines[:input.Limit]
+		cursor := output.Lines[input.Limit-1].Cursor()
+		output.NextCursor = &cursor
+	}
+
+	return output, nil
+}
+
+// validateInput valida y normaliza los datos de entrada
+func (uc *ListTaskLogsUseCase) validateInput(input *ListTaskLogsInput) error {
+	if input.TaskID == uuid.Nil {
+		return fmt.Errorf("%w: task id is required", entity.ErrMissingRequiredFields)
+	}
+	if input.Since != nil && input.Until != nil && !input.Since.Before(*input.Until) {
+		return fmt.Errorf("%w: since must be before until", entity.ErrInvalidLogQuery)
+	}
+
+	// Validar y normalizar el tamaño de página
+	if input.Limit < 1 {
+		input.Limit = 100 // Default
+	}
+	if input.Limit > entity.MaxLogBatchSize {
+		input.Limit = entity.MaxLogBatchSize // Máximo permitido
+	}
+
+	return nil
+}
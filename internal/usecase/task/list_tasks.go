@@ -10,11 +10,17 @@ import (
 
 // ListTasksInput representa los datos de entrada para listar tareas
 type ListTasksInput struct {
-	State          *entity.State // Filtro opcional por estado
-	NameContains   *string       // Filtro opcional por nombre (búsqueda parcial)
-	Page           int           // Número de página (1-indexed)
-	Limit          int           // Cantidad de resultados por página
-	IncludeDeleted bool          // Incluir tareas eliminadas
+	State           *entity.State     // Filtro opcional por estado
+	NameContains    *string           // Filtro opcional por nombre (búsqueda parcial)
+	MinProgress     *int              // Filtro opcional por progreso efectivo mínimo (0-100)
+	SLAStatus       *entity.SLAStatus // Filtro opcional por estado del SLA en el momento de la consulta
+	Labels          map[string]string // Filtro opcional por etiquetas (ver entity.ParseLabelSelector)
+	ErrorCode       *string           // Filtro opcional por código de error de la tarea o de sus subtareas
+	Automation      *string           // Filtro opcional por clave del automatismo del que son ejecuciones
+	IncludePayloads bool              // Incluir los documentos input y output, que por defecto se omiten
+	Page            int               // Número de página (1-indexed)
+	Limit           int               // Cantidad de resultados por página
+	IncludeDeleted  bool              // Incluir tareas eliminadas
 }
 
 // ListTasksOutput representa el resultado de listar tareas
@@ -29,12 +35,14 @@ type ListTasksOutput struct {
 // ListTasksUseCase maneja el listado paginado de tareas con filtros
 type ListTasksUseCase struct {
 	taskRepo repository.TaskRepository
+	clock    entity.Clock
 }
 
 // NewListTasksUseCase crea una nueva instancia del caso de uso
-func NewListTasksUseCase(taskRepo repository.TaskRepository) *ListTasksUseCase {
+func NewListTasksUseCase(taskRepo repository.TaskRepository, clock entity.Clock) *ListTasksUseCase {
 	return &ListTasksUseCase{
 		taskRepo: taskRepo,
+		clock:    clock,
 	}
 }
 
@@ -47,12 +55,19 @@ func (uc *ListTasksUseCase) Execute(ctx context.Context, input ListTasksInput) (
 
 	// Construir filtros para el repositorio
 	filters := repository.TaskFilters{
-		State:          input.State,
-		Name:           input.NameContains,
-		Page:           input.Page,
-		Limit:          input.Limit,
-		Offset:         (input.Page - 1) * input.Limit,
-		IncludeDeleted: input.IncludeDeleted,
+		State:           input.State,
+		Name:            input.NameContains,
+		MinProgress:     input.MinProgress,
+		SLAStatus:       input.SLAStatus,
+		Labels:          input.Labels,
+		ErrorCode:       input.ErrorCode,
+		Automation:      input.Automation,
+		IncludePayloads: input.IncludePayloads,
+		Now:             uc.clock.Now(),
+		Page:            input.Page,
+		Limit:           input.Limit,
+		Offset:          (input.Page - 1) * input.Limit,
+		IncludeDeleted:  input.IncludeDeleted,
 	}
 
 	// Obtener tareas del repositorio
@@ -60,6 +75,9 @@ func (uc *ListTasksUseCase) Execute(ctx context.Context, input ListTasksInput) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
+	for _, task := range result.Tasks {
+		task.SetClock(uc.clock)
+	}
 
 	return &ListTasksOutput{
 		Tasks:      result.Tasks,
@@ -88,5 +106,10 @@ func (uc *ListTasksUseCase) validateInput(input *ListTasksInput) error {
 		return fmt.Errorf("%w: invalid state filter", entity.ErrInvalidStateTransition)
 	}
 
+	// Validar el umbral de progreso si se proporciona
+	if input.MinProgress != nil && (*input.MinProgress < 0 || *input.MinProgress > entity.MaxProgress) {
+		return fmt.Errorf("%w: min_progress must be between 0 and %d", entity.ErrInvalidProgress, entity.MaxProgress)
+	}
+
 	return nil
 }
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// DefaultArtifactPurgeBatchSize es el número máximo de contenidos que borra una pasada del purgador
+const DefaultArtifactPurgeBatchSize = 100
+
+// PurgeArtifactBlobsInput representa los datos de entrada de una pasada del purgador de artefactos
+type PurgeArtifactBlobsInput struct {
+	Limit int // Máximo de contenidos a borrar (DefaultArtifactPurgeBatchSize si es 0)
+}
+
+// PurgeArtifactBlobsOutput representa el resultado de una pasada del purgador de artefactos
+type PurgeArtifactBlobsOutput struct {
+	Purged []string         // Claves cuyo contenido se borró del BlobStore
+	Failed map[string]error // Claves cuyo contenido no pudo borrarse; se reintentan en la siguiente pasada
+}
+
+// PurgeArtifactBlobsUseCase borra del BlobStore el contenido de los artefactos de las tareas purgadas
+// Varias réplicas pueden ejecutarlo a la vez sin bloqueo: borrar un contenido ya borrado no es un error
+// y las claves, que incluyen el ID del artefacto, nunca se reutilizan
+type PurgeArtifactBlobsUseCase struct {
+	artifactRepo repository.ArtifactRepository
+	blobStore    repository.BlobStore
+}
+
+// NewPurgeArtifactBlobsUseCase crea una nueva instancia del caso de uso
+func NewPurgeArtifactBlobsUseCase(artifactRepo repository.ArtifactRepository, blobStore repository.BlobStore) *PurgeArtifactBlobsUseCase {
+	return &PurgeArtifactBlobsUseCase{
+		artifactRepo: artifactRepo,
+		blobStore:    blobStore,
+	}
+}
+
+// Execute ejecuta una pasada del purgador
+// Un contenido que no puede borrarse no detiene la pasada: su clave se conserva para la siguiente
+func (uc *PurgeArtifactBlobsUseCase) Execute(ctx context.Context, input PurgeArtifactBlobsInput) (*PurgeArtifactBlobsOutput, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultArtifactPurgeBatchSize
+	}
+
+	keys, err := uc.artifactRepo.FindOrphanedBlobKeys(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphaned artifact content: %w", err)
+	}
+
+	output := &PurgeArtifactBlobsOutput{Purged: make([]string, 0, len(keys)), Failed: make(map[string]error)}
+	for _, key := range keys {
+		if err := uc.blobStore.Delete(ctx, key); err != nil {
+			output.Failed[key] = err
+			continue
+		}
+		output.Purged = append(output.Purged, key)
+	}
+
+	if err := uc.artifactRepo.DeleteOrphanedBlobKeys(ctx, output.Purged); err != nil {
+		return nil, fmt.Errorf("failed to mark artifact content as purged: %w", err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,160 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+)
+
+// staleTaskReaperLock es el bloqueo que garantiza una sola pasada del reaper a la vez entre réplicas
+const staleTaskReaperLock = "stale-task-reaper"
+
+// HeartbeatTimeoutErrorCode es el código de error con el que el reaper cierra las tareas sin latidos
+const HeartbeatTimeoutErrorCode = "HEARTBEAT_TIMEOUT"
+
+// DefaultReapBatchSize es el número máximo de tareas que cierra una pasada del reaper
+const DefaultReapBatchSize = 100
+
+// ReapStaleTasksInput representa los datos de entrada de una pasada del reaper
+type ReapStaleTasksInput struct {
+	Now   time.Time // Instante con el que se evalúan los plazos sin latidos
+	Limit int       // Máximo de tareas a cerrar (DefaultReapBatchSize si es 0)
+}
+
+// SkippedStaleTask es una tarea sin señales de vida que el reaper no pudo cerrar
+type SkippedStaleTask struct {
+	TaskID uuid.UUID
+	Reason error
+}
+
+// ReapStaleTasksOutput representa el resultado de una pasada del reaper
+type ReapStaleTasksOutput struct {
+	Failed  []*entity.Task     // Tareas cerradas como FAILED
+	Skipped []SkippedStaleTask // Tareas cuyo workflow no permite pasar a FAILED
+	Locked  bool               // Otra réplica estaba ejecutando su pasada: no se revisó ninguna tarea
+}
+
+// ReapStaleTasksUseCase cierra como FAILED las tareas IN_PROGRESS cuyo proceso dejó de enviar latidos
+// Los cambios se atribuyen a entity.SystemActor y respetan la máquina de estados del workflow de cada tarea
+type ReapStaleTasksUseCase struct {
+	taskRepo    repository.TaskRepository
+	eventRepo   repository.TaskEventRepository
+	txManager   repository.TransactionManager
+	lockManager repository.LockManager
+	workflows   *service.WorkflowRegistry
+}
+
+// NewReapStaleTasksUseCase crea una nueva instancia del caso de uso
+func NewReapStaleTasksUseCase(
+	taskRepo repository.TaskRepository,
+	eventRepo repository.TaskEventRepository,
+	txManager repository.TransactionManager,
+	lockManager repository.LockManager,
+	workflows *service.WorkflowRegistry,
+) *ReapStaleTasksUseCase {
+	return &ReapStaleTasksUseCase{
+		taskRepo:    taskRepo,
+		eventRepo:   eventRepo,
+		txManager:   txManager,
+		lockManager: lockManager,
+		workflows:   workflows,
+	}
+}
+
+// Execute ejecuta una pasada del reaper
+// La pasada completa se confirma en una única transacción que mantiene el bloqueo del reaper,
+// de forma que varias réplicas pueden ejecutarlo a la vez sin cerrar dos veces la misma tarea
+func (uc *ReapStaleTasksUseCase) Execute(ctx context.Context, input ReapStaleTasksInput) (*ReapStaleTasksOutput, error) {
+	if input.Now.IsZero() {
+		return nil, fmt.Errorf("%w: now is required", entity.ErrMissingRequiredFields)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultReapBatchSize
+	}
+
+	output := &ReapStaleTasksOutput{
+		Failed:  make([]*entity.Task, 0),
+		Skipped: make([]SkippedStaleTask, 0),
+	}
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		acquired, err := uc.lockManager.TryLock(ctx, staleTaskReaperLock)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			output.Locked = true
+			return nil
+		}
+
+		ids, err := uc.taskRepo.FindStaleIDs(ctx, input.Now, limit)
+		if err != nil {
+			return fmt.Errorf("failed to find stale tasks: %w", err)
+		}
+
+		for _, id := range ids {
+			task, err := uc.taskRepo.FindByIDForUpdate(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to find stale task %s: %w", id, err)
+			}
+			// El cierre y su historial se fechan en el instante de la pasada
+			task.SetClock(entity.FixedClock(input.Now))
+			if !task.HeartbeatExpired(input.Now) {
+				continue
+			}
+
+			// Una tarea que su workflow no deja pasar a FAILED no debe bloquear al resto
+			if err := uc.validateFailure(task); err != nil {
+				output.Skipped = append(output.Skipped, SkippedStaleTask{TaskID: task.ID, Reason: err})
+				continue
+			}
+
+			if err := uc.fail(ctx, task); err != nil {
+				return err
+			}
+			output.Failed = append(output.Failed, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// validateFailure comprueba que el workflow de la tarea permite pasarla a FAILED
+func (uc *ReapStaleTasksUseCase) validateFailure(task *entity.Task) error {
+	stateMachine, err := uc.workflows.Get(task.Workflow)
+	if err != nil {
+		return err
+	}
+	return stateMachine.ValidateTaskStateTransition(task, entity.StateFailed)
+}
+
+// fail cierra la tarea como FAILED en nombre del sistema y registra el cambio en su historial
+func (uc *ReapStaleTasksUseCase) fail(ctx context.Context, task *entity.Task) error {
+	before := task.Snapshot()
+	failure := &entity.Failure{
+		Code:    HeartbeatTimeoutErrorCode,
+		Message: fmt.Sprintf("no heartbeat received within %s", task.HeartbeatTimeout),
+	}
+	if err := task.UpdateStateWithFailure(entity.StateFailed, entity.SystemActor, failure); err != nil {
+		return fmt.Errorf("failed to update task state: %w", err)
+	}
+
+	if err := uc.taskRepo.Update(ctx, task); err != nil {
+		return fmt.Errorf("failed to persist stale task %s: %w", task.ID, err)
+	}
+
+	if err := uc.eventRepo.Create(ctx, task.EventsSince(before, entity.SystemActor)...); err != nil {
+		return fmt.Errorf("failed to record task history: %w", err)
+	}
+	return nil
+}
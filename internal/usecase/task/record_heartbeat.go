@@ -0,0 +1,71 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// RecordHeartbeatInput representa los datos de entrada para registrar un latido de una tarea
+type RecordHeartbeatInput struct {
+	ID uuid.UUID
+}
+
+// RecordHeartbeatOutput representa el resultado de registrar un latido
+type RecordHeartbeatOutput struct {
+	Task *entity.Task
+}
+
+// RecordHeartbeatUseCase maneja los latidos con los que el proceso de una tarea indica que sigue vivo
+// Los latidos no modifican la versión de la tarea ni se registran en su historial
+type RecordHeartbeatUseCase struct {
+	taskRepo  repository.TaskRepository
+	txManager repository.TransactionManager
+	clock     entity.Clock
+}
+
+// NewRecordHeartbeatUseCase crea una nueva instancia del caso de uso
+func NewRecordHeartbeatUseCase(taskRepo repository.TaskRepository, txManager repository.TransactionManager, clock entity.Clock) *RecordHeartbeatUseCase {
+	return &RecordHeartbeatUseCase{
+		taskRepo:  taskRepo,
+		txManager: txManager,
+		clock:     clock,
+	}
+}
+
+// Execute ejecuta el caso de uso de registro de latido
+func (uc *RecordHeartbeatUseCase) Execute(ctx context.Context, input RecordHeartbeatInput) (*RecordHeartbeatOutput, error) {
+	if input.ID == uuid.Nil {
+		return nil, fmt.Errorf("%w: id is required", entity.ErrMissingRequiredFields)
+	}
+
+	var task *entity.Task
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Bloquear la fila para que el latido no compita con el reaper que cierra la tarea
+		var err error
+		task, err = uc.taskRepo.FindByIDForUpdate(ctx, input.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find task: %w", err)
+		}
+
+		task.SetClock(uc.clock)
+
+		if err := task.RecordHeartbeat(task.Now()); err != nil {
+			return err
+		}
+
+		if err := uc.taskRepo.RecordHeartbeat(ctx, task); err != nil {
+			return fmt.Errorf("failed to persist task heartbeat: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordHeartbeatOutput{Task: task}, nil
+}
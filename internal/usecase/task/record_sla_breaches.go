@@ -0,0 +1,109 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// slaBreachMonitorLock es el bloqueo que garantiza una sola pasada del monitor de SLA a la vez entre réplicas
+const slaBreachMonitorLock = "sla-breach-monitor"
+
+// DefaultSLABreachBatchSize es el número máximo de incumplimientos que registra una pasada del monitor
+const DefaultSLABreachBatchSize = 100
+
+// RecordSLABreachesInput representa los datos de entrada de una pasada del monitor de SLA
+type RecordSLABreachesInput struct {
+	Now   time.Time // Instante con el que se evalúan los plazos
+	Limit int       // Máximo de incumplimientos a registrar (DefaultSLABreachBatchSize si es 0)
+}
+
+// RecordSLABreachesOutput representa el resultado de una pasada del monitor de SLA
+type RecordSLABreachesOutput struct {
+	Breached []*entity.Task // Tareas cuyo incumplimiento se registró en esta pasada
+	Locked   bool           // Otra réplica estaba ejecutando su pasada: no se revisó ninguna tarea
+}
+
+// RecordSLABreachesUseCase registra en el historial las tareas cuyo plazo venció sin terminar
+// Cada incumplimiento se registra una sola vez por intento, a nombre de entity.SystemActor
+type RecordSLABreachesUseCase struct {
+	taskRepo    repository.TaskRepository
+	eventRepo   repository.TaskEventRepository
+	txManager   repository.TransactionManager
+	lockManager repository.LockManager
+}
+
+// NewRecordSLABreachesUseCase crea una nueva instancia del caso de uso
+func NewRecordSLABreachesUseCase(
+	taskRepo repository.TaskRepository,
+	eventRepo repository.TaskEventRepository,
+	txManager repository.TransactionManager,
+	lockManager repository.LockManager,
+) *RecordSLABreachesUseCase {
+	return &RecordSLABreachesUseCase{
+		taskRepo:    taskRepo,
+		eventRepo:   eventRepo,
+		txManager:   txManager,
+		lockManager: lockManager,
+	}
+}
+
+// Execute ejecuta una pasada del monitor de SLA
+// La pasada completa se confirma en una única transacción que mantiene el bloqueo del monitor,
+// de forma que varias réplicas pueden ejecutarlo a la vez sin registrar dos veces el mismo incumplimiento
+func (uc *RecordSLABreachesUseCase) Execute(ctx context.Context, input RecordSLABreachesInput) (*RecordSLABreachesOutput, error) {
+	if input.Now.IsZero() {
+		return nil, fmt.Errorf("%w: now is required", entity.ErrMissingRequiredFields)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultSLABreachBatchSize
+	}
+
+	output := &RecordSLABreachesOutput{Breached: make([]*entity.Task, 0)}
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		acquired, err := uc.lockManager.TryLock(ctx, slaBreachMonitorLock)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			output.Locked = true
+			return nil
+		}
+
+		ids, err := uc.taskRepo.FindSLABreachIDs(ctx, input.Now, limit)
+		if err != nil {
+			return fmt.Errorf("failed to find SLA breaches: %w", err)
+		}
+
+		for _, id := range ids {
+			task, err := uc.taskRepo.FindByIDForUpdate(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to find task %s: %w", id, err)
+			}
+			task.SetClock(entity.FixedClock(input.Now))
+
+			before := task.Snapshot()
+			if !task.DetectSLABreach(input.Now) {
+				continue
+			}
+
+			if err := uc.taskRepo.RecordSLABreach(ctx, task); err != nil {
+				return fmt.Errorf("failed to persist SLA breach of task %s: %w", task.ID, err)
+			}
+			if err := uc.eventRepo.Create(ctx, task.EventsSince(before, entity.SystemActor)...); err != nil {
+				return fmt.Errorf("failed to record task history: %w", err)
+			}
+			output.Breached = append(output.Breached, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
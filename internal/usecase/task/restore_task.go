@@ -0,0 +1,94 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// RestoreTaskInput representa los datos de entrada para restaurar una tarea eliminada
+type RestoreTaskInput struct {
+	ID         uuid.UUID
+	RestoredBy string
+}
+
+// RestoreTaskOutput representa el resultado de restaurar una tarea
+type RestoreTaskOutput struct {
+	Task *entity.Task
+}
+
+// RestoreTaskUseCase maneja la restauración de tareas eliminadas (soft delete)
+// dentro de la ventana de retención de 30 días
+type RestoreTaskUseCase struct {
+	taskRepo  repository.TaskRepository
+	eventRepo repository.TaskEventRepository
+	txManager repository.TransactionManager
+	clock     entity.Clock
+}
+
+// NewRestoreTaskUseCase crea una nueva instancia del caso de uso
+func NewRestoreTaskUseCase(
+	taskRepo repository.TaskRepository,
+	eventRepo repository.TaskEventRepository,
+	txManager repository.TransactionManager,
+	clock entity.Clock,
+) *RestoreTaskUseCase {
+	return &RestoreTaskUseCase{
+		taskRepo:  taskRepo,
+		eventRepo: eventRepo,
+		txManager: txManager,
+		clock:     clock,
+	}
+}
+
+// Execute ejecuta el caso de uso de restauración de tarea
+func (uc *RestoreTaskUseCase) Execute(ctx context.Context, input RestoreTaskInput) (*RestoreTaskOutput, error) {
+	// Validar input
+	if err := uc.validateInput(input); err != nil {
+		return nil, err
+	}
+
+	var task *entity.Task
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Restaurar tarea y subtareas
+		now := uc.clock.Now()
+		if err := uc.taskRepo.Restore(ctx, input.ID, input.RestoredBy, now); err != nil {
+			return fmt.Errorf("failed to restore task: %w", err)
+		}
+
+		event := entity.NewTaskEvent(input.ID, entity.EventTaskRestored, input.RestoredBy, nil, nil, now)
+		if err := uc.eventRepo.Create(ctx, event); err != nil {
+			return fmt.Errorf("failed to record task history: %w", err)
+		}
+
+		// Recargar la tarea restaurada con sus subtareas
+		var err error
+		task, err = uc.taskRepo.FindByID(ctx, input.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find restored task: %w", err)
+		}
+		task.SetClock(uc.clock)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RestoreTaskOutput{Task: task}, nil
+}
+
+// validateInput valida los datos de entrada
+func (uc *RestoreTaskUseCase) validateInput(input RestoreTaskInput) error {
+	if input.ID == uuid.Nil {
+		return fmt.Errorf("%w: id is required", entity.ErrMissingRequiredFields)
+	}
+	if input.RestoredBy == "" {
+		return fmt.Errorf("%w: restored_by is required", entity.ErrMissingRequiredFields)
+	}
+	return nil
+}
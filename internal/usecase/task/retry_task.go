@@ -0,0 +1,116 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// RetryTaskInput representa los datos de entrada para reintentar una tarea fallida
+type RetryTaskInput struct {
+	ID        uuid.UUID
+	RetriedBy string
+
+	// ExpectedVersion es la versión que el cliente espera reintentar (If-Match)
+	// Si es nil no se valida y solo aplica el control optimista del repositorio
+	ExpectedVersion *int
+}
+
+// RetryTaskOutput representa el resultado de reintentar una tarea
+type RetryTaskOutput struct {
+	Task    *entity.Task        // Tarea con el nuevo intento en curso
+	Attempt *entity.TaskAttempt // Intento cerrado por el reintento
+}
+
+// RetryTaskUseCase maneja el reintento de tareas FAILED
+// Cierra el intento actual, lo conserva en el historial de intentos y abre uno nuevo
+type RetryTaskUseCase struct {
+	taskRepo    repository.TaskRepository
+	attemptRepo repository.TaskAttemptRepository
+	eventRepo   repository.TaskEventRepository
+	txManager   repository.TransactionManager
+	clock       entity.Clock
+}
+
+// NewRetryTaskUseCase crea una nueva instancia del caso de uso
+func NewRetryTaskUseCase(
+	taskRepo repository.TaskRepository,
+	attemptRepo repository.TaskAttemptRepository,
+	eventRepo repository.TaskEventRepository,
+	txManager repository.TransactionManager,
+	clock entity.Clock,
+) *RetryTaskUseCase {
+	return &RetryTaskUseCase{
+		taskRepo:    taskRepo,
+		attemptRepo: attemptRepo,
+		eventRepo:   eventRepo,
+		txManager:   txManager,
+		clock:       clock,
+	}
+}
+
+// Execute ejecuta el caso de uso de reintento de tarea
+// El intento cerrado, la tarea reiniciada y su historial se confirman en una única transacción
+func (uc *RetryTaskUseCase) Execute(ctx context.Context, input RetryTaskInput) (*RetryTaskOutput, error) {
+	// Validar input
+	if err := uc.validateInput(input); err != nil {
+		return nil, err
+	}
+
+	var output *RetryTaskOutput
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Buscar tarea bloqueando su fila hasta el commit
+		task, err := uc.taskRepo.FindByIDForUpdate(ctx, input.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find task: %w", err)
+		}
+		task.SetClock(uc.clock)
+
+		if err := entity.ValidateVersion(input.ExpectedVersion, task.Version); err != nil {
+			return err
+		}
+
+		before := task.Snapshot()
+
+		attempt, err := task.Retry(input.RetriedBy)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.attemptRepo.Create(ctx, attempt); err != nil {
+			return fmt.Errorf("failed to record task attempt: %w", err)
+		}
+
+		if err := uc.taskRepo.Update(ctx, task); err != nil {
+			return fmt.Errorf("failed to persist task retry: %w", err)
+		}
+
+		events := append([]*entity.TaskEvent{task.RetryEvent(input.RetriedBy)}, task.EventsSince(before, input.RetriedBy)...)
+		if err := uc.eventRepo.Create(ctx, events...); err != nil {
+			return fmt.Errorf("failed to record task history: %w", err)
+		}
+
+		output = &RetryTaskOutput{Task: task, Attempt: attempt}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// validateInput valida los datos de entrada
+func (uc *RetryTaskUseCase) validateInput(input RetryTaskInput) error {
+	if input.ID == uuid.Nil {
+		return fmt.Errorf("%w: id is required", entity.ErrMissingRequiredFields)
+	}
+	if input.RetriedBy == "" {
+		return fmt.Errorf("%w: retried_by is required", entity.ErrMissingRequiredFields)
+	}
+	return nil
+}
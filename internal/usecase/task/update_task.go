@@ -3,6 +3,7 @@ package task
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -16,15 +17,61 @@ type UpdateSubtaskItemInput struct {
 	ID    *uuid.UUID    // Si tiene ID, es una subtarea existente a actualizar
 	Name  *string       // Si no tiene ID pero tiene Name, es una nueva subtarea a crear
 	State *entity.State // Nuevo estado (opcional)
+
+	// Failure es el motivo del fallo; solo se admite junto con State FAILED (opcional)
+	Failure *entity.Failure
+
+	// Input son los parámetros de entrada; solo se admite al crear la subtarea (opcional)
+	Input entity.Payload
+
+	// Output son los resultados de la subtarea; solo se admite junto con un State final (opcional)
+	Output entity.Payload
+
+	// DependsOn reemplaza las dependencias de la subtarea por estos IDs o nombres (opcional, nil no las modifica)
+	DependsOn *[]string
+
+	// Subtasks son las subtareas anidadas bajo esta, con la misma semántica que la lista de la tarea
+	Subtasks []UpdateSubtaskItemInput
+
+	// Progress actualiza el progreso reportado; los campos nil no se modifican
+	Progress entity.ProgressReport
+}
+
+// updatedSubtask asocia cada subtarea del request con el input que la describe
+type updatedSubtask struct {
+	input   UpdateSubtaskItemInput
+	subtask *entity.Subtask
 }
 
 // UpdateTaskInput representa los datos de entrada para actualizar una tarea
 type UpdateTaskInput struct {
 	ID        uuid.UUID
-	Name      *string       // Opcional: nuevo nombre
-	State     *entity.State // Opcional: nuevo estado
+	Name      *string         // Opcional: nuevo nombre
+	State     *entity.State   // Opcional: nuevo estado
+	Failure   *entity.Failure // Opcional: motivo del fallo, solo junto con State FAILED
+	Output    entity.Payload  // Opcional: resultados de la tarea, solo junto con un State final
 	UpdatedBy string
 	Subtasks  []UpdateSubtaskItemInput // Opcional: lista de subtareas a actualizar/añadir/eliminar
+	Progress  entity.ProgressReport    // Opcional: progreso reportado (los campos nil no se modifican)
+
+	// HeartbeatTimeout reemplaza los segundos sin latidos de la tarea (opcional, 0 desactiva la supervisión)
+	HeartbeatTimeout *int
+
+	// ExpectedDuration reemplaza los segundos comprometidos desde el arranque (opcional, 0 elimina el compromiso)
+	ExpectedDuration *int
+
+	// Deadline reemplaza la fecha límite comprometida (opcional)
+	Deadline *time.Time
+
+	// Labels reemplaza todas las etiquetas de la tarea (opcional, un mapa vacío las elimina)
+	Labels *map[string]string
+
+	// Metadata reemplaza los metadatos de la tarea (opcional, un objeto vacío los elimina)
+	Metadata *map[string]interface{}
+
+	// ExpectedVersion es la versión que el cliente espera modificar (If-Match)
+	// Si es nil no se valida y solo aplica el control optimista del repositorio
+	ExpectedVersion *int
 }
 
 // UpdateTaskOutput representa el resultado de actualizar una tarea
@@ -34,36 +81,80 @@ type UpdateTaskOutput struct {
 
 // UpdateTaskUseCase maneja la actualización de tareas existentes
 type UpdateTaskUseCase struct {
-	taskRepo     repository.TaskRepository
-	subtaskRepo  repository.SubtaskRepository
-	stateMachine *service.StateMachine
+	taskRepo        repository.TaskRepository
+	subtaskRepo     repository.SubtaskRepository
+	eventRepo       repository.TaskEventRepository
+	txManager       repository.TransactionManager
+	workflows       *service.WorkflowRegistry
+	maxPayloadBytes int
+	clock           entity.Clock
 }
 
 // NewUpdateTaskUseCase crea una nueva instancia del caso de uso
+// maxPayloadBytes es el tamaño máximo de los documentos input y output de la tarea y sus subtareas
 func NewUpdateTaskUseCase(
 	taskRepo repository.TaskRepository,
 	subtaskRepo repository.SubtaskRepository,
-	stateMachine *service.StateMachine,
+	eventRepo repository.TaskEventRepository,
+	txManager repository.TransactionManager,
+	workflows *service.WorkflowRegistry,
+	maxPayloadBytes int,
+	clock entity.Clock,
 ) *UpdateTaskUseCase {
 	return &UpdateTaskUseCase{
-		taskRepo:     taskRepo,
-		subtaskRepo:  subtaskRepo,
-		stateMachine: stateMachine,
+		taskRepo:        taskRepo,
+		subtaskRepo:     subtaskRepo,
+		eventRepo:       eventRepo,
+		txManager:       txManager,
+		workflows:       workflows,
+		maxPayloadBytes: maxPayloadBytes,
+		clock:           clock,
 	}
 }
 
 // Execute ejecuta el caso de uso de actualización de tarea
+// Los cambios y su historial se confirman en una única transacción
 func (uc *UpdateTaskUseCase) Execute(ctx context.Context, input UpdateTaskInput) (*UpdateTaskOutput, error) {
 	// Validar input
 	if err := uc.validateInput(input); err != nil {
 		return nil, err
 	}
 
-	// Buscar tarea existente
-	task, err := uc.taskRepo.FindByID(ctx, input.ID)
+	var task *entity.Task
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		task, err = uc.execute(ctx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateTaskOutput{Task: task}, nil
+}
+
+// execute aplica la actualización dentro de la transacción abierta por Execute
+func (uc *UpdateTaskUseCase) execute(ctx context.Context, input UpdateTaskInput) (*entity.Task, error) {
+	// Buscar tarea existente bloqueando su fila hasta el commit
+	task, err := uc.taskRepo.FindByIDForUpdate(ctx, input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find task: %w", err)
 	}
+	task.SetClock(uc.clock)
+
+	// Las transiciones se validan con la máquina de estados del workflow de la tarea
+	stateMachine, err := uc.workflows.Get(task.Workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	// Capturar los valores actuales para registrar el historial
+	before := task.Snapshot()
+
+	// Verificar la precondición de versión antes de aplicar cambios
+	if err := entity.ValidateVersion(input.ExpectedVersion, task.Version); err != nil {
+		return nil, err
+	}
 
 	// Actualizar nombre si se proporciona
 	if input.Name != nil {
@@ -74,32 +165,90 @@ func (uc *UpdateTaskUseCase) Execute(ctx context.Context, input UpdateTaskInput)
 		task.UpdatedBy = input.UpdatedBy
 	}
 
+	// Actualizar el progreso reportado si se proporciona
+	if !input.Progress.IsEmpty() {
+		if err := task.ReportProgress(input.Progress); err != nil {
+			return nil, err
+		}
+		task.UpdatedBy = input.UpdatedBy
+	}
+
+	// Actualizar el plazo sin latidos si se proporciona
+	if input.HeartbeatTimeout != nil {
+		if err := task.SetHeartbeatTimeout(*input.HeartbeatTimeout); err != nil {
+			return nil, err
+		}
+		task.UpdatedBy = input.UpdatedBy
+	}
+
+	// Actualizar el SLA si se proporciona
+	if input.ExpectedDuration != nil {
+		if err := task.SetExpectedDuration(*input.ExpectedDuration); err != nil {
+			return nil, err
+		}
+		task.UpdatedBy = input.UpdatedBy
+	}
+	if input.Deadline != nil {
+		if err := task.SetDeadline(*input.Deadline); err != nil {
+			return nil, err
+		}
+		task.UpdatedBy = input.UpdatedBy
+	}
+
+	// Reemplazar etiquetas y metadatos si se proporcionan
+	if input.Labels != nil {
+		if err := task.SetLabels(*input.Labels); err != nil {
+			return nil, err
+		}
+		task.UpdatedBy = input.UpdatedBy
+	}
+	if input.Metadata != nil {
+		if err := task.SetMetadata(*input.Metadata); err != nil {
+			return nil, err
+		}
+		task.UpdatedBy = input.UpdatedBy
+	}
+
 	// Actualizar estado si se proporciona
 	if input.State != nil {
 		// Validar transición de estado
-		if err := uc.stateMachine.ValidateTaskStateTransition(task, *input.State); err != nil {
+		if err := stateMachine.ValidateTaskStateTransition(task, *input.State); err != nil {
 			return nil, err
 		}
 
-		// Actualizar estado usando el método del dominio que gestiona fechas
-		if err := task.UpdateState(*input.State, input.UpdatedBy); err != nil {
+		// Actualizar estado usando el método del dominio que gestiona fechas y el motivo del fallo
+		if err := task.UpdateStateWithFailure(*input.State, input.UpdatedBy, input.Failure); err != nil {
 			return nil, fmt.Errorf("failed to update task state: %w", err)
 		}
+
+		// Registrar los resultados una vez la tarea está en el estado final
+		if err := task.SetOutput(input.Output, uc.maxPayloadBytes); err != nil {
+			return nil, err
+		}
 	}
 
 	// Manejar subtareas si se proporcionan
 	if len(input.Subtasks) > 0 {
-		if err := uc.handleSubtasks(ctx, task, input.Subtasks); err != nil {
+		if err := uc.handleSubtasks(ctx, stateMachine, task, input.Subtasks); err != nil {
 			return nil, fmt.Errorf("failed to handle subtasks: %w", err)
 		}
 	}
 
-	// Persistir cambios
+	// Una tarea que termina fuera de plazo registra el incumplimiento junto con el cambio, sin esperar al monitor
+	task.DetectSLABreach(task.Now())
+
+	// Persistir cambios: cualquier actualización de la tarea avanza su fecha de modificación
+	task.UpdatedAt = task.Now()
 	if err := uc.taskRepo.Update(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to persist task updates: %w", err)
 	}
 
-	return &UpdateTaskOutput{Task: task}, nil
+	// Registrar el historial de los cambios aplicados
+	if err := uc.eventRepo.Create(ctx, task.EventsSince(before, input.UpdatedBy)...); err != nil {
+		return nil, fmt.Errorf("failed to record task history: %w", err)
+	}
+
+	return task, nil
 }
 
 // validateInput valida los datos de entrada
@@ -111,15 +260,48 @@ func (uc *UpdateTaskUseCase) validateInput(input UpdateTaskInput) error {
 		return fmt.Errorf("%w: updated_by is required", entity.ErrMissingRequiredFields)
 	}
 	// Al menos uno de los campos debe estar presente
-	if input.Name == nil && input.State == nil && len(input.Subtasks) == 0 {
-		return fmt.Errorf("%w: at least one field (name, state, or subtasks) must be provided", entity.ErrMissingRequiredFields)
+	if input.Name == nil && input.State == nil && len(input.Subtasks) == 0 && input.Progress.IsEmpty() &&
+		input.HeartbeatTimeout == nil && input.ExpectedDuration == nil && input.Deadline == nil &&
+		input.Labels == nil && input.Metadata == nil {
+		return fmt.Errorf("%w: at least one field (name, state, subtasks, progress, heartbeat_timeout, "+
+			"expected_duration, deadline, labels, or metadata) must be provided", entity.ErrMissingRequiredFields)
+	}
+	if input.Failure != nil && input.State == nil {
+		return fmt.Errorf("%w: failure requires state %s", entity.ErrInvalidFailure, entity.StateFailed)
+	}
+	if input.Output != nil && input.State == nil {
+		return fmt.Errorf("%w: output requires a final state", entity.ErrInvalidPayload)
+	}
+	return validateSubtaskItems(input.Subtasks)
+}
+
+// validateSubtaskItems comprueba que las subtareas del request solo informen el fallo y los resultados
+// junto con un estado, y los parámetros de entrada solo al crearse
+func validateSubtaskItems(subtaskInputs []UpdateSubtaskItemInput) error {
+	for _, stInput := range subtaskInputs {
+		if stInput.Failure != nil && stInput.State == nil {
+			return fmt.Errorf("%w: subtask failure requires state %s", entity.ErrInvalidFailure, entity.StateFailed)
+		}
+		if stInput.Output != nil && stInput.State == nil {
+			return fmt.Errorf("%w: subtask output requires a final state", entity.ErrInvalidPayload)
+		}
+		if stInput.Input != nil && stInput.ID != nil {
+			return fmt.Errorf("%w: input can only be provided when creating subtask, not for %s",
+				entity.ErrInvalidPayload, *stInput.ID)
+		}
+		if err := validateSubtaskItems(stInput.Subtasks); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // handleSubtasks procesa las subtareas del request de actualización
+// La jerarquía queda como la describe el request: cada subtarea cuelga de aquella en la que está anidada.
+// Los estados se aplican al final, una vez resueltas las dependencias de todas las subtareas
 func (uc *UpdateTaskUseCase) handleSubtasks(
 	ctx context.Context,
+	stateMachine *service.StateMachine,
 	task *entity.Task,
 	subtaskInputs []UpdateSubtaskItemInput,
 ) error {
@@ -131,48 +313,102 @@ func (uc *UpdateTaskUseCase) handleSubtasks(
 		}
 	}
 
-	// Procesar cada subtarea del input
+	// Procesar cada subtarea del input, cada padre antes que sus hijos
 	processedIDs := make(map[uuid.UUID]bool)
+	subtasks, err := uc.upsertSubtasks(ctx, task, nil, subtaskInputs, existingSubtasksMap, processedIDs)
+	if err != nil {
+		return err
+	}
+
+	// Eliminar subtareas que no están en la lista (soft delete)
+	for _, existingSubtask := range task.Subtasks {
+		if !existingSubtask.IsDeleted() && !processedIDs[existingSubtask.ID] {
+			// Marcar como eliminada
+			existingSubtask.Delete()
+		}
+	}
+
+	// Resolver las dependencias contra la lista final de subtareas
+	for _, updated := range subtasks {
+		if updated.input.DependsOn == nil {
+			continue
+		}
+		if err := task.SetDependencies(updated.subtask, *updated.input.DependsOn); err != nil {
+			return err
+		}
+	}
+	if err := task.ValidateDependencies(); err != nil {
+		return err
+	}
+
+	// Aplicar los estados solicitados
+	for _, updated := range subtasks {
+		if updated.input.State == nil {
+			continue
+		}
+
+		// Validar transición de estado considerando la tarea padre, la subtarea padre y las dependencias
+		if err := stateMachine.ValidateSubtaskStateTransition(task, updated.subtask, *updated.input.State); err != nil {
+			return err
+		}
+
+		// Actualizar estado, fechas y motivo del fallo
+		if err := updated.subtask.UpdateStateWithFailure(*updated.input.State, updated.input.Failure); err != nil {
+			return err
+		}
+		if err := updated.subtask.SetOutput(updated.input.Output, uc.maxPayloadBytes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertSubtasks actualiza o crea las subtareas de inputs colgando de parent (de la tarea si es nil),
+// junto con sus subtareas anidadas, y las retorna con cada padre antes que sus hijos
+func (uc *UpdateTaskUseCase) upsertSubtasks(
+	ctx context.Context,
+	task *entity.Task,
+	parent *entity.Subtask,
+	subtaskInputs []UpdateSubtaskItemInput,
+	existingSubtasksMap map[uuid.UUID]*entity.Subtask,
+	processedIDs map[uuid.UUID]bool,
+) ([]updatedSubtask, error) {
+	result := make([]updatedSubtask, 0, len(subtaskInputs))
 	for _, stInput := range subtaskInputs {
+		var subtask *entity.Subtask
+
 		if stInput.ID != nil {
 			// Actualizar subtarea existente
-			subtask, exists := existingSubtasksMap[*stInput.ID]
+			if processedIDs[*stInput.ID] {
+				return nil, fmt.Errorf("%w: subtask %s appears more than once", entity.ErrInvalidSubtaskParent, *stInput.ID)
+			}
+
+			existing, exists := existingSubtasksMap[*stInput.ID]
 			if !exists {
 				// Intentar cargar desde el repositorio
 				loadedSubtask, err := uc.subtaskRepo.FindByID(ctx, *stInput.ID)
 				if err != nil {
-					return fmt.Errorf("subtask with ID %s not found: %w", *stInput.ID, err)
+					return nil, fmt.Errorf("subtask with ID %s not found: %w", *stInput.ID, err)
 				}
-				subtask = loadedSubtask
+				loadedSubtask.SetClock(uc.clock)
+				existing = loadedSubtask
 			}
+			subtask = existing
 
 			// Actualizar nombre si se proporciona
 			if stInput.Name != nil {
 				if err := entity.ValidateName(*stInput.Name); err != nil {
-					return err
+					return nil, err
 				}
 				subtask.Name = *stInput.Name
 			}
 
-			// Actualizar estado si se proporciona
-			if stInput.State != nil {
-				// Validar transición de estado
-				if err := uc.stateMachine.ValidateSubtaskStateTransition(task, subtask, *stInput.State); err != nil {
-					return err
-				}
-
-				// Actualizar estado y fechas
-				if *stInput.State == entity.StateInProgress {
-					subtask.SetStartDate()
-				}
-				if stInput.State.IsFinal() {
-					subtask.SetEndDate()
-				}
-				subtask.State = *stInput.State
+			if err := subtask.ReportProgress(stInput.Progress); err != nil {
+				return nil, err
 			}
 
 			subtask.UpdatedAt = task.UpdatedAt
-			processedIDs[subtask.ID] = true
 
 			// Actualizar en la lista de subtareas de la tarea
 			found := false
@@ -186,39 +422,43 @@ func (uc *UpdateTaskUseCase) handleSubtasks(
 			if !found {
 				task.Subtasks = append(task.Subtasks, subtask)
 			}
+
+			// Colgar la subtarea donde esté anidada en el request
+			if err := task.SetParentSubtask(subtask, parent); err != nil {
+				return nil, err
+			}
 		} else if stInput.Name != nil {
 			// Crear nueva subtarea
-			newSubtask, err := entity.NewSubtask(*stInput.Name)
+			newSubtask, err := entity.NewSubtask(*stInput.Name, uc.clock)
 			if err != nil {
-				return fmt.Errorf("failed to create subtask: %w", err)
+				return nil, fmt.Errorf("failed to create subtask: %w", err)
 			}
-
-			// Establecer estado si se proporciona
-			if stInput.State != nil {
-				// Validar transición de estado (desde PENDING)
-				if err := uc.stateMachine.ValidateSubtaskStateTransition(task, newSubtask, *stInput.State); err != nil {
-					return err
-				}
-				newSubtask.State = *stInput.State
-				if *stInput.State == entity.StateInProgress {
-					newSubtask.SetStartDate()
-				}
-				if stInput.State.IsFinal() {
-					newSubtask.SetEndDate()
-				}
+			if err := newSubtask.ReportProgress(stInput.Progress); err != nil {
+				return nil, err
+			}
+			if err := newSubtask.SetInput(stInput.Input, uc.maxPayloadBytes); err != nil {
+				return nil, err
 			}
 
-			task.AddSubtask(newSubtask)
+			if parent == nil {
+				task.AddSubtask(newSubtask)
+			} else {
+				task.AddChildSubtask(parent, newSubtask)
+			}
+			subtask = newSubtask
+		} else {
+			continue
 		}
-	}
 
-	// Eliminar subtareas que no están en la lista (soft delete)
-	for _, existingSubtask := range task.Subtasks {
-		if !existingSubtask.IsDeleted() && !processedIDs[existingSubtask.ID] {
-			// Marcar como eliminada
-			existingSubtask.Delete()
+		processedIDs[subtask.ID] = true
+		result = append(result, updatedSubtask{input: stInput, subtask: subtask})
+
+		children, err := uc.upsertSubtasks(ctx, task, subtask, stInput.Subtasks, existingSubtasksMap, processedIDs)
+		if err != nil {
+			return nil, err
 		}
+		result = append(result, children...)
 	}
 
-	return nil
+	return result, nil
 }
@@ -0,0 +1,143 @@
+package task
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/repository"
+)
+
+// UploadArtifactInput representa los datos de entrada para adjuntar un fichero a una tarea
+type UploadArtifactInput struct {
+	TaskID      uuid.UUID
+	Name        string // Nombre del fichero; se conserva solo su último segmento
+	ContentType string // Tipo de contenido (opcional, entity.DefaultArtifactContentType por defecto)
+	Size        int64  // Tamaño declarado del contenido en bytes
+	Content     io.Reader
+	UploadedBy  string
+}
+
+// UploadArtifactOutput representa el resultado de adjuntar un fichero
+type UploadArtifactOutput struct {
+	Artifact *entity.Artifact
+}
+
+// UploadArtifactUseCase maneja la subida de artefactos de las tareas
+// El contenido se guarda en el BlobStore antes que los metadatos: un artefacto registrado siempre tiene contenido
+type UploadArtifactUseCase struct {
+	taskRepo     repository.TaskRepository
+	artifactRepo repository.ArtifactRepository
+	blobStore    repository.BlobStore
+	maxBytes     int64
+	clock        entity.Clock
+}
+
+// NewUploadArtifactUseCase crea una nueva instancia del caso de uso
+// maxBytes es el tamaño máximo del contenido de cada artefacto
+func NewUploadArtifactUseCase(
+	taskRepo repository.TaskRepository,
+	artifactRepo repository.ArtifactRepository,
+	blobStore repository.BlobStore,
+	maxBytes int64,
+	clock entity.Clock,
+) *UploadArtifactUseCase {
+	return &UploadArtifactUseCase{
+		taskRepo:     taskRepo,
+		artifactRepo: artifactRepo,
+		blobStore:    blobStore,
+		maxBytes:     maxBytes,
+		clock:        clock,
+	}
+}
+
+// Execute ejecuta el caso de uso de subida de artefactos
+// El digest SHA-256 se calcula mientras el contenido se envía al BlobStore, sin leerlo dos veces
+func (uc *UploadArtifactUseCase) Execute(ctx context.Context, input UploadArtifactInput) (*UploadArtifactOutput, error) {
+	if err := uc.validateInput(input); err != nil {
+		return nil, err
+	}
+
+	// Verificar que la tarea existe y no está eliminada
+	if _, err := uc.taskRepo.FindByID(ctx, input.TaskID); err != nil {
+		return nil, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	artifact, err := entity.NewArtifact(input.TaskID, input.Name, input.ContentType, input.UploadedBy, input.Size, uc.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	content := newDigestReader(input.Content)
+	if err := uc.blobStore.Put(ctx, artifact.StorageKey, content, artifact.Size, artifact.ContentType); err != nil {
+		return nil, fmt.Errorf("failed to store artifact content: %w", err)
+	}
+
+	if err := artifact.SetContentDigest(content.Sum(), content.BytesRead()); err != nil {
+		uc.discardContent(ctx, artifact)
+		return nil, err
+	}
+
+	if err := uc.artifactRepo.Create(ctx, artifact); err != nil {
+		uc.discardContent(ctx, artifact)
+		return nil, fmt.Errorf("failed to persist artifact: %w", err)
+	}
+
+	return &UploadArtifactOutput{Artifact: artifact}, nil
+}
+
+// validateInput valida los datos de entrada
+func (uc *UploadArtifactUseCase) validateInput(input UploadArtifactInput) error {
+	if input.TaskID == uuid.Nil {
+		return fmt.Errorf("%w: task id is required", entity.ErrMissingRequiredFields)
+	}
+	if input.Content == nil {
+		return fmt.Errorf("%w: file is required", entity.ErrInvalidArtifact)
+	}
+	if input.Size > uc.maxBytes {
+		return fmt.Errorf("%w: file has %d bytes, the maximum is %d", entity.ErrArtifactTooLarge, input.Size, uc.maxBytes)
+	}
+	return nil
+}
+
+// discardContent borra el contenido de un artefacto que no llegó a registrarse
+// Se borra aunque el cliente haya cancelado la petición; si falla, el contenido queda huérfano en el almacén
+func (uc *UploadArtifactUseCase) discardContent(ctx context.Context, artifact *entity.Artifact) {
+	_ = uc.blobStore.Delete(context.WithoutCancel(ctx), artifact.StorageKey)
+}
+
+// digestReader calcula el SHA-256 y cuenta los bytes del contenido a medida que se lee
+type digestReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	read   int64
+}
+
+// newDigestReader envuelve reader para calcular su digest
+func newDigestReader(reader io.Reader) *digestReader {
+	return &digestReader{reader: reader, hash: sha256.New()}
+}
+
+// Read implementa io.Reader
+func (r *digestReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	r.read += int64(n)
+	return n, err
+}
+
+// Sum retorna el SHA-256 hexadecimal de los bytes leídos
+func (r *digestReader) Sum() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
+
+// BytesRead retorna el número de bytes leídos
+func (r *digestReader) BytesRead() int64 {
+	return r.read
+}
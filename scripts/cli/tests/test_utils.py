@@ -8,13 +8,15 @@ class TestConstants:
 
     def test_states_list(self):
         """Test STATES constant contains all valid states."""
-        expected_states = ['PENDING', 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'CANCELLED']
+        expected_states = ['PENDING', 'IN_PROGRESS', 'PAUSED', 'RETRYING', 'COMPLETED', 'FAILED', 'CANCELLED']
         assert STATES == expected_states
 
     def test_state_colors_mapping(self):
         """Test STATE_COLORS contains all states."""
         assert 'PENDING' in STATE_COLORS
         assert 'IN_PROGRESS' in STATE_COLORS
+        assert 'PAUSED' in STATE_COLORS
+        assert 'RETRYING' in STATE_COLORS
         assert 'COMPLETED' in STATE_COLORS
         assert 'FAILED' in STATE_COLORS
         assert 'CANCELLED' in STATE_COLORS
@@ -23,6 +25,8 @@ class TestConstants:
         """Test STATE_COLORS has valid color values."""
         assert STATE_COLORS['PENDING'] == 'yellow'
         assert STATE_COLORS['IN_PROGRESS'] == 'blue'
+        assert STATE_COLORS['PAUSED'] == 'cyan'
+        assert STATE_COLORS['RETRYING'] == 'bright_blue'
         assert STATE_COLORS['COMPLETED'] == 'green'
         assert STATE_COLORS['FAILED'] == 'red'
         assert STATE_COLORS['CANCELLED'] == 'magenta'
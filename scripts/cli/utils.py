@@ -8,11 +8,13 @@ from rich.json import JSON
 
 console = Console()
 
-STATES = ['PENDING', 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'CANCELLED']
+STATES = ['PENDING', 'IN_PROGRESS', 'PAUSED', 'RETRYING', 'COMPLETED', 'FAILED', 'CANCELLED']
 
 STATE_COLORS = {
     'PENDING': 'yellow',
     'IN_PROGRESS': 'blue',
+    'PAUSED': 'cyan',
+    'RETRYING': 'bright_blue',
     'COMPLETED': 'green',
     'FAILED': 'red',
     'CANCELLED': 'magenta'
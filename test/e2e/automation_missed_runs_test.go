@@ -0,0 +1,121 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/adapter/repository/postgres"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	automationUsecase "github.com/grupoapi/proces-log/internal/usecase/automation"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_AutomationMissedRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router y detector sobre la misma base de datos
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+	automationRepo := postgres.NewAutomationRepository(pg.Pool)
+	detector := automationUsecase.NewDetectMissedRunsUseCase(
+		automationRepo,
+		postgres.NewTransactionManager(pg.Pool),
+		postgres.NewAdvisoryLockManager(pg.Pool),
+	)
+
+	w := doJSON(router, http.MethodPost, "/Automatismo", map[string]interface{}{
+		"key": "ops.every-five", "owner_team": "ops", "schedule": "*/5 * * * *", "grace_period": 60, "created_by": "alice",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created httpHandler.AutomationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, 60, created.GracePeriod)
+
+	w = doJSON(router, http.MethodPost, "/Automatismo", map[string]interface{}{
+		"key": "ops.too-lenient", "owner_team": "ops", "schedule": "@daily", "grace_period": 0, "created_by": "alice",
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	// Las activaciones se revisan desde el registro del automatismo
+	automation, err := automationRepo.FindByKey(ctx, "ops.every-five")
+	require.NoError(t, err)
+	first := automation.Schedule.Next(automation.ScheduleCheckedUntil)
+
+	// Solo la primera activación tiene una ejecución creada dentro de su margen
+	w = doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name": "Every five", "created_by": "scheduler", "automation": "ops.every-five",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var run httpHandler.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &run))
+	_, err = pg.Pool.Exec(ctx, "UPDATE tasks SET created_at = $1 WHERE id = $2", first.Add(30*time.Second), run.ID)
+	require.NoError(t, err)
+
+	now := first.Add(11 * time.Minute)
+
+	t.Run("activations without a run are recorded once", func(t *testing.T) {
+		output, err := detector.Execute(ctx, automationUsecase.DetectMissedRunsInput{Now: now})
+		require.NoError(t, err)
+		require.Len(t, output.Missed, 2)
+		assert.True(t, first.Add(5*time.Minute).Equal(output.Missed[0].ExpectedAt))
+		assert.True(t, first.Add(10*time.Minute).Equal(output.Missed[1].ExpectedAt))
+		assert.True(t, first.Add(11*time.Minute).Equal(output.Missed[1].Deadline))
+
+		output, err = detector.Execute(ctx, automationUsecase.DetectMissedRunsInput{Now: now})
+		require.NoError(t, err)
+		assert.Empty(t, output.Missed)
+	})
+
+	t.Run("missed runs are listed and summarized", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatismo/ops.every-five/missed", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list httpHandler.MissedRunListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Equal(t, 2, list.Pagination.Total)
+		require.Len(t, list.MissedRuns, 2)
+		assert.True(t, first.Add(10*time.Minute).Equal(list.MissedRuns[0].ExpectedAt), "most recent first")
+
+		w = doJSON(router, http.MethodGet, "/Automatismo/ops.every-five", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var automation httpHandler.AutomationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &automation))
+		require.NotNil(t, automation.Runs)
+		assert.Equal(t, 1, automation.Runs.Total)
+		assert.Equal(t, 2, automation.Runs.Missed)
+		require.NotNil(t, automation.Runs.LastMissedAt)
+		assert.True(t, first.Add(10*time.Minute).Equal(*automation.Runs.LastMissedAt))
+
+		w = doJSON(router, http.MethodGet, "/Automatismo/ops.unknown/missed", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("changing the grace period keeps the checked activations", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Automatismo/ops.every-five", map[string]interface{}{
+			"grace_period": 120, "updated_by": "bob",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		automation, err := automationRepo.FindByKey(ctx, "ops.every-five")
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, automation.GracePeriod)
+		assert.True(t, first.Add(10*time.Minute).Equal(automation.ScheduleCheckedUntil))
+	})
+}
@@ -0,0 +1,205 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_Automations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	// Registrar el automatismo con su plantilla y planificación
+	w := doJSON(router, http.MethodPost, "/Automatismo", map[string]interface{}{
+		"key":         "billing.nightly-export",
+		"owner_team":  "billing",
+		"description": "Exports yesterday's invoices to the ERP",
+		"subtask_template": []map[string]interface{}{
+			{"name": "extract"},
+			{"name": "load", "depends_on": []string{"extract"}, "subtasks": []map[string]interface{}{
+				{"name": "load-headers"},
+				{"name": "load-lines", "depends_on": []string{"load-headers"}},
+			}},
+		},
+		"schedule":   "0 2 * * MON-FRI",
+		"timezone":   "Europe/Madrid",
+		"created_by": "alice",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+	t.Run("duplicate and invalid definitions are rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatismo", map[string]interface{}{
+			"key": "billing.nightly-export", "owner_team": "other", "created_by": "bob",
+		})
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodPost, "/Automatismo", map[string]interface{}{
+			"key": "Billing Export", "owner_team": "billing", "created_by": "bob",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodPost, "/Automatismo", map[string]interface{}{
+			"key": "billing.weekly", "owner_team": "billing", "schedule": "0 25 * * *", "created_by": "bob",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "invalid-schedule")
+
+		w = doJSON(router, http.MethodPost, "/Automatismo", map[string]interface{}{
+			"key": "billing.cyclic", "owner_team": "billing", "created_by": "bob",
+			"subtask_template": []map[string]interface{}{
+				{"name": "a", "depends_on": []string{"b"}},
+				{"name": "b", "depends_on": []string{"a"}},
+			},
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	})
+
+	// Crear ejecuciones: sin subtareas se instancian las de la plantilla
+	createRun := func(t *testing.T, body map[string]interface{}) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	first := createRun(t, map[string]interface{}{
+		"name": "Nightly export 2026-03-02", "created_by": "scheduler", "automation": "billing.nightly-export",
+	})
+	assert.Equal(t, "billing.nightly-export", first.Automation)
+	require.Len(t, first.Subtasks, 2)
+	assert.Equal(t, "extract", first.Subtasks[0].Name)
+	assert.Equal(t, "load", first.Subtasks[1].Name)
+	assert.Equal(t, []string{first.Subtasks[0].ID}, first.Subtasks[1].DependsOn)
+	require.Len(t, first.Subtasks[1].Subtasks, 2)
+	assert.Equal(t, "load-lines", first.Subtasks[1].Subtasks[1].Name)
+
+	// Las subtareas explícitas prevalecen sobre la plantilla
+	second := createRun(t, map[string]interface{}{
+		"name": "Manual re-export", "created_by": "alice", "automation": "billing.nightly-export",
+		"state":    "IN_PROGRESS",
+		"subtasks": []map[string]interface{}{{"name": "load"}},
+	})
+	require.Len(t, second.Subtasks, 1)
+
+	createRun(t, map[string]interface{}{"name": "Standalone task", "created_by": "alice"})
+
+	w = doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name": "Orphan run", "created_by": "alice", "automation": "billing.unknown",
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "invalid-automation")
+
+	t.Run("runs are listed and summarized per definition", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/AutomatizacionListado?automation=billing.nightly-export", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list httpHandler.TaskListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Equal(t, 2, list.Pagination.Total)
+
+		w = doJSON(router, http.MethodGet, "/Automatismo/billing.nightly-export", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var automation httpHandler.AutomationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &automation))
+		require.NotNil(t, automation.Runs)
+		assert.Equal(t, 2, automation.Runs.Total)
+		assert.Equal(t, map[string]int{"PENDING": 1, "IN_PROGRESS": 1}, automation.Runs.ByState)
+		require.NotNil(t, automation.Runs.LastRun)
+		assert.Equal(t, second.ID, automation.Runs.LastRun.TaskID)
+
+		w = doJSON(router, http.MethodGet, "/AutomatismoListado?owner_team=billing", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var automations httpHandler.AutomationListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &automations))
+		require.Len(t, automations.Automations, 1)
+		assert.Equal(t, 2, automations.Automations[0].Runs.Total)
+	})
+
+	t.Run("update with If-Match replaces the template for new runs only", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/Automatismo/billing.nightly-export",
+			strings.NewReader(`{"subtask_template": [{"name": "export"}], "schedule": "", "updated_by": "bob"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+		var automation httpHandler.AutomationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &automation))
+		assert.Nil(t, automation.Schedule)
+		assert.Equal(t, "bob", automation.UpdatedBy)
+
+		// Una versión obsoleta no se aplica
+		req = httptest.NewRequest(http.MethodPut, "/Automatismo/billing.nightly-export",
+			strings.NewReader(`{"description": "stale", "updated_by": "carol"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+
+		run := createRun(t, map[string]interface{}{
+			"name": "Nightly export 2026-03-03", "created_by": "scheduler", "automation": "billing.nightly-export",
+		})
+		require.Len(t, run.Subtasks, 1)
+		assert.Equal(t, "export", run.Subtasks[0].Name)
+
+		w = doJSON(router, http.MethodGet, "/Automatizacion/"+first.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var unchanged httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &unchanged))
+		assert.Len(t, unchanged.Subtasks, 2)
+	})
+
+	t.Run("deleted definitions keep their runs and their key", func(t *testing.T) {
+		w := doJSON(router, http.MethodDelete, "/Automatismo/billing.nightly-export", map[string]interface{}{"deleted_by": "alice"})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodGet, "/Automatismo/billing.nightly-export", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name": "Late run", "created_by": "scheduler", "automation": "billing.nightly-export",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodPost, "/Automatismo", map[string]interface{}{
+			"key": "billing.nightly-export", "owner_team": "billing", "created_by": "alice",
+		})
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodGet, "/AutomatizacionListado?automation=billing.nightly-export", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list httpHandler.TaskListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Equal(t, 3, list.Pagination.Total)
+	})
+}
@@ -0,0 +1,134 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_CompletionPolicies(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	// startTask crea una tarea en ejecución con dos subtareas y retorna los IDs de tarea y subtareas
+	startTask := func(t *testing.T, policy string) (string, []string) {
+		body := map[string]interface{}{
+			"name":       "Policy Task",
+			"created_by": "team-a",
+			"state":      "IN_PROGRESS",
+			"subtasks":   []map[string]interface{}{{"name": "Step 1"}, {"name": "Step 2"}},
+		}
+		if policy != "" {
+			body["completion_policy"] = policy
+		}
+
+		w := doJSON(router, http.MethodPost, "/Automatizacion", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task.ID, []string{task.Subtasks[0].ID, task.Subtasks[1].ID}
+	}
+
+	setSubtaskState := func(t *testing.T, subtaskID string, states ...string) {
+		for _, state := range states {
+			w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+				"state":      state,
+				"updated_by": "worker",
+			})
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+	}
+
+	getTask := func(t *testing.T, taskID string) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	subtaskState := func(task httpHandler.TaskResponse, subtaskID string) string {
+		for _, subtask := range task.Subtasks {
+			if subtask.ID == subtaskID {
+				return subtask.State
+			}
+		}
+		return ""
+	}
+
+	t.Run("Default policy requires every subtask to complete", func(t *testing.T) {
+		taskID, subtasks := startTask(t, "")
+		setSubtaskState(t, subtasks[0], "IN_PROGRESS", "COMPLETED")
+		setSubtaskState(t, subtasks[1], "IN_PROGRESS", "FAILED")
+
+		task := getTask(t, taskID)
+		assert.Equal(t, "all-must-complete", task.CompletionPolicy)
+		assert.Equal(t, "IN_PROGRESS", task.State)
+	})
+
+	t.Run("Fail-fast fails the parent on the first failure", func(t *testing.T) {
+		taskID, subtasks := startTask(t, "fail-fast")
+		setSubtaskState(t, subtasks[1], "IN_PROGRESS")
+		setSubtaskState(t, subtasks[0], "IN_PROGRESS", "FAILED")
+
+		task := getTask(t, taskID)
+		assert.Equal(t, "FAILED", task.State)
+		assert.NotNil(t, task.EndDate)
+		assert.Equal(t, "FAILED", subtaskState(task, subtasks[1]), "open subtasks inherit the parent final state")
+	})
+
+	t.Run("All-final waits for every subtask and fails if any failed", func(t *testing.T) {
+		taskID, subtasks := startTask(t, "all-final")
+		setSubtaskState(t, subtasks[0], "IN_PROGRESS", "FAILED")
+		assert.Equal(t, "IN_PROGRESS", getTask(t, taskID).State)
+
+		setSubtaskState(t, subtasks[1], "IN_PROGRESS", "COMPLETED")
+
+		task := getTask(t, taskID)
+		assert.Equal(t, "FAILED", task.State)
+		assert.Equal(t, "COMPLETED", subtaskState(task, subtasks[1]), "finished subtasks keep their state")
+	})
+
+	t.Run("Ignore-cancelled completes when the rest complete", func(t *testing.T) {
+		taskID, subtasks := startTask(t, "ignore-cancelled")
+		setSubtaskState(t, subtasks[0], "CANCELLED")
+		setSubtaskState(t, subtasks[1], "IN_PROGRESS", "COMPLETED")
+
+		task := getTask(t, taskID)
+		assert.Equal(t, "COMPLETED", task.State)
+		assert.Equal(t, "CANCELLED", subtaskState(task, subtasks[0]))
+	})
+
+	t.Run("Unknown policy is rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":              "Policy Task",
+			"created_by":        "team-a",
+			"completion_policy": "first-wins",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
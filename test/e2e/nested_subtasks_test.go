@@ -0,0 +1,149 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_NestedSubtasks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name":       "Release",
+		"created_by": "team-release",
+		"state":      "IN_PROGRESS",
+		"subtasks": []map[string]interface{}{
+			{"name": "Build"},
+			{"name": "Test", "subtasks": []map[string]interface{}{
+				{"name": "Unit"},
+				{"name": "Integration", "subtasks": []map[string]interface{}{{"name": "Smoke"}}},
+			}},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created httpHandler.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	getTask := func(t *testing.T) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+created.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	// flatten indexa las subtareas del árbol por nombre
+	var flatten func(subtasks []httpHandler.SubtaskResponse, into map[string]httpHandler.SubtaskResponse)
+	flatten = func(subtasks []httpHandler.SubtaskResponse, into map[string]httpHandler.SubtaskResponse) {
+		for _, subtask := range subtasks {
+			into[subtask.Name] = subtask
+			flatten(subtask.Subtasks, into)
+		}
+	}
+	subtasksByName := func(task httpHandler.TaskResponse) map[string]httpHandler.SubtaskResponse {
+		byName := make(map[string]httpHandler.SubtaskResponse)
+		flatten(task.Subtasks, byName)
+		return byName
+	}
+
+	setState := func(name, state string) int {
+		w := doJSON(router, http.MethodPut, "/Subtask/"+subtasksByName(created)[name].ID, map[string]interface{}{
+			"state":      state,
+			"updated_by": "runner",
+		})
+		return w.Code
+	}
+
+	t.Run("The hierarchy is loaded back as a tree", func(t *testing.T) {
+		task := getTask(t)
+		require.Len(t, task.Subtasks, 2)
+
+		byName := subtasksByName(task)
+		require.Len(t, byName, 5)
+		require.Len(t, byName["Test"].Subtasks, 2)
+		require.NotNil(t, byName["Smoke"].ParentSubtaskID)
+		assert.Equal(t, byName["Integration"].ID, *byName["Smoke"].ParentSubtaskID)
+		assert.Nil(t, byName["Build"].ParentSubtaskID)
+	})
+
+	t.Run("A nested subtask cannot start before its parent", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, setState("Unit", "IN_PROGRESS"))
+	})
+
+	t.Run("Completing every child completes the parent subtasks", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, setState("Test", "IN_PROGRESS"))
+		require.Equal(t, http.StatusOK, setState("Integration", "IN_PROGRESS"))
+		require.Equal(t, http.StatusOK, setState("Unit", "IN_PROGRESS"))
+		require.Equal(t, http.StatusOK, setState("Unit", "COMPLETED"))
+		require.Equal(t, http.StatusOK, setState("Smoke", "IN_PROGRESS"))
+		require.Equal(t, http.StatusOK, setState("Smoke", "COMPLETED"))
+
+		task := getTask(t)
+		byName := subtasksByName(task)
+		assert.Equal(t, "COMPLETED", byName["Integration"].State)
+		assert.Equal(t, "COMPLETED", byName["Test"].State)
+
+		// La tarea espera a las demás subtareas raíz
+		assert.Equal(t, "IN_PROGRESS", task.State)
+
+		require.Equal(t, http.StatusOK, setState("Build", "IN_PROGRESS"))
+		require.Equal(t, http.StatusOK, setState("Build", "COMPLETED"))
+		assert.Equal(t, "COMPLETED", getTask(t).State)
+	})
+
+	t.Run("Deleting a subtask deletes its branch", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":       "Cleanup",
+			"created_by": "team-release",
+			"subtasks": []map[string]interface{}{
+				{"name": "Keep"},
+				{"name": "Drop", "subtasks": []map[string]interface{}{{"name": "Drop child"}}},
+			},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+
+		w = doJSON(router, http.MethodDelete, "/Subtask/"+subtasksByName(task)["Drop"].ID, map[string]interface{}{
+			"deleted_by": "team-release",
+		})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodGet, "/Automatizacion/"+task.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var remaining httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &remaining))
+
+		byName := subtasksByName(remaining)
+		assert.Len(t, byName, 1)
+		assert.Contains(t, byName, "Keep")
+	})
+}
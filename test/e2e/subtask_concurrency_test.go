@@ -0,0 +1,121 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_ConcurrentSubtaskCompletion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	// Crear tarea con varias subtareas
+	const subtaskCount = 5
+	subtaskBodies := make([]map[string]interface{}, subtaskCount)
+	for i := range subtaskBodies {
+		subtaskBodies[i] = map[string]interface{}{"name": "Subtask " + string(rune('A'+i))}
+	}
+	createW := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name":       "Concurrent Task",
+		"created_by": "test-user",
+		"subtasks":   subtaskBodies,
+	})
+	require.Equal(t, http.StatusCreated, createW.Code)
+
+	var createdTask map[string]interface{}
+	require.NoError(t, json.Unmarshal(createW.Body.Bytes(), &createdTask))
+	taskID := createdTask["id"].(string)
+
+	// Iniciar la tarea y todas sus subtareas
+	startW := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+		"id":         taskID,
+		"state":      "IN_PROGRESS",
+		"updated_by": "test-user",
+	})
+	require.Equal(t, http.StatusOK, startW.Code)
+
+	subtaskIDs := make([]string, 0, subtaskCount)
+	for _, st := range createdTask["subtasks"].([]interface{}) {
+		subtaskID := st.(map[string]interface{})["id"].(string)
+		subtaskIDs = append(subtaskIDs, subtaskID)
+
+		w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+			"state":      "IN_PROGRESS",
+			"updated_by": "test-user",
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	// Completar todas las subtareas a la vez
+	var wg sync.WaitGroup
+	codes := make([]int, len(subtaskIDs))
+	for i, subtaskID := range subtaskIDs {
+		wg.Add(1)
+		go func(i int, subtaskID string) {
+			defer wg.Done()
+			w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+				"state":      "COMPLETED",
+				"updated_by": "test-user",
+			})
+			codes[i] = w.Code
+		}(i, subtaskID)
+	}
+	wg.Wait()
+
+	for i, code := range codes {
+		assert.Equal(t, http.StatusOK, code, "subtask %s should complete", subtaskIDs[i])
+	}
+
+	// La última completación debe ver todas las subtareas completadas y cerrar la tarea padre
+	getW := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID, nil)
+	require.Equal(t, http.StatusOK, getW.Code)
+
+	var task map[string]interface{}
+	require.NoError(t, json.Unmarshal(getW.Body.Bytes(), &task))
+	assert.Equal(t, "COMPLETED", task["state"])
+	assert.NotNil(t, task["end_date"])
+}
+
+// doJSON ejecuta una request JSON contra el router y retorna la respuesta grabada
+func doJSON(router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var reader *bytes.Buffer
+	if body != nil {
+		bodyBytes, _ := json.Marshal(body)
+		reader = bytes.NewBuffer(bodyBytes)
+	} else {
+		reader = bytes.NewBuffer(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
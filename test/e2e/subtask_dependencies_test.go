@@ -0,0 +1,115 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_SubtaskDependencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	t.Run("Cycles are rejected on creation", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":       "Cyclic Pipeline",
+			"created_by": "team-etl",
+			"subtasks": []map[string]interface{}{
+				{"name": "A", "depends_on": []string{"B"}},
+				{"name": "B", "depends_on": []string{"A"}},
+			},
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Unknown dependencies are rejected on creation", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":       "Broken Pipeline",
+			"created_by": "team-etl",
+			"subtasks":   []map[string]interface{}{{"name": "Load", "depends_on": []string{"Extract"}}},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name":       "ETL Pipeline",
+		"created_by": "team-etl",
+		"state":      "IN_PROGRESS",
+		"subtasks": []map[string]interface{}{
+			{"name": "Extract"},
+			{"name": "Transform", "depends_on": []string{"Extract"}},
+			{"name": "Load", "depends_on": []string{"Transform"}},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var task httpHandler.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+
+	ids := make(map[string]string, len(task.Subtasks))
+	for _, subtask := range task.Subtasks {
+		ids[subtask.Name] = subtask.ID
+	}
+
+	ready := func(t *testing.T) []string {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+task.ID+"/ready", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response httpHandler.ReadySubtasksResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+		names := make([]string, 0, len(response.Subtasks))
+		for _, subtask := range response.Subtasks {
+			names = append(names, subtask.Name)
+		}
+		return names
+	}
+
+	setState := func(name, state string) int {
+		w := doJSON(router, http.MethodPut, "/Subtask/"+ids[name], map[string]interface{}{
+			"state":      state,
+			"updated_by": "worker",
+		})
+		return w.Code
+	}
+
+	t.Run("Only subtasks without pending dependencies are ready", func(t *testing.T) {
+		assert.Equal(t, []string{"Extract"}, ready(t))
+	})
+
+	t.Run("Subtask cannot start before its dependencies complete", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, setState("Transform", "IN_PROGRESS"))
+	})
+
+	t.Run("Completing a dependency unblocks the next step", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, setState("Extract", "IN_PROGRESS"))
+		assert.Empty(t, ready(t))
+
+		require.Equal(t, http.StatusOK, setState("Extract", "COMPLETED"))
+		assert.Equal(t, []string{"Transform"}, ready(t))
+
+		assert.Equal(t, http.StatusOK, setState("Transform", "IN_PROGRESS"))
+	})
+}
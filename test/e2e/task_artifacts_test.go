@@ -0,0 +1,179 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grupoapi/proces-log/internal/adapter/blobstore"
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/adapter/repository/postgres"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+// uploadArtifact sube un fichero como multipart/form-data al endpoint de artefactos de la tarea
+func uploadArtifact(t *testing.T, router *gin.Engine, taskID, filename, content, uploadedBy string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	require.NoError(t, writer.WriteField("uploaded_by", uploadedBy))
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = io.WriteString(part, content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/Automatizacion/"+taskID+"/artifacts", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestE2E_TaskArtifacts(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router con un almacén filesystem temporal y un límite pequeño para probar el tamaño máximo
+	storeDir := t.TempDir()
+	blobStore, err := blobstore.NewFilesystemStore(storeDir)
+	require.NoError(t, err)
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()),
+		httpHandler.WithBlobStore(blobStore),
+		httpHandler.WithMaxArtifactBytes(1024))
+
+	w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name":       "Invoice Robot",
+		"created_by": "team-rpa",
+		"state":      "IN_PROGRESS",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var task httpHandler.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+
+	report := "<html><body>42 invoices processed</body></html>"
+	digest := sha256.Sum256([]byte(report))
+
+	var uploaded httpHandler.ArtifactResponse
+	t.Run("Upload stores metadata and content", func(t *testing.T) {
+		w := uploadArtifact(t, router, task.ID, "report.html", report, "robot-01")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+
+		assert.Equal(t, task.ID, uploaded.TaskID)
+		assert.Equal(t, "report.html", uploaded.Name)
+		assert.Equal(t, int64(len(report)), uploaded.Size)
+		assert.Equal(t, hex.EncodeToString(digest[:]), uploaded.SHA256)
+		assert.Equal(t, "robot-01", uploaded.UploadedBy)
+	})
+
+	t.Run("Download returns the content", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+task.ID+"/artifacts/"+uploaded.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		assert.Equal(t, report, w.Body.String())
+		assert.Equal(t, `attachment; filename=report.html`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, uploaded.SHA256, w.Header().Get("X-Checksum-Sha256"))
+	})
+
+	t.Run("List returns the artifacts of the task", func(t *testing.T) {
+		w := uploadArtifact(t, router, task.ID, "screenshot.png", "\x89PNG", "robot-01")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodGet, "/Automatizacion/"+task.ID+"/artifacts", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var list httpHandler.ArtifactListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Artifacts, 2)
+		assert.Equal(t, "report.html", list.Artifacts[0].Name)
+		assert.Equal(t, "screenshot.png", list.Artifacts[1].Name)
+	})
+
+	t.Run("Upload rejects files over the maximum size", func(t *testing.T) {
+		w := uploadArtifact(t, router, task.ID, "dump.bin", strings.Repeat("x", 2048), "robot-01")
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code, w.Body.String())
+	})
+
+	t.Run("Upload requires uploaded_by", func(t *testing.T) {
+		w := uploadArtifact(t, router, task.ID, "report.html", report, "")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("Artifacts of another task are not found", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+uuid.NewString()+"/artifacts/"+uploaded.ID, nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodGet, "/Automatizacion/"+task.ID+"/artifacts/"+uuid.NewString(), nil)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		var problem httpHandler.ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "https://api.grupoapi.com/problems/artifact-not-found", problem.Type)
+	})
+
+	t.Run("Hard delete removes the artifacts and the purger deletes their content", func(t *testing.T) {
+		w := doJSON(router, http.MethodDelete, "/Automatizacion/"+task.ID, map[string]interface{}{
+			"deleted_by": "team-rpa",
+		})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		// Purgar como si hubiera pasado la ventana de retención de 30 días
+		deleted, err := postgres.NewTaskRepository(pg.Pool).HardDelete(ctx, time.Now().Add(31*24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		var remaining, orphaned int
+		err = pg.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM task_artifacts WHERE task_id = $1", task.ID).Scan(&remaining)
+		require.NoError(t, err)
+		assert.Zero(t, remaining)
+		err = pg.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM orphaned_blobs").Scan(&orphaned)
+		require.NoError(t, err)
+		assert.Equal(t, 2, orphaned)
+
+		// El contenido sigue en el almacén hasta que pasa el purgador
+		_, err = os.Stat(filepath.Join(storeDir, "tasks", task.ID))
+		require.NoError(t, err)
+
+		purge := taskUsecase.NewPurgeArtifactBlobsUseCase(postgres.NewArtifactRepository(pg.Pool), blobStore)
+		output, err := purge.Execute(ctx, taskUsecase.PurgeArtifactBlobsInput{})
+		require.NoError(t, err)
+		assert.Len(t, output.Purged, 2)
+		assert.Empty(t, output.Failed)
+
+		_, err = os.Stat(filepath.Join(storeDir, "tasks", task.ID))
+		assert.True(t, os.IsNotExist(err), "the task directory should be removed")
+		err = pg.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM orphaned_blobs").Scan(&orphaned)
+		require.NoError(t, err)
+		assert.Zero(t, orphaned)
+	})
+}
@@ -0,0 +1,112 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskAutoStart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	createTask := func(t *testing.T, autoStart bool) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":       "Auto Start Task",
+			"created_by": "team-a",
+			"auto_start": autoStart,
+			"subtasks":   []map[string]interface{}{{"name": "Step 1"}, {"name": "Step 2"}},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	startSubtask := func(subtaskID string) int {
+		w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+			"state":      "IN_PROGRESS",
+			"updated_by": "runner-1",
+		})
+		return w.Code
+	}
+
+	getTask := func(t *testing.T, taskID string) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	t.Run("Without auto_start the parent must be started first", func(t *testing.T) {
+		task := createTask(t, false)
+		assert.False(t, task.AutoStart)
+
+		assert.Equal(t, http.StatusBadRequest, startSubtask(task.Subtasks[0].ID))
+		assert.Equal(t, "PENDING", getTask(t, task.ID).State)
+	})
+
+	t.Run("Starting a subtask starts the pending parent", func(t *testing.T) {
+		task := createTask(t, true)
+		assert.True(t, task.AutoStart)
+
+		require.Equal(t, http.StatusOK, startSubtask(task.Subtasks[0].ID))
+
+		started := getTask(t, task.ID)
+		assert.Equal(t, "IN_PROGRESS", started.State)
+		assert.NotNil(t, started.StartDate)
+		require.NotNil(t, started.UpdatedBy)
+		assert.Equal(t, "runner-1", *started.UpdatedBy)
+
+		// Las demás subtareas no cambian
+		for _, subtask := range started.Subtasks {
+			if subtask.ID == task.Subtasks[1].ID {
+				assert.Equal(t, "PENDING", subtask.State)
+			}
+		}
+
+		// El arranque queda en el historial con el actor que lo provocó
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+task.ID+"/history", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var history httpHandler.TaskHistoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+
+		var found bool
+		for _, event := range history.Events {
+			if event.Type == "TASK_STATE_CHANGED" && event.NewValue != nil && *event.NewValue == "IN_PROGRESS" {
+				found = true
+				assert.Equal(t, "runner-1", event.Actor)
+			}
+		}
+		assert.True(t, found, "auto-start must be recorded in the task history")
+
+		// La siguiente subtarea arranca con la tarea ya en curso
+		assert.Equal(t, http.StatusOK, startSubtask(task.Subtasks[1].ID))
+	})
+}
@@ -0,0 +1,161 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskFailureDetails(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	createTask := func(t *testing.T, body map[string]interface{}) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	listIDs := func(t *testing.T, query string) []string {
+		w := doJSON(router, http.MethodGet, "/AutomatizacionListado?"+query, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var list httpHandler.TaskListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+
+		ids := make([]string, 0, len(list.Tasks))
+		for _, task := range list.Tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	failed := createTask(t, map[string]interface{}{
+		"name":       "Failing Import",
+		"created_by": "team-etl",
+		"state":      "IN_PROGRESS",
+	})
+	withFailedSubtask := createTask(t, map[string]interface{}{
+		"name":       "Partial Import",
+		"created_by": "team-etl",
+		"state":      "IN_PROGRESS",
+		"subtasks":   []map[string]interface{}{{"name": "Load", "state": "IN_PROGRESS"}},
+	})
+
+	t.Run("Failure details are persisted and returned", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         failed.ID,
+			"state":      "FAILED",
+			"updated_by": "team-etl",
+			"failure": map[string]interface{}{
+				"error_code":    "HTTP_TIMEOUT",
+				"error_message": "upstream timed out after 30s",
+				"details":       map[string]interface{}{"url": "https://example.com/export", "attempts": 3},
+			},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodGet, "/Automatizacion/"+failed.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		require.NotNil(t, task.Failure)
+		assert.Equal(t, "HTTP_TIMEOUT", task.Failure.ErrorCode)
+		assert.Equal(t, "upstream timed out after 30s", task.Failure.ErrorMessage)
+		assert.Equal(t, map[string]interface{}{"url": "https://example.com/export", "attempts": float64(3)}, task.Failure.Details)
+	})
+
+	t.Run("Subtask failure details are persisted and returned", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Subtask/"+withFailedSubtask.Subtasks[0].ID, map[string]interface{}{
+			"state":      "FAILED",
+			"updated_by": "team-etl",
+			"failure": map[string]interface{}{
+				"error_code":    "ROW_REJECTED",
+				"error_message": "row 42 rejected",
+				"details":       "constraint violation on column amount",
+			},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var subtask httpHandler.SubtaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &subtask))
+		require.NotNil(t, subtask.Failure)
+		assert.Equal(t, "ROW_REJECTED", subtask.Failure.ErrorCode)
+		assert.Equal(t, "constraint violation on column amount", subtask.Failure.Details)
+	})
+
+	t.Run("Failure on a non FAILED transition is rejected", func(t *testing.T) {
+		pending := createTask(t, map[string]interface{}{
+			"name":       "Pending Import",
+			"created_by": "team-etl",
+		})
+
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         pending.ID,
+			"state":      "IN_PROGRESS",
+			"updated_by": "team-etl",
+			"failure":    map[string]interface{}{"error_code": "HTTP_TIMEOUT", "error_message": "boom"},
+		})
+		require.Equal(t, http.StatusBadRequest, w.Code)
+
+		var problem httpHandler.ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "https://api.grupoapi.com/problems/invalid-failure", problem.Type)
+	})
+
+	t.Run("List filters by error code of the task or its subtasks", func(t *testing.T) {
+		assert.Equal(t, []string{failed.ID}, listIDs(t, "error_code=HTTP_TIMEOUT"))
+		assert.Equal(t, []string{withFailedSubtask.ID}, listIDs(t, "error_code=ROW_REJECTED"))
+		assert.Empty(t, listIDs(t, "error_code=UNKNOWN"))
+	})
+
+	t.Run("Retry moves the failure to the closed attempt", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion/"+failed.ID+"/retry", map[string]interface{}{
+			"retried_by": "team-etl",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Nil(t, task.Failure)
+		assert.Empty(t, listIDs(t, "error_code=HTTP_TIMEOUT"))
+
+		w = doJSON(router, http.MethodGet, "/Automatizacion/"+failed.ID+"/attempts", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var attempts httpHandler.TaskAttemptsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &attempts))
+		require.Len(t, attempts.Attempts, 2)
+		require.NotNil(t, attempts.Attempts[0].Failure)
+		assert.Equal(t, "HTTP_TIMEOUT", attempts.Attempts[0].Failure.ErrorCode)
+		assert.Equal(t, "upstream timed out after 30s", attempts.Attempts[0].Failure.ErrorMessage)
+		assert.Equal(t, map[string]interface{}{"url": "https://example.com/export", "attempts": float64(3)}, attempts.Attempts[0].Failure.Details)
+		assert.Nil(t, attempts.Attempts[1].Failure)
+	})
+}
@@ -0,0 +1,174 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/adapter/repository/postgres"
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+	"github.com/grupoapi/proces-log/test/helpers"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskHeartbeats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router y reaper sobre la misma base de datos, con un reloj que solo avanza cuando el test lo indica
+	// PostgreSQL guarda las fechas con precisión de microsegundos
+	clock := helpers.NewFakeClock(time.Now().UTC().Truncate(time.Microsecond))
+	workflows := service.NewWorkflowRegistry(service.NewStateMachine())
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, workflows, httpHandler.WithClock(clock))
+	txManager := postgres.NewTransactionManager(pg.Pool)
+	lockManager := postgres.NewAdvisoryLockManager(pg.Pool)
+	reaper := taskUsecase.NewReapStaleTasksUseCase(
+		postgres.NewTaskRepository(pg.Pool),
+		postgres.NewTaskEventRepository(pg.Pool),
+		txManager,
+		lockManager,
+		workflows,
+	)
+
+	createTask := func(t *testing.T, body map[string]interface{}) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	getTask := func(t *testing.T, taskID string) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	reapedIDs := func(output *taskUsecase.ReapStaleTasksOutput) []string {
+		ids := make([]string, 0, len(output.Failed))
+		for _, task := range output.Failed {
+			ids = append(ids, task.ID.String())
+		}
+		return ids
+	}
+
+	supervised := createTask(t, map[string]interface{}{
+		"name":              "Supervised Import",
+		"created_by":        "team-etl",
+		"state":             "IN_PROGRESS",
+		"heartbeat_timeout": 60,
+		"subtasks":          []map[string]interface{}{{"name": "Extract"}},
+	})
+	unsupervised := createTask(t, map[string]interface{}{
+		"name":       "Unsupervised Import",
+		"created_by": "team-etl",
+		"state":      "IN_PROGRESS",
+	})
+
+	t.Run("Heartbeats are recorded without a new version", func(t *testing.T) {
+		beatAt := clock.Advance(10 * time.Second)
+		w := doJSON(router, http.MethodPost, "/Automatizacion/"+supervised.ID+"/heartbeat", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var beat httpHandler.HeartbeatResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &beat))
+		assert.Equal(t, "IN_PROGRESS", beat.TaskState)
+		require.NotNil(t, beat.HeartbeatTimeout)
+		assert.Equal(t, int64(60), *beat.HeartbeatTimeout)
+		assert.True(t, beatAt.Equal(beat.LastHeartbeatAt))
+
+		task := getTask(t, supervised.ID)
+		require.NotNil(t, task.LastHeartbeatAt)
+		assert.Equal(t, supervised.Version, task.Version)
+	})
+
+	t.Run("Heartbeats for unknown tasks are rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion/"+uuid.NewString()+"/heartbeat", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Only one replica reaps at a time", func(t *testing.T) {
+		err := txManager.WithinTransaction(ctx, func(lockedCtx context.Context) error {
+			acquired, err := lockManager.TryLock(lockedCtx, "stale-task-reaper")
+			require.NoError(t, err)
+			require.True(t, acquired)
+
+			// Otra réplica, con su propia transacción, encuentra el bloqueo tomado
+			output, err := reaper.Execute(ctx, taskUsecase.ReapStaleTasksInput{Now: clock.Now().Add(time.Hour)})
+			require.NoError(t, err)
+			assert.True(t, output.Locked)
+			assert.Empty(t, output.Failed)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Tasks within their timeout are kept", func(t *testing.T) {
+		output, err := reaper.Execute(ctx, taskUsecase.ReapStaleTasksInput{Now: clock.Advance(time.Minute)})
+		require.NoError(t, err)
+		assert.NotContains(t, reapedIDs(output), supervised.ID)
+	})
+
+	t.Run("Stale tasks are failed by the system", func(t *testing.T) {
+		reapedAt := clock.Advance(time.Second)
+		output, err := reaper.Execute(ctx, taskUsecase.ReapStaleTasksInput{Now: reapedAt})
+		require.NoError(t, err)
+		assert.Contains(t, reapedIDs(output), supervised.ID)
+		assert.NotContains(t, reapedIDs(output), unsupervised.ID)
+
+		task := getTask(t, supervised.ID)
+		assert.Equal(t, "FAILED", task.State)
+		require.NotNil(t, task.UpdatedBy)
+		assert.Equal(t, entity.SystemActor, *task.UpdatedBy)
+		require.NotNil(t, task.EndDate)
+		assert.True(t, reapedAt.Equal(*task.EndDate))
+		assert.Equal(t, "FAILED", task.Subtasks[0].State)
+		assert.Equal(t, "IN_PROGRESS", getTask(t, unsupervised.ID).State)
+
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+supervised.ID+"/history", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var history httpHandler.TaskHistoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		last := history.Events[len(history.Events)-1]
+		assert.Equal(t, entity.SystemActor, last.Actor)
+		assert.True(t, reapedAt.Equal(last.OccurredAt))
+	})
+
+	t.Run("Failed tasks no longer accept heartbeats", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion/"+supervised.ID+"/heartbeat", nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Invalid timeouts are rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":                unsupervised.ID,
+			"heartbeat_timeout": -5,
+			"updated_by":        "team-etl",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
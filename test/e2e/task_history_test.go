@@ -0,0 +1,185 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	// Crear tarea con una subtarea
+	createW := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name":       "History Task",
+		"created_by": "team-a",
+		"subtasks":   []map[string]interface{}{{"name": "Step 1"}},
+	})
+	require.Equal(t, http.StatusCreated, createW.Code)
+
+	var createdTask map[string]interface{}
+	require.NoError(t, json.Unmarshal(createW.Body.Bytes(), &createdTask))
+	taskID := createdTask["id"].(string)
+	subtaskID := createdTask["subtasks"].([]interface{})[0].(map[string]interface{})["id"].(string)
+
+	// Cambios realizados por distintos actores
+	w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+		"id":         taskID,
+		"name":       "History Task Renamed",
+		"state":      "IN_PROGRESS",
+		"updated_by": "team-b",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+		"state":      "IN_PROGRESS",
+		"updated_by": "team-c",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+		"state":      "COMPLETED",
+		"updated_by": "team-c",
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	t.Run("Timeline records every change in order", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID+"/history", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var history struct {
+			Events []struct {
+				Type     string  `json:"type"`
+				Actor    string  `json:"actor"`
+				OldValue *string `json:"old_value"`
+				NewValue *string `json:"new_value"`
+			} `json:"events"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+
+		types := make([]string, 0, len(history.Events))
+		for _, e := range history.Events {
+			types = append(types, e.Type)
+		}
+		assert.Equal(t, []string{
+			"TASK_CREATED",
+			"SUBTASK_ADDED",
+			"TASK_RENAMED",
+			"TASK_STATE_CHANGED",
+			"SUBTASK_STATE_CHANGED",
+			"SUBTASK_STATE_CHANGED",
+			"TASK_STATE_CHANGED",
+		}, types)
+
+		// El cambio a IN_PROGRESS lo hizo team-b
+		assert.Equal(t, "team-b", history.Events[3].Actor)
+		assert.Equal(t, "PENDING", *history.Events[3].OldValue)
+		assert.Equal(t, "IN_PROGRESS", *history.Events[3].NewValue)
+
+		// La autocompletación de la tarea se atribuye a quien completó la última subtarea
+		assert.Equal(t, "team-c", history.Events[6].Actor)
+		assert.Equal(t, "COMPLETED", *history.Events[6].NewValue)
+	})
+
+	t.Run("Timeline is paginated", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID+"/history?page=2&limit=5", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var history map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		assert.Len(t, history["events"], 2)
+
+		pagination := history["pagination"].(map[string]interface{})
+		assert.Equal(t, float64(7), pagination["total"])
+		assert.Equal(t, float64(2), pagination["total_pages"])
+	})
+
+	t.Run("Deleting a branch records every removed subtask", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":       "Branch History Task",
+			"created_by": "team-a",
+			"subtasks": []map[string]interface{}{
+				{"name": "Report"},
+				{"name": "Load", "subtasks": []map[string]interface{}{
+					{"name": "Load A", "subtasks": []map[string]interface{}{{"name": "Load A1"}}},
+					{"name": "Load B"},
+				}},
+			},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+
+		// Indexar las subtareas del árbol por nombre
+		ids := make(map[string]string)
+		var index func(subtasks []httpHandler.SubtaskResponse)
+		index = func(subtasks []httpHandler.SubtaskResponse) {
+			for _, subtask := range subtasks {
+				ids[subtask.Name] = subtask.ID
+				index(subtask.Subtasks)
+			}
+		}
+		index(task.Subtasks)
+		require.Len(t, ids, 5)
+
+		w = doJSON(router, http.MethodDelete, "/Subtask/"+ids["Load"], map[string]interface{}{
+			"deleted_by": "team-b",
+		})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodGet, "/Automatizacion/"+task.ID+"/history", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var history httpHandler.TaskHistoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+
+		removed := make(map[string]string)
+		var removedOrder []string
+		for _, e := range history.Events {
+			if e.Type != "SUBTASK_REMOVED" {
+				continue
+			}
+			require.NotNil(t, e.SubtaskID)
+			require.NotNil(t, e.OldValue)
+			assert.Equal(t, "team-b", e.Actor)
+			assert.Nil(t, e.NewValue)
+			removed[*e.OldValue] = *e.SubtaskID
+			removedOrder = append(removedOrder, *e.OldValue)
+		}
+
+		// Un evento por cada subtarea de la rama, empezando por la eliminada; Report no se toca
+		assert.Equal(t, map[string]string{
+			"Load":    ids["Load"],
+			"Load A":  ids["Load A"],
+			"Load B":  ids["Load B"],
+			"Load A1": ids["Load A1"],
+		}, removed)
+		require.Len(t, removedOrder, 4)
+		assert.Equal(t, "Load", removedOrder[0])
+		assert.Equal(t, "Load A1", removedOrder[3])
+	})
+}
@@ -0,0 +1,177 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+	"github.com/grupoapi/proces-log/test/helpers"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskIdempotency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router con un reloj fijo para controlar la caducidad de las claves
+	clock := helpers.NewTestClock()
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()),
+		httpHandler.WithClock(clock))
+
+	// createWithKey ejecuta POST /Automatizacion con la cabecera Idempotency-Key
+	createWithKey := func(key string, body map[string]interface{}) *httptest.ResponseRecorder {
+		bodyBytes, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/Automatizacion", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	decodeTask := func(t *testing.T, w *httptest.ResponseRecorder) httpHandler.TaskResponse {
+		t.Helper()
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	countTasks := func(t *testing.T, name string) int {
+		t.Helper()
+		var count int
+		require.NoError(t, pg.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM tasks WHERE name = $1`, name).Scan(&count))
+		return count
+	}
+
+	t.Run("Replay returns the original response", func(t *testing.T) {
+		body := map[string]interface{}{
+			"name":       "Idempotent Import",
+			"created_by": "team-import",
+			"subtasks":   []map[string]interface{}{{"name": "Download"}},
+		}
+
+		first := createWithKey("import-2026-10-17", body)
+		require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+		assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+		replay := createWithKey("import-2026-10-17", body)
+		require.Equal(t, http.StatusCreated, replay.Code, replay.Body.String())
+		assert.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, first.Header().Get("ETag"), replay.Header().Get("ETag"))
+		assert.JSONEq(t, first.Body.String(), replay.Body.String())
+
+		assert.Equal(t, 1, countTasks(t, "Idempotent Import"))
+	})
+
+	t.Run("Same key with a different body is rejected", func(t *testing.T) {
+		first := createWithKey("export-2026-10-17", map[string]interface{}{
+			"name":       "Idempotent Export",
+			"created_by": "team-export",
+		})
+		require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+
+		w := createWithKey("export-2026-10-17", map[string]interface{}{
+			"name":       "Idempotent Export (edited)",
+			"created_by": "team-export",
+		})
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+
+		var problem map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "https://api.grupoapi.com/problems/idempotency-key-reused", problem["type"])
+
+		assert.Equal(t, 0, countTasks(t, "Idempotent Export (edited)"))
+	})
+
+	t.Run("Key can be reused once it expires", func(t *testing.T) {
+		body := map[string]interface{}{
+			"name":       "Idempotent Sync",
+			"created_by": "team-sync",
+		}
+
+		first := createWithKey("sync-daily", body)
+		require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+		original := decodeTask(t, first)
+
+		// Al final de su vigencia la clave sigue devolviendo la respuesta original
+		clock.Advance(taskUsecase.DefaultIdempotencyTTL - time.Second)
+		w := createWithKey("sync-daily", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, original.ID, decodeTask(t, w).ID)
+
+		// Caducada, la misma clave crea una tarea nueva
+		reusedAt := clock.Advance(time.Second)
+		w = createWithKey("sync-daily", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+		renewed := decodeTask(t, w)
+		assert.NotEqual(t, original.ID, renewed.ID)
+		assert.True(t, reusedAt.Equal(renewed.CreatedAt), "created_at: want %s, got %s", reusedAt, renewed.CreatedAt)
+
+		// Y la nueva creación queda protegida por la clave durante otra vigencia completa
+		w = createWithKey("sync-daily", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, renewed.ID, decodeTask(t, w).ID)
+
+		assert.Equal(t, 2, countTasks(t, "Idempotent Sync"))
+	})
+
+	t.Run("Concurrent requests with the same key create one task", func(t *testing.T) {
+		const requests = 10
+		body := map[string]interface{}{
+			"name":       "Idempotent Concurrent",
+			"created_by": "team-concurrent",
+			"subtasks":   []map[string]interface{}{{"name": "Step"}},
+		}
+
+		var wg sync.WaitGroup
+		responses := make([]*httptest.ResponseRecorder, requests)
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				responses[i] = createWithKey("concurrent-run", body)
+			}(i)
+		}
+		wg.Wait()
+
+		ids := make(map[string]bool)
+		replayed := 0
+		for _, w := range responses {
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			ids[decodeTask(t, w).ID] = true
+			if w.Header().Get("Idempotent-Replayed") == "true" {
+				replayed++
+			}
+		}
+
+		assert.Len(t, ids, 1, "every request must return the same task")
+		assert.Equal(t, requests-1, replayed, "only the first request creates the task")
+		assert.Equal(t, 1, countTasks(t, "Idempotent Concurrent"))
+	})
+}
@@ -0,0 +1,126 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskLabelsAndMetadata(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	createTask := func(t *testing.T, body map[string]interface{}) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	listIDs := func(t *testing.T, selector string) []string {
+		w := doJSON(router, http.MethodGet, "/AutomatizacionListado?labels="+url.QueryEscape(selector), nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var list httpHandler.TaskListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+
+		ids := make([]string, 0, len(list.Tasks))
+		for _, task := range list.Tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	billing := createTask(t, map[string]interface{}{
+		"name":       "Billing Import",
+		"created_by": "team-etl",
+		"labels":     map[string]string{"env": "prod", "team": "finance"},
+		"metadata":   map[string]interface{}{"host": "etl-01", "source": map[string]interface{}{"bucket": "invoices"}},
+	})
+	staging := createTask(t, map[string]interface{}{
+		"name":       "Billing Import Staging",
+		"created_by": "team-etl",
+		"labels":     map[string]string{"env": "staging", "team": "finance"},
+	})
+	unlabeled := createTask(t, map[string]interface{}{
+		"name":       "Unlabeled Import",
+		"created_by": "team-etl",
+	})
+
+	t.Run("Labels and metadata are returned", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+billing.ID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, map[string]string{"env": "prod", "team": "finance"}, task.Labels)
+		assert.Equal(t, "etl-01", task.Metadata["host"])
+		assert.Equal(t, map[string]interface{}{"bucket": "invoices"}, task.Metadata["source"])
+		assert.Empty(t, unlabeled.Labels)
+	})
+
+	t.Run("List filters by label selector", func(t *testing.T) {
+		ids := listIDs(t, "team=finance")
+		assert.ElementsMatch(t, []string{billing.ID, staging.ID}, ids)
+
+		ids = listIDs(t, "env=prod,team=finance")
+		assert.Equal(t, []string{billing.ID}, ids)
+
+		assert.Empty(t, listIDs(t, "env=dev"))
+
+		w := doJSON(router, http.MethodGet, "/AutomatizacionListado?labels=env", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Update replaces labels and metadata", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         staging.ID,
+			"labels":     map[string]string{"env": "prod"},
+			"metadata":   map[string]interface{}{},
+			"updated_by": "team-etl",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, map[string]string{"env": "prod"}, task.Labels)
+		assert.Empty(t, task.Metadata)
+
+		assert.ElementsMatch(t, []string{billing.ID, staging.ID}, listIDs(t, "env=prod"))
+		assert.Equal(t, []string{billing.ID}, listIDs(t, "team=finance"))
+	})
+
+	t.Run("Invalid labels are rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":       "Bad Labels",
+			"created_by": "team-etl",
+			"labels":     map[string]string{"Env": "prod"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
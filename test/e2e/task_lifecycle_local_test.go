@@ -17,6 +17,7 @@ import (
 
 	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
 	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/service"
 	"github.com/grupoapi/proces-log/test/integration"
 )
 
@@ -42,12 +43,11 @@ func TestE2E_TaskCompleteLifecycle_Local(t *testing.T) {
 	pg := integration.SetupPostgresLocal(ctx, t)
 	defer pg.Teardown(ctx, t)
 
-	// Create tables
-	pg.CreateTasksTable(ctx, t)
-	pg.CreateSubtasksTable(ctx, t)
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
 
 	// Setup router completo con todos los handlers
-	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode)
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
 
 	t.Run("Health Check Works", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/health", nil)
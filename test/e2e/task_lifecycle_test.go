@@ -14,6 +14,7 @@ import (
 
 	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
 	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/service"
 	"github.com/grupoapi/proces-log/test/integration"
 )
 
@@ -28,12 +29,11 @@ func TestE2E_TaskCompleteLifecycle(t *testing.T) {
 	pg := integration.SetupPostgresContainer(ctx, t)
 	defer pg.Teardown(ctx, t)
 
-	// Create tables
-	pg.CreateTasksTable(ctx, t)
-	pg.CreateSubtasksTable(ctx, t)
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
 
 	// Setup router completo con todos los handlers
-	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode)
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
 
 	t.Run("Health Check Works", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/health", nil)
@@ -183,12 +183,11 @@ func TestE2E_TaskStateTransitions(t *testing.T) {
 	pg := integration.SetupPostgresContainer(ctx, t)
 	defer pg.Teardown(ctx, t)
 
-	// Create tables
-	pg.CreateTasksTable(ctx, t)
-	pg.CreateSubtasksTable(ctx, t)
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
 
 	// Setup router
-	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode)
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
 
 	// Crear tarea vía API
 	createBody := map[string]interface{}{
@@ -270,12 +269,11 @@ func TestE2E_TaskWithSubtasks(t *testing.T) {
 	pg := integration.SetupPostgresContainer(ctx, t)
 	defer pg.Teardown(ctx, t)
 
-	// Create tables
-	pg.CreateTasksTable(ctx, t)
-	pg.CreateSubtasksTable(ctx, t)
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
 
 	// Setup router
-	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode)
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
 
 	// Crear tarea con subtareas vía API
 	createBody := map[string]interface{}{
@@ -382,12 +380,11 @@ func TestE2E_Pagination(t *testing.T) {
 	pg := integration.SetupPostgresContainer(ctx, t)
 	defer pg.Teardown(ctx, t)
 
-	// Create tables
-	pg.CreateTasksTable(ctx, t)
-	pg.CreateSubtasksTable(ctx, t)
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
 
 	// Setup router
-	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode)
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
 
 	// Crear múltiples tareas vía API
 	for i := 0; i < 25; i++ {
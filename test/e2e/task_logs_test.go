@@ -0,0 +1,165 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/adapter/repository/postgres"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	createTask := func(t *testing.T, body map[string]interface{}) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	listLogs := func(t *testing.T, taskID string, query url.Values) httpHandler.TaskLogsResponse {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID+"/logs?"+query.Encode(), nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var logs httpHandler.TaskLogsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &logs))
+		return logs
+	}
+
+	messages := func(logs httpHandler.TaskLogsResponse) []string {
+		result := make([]string, 0, len(logs.Lines))
+		for _, line := range logs.Lines {
+			result = append(result, line.Message)
+		}
+		return result
+	}
+
+	task := createTask(t, map[string]interface{}{
+		"name":       "Nightly Export",
+		"created_by": "team-etl",
+		"state":      "IN_PROGRESS",
+		"subtasks":   []map[string]interface{}{{"name": "Extract", "state": "IN_PROGRESS"}},
+	})
+	subtaskID := task.Subtasks[0].ID
+
+	t.Run("Append a batch of log lines", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion/"+task.ID+"/logs", map[string]interface{}{
+			"lines": []map[string]interface{}{
+				{"timestamp": "2026-03-01T08:00:00Z", "level": "INFO", "message": "export started"},
+				{"timestamp": "2026-03-01T08:01:00Z", "level": "debug", "message": "fetched page 1", "subtask_id": subtaskID},
+				{"timestamp": "2026-03-01T08:02:00Z", "level": "WARN", "message": "slow response", "subtask_id": subtaskID},
+				{"timestamp": "2026-03-01T08:03:00Z", "level": "ERROR", "message": "row 42 rejected", "subtask_id": subtaskID},
+				{"timestamp": "2026-03-01T08:04:00Z", "level": "INFO", "message": "export finished"},
+			},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response httpHandler.AppendTaskLogsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 5, response.Accepted)
+		assert.Equal(t, 5, response.TotalLines)
+	})
+
+	t.Run("List returns the lines in chronological order", func(t *testing.T) {
+		logs := listLogs(t, task.ID, url.Values{})
+		assert.Equal(t, []string{
+			"export started", "fetched page 1", "slow response", "row 42 rejected", "export finished",
+		}, messages(logs))
+		assert.Nil(t, logs.NextCursor)
+		require.NotNil(t, logs.Lines[1].SubtaskID)
+		assert.Equal(t, subtaskID, *logs.Lines[1].SubtaskID)
+	})
+
+	t.Run("List filters by level, subtask and time range", func(t *testing.T) {
+		assert.Equal(t, []string{"slow response", "row 42 rejected"},
+			messages(listLogs(t, task.ID, url.Values{"level": {"WARN"}})))
+		assert.Equal(t, []string{"fetched page 1", "slow response", "row 42 rejected"},
+			messages(listLogs(t, task.ID, url.Values{"subtask_id": {subtaskID}})))
+		assert.Equal(t, []string{"fetched page 1", "slow response"},
+			messages(listLogs(t, task.ID, url.Values{"since": {"2026-03-01T08:01:00Z"}, "until": {"2026-03-01T08:03:00Z"}})))
+	})
+
+	t.Run("Cursor pagination walks every line once", func(t *testing.T) {
+		var collected []string
+		query := url.Values{"limit": {"2"}}
+		for pages := 0; pages < 5; pages++ {
+			logs := listLogs(t, task.ID, query)
+			collected = append(collected, messages(logs)...)
+			if logs.NextCursor == nil {
+				break
+			}
+			query.Set("cursor", *logs.NextCursor)
+		}
+		assert.Equal(t, []string{
+			"export started", "fetched page 1", "slow response", "row 42 rejected", "export finished",
+		}, collected)
+	})
+
+	t.Run("Lines of a subtask from another task are rejected", func(t *testing.T) {
+		other := createTask(t, map[string]interface{}{
+			"name":       "Other Export",
+			"created_by": "team-etl",
+			"subtasks":   []map[string]interface{}{{"name": "Extract"}},
+		})
+
+		w := doJSON(router, http.MethodPost, "/Automatizacion/"+task.ID+"/logs", map[string]interface{}{
+			"lines": []map[string]interface{}{
+				{"level": "INFO", "message": "hello", "subtask_id": other.Subtasks[0].ID},
+			},
+		})
+		require.Equal(t, http.StatusBadRequest, w.Code)
+
+		var problem httpHandler.ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "https://api.grupoapi.com/problems/invalid-log-line", problem.Type)
+	})
+
+	t.Run("Logs of an unknown task return 404", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/00000000-0000-0000-0000-000000000000/logs", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Hard delete purges the task logs", func(t *testing.T) {
+		w := doJSON(router, http.MethodDelete, "/Automatizacion/"+task.ID, map[string]interface{}{
+			"deleted_by": "team-etl",
+		})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		// Purgar como si hubiera pasado la ventana de retención de 30 días
+		deleted, err := postgres.NewTaskRepository(pg.Pool).HardDelete(ctx, time.Now().Add(31*24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		var remaining int
+		err = pg.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM task_logs WHERE task_id = $1", task.ID).Scan(&remaining)
+		require.NoError(t, err)
+		assert.Zero(t, remaining)
+	})
+}
@@ -0,0 +1,97 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskPauseResume(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	createW := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name":       "Nightly Batch",
+		"created_by": "team-etl",
+		"subtasks":   []map[string]interface{}{{"name": "Extract"}},
+	})
+	require.Equal(t, http.StatusCreated, createW.Code)
+
+	var createdTask map[string]interface{}
+	require.NoError(t, json.Unmarshal(createW.Body.Bytes(), &createdTask))
+	taskID := createdTask["id"].(string)
+	subtaskID := createdTask["subtasks"].([]interface{})[0].(map[string]interface{})["id"].(string)
+
+	updateTaskState := func(t *testing.T, state string) map[string]interface{} {
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         taskID,
+			"state":      state,
+			"updated_by": "team-etl",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var task map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	updateTaskState(t, "IN_PROGRESS")
+
+	t.Run("Pause a running task", func(t *testing.T) {
+		task := updateTaskState(t, "PAUSED")
+
+		assert.Equal(t, "PAUSED", task["state"])
+		assert.NotNil(t, task["paused_at"])
+		assert.NotNil(t, task["duration_seconds"])
+	})
+
+	t.Run("Subtask cannot start while parent is paused", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+			"state":      "IN_PROGRESS",
+			"updated_by": "team-etl",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Paused task cannot complete directly", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         taskID,
+			"state":      "COMPLETED",
+			"updated_by": "team-etl",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Resume and start subtasks again", func(t *testing.T) {
+		task := updateTaskState(t, "IN_PROGRESS")
+		assert.Nil(t, task["paused_at"])
+
+		w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+			"state":      "IN_PROGRESS",
+			"updated_by": "team-etl",
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
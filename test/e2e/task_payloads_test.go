@@ -0,0 +1,184 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskPayloads(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router con un límite pequeño para probar el tamaño máximo
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()),
+		httpHandler.WithMaxPayloadBytes(1024))
+
+	getTask := func(t *testing.T, taskID string) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	listTasks := func(t *testing.T, query string) httpHandler.TaskListResponse {
+		w := doJSON(router, http.MethodGet, "/AutomatizacionListado?name=Monthly%20Export"+query, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var list httpHandler.TaskListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		return list
+	}
+
+	assertInvalidPayload := func(t *testing.T, w *httptest.ResponseRecorder) {
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		var problem httpHandler.ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "https://api.grupoapi.com/problems/invalid-payload", problem.Type)
+	}
+
+	w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name":       "Monthly Export",
+		"created_by": "team-etl",
+		"state":      "IN_PROGRESS",
+		"input":      map[string]interface{}{"from": "2026-03-01", "to": "2026-03-31"},
+		"subtasks": []map[string]interface{}{
+			{"name": "Extract", "state": "IN_PROGRESS", "input": map[string]interface{}{"file": "march.csv"}},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created httpHandler.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	subtaskID := created.Subtasks[0].ID
+
+	t.Run("Get returns the input set at creation", func(t *testing.T) {
+		task := getTask(t, created.ID)
+		assert.JSONEq(t, `{"from":"2026-03-01","to":"2026-03-31"}`, string(task.Input))
+		assert.Nil(t, task.Output)
+		assert.JSONEq(t, `{"file":"march.csv"}`, string(task.Subtasks[0].Input))
+	})
+
+	t.Run("Output on a non-final transition is rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+			"state":      "PAUSED",
+			"updated_by": "team-etl",
+			"output":     map[string]interface{}{"rows": 10},
+		})
+		assertInvalidPayload(t, w)
+	})
+
+	t.Run("Output over the size cap is rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+			"state":      "COMPLETED",
+			"updated_by": "team-etl",
+			"output":     map[string]interface{}{"blob": strings.Repeat("x", 2048)},
+		})
+		assertInvalidPayload(t, w)
+	})
+
+	t.Run("Output is accepted with a final state", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+			"state":      "COMPLETED",
+			"updated_by": "team-etl",
+			"output":     map[string]interface{}{"rows": 1200},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         created.ID,
+			"state":      "COMPLETED",
+			"updated_by": "team-etl",
+			"output":     map[string]interface{}{"rows": 1200, "path": "s3://exports/march.csv"},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		task := getTask(t, created.ID)
+		assert.JSONEq(t, `{"rows":1200,"path":"s3://exports/march.csv"}`, string(task.Output))
+		assert.JSONEq(t, `{"rows":1200}`, string(task.Subtasks[0].Output))
+		assert.JSONEq(t, `{"file":"march.csv"}`, string(task.Subtasks[0].Input))
+	})
+
+	t.Run("List omits payloads unless requested", func(t *testing.T) {
+		list := listTasks(t, "")
+		require.Len(t, list.Tasks, 1)
+		assert.Nil(t, list.Tasks[0].Input)
+		assert.Nil(t, list.Tasks[0].Output)
+		assert.Nil(t, list.Tasks[0].Subtasks[0].Output)
+
+		list = listTasks(t, "&include_payloads=true")
+		require.Len(t, list.Tasks, 1)
+		assert.JSONEq(t, `{"from":"2026-03-01","to":"2026-03-31"}`, string(list.Tasks[0].Input))
+		assert.JSONEq(t, `{"rows":1200}`, string(list.Tasks[0].Subtasks[0].Output))
+	})
+
+	t.Run("Retry keeps the output on the closed attempt", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":       "Weekly Export",
+			"created_by": "team-etl",
+			"state":      "IN_PROGRESS",
+			"subtasks":   []map[string]interface{}{{"name": "Extract", "state": "IN_PROGRESS"}},
+		})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var failed httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &failed))
+
+		w = doJSON(router, http.MethodPut, "/Subtask/"+failed.Subtasks[0].ID, map[string]interface{}{
+			"state":      "FAILED",
+			"updated_by": "team-etl",
+			"output":     map[string]interface{}{"rows": 10},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         failed.ID,
+			"state":      "FAILED",
+			"updated_by": "team-etl",
+			"output":     map[string]interface{}{"rows": 10, "rejected": 2},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodPost, "/Automatizacion/"+failed.ID+"/retry", map[string]interface{}{
+			"retried_by": "team-etl",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		task := getTask(t, failed.ID)
+		assert.Nil(t, task.Output)
+		assert.Nil(t, task.Subtasks[0].Output)
+
+		w = doJSON(router, http.MethodGet, "/Automatizacion/"+failed.ID+"/attempts", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var attempts httpHandler.TaskAttemptsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &attempts))
+		require.Len(t, attempts.Attempts, 2)
+		assert.JSONEq(t, `{"rows":10,"rejected":2}`, string(attempts.Attempts[0].Output))
+		require.Len(t, attempts.Attempts[0].Subtasks, 1)
+		assert.JSONEq(t, `{"rows":10}`, string(attempts.Attempts[0].Subtasks[0].Output))
+		assert.Nil(t, attempts.Attempts[1].Output)
+	})
+}
@@ -0,0 +1,148 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskProgress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	createTask := func(t *testing.T, body map[string]interface{}) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	getTask := func(t *testing.T, taskID string) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	listIDs := func(t *testing.T, query string) []string {
+		w := doJSON(router, http.MethodGet, "/AutomatizacionListado?"+query, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var list httpHandler.TaskListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+
+		ids := make([]string, 0, len(list.Tasks))
+		for _, task := range list.Tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	pipeline := createTask(t, map[string]interface{}{
+		"name":       "Progress Pipeline",
+		"created_by": "team-etl",
+		"state":      "IN_PROGRESS",
+		"subtasks":   []map[string]interface{}{{"name": "Extract"}, {"name": "Load"}},
+	})
+	reported := createTask(t, map[string]interface{}{
+		"name":       "Reported Import",
+		"created_by": "team-etl",
+		"progress":   75,
+	})
+	unknown := createTask(t, map[string]interface{}{
+		"name":       "Unknown Progress",
+		"created_by": "team-etl",
+	})
+
+	t.Run("Progress is derived from subtasks until reported", func(t *testing.T) {
+		require.NotNil(t, pipeline.Progress)
+		assert.Equal(t, 0, *pipeline.Progress)
+		require.NotNil(t, reported.Progress)
+		assert.Equal(t, 75, *reported.Progress)
+		assert.Nil(t, unknown.Progress)
+	})
+
+	t.Run("Subtasks report item counters", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Subtask/"+pipeline.Subtasks[0].ID, map[string]interface{}{
+			"items_processed": 40,
+			"items_total":     80,
+			"updated_by":      "extractor",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var subtask httpHandler.SubtaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &subtask))
+		require.NotNil(t, subtask.Progress)
+		assert.Equal(t, 50, *subtask.Progress)
+
+		// Los contadores no pueden superar el total
+		w = doJSON(router, http.MethodPut, "/Subtask/"+pipeline.Subtasks[0].ID, map[string]interface{}{
+			"items_processed": 81,
+			"updated_by":      "extractor",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Finished subtasks move the task progress", func(t *testing.T) {
+		for _, state := range []string{"IN_PROGRESS", "COMPLETED"} {
+			w := doJSON(router, http.MethodPut, "/Subtask/"+pipeline.Subtasks[0].ID, map[string]interface{}{
+				"state":      state,
+				"updated_by": "extractor",
+			})
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+
+		task := getTask(t, pipeline.ID)
+		require.NotNil(t, task.Progress)
+		assert.Equal(t, 50, *task.Progress)
+	})
+
+	t.Run("Out of range progress is rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         reported.ID,
+			"progress":   120,
+			"updated_by": "team-etl",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("List filters by progress threshold", func(t *testing.T) {
+		ids := listIDs(t, "min_progress=50")
+		assert.Contains(t, ids, pipeline.ID)
+		assert.Contains(t, ids, reported.ID)
+		assert.NotContains(t, ids, unknown.ID)
+
+		ids = listIDs(t, "min_progress=60")
+		assert.NotContains(t, ids, pipeline.ID)
+		assert.Contains(t, ids, reported.ID)
+
+		w := doJSON(router, http.MethodGet, "/AutomatizacionListado?min_progress=101", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,136 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskRetry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()))
+
+	createW := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name":       "Nightly Import",
+		"created_by": "team-etl",
+		"subtasks":   []map[string]interface{}{{"name": "Download"}},
+	})
+	require.Equal(t, http.StatusCreated, createW.Code)
+
+	var createdTask map[string]interface{}
+	require.NoError(t, json.Unmarshal(createW.Body.Bytes(), &createdTask))
+	taskID := createdTask["id"].(string)
+	assert.Equal(t, float64(1), createdTask["attempt"])
+
+	updateTaskState := func(t *testing.T, state string) {
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         taskID,
+			"state":      state,
+			"updated_by": "team-etl",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	t.Run("Only failed tasks can be retried", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion/"+taskID+"/retry", map[string]interface{}{
+			"retried_by": "team-etl",
+		})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	updateTaskState(t, "IN_PROGRESS")
+	updateTaskState(t, "FAILED")
+
+	t.Run("Retry opens a new attempt", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion/"+taskID+"/retry", map[string]interface{}{
+			"retried_by": "team-ops",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var task map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, "PENDING", task["state"])
+		assert.Equal(t, float64(2), task["attempt"])
+		assert.Nil(t, task["start_date"])
+		assert.Nil(t, task["end_date"])
+		subtask := task["subtasks"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "PENDING", subtask["state"])
+	})
+
+	updateTaskState(t, "IN_PROGRESS")
+
+	t.Run("Get shows the latest attempt and a summary", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var task map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, "IN_PROGRESS", task["state"])
+		assert.Equal(t, float64(2), task["attempt"])
+
+		attempts := task["attempts"].([]interface{})
+		require.Len(t, attempts, 2)
+		assert.Equal(t, "FAILED", attempts[0].(map[string]interface{})["state"])
+		assert.Equal(t, "IN_PROGRESS", attempts[1].(map[string]interface{})["state"])
+		assert.Equal(t, true, attempts[1].(map[string]interface{})["current"])
+	})
+
+	t.Run("Previous attempts stay queryable", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID+"/attempts", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response httpHandler.TaskAttemptsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Attempts, 2)
+
+		first := response.Attempts[0]
+		assert.Equal(t, 1, first.Attempt)
+		assert.Equal(t, "FAILED", first.State)
+		assert.NotNil(t, first.StartDate)
+		assert.NotNil(t, first.EndDate)
+		assert.Equal(t, "team-ops", *first.RetriedBy)
+		require.Len(t, first.Subtasks, 1)
+		assert.Equal(t, "FAILED", first.Subtasks[0].State)
+	})
+
+	t.Run("Retry is recorded in the history", func(t *testing.T) {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID+"/history?limit=100", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var history httpHandler.TaskHistoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+
+		var retried *httpHandler.TaskEventResponse
+		for i := range history.Events {
+			if history.Events[i].Type == "TASK_RETRIED" {
+				retried = &history.Events[i]
+			}
+		}
+		require.NotNil(t, retried)
+		assert.Equal(t, "team-ops", retried.Actor)
+		assert.Equal(t, "1", *retried.OldValue)
+		assert.Equal(t, "2", *retried.NewValue)
+	})
+}
@@ -0,0 +1,176 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/adapter/repository/postgres"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	taskUsecase "github.com/grupoapi/proces-log/internal/usecase/task"
+	"github.com/grupoapi/proces-log/test/helpers"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskSLA(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router y monitor de SLA sobre la misma base de datos, con un reloj que solo avanza cuando el test lo indica
+	// Las fechas límite del request tienen precisión de segundos
+	clock := helpers.NewFakeClock(time.Now().UTC().Truncate(time.Second))
+	router := httpHandler.SetupRouter(
+		pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()), httpHandler.WithClock(clock),
+	)
+	monitor := taskUsecase.NewRecordSLABreachesUseCase(
+		postgres.NewTaskRepository(pg.Pool),
+		postgres.NewTaskEventRepository(pg.Pool),
+		postgres.NewTransactionManager(pg.Pool),
+		postgres.NewAdvisoryLockManager(pg.Pool),
+	)
+
+	createTask := func(t *testing.T, body map[string]interface{}) httpHandler.TaskResponse {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", body)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task
+	}
+
+	listIDs := func(t *testing.T, query string) []string {
+		w := doJSON(router, http.MethodGet, "/AutomatizacionListado?"+query, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var list httpHandler.TaskListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+
+		ids := make([]string, 0, len(list.Tasks))
+		for _, task := range list.Tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	breachEvents := func(t *testing.T, taskID string) []httpHandler.TaskEventResponse {
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID+"/history", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var history httpHandler.TaskHistoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+
+		events := make([]httpHandler.TaskEventResponse, 0)
+		for _, event := range history.Events {
+			if event.Type == "TASK_SLA_BREACHED" {
+				events = append(events, event)
+			}
+		}
+		return events
+	}
+
+	late := createTask(t, map[string]interface{}{
+		"name":              "Late Import",
+		"created_by":        "team-etl",
+		"state":             "IN_PROGRESS",
+		"expected_duration": 1,
+	})
+	relaxed := createTask(t, map[string]interface{}{
+		"name":       "Relaxed Import",
+		"created_by": "team-etl",
+		"deadline":   clock.Now().Add(24 * time.Hour).Format(time.RFC3339),
+	})
+	unbounded := createTask(t, map[string]interface{}{
+		"name":       "Unbounded Import",
+		"created_by": "team-etl",
+	})
+
+	t.Run("Tasks report their SLA status", func(t *testing.T) {
+		require.NotNil(t, relaxed.SLAStatus)
+		assert.Equal(t, "on_track", *relaxed.SLAStatus)
+		require.NotNil(t, relaxed.SLADueAt)
+		assert.Nil(t, unbounded.SLAStatus)
+		assert.True(t, clock.Now().Add(24*time.Hour).Equal(*relaxed.SLADueAt))
+		assert.Nil(t, unbounded.SLADueAt)
+	})
+
+	t.Run("Invalid SLA is rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":       "Past Deadline",
+			"created_by": "team-etl",
+			"deadline":   clock.Now().Add(-time.Hour).Format(time.RFC3339),
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = doJSON(router, http.MethodGet, "/AutomatizacionListado?sla_status=late", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("List filters by SLA status", func(t *testing.T) {
+		assert.Empty(t, listIDs(t, "sla_status=breached"))
+
+		clock.Advance(2 * time.Second)
+		assert.Equal(t, []string{late.ID}, listIDs(t, "sla_status=breached"))
+
+		ids := listIDs(t, "sla_status=on_track")
+		assert.Contains(t, ids, relaxed.ID)
+		assert.NotContains(t, ids, unbounded.ID)
+	})
+
+	t.Run("Monitor records the breach once", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := monitor.Execute(ctx, taskUsecase.RecordSLABreachesInput{Now: clock.Now()})
+			require.NoError(t, err)
+		}
+
+		events := breachEvents(t, late.ID)
+		require.Len(t, events, 1)
+		assert.Equal(t, "system", events[0].Actor)
+		// El incumplimiento se fecha en el vencimiento del plazo, no en la pasada que lo detecta
+		require.NotNil(t, late.StartDate)
+		assert.True(t, late.StartDate.Add(time.Second).Equal(events[0].OccurredAt))
+		assert.Empty(t, breachEvents(t, relaxed.ID))
+	})
+
+	t.Run("Finishing late records the breach with the update", func(t *testing.T) {
+		short := createTask(t, map[string]interface{}{
+			"name":              "Short Import",
+			"created_by":        "team-etl",
+			"state":             "IN_PROGRESS",
+			"expected_duration": 1,
+		})
+		clock.Advance(2 * time.Second)
+
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         short.ID,
+			"state":      "COMPLETED",
+			"updated_by": "team-etl",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var task httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		require.NotNil(t, task.SLAStatus)
+		assert.Equal(t, "breached", *task.SLAStatus)
+		require.NotNil(t, task.SLABreachedAt)
+		require.NotNil(t, short.StartDate)
+		assert.True(t, short.StartDate.Add(time.Second).Equal(*task.SLABreachedAt))
+		assert.Len(t, breachEvents(t, short.ID), 1)
+	})
+}
@@ -0,0 +1,151 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/adapter/repository/postgres"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/helpers"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_TaskTimestampsFollowClock(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Setup router con un reloj fijo: todas las fechas persistidas deben salir de él, no de la base de datos
+	clock := helpers.NewTestClock()
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, service.NewWorkflowRegistry(service.NewStateMachine()),
+		httpHandler.WithClock(clock))
+
+	assertTime := func(t *testing.T, want, got time.Time, field string) {
+		t.Helper()
+		assert.True(t, want.Equal(got), "%s: want %s, got %s", field, want, got)
+	}
+
+	// deletedAt lee las fechas de eliminación y modificación de la tarea y de su subtarea directamente de la base de datos
+	deletedAt := func(t *testing.T, taskID string) (taskDeleted, taskUpdated, subtaskDeleted, subtaskUpdated *time.Time) {
+		t.Helper()
+		err := pg.Pool.QueryRow(ctx, `
+			SELECT t.deleted_at, t.updated_at, s.deleted_at, s.updated_at
+			FROM tasks t JOIN subtasks s ON s.task_id = t.id
+			WHERE t.id = $1
+		`, taskID).Scan(&taskDeleted, &taskUpdated, &subtaskDeleted, &subtaskUpdated)
+		require.NoError(t, err)
+		return taskDeleted, taskUpdated, subtaskDeleted, subtaskUpdated
+	}
+
+	created := clock.Now()
+	w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+		"name":       "Clocked Export",
+		"created_by": "team-etl",
+		"subtasks":   []map[string]interface{}{{"name": "Extract"}},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var task httpHandler.TaskResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+
+	t.Run("Create stores the clock time", func(t *testing.T) {
+		assertTime(t, created, task.CreatedAt, "created_at")
+		assertTime(t, created, task.UpdatedAt, "updated_at")
+		assertTime(t, created, task.Subtasks[0].UpdatedAt, "subtask updated_at")
+	})
+
+	t.Run("Update stores the clock time", func(t *testing.T) {
+		renamed := clock.Advance(time.Minute)
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         task.ID,
+			"name":       "Clocked Export v2",
+			"updated_by": "team-etl",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var updated httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		assertTime(t, renamed, updated.UpdatedAt, "updated_at")
+		assertTime(t, created, updated.Subtasks[0].UpdatedAt, "unchanged subtask updated_at")
+	})
+
+	t.Run("Delete and restore store the clock time", func(t *testing.T) {
+		deleted := clock.Advance(time.Hour)
+		w := doJSON(router, http.MethodDelete, "/Automatizacion/"+task.ID, map[string]interface{}{
+			"deleted_by": "team-etl",
+		})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		taskDeleted, taskUpdated, subtaskDeleted, subtaskUpdated := deletedAt(t, task.ID)
+		require.NotNil(t, taskDeleted)
+		require.NotNil(t, subtaskDeleted)
+		assertTime(t, deleted, *taskDeleted, "deleted_at")
+		assertTime(t, deleted, *taskUpdated, "updated_at")
+		assertTime(t, deleted, *subtaskDeleted, "subtask deleted_at")
+		assertTime(t, deleted, *subtaskUpdated, "subtask updated_at")
+
+		// La ventana de retención se evalúa con el reloj de la aplicación
+		restored := clock.Advance(29 * 24 * time.Hour)
+		w = doJSON(router, http.MethodPost, "/Automatizacion/"+task.ID+"/restore", map[string]interface{}{
+			"restored_by": "team-etl",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var restoredTask httpHandler.TaskResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &restoredTask))
+		assert.Nil(t, restoredTask.DeletedAt)
+		assertTime(t, restored, restoredTask.UpdatedAt, "updated_at")
+		require.Len(t, restoredTask.Subtasks, 1)
+		assertTime(t, restored, restoredTask.Subtasks[0].UpdatedAt, "subtask updated_at")
+
+		w = doJSON(router, http.MethodGet, "/Automatizacion/"+task.ID+"/history", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var history httpHandler.TaskHistoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		occurredAt := make(map[string]time.Time, len(history.Events))
+		for _, event := range history.Events {
+			occurredAt[event.Type] = event.OccurredAt
+		}
+		assertTime(t, deleted, occurredAt["TASK_DELETED"], "TASK_DELETED occurred_at")
+		assertTime(t, restored, occurredAt["TASK_RESTORED"], "TASK_RESTORED occurred_at")
+	})
+
+	t.Run("Retention window and purge follow the clock", func(t *testing.T) {
+		w := doJSON(router, http.MethodDelete, "/Automatizacion/"+task.ID, map[string]interface{}{
+			"deleted_by": "team-etl",
+		})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		repo := postgres.NewTaskRepository(pg.Pool)
+		deleted, err := repo.HardDelete(ctx, clock.Advance(30*24*time.Hour-time.Second))
+		require.NoError(t, err)
+		assert.Zero(t, deleted, "still inside the retention window")
+
+		clock.Advance(2 * time.Second)
+		w = doJSON(router, http.MethodPost, "/Automatizacion/"+task.ID+"/restore", map[string]interface{}{
+			"restored_by": "team-etl",
+		})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+		deleted, err = repo.HardDelete(ctx, clock.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+	})
+}
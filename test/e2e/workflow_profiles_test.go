@@ -0,0 +1,202 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpHandler "github.com/grupoapi/proces-log/internal/adapter/handler/http"
+	"github.com/grupoapi/proces-log/internal/domain/entity"
+	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/integration"
+)
+
+func TestE2E_WorkflowProfiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Setup PostgreSQL container
+	pg := integration.SetupPostgresContainer(ctx, t)
+	defer pg.Teardown(ctx, t)
+
+	// Create schema
+	pg.ApplyMigrations(ctx, t)
+
+	// Perfil "batch-etl": permite rechazar un trabajo antes de empezar (PENDING -> FAILED)
+	def := service.DefaultStateMachineDefinition()
+	def.States[entity.StatePending] = service.StateDefinition{Transitions: []entity.State{
+		entity.StateInProgress, entity.StateCancelled, entity.StateFailed,
+	}}
+	batchETL, err := service.NewStateMachineFromDefinition(def)
+	require.NoError(t, err)
+
+	// Perfil "rpa" tal y como se distribuye: los robots pasan a RETRYING mientras repiten un paso
+	rpaDef, err := service.LoadStateMachineDefinition(filepath.Join("..", "..", "configs", "workflows", "rpa.yaml"))
+	require.NoError(t, err)
+	rpa, err := service.NewStateMachineFromDefinition(rpaDef)
+	require.NoError(t, err)
+
+	workflows := service.NewWorkflowRegistry(service.NewStateMachine())
+	require.NoError(t, workflows.Register("batch-etl", batchETL))
+	require.NoError(t, workflows.Register("rpa", rpa))
+
+	// Setup router
+	router := httpHandler.SetupRouter(pg.Pool, gin.TestMode, workflows)
+
+	createTask := func(t *testing.T, workflow string) string {
+		body := map[string]interface{}{
+			"name":       "Workflow Task",
+			"created_by": "team-etl",
+			"subtasks":   []map[string]interface{}{{"name": "Extract"}},
+		}
+		if workflow != "" {
+			body["workflow"] = workflow
+		}
+
+		w := doJSON(router, http.MethodPost, "/Automatizacion", body)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var task map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		return task["id"].(string)
+	}
+
+	t.Run("Profile is stored on the task", func(t *testing.T) {
+		taskID := createTask(t, "batch-etl")
+
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var task map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+		assert.Equal(t, "batch-etl", task["workflow"])
+	})
+
+	t.Run("Profile transitions apply to the task", func(t *testing.T) {
+		taskID := createTask(t, "batch-etl")
+
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         taskID,
+			"state":      "FAILED",
+			"updated_by": "team-etl",
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Default workflow keeps the default transitions", func(t *testing.T) {
+		taskID := createTask(t, "")
+
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         taskID,
+			"state":      "FAILED",
+			"updated_by": "team-etl",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("RPA task goes through RETRYING", func(t *testing.T) {
+		taskID := createTask(t, "rpa")
+
+		setTaskState := func(t *testing.T, state string) int {
+			w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+				"id":         taskID,
+				"state":      state,
+				"updated_by": "robot-07",
+			})
+			return w.Code
+		}
+		getTask := func(t *testing.T) httpHandler.TaskResponse {
+			w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID, nil)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var task httpHandler.TaskResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+			return task
+		}
+
+		require.Equal(t, http.StatusOK, setTaskState(t, "IN_PROGRESS"))
+		require.Equal(t, http.StatusOK, setTaskState(t, "RETRYING"))
+
+		task := getTask(t)
+		assert.Equal(t, "RETRYING", task.State)
+		assert.NotNil(t, task.StartDate)
+		assert.Nil(t, task.EndDate, "RETRYING is not a final state")
+
+		// Tras reintentar, el robot vuelve a IN_PROGRESS antes de terminar
+		assert.Equal(t, http.StatusBadRequest, setTaskState(t, "COMPLETED"))
+		require.Equal(t, http.StatusOK, setTaskState(t, "IN_PROGRESS"))
+
+		// Las subtareas del perfil también pueden reintentar
+		subtaskID := task.Subtasks[0].ID
+		for _, state := range []string{"IN_PROGRESS", "RETRYING", "IN_PROGRESS", "COMPLETED"} {
+			w := doJSON(router, http.MethodPut, "/Subtask/"+subtaskID, map[string]interface{}{
+				"state":      state,
+				"updated_by": "robot-07",
+			})
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+
+		task = getTask(t)
+		assert.Equal(t, "COMPLETED", task.State)
+		assert.Equal(t, "COMPLETED", task.Subtasks[0].State)
+
+		w := doJSON(router, http.MethodGet, "/Automatizacion/"+taskID+"/history", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var history httpHandler.TaskHistoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+		var taskChanges, subtaskChanges []string
+		for _, event := range history.Events {
+			if event.OldValue == nil || event.NewValue == nil {
+				continue
+			}
+			change := *event.OldValue + "->" + *event.NewValue
+			switch event.Type {
+			case "TASK_STATE_CHANGED":
+				taskChanges = append(taskChanges, change)
+			case "SUBTASK_STATE_CHANGED":
+				subtaskChanges = append(subtaskChanges, change)
+			}
+		}
+		assert.Contains(t, taskChanges, "IN_PROGRESS->RETRYING")
+		assert.Contains(t, taskChanges, "RETRYING->IN_PROGRESS")
+		assert.Contains(t, subtaskChanges, "IN_PROGRESS->RETRYING")
+		assert.Contains(t, subtaskChanges, "RETRYING->IN_PROGRESS")
+	})
+
+	t.Run("RETRYING is rejected outside the profiles that declare it", func(t *testing.T) {
+		taskID := createTask(t, "batch-etl")
+
+		w := doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         taskID,
+			"state":      "IN_PROGRESS",
+			"updated_by": "team-etl",
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = doJSON(router, http.MethodPut, "/Automatizacion", map[string]interface{}{
+			"id":         taskID,
+			"state":      "RETRYING",
+			"updated_by": "team-etl",
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("Unknown workflow is rejected", func(t *testing.T) {
+		w := doJSON(router, http.MethodPost, "/Automatizacion", map[string]interface{}{
+			"name":       "Workflow Task",
+			"created_by": "team-mainframe",
+			"workflow":   "mainframe",
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
@@ -14,11 +14,20 @@ type TaskBuilder struct {
 }
 
 // NewTaskBuilder crea un builder de tareas con valores por defecto.
+// Sus fechas se toman de un reloj detenido en DefaultTestTime; WithClock lo reemplaza.
 func NewTaskBuilder() *TaskBuilder {
-	task, _ := entity.NewTask("Test Task", "test-user")
+	task, _ := entity.NewTask("Test Task", "test-user", NewFakeClock(DefaultTestTime))
 	return &TaskBuilder{task: task}
 }
 
+// WithClock asigna el reloj del que la tarea y sus subtareas toman las fechas.
+func (b *TaskBuilder) WithClock(clock entity.Clock) *TaskBuilder {
+	b.task.SetClock(clock)
+	b.task.CreatedAt = clock.Now()
+	b.task.UpdatedAt = clock.Now()
+	return b
+}
+
 // WithID asigna un ID específico.
 func (b *TaskBuilder) WithID(id uuid.UUID) *TaskBuilder {
 	b.task.ID = id
@@ -72,8 +81,9 @@ type SubtaskBuilder struct {
 }
 
 // NewSubtaskBuilder crea un builder de subtareas con valores por defecto.
+// Sus fechas se toman de un reloj detenido en DefaultTestTime; al añadirla a una tarea usa el de la tarea.
 func NewSubtaskBuilder() *SubtaskBuilder {
-	subtask, _ := entity.NewSubtask("Test Subtask")
+	subtask, _ := entity.NewSubtask("Test Subtask", NewFakeClock(DefaultTestTime))
 	return &SubtaskBuilder{subtask: subtask}
 }
 
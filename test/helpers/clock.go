@@ -0,0 +1,48 @@
+package helpers
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultTestTime es el instante en el que arrancan los relojes de los tests.
+var DefaultTestTime = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+// FakeClock es un reloj controlable que solo avanza cuando el test lo indica.
+// Implementa entity.Clock y es seguro para uso concurrente.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock crea un reloj detenido en now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now retorna el instante actual del reloj.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance avanza el reloj d y retorna el nuevo instante.
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+// Set fija el reloj en t.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// NewTestClock crea un reloj detenido en DefaultTestTime.
+func NewTestClock() *FakeClock {
+	return NewFakeClock(DefaultTestTime)
+}
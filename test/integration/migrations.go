@@ -0,0 +1,38 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// migrationsDir retorna la ruta absoluta al directorio de migraciones del repositorio.
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "internal", "adapter", "repository", "postgres", "migrations")
+}
+
+// applyMigrations ejecuta en orden todos los scripts *.up.sql, de forma que los tests
+// usan exactamente el mismo esquema que producción.
+func applyMigrations(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(migrationsDir(), "*.up.sql"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files, "no migrations found")
+	sort.Strings(files)
+
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		_, err = pool.Exec(ctx, string(sql))
+		require.NoError(t, err, "failed to apply migration %s", filepath.Base(file))
+	}
+}
@@ -101,65 +101,11 @@ func (pc *PostgresContainer) ExecuteSQL(ctx context.Context, t *testing.T, sql s
 	require.NoError(t, err)
 }
 
-// CreateTasksTable crea la tabla tasks.
-func (pc *PostgresContainer) CreateTasksTable(ctx context.Context, t *testing.T) {
+// ApplyMigrations crea el esquema completo ejecutando las migraciones del repositorio.
+func (pc *PostgresContainer) ApplyMigrations(ctx context.Context, t *testing.T) {
 	t.Helper()
 
-	sql := `
-		CREATE TABLE IF NOT EXISTS tasks (
-			id UUID PRIMARY KEY,
-			name VARCHAR(256) NOT NULL,
-			state VARCHAR(20) NOT NULL,
-			created_by VARCHAR(256) NOT NULL,
-			updated_by VARCHAR(256),
-			deleted_by VARCHAR(256),
-			start_date TIMESTAMP,
-			end_date TIMESTAMP,
-			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			deleted_at TIMESTAMP,
-			CONSTRAINT name_valid CHECK (name ~ '^[a-zA-Z0-9 _-]+$'),
-			CONSTRAINT state_valid CHECK (state IN ('PENDING', 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'CANCELLED'))
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_tasks_state ON tasks(state) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_tasks_name ON tasks(name) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_tasks_created_at ON tasks(created_at) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
-	`
-
-	pc.ExecuteSQL(ctx, t, sql)
-}
-
-// CreateSubtasksTable crea la tabla subtasks.
-func (pc *PostgresContainer) CreateSubtasksTable(ctx context.Context, t *testing.T) {
-	t.Helper()
-
-	sql := `
-		CREATE TABLE IF NOT EXISTS subtasks (
-			id UUID PRIMARY KEY,
-			task_id UUID NOT NULL,
-			name VARCHAR(256) NOT NULL,
-			state VARCHAR(20) NOT NULL,
-			created_by VARCHAR(256) NOT NULL,
-			updated_by VARCHAR(256),
-			deleted_by VARCHAR(256),
-			start_date TIMESTAMP,
-			end_date TIMESTAMP,
-			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
-			deleted_at TIMESTAMP,
-			CONSTRAINT fk_task FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
-			CONSTRAINT name_valid CHECK (name ~ '^[a-zA-Z0-9 _-]+$'),
-			CONSTRAINT state_valid CHECK (state IN ('PENDING', 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'CANCELLED'))
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_subtasks_task_id ON subtasks(task_id) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_subtasks_state ON subtasks(state) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_subtasks_deleted_at ON subtasks(deleted_at) WHERE deleted_at IS NOT NULL;
-	`
-
-	pc.ExecuteSQL(ctx, t, sql)
+	applyMigrations(ctx, t, pc.Pool)
 }
 
 // TruncateTables limpia todas las tablas.
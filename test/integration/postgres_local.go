@@ -110,70 +110,11 @@ func (pl *PostgresLocal) ExecuteSQL(ctx context.Context, t *testing.T, sql strin
 	require.NoError(t, err)
 }
 
-// CreateTasksTable crea la tabla tasks.
-func (pl *PostgresLocal) CreateTasksTable(ctx context.Context, t *testing.T) {
+// ApplyMigrations crea el esquema completo ejecutando las migraciones del repositorio.
+func (pl *PostgresLocal) ApplyMigrations(ctx context.Context, t *testing.T) {
 	t.Helper()
 
-	sql := `
-		CREATE TABLE IF NOT EXISTS tasks (
-			id UUID PRIMARY KEY,
-			name VARCHAR(256) NOT NULL,
-			state VARCHAR(20) NOT NULL,
-			created_by VARCHAR(256) NOT NULL,
-			updated_by VARCHAR(256),
-			start_date TIMESTAMPTZ,
-			end_date TIMESTAMPTZ,
-			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-			deleted_at TIMESTAMPTZ,
-			CONSTRAINT name_valid CHECK (name ~ '^[a-zA-Z0-9 _-]+$'),
-			CONSTRAINT state_valid CHECK (state IN ('PENDING', 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'CANCELLED'))
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_tasks_state ON tasks(state) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_tasks_name ON tasks(name) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_tasks_created_at ON tasks(created_at DESC) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
-	`
-
-	pl.ExecuteSQL(ctx, t, sql)
-}
-
-// CreateSubtasksTable crea la tabla subtasks.
-func (pl *PostgresLocal) CreateSubtasksTable(ctx context.Context, t *testing.T) {
-	t.Helper()
-
-	sql := `
-		CREATE TABLE IF NOT EXISTS subtasks (
-			id UUID PRIMARY KEY,
-			task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
-			name VARCHAR(256) NOT NULL,
-			state VARCHAR(20) NOT NULL,
-			start_date TIMESTAMPTZ,
-			end_date TIMESTAMPTZ,
-			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-			deleted_at TIMESTAMPTZ,
-			CONSTRAINT name_valid CHECK (name ~ '^[a-zA-Z0-9 _-]+$'),
-			CONSTRAINT state_valid CHECK (state IN ('PENDING', 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'CANCELLED')),
-			CONSTRAINT valid_subtask_dates CHECK (
-				(start_date IS NULL OR start_date >= created_at) AND
-				(end_date IS NULL OR (start_date IS NOT NULL AND end_date >= start_date))
-			),
-			CONSTRAINT valid_subtask_final_state_dates CHECK (
-				(state IN ('COMPLETED', 'FAILED', 'CANCELLED') AND end_date IS NOT NULL) OR
-				(state NOT IN ('COMPLETED', 'FAILED', 'CANCELLED'))
-			)
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_subtasks_task_id ON subtasks(task_id) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_subtasks_state ON subtasks(state) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_subtasks_created_at ON subtasks(created_at DESC) WHERE deleted_at IS NULL;
-		CREATE INDEX IF NOT EXISTS idx_subtasks_deleted_at ON subtasks(deleted_at) WHERE deleted_at IS NOT NULL;
-		CREATE INDEX IF NOT EXISTS idx_subtasks_task_state ON subtasks(task_id, state) WHERE deleted_at IS NULL;
-	`
-
-	pl.ExecuteSQL(ctx, t, sql)
+	applyMigrations(ctx, t, pl.Pool)
 }
 
 // TruncateTables limpia todas las tablas.
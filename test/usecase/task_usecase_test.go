@@ -12,6 +12,7 @@ import (
 
 	"github.com/grupoapi/proces-log/internal/domain/entity"
 	"github.com/grupoapi/proces-log/internal/domain/service"
+	"github.com/grupoapi/proces-log/test/helpers"
 )
 
 // MockTaskRepository es un mock del TaskRepository.
@@ -106,7 +107,7 @@ func TestCreateTaskUseCase_Example(t *testing.T) {
 	stateMachine := service.NewStateMachine()
 
 	// Create test task
-	task, err := entity.NewTask("Test Task", "test-user")
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
 	require.NoError(t, err)
 
 	// Configure mock expectations
@@ -139,7 +140,7 @@ func TestUpdateTaskStateUseCase_Example(t *testing.T) {
 
 	// Create test task
 	taskID := uuid.New()
-	task, err := entity.NewTask("Test Task", "test-user")
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
 	require.NoError(t, err)
 	task.ID = taskID
 
@@ -173,7 +174,7 @@ func TestUpdateTaskStateUseCase_InvalidTransition_Example(t *testing.T) {
 
 	// Create test task in completed state
 	taskID := uuid.New()
-	task, err := entity.NewTask("Test Task", "test-user")
+	task, err := entity.NewTask("Test Task", "test-user", helpers.NewTestClock())
 	require.NoError(t, err)
 	task.ID = taskID
 	task.State = entity.StateCompleted